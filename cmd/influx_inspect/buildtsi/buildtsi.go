@@ -0,0 +1,287 @@
+// Package buildtsi converts the inmem index of shards to tsi1 indexes.
+package buildtsi
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+// Command represents the program execution for "influx_inspect buildtsi".
+type Command struct {
+	// Standard input/output, overridden for testing.
+	Stderr io.Writer
+	Stdout io.Writer
+
+	dataDir         string
+	walDir          string
+	database        string
+	retentionPolicy string
+	shardID         uint64
+	verbose         bool
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("buildtsi", flag.ExitOnError)
+	fs.StringVar(&cmd.dataDir, "datadir", os.Getenv("HOME")+"/.influxdb/data", "Data storage path")
+	fs.StringVar(&cmd.walDir, "waldir", os.Getenv("HOME")+"/.influxdb/wal", "WAL storage path")
+	fs.StringVar(&cmd.database, "database", "", "Optional: the database to convert")
+	fs.StringVar(&cmd.retentionPolicy, "retention", "", "Optional: the retention policy to convert (requires -database)")
+	fs.Uint64Var(&cmd.shardID, "shard", 0, "Optional: the shard to convert")
+	fs.BoolVar(&cmd.verbose, "v", false, "Verbose output")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = func() {
+		fmt.Fprintf(cmd.Stdout, "Builds tsi1 indexes for shards using the inmem index.\n")
+		fmt.Fprintf(cmd.Stdout, "The server must not be running.\n\n")
+		fmt.Fprintf(cmd.Stdout, "Usage: %s buildtsi [flags]\n\n", filepath.Base(os.Args[0]))
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cmd.retentionPolicy != "" && cmd.database == "" {
+		return errors.New("database is required when retention policy is set")
+	}
+
+	return cmd.run()
+}
+
+func (cmd *Command) run() error {
+	dbs, err := ioutil.ReadDir(cmd.dataDir)
+	if err != nil {
+		return err
+	}
+
+	var converted int
+	for _, db := range dbs {
+		if !db.IsDir() || (cmd.database != "" && db.Name() != cmd.database) {
+			continue
+		}
+
+		rps, err := ioutil.ReadDir(filepath.Join(cmd.dataDir, db.Name()))
+		if err != nil {
+			return err
+		}
+
+		for _, rp := range rps {
+			if !rp.IsDir() || (cmd.retentionPolicy != "" && rp.Name() != cmd.retentionPolicy) {
+				continue
+			}
+
+			shards, err := ioutil.ReadDir(filepath.Join(cmd.dataDir, db.Name(), rp.Name()))
+			if err != nil {
+				return err
+			}
+
+			for _, sh := range shards {
+				id, err := strconv.ParseUint(sh.Name(), 10, 64)
+				if err != nil || !sh.IsDir() || (cmd.shardID != 0 && id != cmd.shardID) {
+					continue
+				}
+
+				dataDir := filepath.Join(cmd.dataDir, db.Name(), rp.Name(), sh.Name())
+				walDir := filepath.Join(cmd.walDir, db.Name(), rp.Name(), sh.Name())
+
+				// Shards with an index directory already use tsi1.
+				if _, err := os.Stat(filepath.Join(dataDir, "index")); err == nil {
+					if cmd.verbose {
+						fmt.Fprintf(cmd.Stdout, "Skipping shard %d: already using tsi1\n", id)
+					}
+					continue
+				} else if !os.IsNotExist(err) {
+					return err
+				}
+
+				fmt.Fprintf(cmd.Stdout, "Converting shard %d (%s.%s)\n", id, db.Name(), rp.Name())
+				n, err := BuildShardIndex(id, db.Name(), dataDir, walDir)
+				if err != nil {
+					return fmt.Errorf("shard %d: %s", id, err)
+				}
+				if cmd.verbose {
+					fmt.Fprintf(cmd.Stdout, "Indexed %d series in shard %d\n", n, id)
+				}
+				converted++
+			}
+		}
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Converted %d shards\n", converted)
+	return nil
+}
+
+// BuildShardIndex builds a tsi1 index for a shard from the series in its TSM
+// and WAL files, replacing any existing index. It returns the number of
+// series indexed.
+func BuildShardIndex(id uint64, database, dataDir, walDir string) (int64, error) {
+	// Build the index in a temporary directory so an interrupted build is
+	// never mistaken for a complete index.
+	tmpPath := filepath.Join(dataDir, "index.tmp")
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return 0, err
+	}
+
+	idx := tsi1.NewIndex()
+	idx.ShardID = id
+	idx.Database = database
+	idx.Path = tmpPath
+	if err := idx.Open(); err != nil {
+		return 0, err
+	}
+
+	b := tsdb.NewSeriesBatch(idx)
+	if err := func() error {
+		if err := ForEachSeriesKey(dataDir, walDir, b.Add); err != nil {
+			return err
+		}
+		return b.Flush()
+	}(); err != nil {
+		idx.Close()
+		os.RemoveAll(tmpPath)
+		return 0, err
+	}
+
+	n := idx.SeriesN()
+	if err := idx.Close(); err != nil {
+		os.RemoveAll(tmpPath)
+		return 0, err
+	}
+
+	// Move an existing index aside before swapping in the new one so the
+	// shard is never left without an index.
+	path, oldPath := filepath.Join(dataDir, "index"), filepath.Join(dataDir, "index.old")
+	if err := os.RemoveAll(oldPath); err != nil {
+		return 0, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, oldPath); err != nil {
+			return 0, err
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, err
+	}
+	return n, os.RemoveAll(oldPath)
+}
+
+// ForEachSeriesKey calls fn with the key of every series in the TSM and WAL
+// files of a shard. A key may be passed more than once and is only valid
+// until fn returns.
+func ForEachSeriesKey(dataDir, walDir string, fn func(key []byte) error) error {
+	if err := forEachTSMSeriesKey(dataDir, fn); err != nil {
+		return err
+	}
+	return forEachWALSeriesKey(walDir, fn)
+}
+
+// forEachTSMSeriesKey calls fn with the series key of every TSM file in dir.
+func forEachTSMSeriesKey(dir string, fn func(key []byte) error) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*."+tsm1.TSMFileExtension))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		r, err := tsm1.NewTSMReader(f)
+		if err != nil {
+			f.Close()
+			return err
+		}
+
+		for i := 0; i < r.KeyCount(); i++ {
+			key, _ := r.KeyAt(i)
+			seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+			if err := fn(seriesKey); err != nil {
+				r.Close()
+				return err
+			}
+		}
+
+		if err := r.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachWALSeriesKey calls fn with the series key of the values in the WAL
+// segments in dir that have not been deleted.
+func forEachWALSeriesKey(dir string, fn func(key []byte) error) error {
+	files, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%s*.%s", tsm1.WALFilePrefix, tsm1.WALFileExtension)))
+	if err != nil {
+		return err
+	}
+
+	// Replay the segments in order so deletes remove earlier writes.
+	// Range deletes are ignored since they may leave values behind.
+	keys := make(map[string]struct{})
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		r := tsm1.NewWALSegmentReader(f)
+		for r.Next() {
+			entry, err := r.Read()
+			if err != nil {
+				// A partially written entry ends the segment, as on startup.
+				break
+			}
+
+			switch t := entry.(type) {
+			case *tsm1.WriteWALEntry:
+				for key := range t.Values {
+					keys[key] = struct{}{}
+				}
+			case *tsm1.DeleteWALEntry:
+				for _, key := range t.Keys {
+					delete(keys, string(key))
+				}
+			}
+		}
+		r.Close()
+	}
+
+	seriesKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey([]byte(key))
+		seriesKeys = append(seriesKeys, string(seriesKey))
+	}
+	sort.Strings(seriesKeys)
+
+	for _, key := range seriesKeys {
+		if err := fn([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
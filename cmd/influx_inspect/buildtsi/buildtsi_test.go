@@ -0,0 +1,91 @@
+package buildtsi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	_ "github.com/influxdata/influxdb/tsdb/engine"
+	_ "github.com/influxdata/influxdb/tsdb/index"
+)
+
+func TestCommand_Run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildtsi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dataDir, walDir := filepath.Join(dir, "data"), filepath.Join(dir, "wal")
+
+	// Write series to the TSM files and the WAL of an inmem shard.
+	store := openStore(t, dataDir, walDir)
+	if err := store.CreateShard("db0", "rp0", 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WriteToShard(1, []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": 1.0}, time.Unix(1, 0)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "B"}), models.Fields{"value": 1.0}, time.Unix(1, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Creating a snapshot writes the cache to a TSM file.
+	if snapshot, err := store.Shard(1).CreateSnapshot(); err != nil {
+		t.Fatal(err)
+	} else {
+		os.RemoveAll(snapshot)
+	}
+	if err := store.WriteToShard(1, []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": 2.0}, time.Unix(2, 0)),
+		models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": 1.0}, time.Unix(2, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	cmd := NewCommand()
+	cmd.Stdout, cmd.Stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dataDir, "db0", "rp0", "1", "index")); err != nil {
+		t.Fatalf("index not built: %s", err)
+	}
+
+	store = openStore(t, dataDir, walDir)
+	defer store.Close()
+
+	sh := store.Shard(1)
+	if got, exp := sh.IndexType(), "tsi1"; got != exp {
+		t.Fatalf("unexpected index type: got %s, exp %s", got, exp)
+	} else if got, exp := sh.SeriesN(), int64(3); got != exp {
+		t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+	}
+
+	// Shards already using tsi1 are skipped.
+	store.Close()
+	buf := &bytes.Buffer{}
+	cmd = NewCommand()
+	cmd.Stdout, cmd.Stderr = buf, &bytes.Buffer{}
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Contains(buf.Bytes(), []byte("Converted 0 shards")) {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func openStore(t *testing.T, dataDir, walDir string) *tsdb.Store {
+	store := tsdb.NewStore(dataDir)
+	store.EngineOptions.Config.WALDir = walDir
+	store.EngineOptions.IndexVersion = "inmem"
+	if err := store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
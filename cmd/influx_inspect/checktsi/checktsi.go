@@ -0,0 +1,347 @@
+// Package checktsi checks the tsi1 indexes of shards against their TSM and
+// WAL files.
+package checktsi
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/influxdb/cmd/influx_inspect/buildtsi"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/escape"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+// Command represents the program execution for "influx_inspect checktsi".
+type Command struct {
+	// Standard input/output, overridden for testing.
+	Stderr io.Writer
+	Stdout io.Writer
+
+	dataDir         string
+	walDir          string
+	database        string
+	retentionPolicy string
+	shardID         uint64
+	rebuild         bool
+	verbose         bool
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("checktsi", flag.ExitOnError)
+	fs.StringVar(&cmd.dataDir, "datadir", os.Getenv("HOME")+"/.influxdb/data", "Data storage path")
+	fs.StringVar(&cmd.walDir, "waldir", os.Getenv("HOME")+"/.influxdb/wal", "WAL storage path")
+	fs.StringVar(&cmd.database, "database", "", "Optional: the database to check")
+	fs.StringVar(&cmd.retentionPolicy, "retention", "", "Optional: the retention policy to check (requires -database)")
+	fs.Uint64Var(&cmd.shardID, "shard", 0, "Optional: the shard to check")
+	fs.BoolVar(&cmd.rebuild, "rebuild", false, "Rebuild the index of inconsistent shards")
+	fs.BoolVar(&cmd.verbose, "v", false, "Verbose output")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = func() {
+		fmt.Fprintf(cmd.Stdout, "Checks tsi1 indexes against the series in the TSM and WAL files of their shards.\n")
+		fmt.Fprintf(cmd.Stdout, "The server must not be running.\n\n")
+		fmt.Fprintf(cmd.Stdout, "Usage: %s checktsi [flags]\n\n", filepath.Base(os.Args[0]))
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cmd.retentionPolicy != "" && cmd.database == "" {
+		return errors.New("database is required when retention policy is set")
+	}
+
+	return cmd.run()
+}
+
+func (cmd *Command) run() error {
+	dbs, err := ioutil.ReadDir(cmd.dataDir)
+	if err != nil {
+		return err
+	}
+
+	var checked, inconsistent, rebuilt int
+	for _, db := range dbs {
+		if !db.IsDir() || (cmd.database != "" && db.Name() != cmd.database) {
+			continue
+		}
+
+		rps, err := ioutil.ReadDir(filepath.Join(cmd.dataDir, db.Name()))
+		if err != nil {
+			return err
+		}
+
+		for _, rp := range rps {
+			if !rp.IsDir() || (cmd.retentionPolicy != "" && rp.Name() != cmd.retentionPolicy) {
+				continue
+			}
+
+			shards, err := ioutil.ReadDir(filepath.Join(cmd.dataDir, db.Name(), rp.Name()))
+			if err != nil {
+				return err
+			}
+
+			for _, sh := range shards {
+				id, err := strconv.ParseUint(sh.Name(), 10, 64)
+				if err != nil || !sh.IsDir() || (cmd.shardID != 0 && id != cmd.shardID) {
+					continue
+				}
+
+				dataDir := filepath.Join(cmd.dataDir, db.Name(), rp.Name(), sh.Name())
+				walDir := filepath.Join(cmd.walDir, db.Name(), rp.Name(), sh.Name())
+
+				// Only shards with an index directory use tsi1.
+				if _, err := os.Stat(filepath.Join(dataDir, "index")); os.IsNotExist(err) {
+					if cmd.verbose {
+						fmt.Fprintf(cmd.Stdout, "Skipping shard %d: not using tsi1\n", id)
+					}
+					continue
+				} else if err != nil {
+					return err
+				}
+
+				r, err := CheckShard(dataDir, walDir)
+				if err != nil {
+					return fmt.Errorf("shard %d: %s", id, err)
+				}
+				checked++
+
+				if r.Consistent() {
+					if cmd.verbose {
+						fmt.Fprintf(cmd.Stdout, "Shard %d (%s.%s): ok, %d series\n", id, db.Name(), rp.Name(), r.SeriesN)
+					}
+					continue
+				}
+				inconsistent++
+
+				fmt.Fprintf(cmd.Stdout, "Shard %d (%s.%s): %d missing, %d orphaned, %d tombstoned, %d damaged files\n",
+					id, db.Name(), rp.Name(), len(r.Missing), len(r.Orphaned), len(r.Tombstoned),
+					len(r.MissingFiles)+len(r.UnlistedFiles)+len(r.TruncatedFiles))
+				for _, key := range r.Missing {
+					fmt.Fprintf(cmd.Stdout, "  missing: %s\n", key)
+				}
+				for _, key := range r.Orphaned {
+					fmt.Fprintf(cmd.Stdout, "  orphaned: %s\n", key)
+				}
+				for _, key := range r.Tombstoned {
+					fmt.Fprintf(cmd.Stdout, "  tombstoned: %s\n", key)
+				}
+				for _, name := range r.MissingFiles {
+					fmt.Fprintf(cmd.Stdout, "  missing file: %s\n", name)
+				}
+				for _, name := range r.UnlistedFiles {
+					fmt.Fprintf(cmd.Stdout, "  unlisted file: %s\n", name)
+				}
+				for _, name := range r.TruncatedFiles {
+					fmt.Fprintf(cmd.Stdout, "  truncated file: %s\n", name)
+				}
+
+				if !cmd.rebuild {
+					continue
+				}
+				n, err := buildtsi.BuildShardIndex(id, db.Name(), dataDir, walDir)
+				if err != nil {
+					return fmt.Errorf("shard %d: rebuild: %s", id, err)
+				}
+				fmt.Fprintf(cmd.Stdout, "Rebuilt index of shard %d with %d series\n", id, n)
+				rebuilt++
+			}
+		}
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Checked %d shards, %d inconsistent", checked, inconsistent)
+	if cmd.rebuild {
+		fmt.Fprintf(cmd.Stdout, ", %d rebuilt", rebuilt)
+	}
+	fmt.Fprintln(cmd.Stdout)
+
+	if inconsistent > rebuilt {
+		return fmt.Errorf("%d inconsistent shards", inconsistent-rebuilt)
+	}
+	return nil
+}
+
+// Report is the result of checking the index of a shard.
+type Report struct {
+	// Number of series in the TSM and WAL files.
+	SeriesN int
+
+	// Series in the TSM or WAL files that are not in the index.
+	Missing []string
+
+	// Series in the index that are not in the TSM or WAL files.
+	Orphaned []string
+
+	// Series in the TSM or WAL files that are tombstoned in the index.
+	Tombstoned []string
+
+	// Index files listed in the manifest that don't exist.
+	MissingFiles []string
+
+	// Index files that aren't listed in the manifest.
+	UnlistedFiles []string
+
+	// Index files that end with a partial write or can't be read.
+	TruncatedFiles []string
+}
+
+// Consistent returns true if the index matches the TSM and WAL files.
+func (r *Report) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Orphaned) == 0 && len(r.Tombstoned) == 0 &&
+		len(r.MissingFiles) == 0 && len(r.UnlistedFiles) == 0 && len(r.TruncatedFiles) == 0
+}
+
+// CheckShard cross-checks the series in the TSM and WAL files of a shard
+// against its tsi1 index and checks the index files against its manifest.
+// The index files are opened read-only.
+func CheckShard(dataDir, walDir string) (*Report, error) {
+	// Collect the series with data by their normalized key.
+	keys := make(map[string]struct{})
+	if err := buildtsi.ForEachSeriesKey(dataDir, walDir, func(key []byte) error {
+		name, tags := models.ParseKey(key)
+		keys[string(models.MakeKey([]byte(name), tags))] = struct{}{}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r := &Report{SeriesN: len(keys)}
+	fs, err := openFileSet(filepath.Join(dataDir, "index"), r)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.Close()
+
+	// Series in the index without data are orphaned.
+	indexed := make(map[string]struct{})
+	itr := fs.SeriesIterator()
+	for e := itr.Next(); e != nil; e = itr.Next() {
+		key := string(models.MakeKey(e.Name(), e.Tags()))
+		indexed[key] = struct{}{}
+		if _, ok := keys[key]; !ok {
+			r.Orphaned = append(r.Orphaned, key)
+		}
+	}
+
+	// Series with data are either missing from the index or tombstoned.
+	buf := make([]byte, 1024)
+	for key := range keys {
+		if _, ok := indexed[key]; ok {
+			continue
+		}
+
+		name, tags := models.ParseKey([]byte(key))
+		if e := fs.Series(escape.Unescape([]byte(name)), tags, buf); e != nil && e.Deleted() {
+			r.Tombstoned = append(r.Tombstoned, key)
+		} else {
+			r.Missing = append(r.Missing, key)
+		}
+	}
+
+	sort.Strings(r.Missing)
+	sort.Strings(r.Orphaned)
+	sort.Strings(r.Tombstoned)
+	return r, nil
+}
+
+// openFileSet opens the files listed in the manifest of the index at path
+// without modifying them.  Files that are missing, not listed in the manifest
+// or truncated are added to r and the remaining files are opened.
+func openFileSet(path string, r *Report) (*tsi1.FileSet, error) {
+	m, err := tsi1.ReadManifestFile(filepath.Join(path, tsi1.ManifestFileName))
+	if os.IsNotExist(err) {
+		m = tsi1.NewManifest()
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Files in the index directory that the manifest doesn't list are left
+	// behind by interrupted compactions and are ignored by the index.
+	fis, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range fis {
+		switch filepath.Ext(fi.Name()) {
+		case tsi1.LogFileExt, tsi1.IndexFileExt:
+			if !m.HasFile(fi.Name()) {
+				r.UnlistedFiles = append(r.UnlistedFiles, fi.Name())
+			}
+		}
+	}
+
+	var files []tsi1.File
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	for _, filename := range m.Files {
+		switch filepath.Ext(filename) {
+		case tsi1.LogFileExt, tsi1.IndexFileExt:
+		default:
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(path, filename)); os.IsNotExist(err) {
+			r.MissingFiles = append(r.MissingFiles, filename)
+			continue
+		} else if err != nil {
+			closeFiles()
+			return nil, err
+		}
+
+		switch filepath.Ext(filename) {
+		case tsi1.LogFileExt:
+			f := tsi1.NewLogFile(filepath.Join(path, filename))
+			if err := f.OpenReadOnly(); err != nil {
+				closeFiles()
+				return nil, err
+			} else if f.PartialWrite() {
+				r.TruncatedFiles = append(r.TruncatedFiles, filename)
+			}
+			files = append(files, f)
+
+		case tsi1.IndexFileExt:
+			f := tsi1.NewIndexFile()
+			f.SetPath(filepath.Join(path, filename))
+			if err := f.Open(); err != nil {
+				// Errors reading the file abort the check but a file whose
+				// contents can't be read is reported.
+				if _, ok := err.(*os.PathError); ok {
+					closeFiles()
+					return nil, err
+				}
+				r.TruncatedFiles = append(r.TruncatedFiles, filename)
+				continue
+			}
+			files = append(files, f)
+		}
+	}
+
+	fs, err := tsi1.NewFileSet(m.Levels, files)
+	if err != nil {
+		closeFiles()
+		return nil, err
+	}
+	return fs, nil
+}
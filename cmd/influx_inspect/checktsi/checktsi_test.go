@@ -0,0 +1,196 @@
+package checktsi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	_ "github.com/influxdata/influxdb/tsdb/engine"
+	_ "github.com/influxdata/influxdb/tsdb/index"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+func TestCommand_Run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checktsi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dataDir, walDir := filepath.Join(dir, "data"), filepath.Join(dir, "wal")
+	shardDir := filepath.Join(dataDir, "db0", "rp0", "1")
+
+	// Write series to the TSM files and the WAL of a tsi1 shard.
+	store := tsdb.NewStore(dataDir)
+	store.EngineOptions.Config.WALDir = walDir
+	store.EngineOptions.IndexVersion = "tsi1"
+	if err := store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateShard("db0", "rp0", 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WriteToShard(1, []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": 1.0}, time.Unix(1, 0)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "B"}), models.Fields{"value": 1.0}, time.Unix(1, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Creating a snapshot writes the cache to a TSM file.
+	if snapshot, err := store.Shard(1).CreateSnapshot(); err != nil {
+		t.Fatal(err)
+	} else {
+		os.RemoveAll(snapshot)
+	}
+	if err := store.WriteToShard(1, []models.Point{
+		models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": 1.0}, time.Unix(2, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// A consistent index passes the check.
+	buf := &bytes.Buffer{}
+	cmd := NewCommand()
+	cmd.Stdout, cmd.Stderr = buf, &bytes.Buffer{}
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir); err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, buf.String())
+	} else if !bytes.Contains(buf.Bytes(), []byte("Checked 1 shards, 0 inconsistent")) {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+
+	// Replace the index with one that is missing, has an orphaned and has a
+	// tombstoned series.
+	if err := os.RemoveAll(filepath.Join(shardDir, "index")); err != nil {
+		t.Fatal(err)
+	}
+	idx := tsi1.NewIndex()
+	idx.Path = filepath.Join(shardDir, "index")
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.CreateSeriesListIfNotExists(nil,
+		[][]byte{[]byte("cpu"), []byte("cpu"), []byte("disk")},
+		[]models.Tags{
+			models.NewTags(map[string]string{"host": "A"}),
+			models.NewTags(map[string]string{"host": "B"}),
+			models.NewTags(map[string]string{"host": "Z"}),
+		},
+	); err != nil {
+		t.Fatal(err)
+	} else if err := idx.DropSeries([]byte("cpu,host=B")); err != nil {
+		t.Fatal(err)
+	} else if err := idx.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := CheckShard(shardDir, filepath.Join(walDir, "db0", "rp0", "1"))
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(r, &Report{
+		SeriesN:    3,
+		Missing:    []string{"mem,host=A"},
+		Orphaned:   []string{"disk,host=Z"},
+		Tombstoned: []string{"cpu,host=B"},
+	}) {
+		t.Fatalf("unexpected report: %#v", r)
+	}
+
+	// Inconsistent shards fail the check unless rebuilt.
+	buf.Reset()
+	cmd = NewCommand()
+	cmd.Stdout, cmd.Stderr = buf, &bytes.Buffer{}
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir); err == nil || err.Error() != "1 inconsistent shards" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !bytes.Contains(buf.Bytes(), []byte("Shard 1 (db0.rp0): 1 missing, 1 orphaned, 1 tombstoned, 0 damaged files")) {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+
+	buf.Reset()
+	cmd = NewCommand()
+	cmd.Stdout, cmd.Stderr = buf, &bytes.Buffer{}
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir, "-rebuild"); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Contains(buf.Bytes(), []byte("Rebuilt index of shard 1 with 3 series")) {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+
+	if r, err := CheckShard(shardDir, filepath.Join(walDir, "db0", "rp0", "1")); err != nil {
+		t.Fatal(err)
+	} else if !r.Consistent() {
+		t.Fatalf("index not rebuilt: %#v", r)
+	}
+
+	// Damage the index files: leave an unlisted file, remove a listed file
+	// and end the log file with a partial write.
+	indexDir := filepath.Join(shardDir, "index")
+	m, err := tsi1.ReadManifestFile(filepath.Join(indexDir, tsi1.ManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logFile string
+	for _, name := range m.Files {
+		if filepath.Ext(name) == tsi1.LogFileExt {
+			logFile = name
+		}
+	}
+	m.Files = append(m.Files, "L0-00000099"+tsi1.IndexFileExt)
+	if err := tsi1.WriteManifestFile(filepath.Join(indexDir, tsi1.ManifestFileName), m); err != nil {
+		t.Fatal(err)
+	} else if err := ioutil.WriteFile(filepath.Join(indexDir, "L0-00000098"+tsi1.LogFileExt), nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(filepath.Join(indexDir, logFile), os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := f.Write([]byte{0, 1, 2}); err != nil {
+		t.Fatal(err)
+	} else if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(filepath.Join(indexDir, logFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err := CheckShard(shardDir, filepath.Join(walDir, "db0", "rp0", "1")); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(r, &Report{
+		SeriesN:        3,
+		MissingFiles:   []string{"L0-00000099" + tsi1.IndexFileExt},
+		UnlistedFiles:  []string{"L0-00000098" + tsi1.LogFileExt},
+		TruncatedFiles: []string{logFile},
+	}) {
+		t.Fatalf("unexpected report: %#v", r)
+	}
+
+	// The check doesn't modify the index files.
+	if other, err := os.Stat(filepath.Join(indexDir, logFile)); err != nil {
+		t.Fatal(err)
+	} else if other.Size() != fi.Size() {
+		t.Fatalf("log file modified: size %d, expected %d", other.Size(), fi.Size())
+	} else if _, err := os.Stat(filepath.Join(indexDir, "L0-00000099"+tsi1.IndexFileExt)); !os.IsNotExist(err) {
+		t.Fatalf("missing file created: %v", err)
+	}
+
+	buf.Reset()
+	cmd = NewCommand()
+	cmd.Stdout, cmd.Stderr = buf, &bytes.Buffer{}
+	if err := cmd.Run("-datadir", dataDir, "-waldir", walDir, "-rebuild"); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Contains(buf.Bytes(), []byte("Shard 1 (db0.rp0): 0 missing, 0 orphaned, 0 tombstoned, 3 damaged files")) {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+
+	if r, err := CheckShard(shardDir, filepath.Join(walDir, "db0", "rp0", "1")); err != nil {
+		t.Fatal(err)
+	} else if !r.Consistent() {
+		t.Fatalf("index not rebuilt: %#v", r)
+	}
+}
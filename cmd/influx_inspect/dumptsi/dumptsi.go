@@ -27,6 +27,7 @@ type Command struct {
 	showTagKeys        bool
 	showTagValues      bool
 	showTagValueSeries bool
+	showTrigrams       bool
 
 	measurementFilter *regexp.Regexp
 	tagKeyFilter      *regexp.Regexp
@@ -50,6 +51,7 @@ func (cmd *Command) Run(args ...string) error {
 	fs.BoolVar(&cmd.showTagKeys, "tag-keys", false, "Show raw tag key data")
 	fs.BoolVar(&cmd.showTagValues, "tag-values", false, "Show raw tag value data")
 	fs.BoolVar(&cmd.showTagValueSeries, "tag-value-series", false, "Show raw series data for each value")
+	fs.BoolVar(&cmd.showTrigrams, "trigrams", false, "Show the trigram index of each tag key in index files")
 	fs.StringVar(&measurementFilter, "measurement-filter", "", "Regex measurement filter")
 	fs.StringVar(&tagKeyFilter, "tag-key-filter", "", "Regex tag key filter")
 	fs.StringVar(&tagValueFilter, "tag-value-filter", "", "Regex tag value filter")
@@ -362,7 +364,7 @@ func (cmd *Command) printIndexFileSummary(f *tsi1.IndexFile) error {
 	// Calculate summary stats.
 	seriesN := f.SeriesN()
 	var measurementN, measurementSeriesN, measurementSeriesSize uint64
-	var keyN uint64
+	var keyN, trigramKeyN, trigramN uint64
 	var valueN, valueSeriesN, valueSeriesSize uint64
 	mitr := f.MeasurementIterator()
 	for me, _ := mitr.Next().(*tsi1.MeasurementBlockElem); me != nil; me, _ = mitr.Next().(*tsi1.MeasurementBlockElem) {
@@ -374,6 +376,15 @@ func (cmd *Command) printIndexFileSummary(f *tsi1.IndexFile) error {
 				valueSeriesN += uint64(ve.SeriesN())
 				valueSeriesSize += uint64(len(ve.SeriesData()))
 			}
+			if ke.HasTrigramIndex() {
+				if err := ke.ForEachTrigram(func(_ []byte, _ []uint64) error {
+					trigramN++
+					return nil
+				}); err != nil {
+					return err
+				}
+				trigramKeyN++
+			}
 			keyN++
 		}
 		measurementN++
@@ -393,11 +404,45 @@ func (cmd *Command) printIndexFileSummary(f *tsi1.IndexFile) error {
 	fmt.Fprintf(tw, "  Series data size:\t%d (%s)\n", valueSeriesSize, formatSize(valueSeriesSize))
 	fmt.Fprintf(tw, "  Bytes per series:\t%.01fb\n", float64(valueSeriesSize)/float64(valueSeriesN))
 	fmt.Fprintf(tw, "Avg tags per series:\t%.01f\n", float64(valueSeriesN)/float64(seriesN))
+	fmt.Fprintf(tw, "Trigram indexed keys:\t%d\n", trigramKeyN)
+	fmt.Fprintf(tw, "  Trigrams:\t%d\n", trigramN)
 	if err := tw.Flush(); err != nil {
 		return err
 	}
 
-	return nil
+	return cmd.printIndexFileTrigrams(f)
+}
+
+// printIndexFileTrigrams prints the trigram index of each tag key in f with
+// the number of values containing each trigram.
+func (cmd *Command) printIndexFileTrigrams(f *tsi1.IndexFile) error {
+	if !cmd.showTrigrams {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(cmd.Stdout, 8, 8, 1, '\t', 0)
+	mitr := f.MeasurementIterator()
+	for me := mitr.Next(); me != nil; me = mitr.Next() {
+		if cmd.measurementFilter != nil && !cmd.measurementFilter.Match(me.Name()) {
+			continue
+		}
+
+		kitr := f.TagKeyIterator(me.Name())
+		for ke, _ := kitr.Next().(*tsi1.TagBlockKeyElem); ke != nil; ke, _ = kitr.Next().(*tsi1.TagBlockKeyElem) {
+			if !ke.HasTrigramIndex() || (cmd.tagKeyFilter != nil && !cmd.tagKeyFilter.Match(ke.Key())) {
+				continue
+			}
+
+			fmt.Fprintf(tw, "%s %s\n", me.Name(), ke.Key())
+			if err := ke.ForEachTrigram(func(t []byte, offsets []uint64) error {
+				fmt.Fprintf(tw, "    %q\t%d\n", t, len(offsets))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Flush()
 }
 
 // matchSeries returns true if the command filters matches the series.
@@ -440,6 +485,8 @@ Usage: influx_inspect dumptsi [flags] path...
             Dump raw tag values
     -tag-value-series
             Dump raw series for each tag value
+    -trigrams
+            Dump the trigram index of each tag key in index files
     -measurement-filter REGEXP
             Filters data by measurement regular expression
     -tag-key-filter REGEXP
@@ -31,11 +31,14 @@ Usage: influx_inspect [[command] [arguments]]
 
 The commands are:
 
+    buildtsi             builds tsi1 indexes for shards using the inmem index
+    checktsi             checks tsi1 indexes against TSM and WAL files
     dumptsi              dumps low-level details about tsi1 files.
     dumptsm              dumps low-level details about tsm1 files.
     export               exports raw data from a shard to line protocol
     help                 display this help message
     report               displays a shard level report
+    reshard              rewrites shards into shard groups of a new duration
     verify               verifies integrity of TSM files
 
 "help" is the default command.
@@ -8,11 +8,14 @@ import (
 	"os"
 
 	"github.com/influxdata/influxdb/cmd"
+	"github.com/influxdata/influxdb/cmd/influx_inspect/buildtsi"
+	"github.com/influxdata/influxdb/cmd/influx_inspect/checktsi"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/dumptsi"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/dumptsm"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/export"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/help"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/report"
+	"github.com/influxdata/influxdb/cmd/influx_inspect/reshard"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/verify"
 	_ "github.com/influxdata/influxdb/tsdb/engine"
 )
@@ -54,6 +57,16 @@ func (m *Main) Run(args ...string) error {
 		if err := help.NewCommand().Run(args...); err != nil {
 			return fmt.Errorf("help: %s", err)
 		}
+	case "buildtsi":
+		name := buildtsi.NewCommand()
+		if err := name.Run(args...); err != nil {
+			return fmt.Errorf("buildtsi: %s", err)
+		}
+	case "checktsi":
+		name := checktsi.NewCommand()
+		if err := name.Run(args...); err != nil {
+			return fmt.Errorf("checktsi: %s", err)
+		}
 	case "dumptsi":
 		name := dumptsi.NewCommand()
 		if err := name.Run(args...); err != nil {
@@ -77,6 +90,11 @@ func (m *Main) Run(args ...string) error {
 		if err := name.Run(args...); err != nil {
 			return fmt.Errorf("report: %s", err)
 		}
+	case "reshard":
+		name := reshard.NewCommand()
+		if err := name.Run(args...); err != nil {
+			return fmt.Errorf("reshard: %s", err)
+		}
 	case "verify":
 		name := verify.NewCommand()
 		if err := name.Run(args...); err != nil {
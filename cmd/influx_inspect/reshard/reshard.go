@@ -0,0 +1,437 @@
+// Package reshard rewrites the shards of a retention policy into shard groups of a new duration.
+package reshard
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/toml"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+// batchSize is the number of points written to a new shard at a time.
+const batchSize = 5000
+
+// Command represents the program execution for "influx_inspect reshard".
+type Command struct {
+	// Standard input/output, overridden for testing.
+	Stderr io.Writer
+	Stdout io.Writer
+
+	metaDir         string
+	dataDir         string
+	walDir          string
+	database        string
+	retentionPolicy string
+	duration        time.Duration
+	indexVersion    string
+	force           bool
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stderr: os.Stderr,
+		Stdout: os.Stdout,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("reshard", flag.ExitOnError)
+	fs.StringVar(&cmd.metaDir, "metadir", os.Getenv("HOME")+"/.influxdb/meta", "Meta storage path")
+	fs.StringVar(&cmd.dataDir, "datadir", os.Getenv("HOME")+"/.influxdb/data", "Data storage path")
+	fs.StringVar(&cmd.walDir, "waldir", os.Getenv("HOME")+"/.influxdb/wal", "WAL storage path")
+	fs.StringVar(&cmd.database, "database", "", "Required: the database to reshard")
+	fs.StringVar(&cmd.retentionPolicy, "retention", "", "Required: the retention policy to reshard")
+	fs.DurationVar(&cmd.duration, "duration", 0, "Optional: the new shard group duration (defaults to the retention policy's shard group duration)")
+	fs.StringVar(&cmd.indexVersion, "index", "", "Optional: the index type of the new shards (defaults to the index type of the old shards)")
+	fs.BoolVar(&cmd.force, "force", false, "Optional: replace the shards even if some points could not be copied")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = func() {
+		fmt.Fprintf(cmd.Stdout, "Rewrites the shards of a retention policy into shard groups of a new duration.\n")
+		fmt.Fprintf(cmd.Stdout, "The server must not be running.\n\n")
+		fmt.Fprintf(cmd.Stdout, "Usage: %s reshard [flags]\n\n", filepath.Base(os.Args[0]))
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cmd.database == "" {
+		return errors.New("database is required")
+	} else if cmd.retentionPolicy == "" {
+		return errors.New("retention policy is required")
+	} else if cmd.duration < 0 {
+		return errors.New("duration must be positive")
+	}
+
+	return cmd.reshard()
+}
+
+func (cmd *Command) reshard() error {
+	// Open the meta store.
+	config := meta.NewConfig()
+	config.Dir = cmd.metaDir
+	client := meta.NewClient(config)
+	if err := client.Open(); err != nil {
+		return err
+	}
+	defer client.Close()
+
+	rpi, err := client.RetentionPolicy(cmd.database, cmd.retentionPolicy)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return fmt.Errorf("retention policy not found: %s.%s", cmd.database, cmd.retentionPolicy)
+	}
+
+	d := cmd.duration
+	if d == 0 {
+		d = rpi.ShardGroupDuration
+	}
+
+	sources, targets, err := planShardGroups(rpi.ShardGroups, d)
+	if err != nil {
+		return err
+	} else if len(sources) == 0 {
+		fmt.Fprintf(cmd.Stdout, "No shard groups of %s.%s need resharding to %s\n", cmd.database, cmd.retentionPolicy, d)
+		return nil
+	}
+
+	indexVersion := cmd.indexVersion
+	if indexVersion == "" {
+		if indexVersion, err = cmd.sourceIndexVersion(sources); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.Stdout, "Resharding %d shard groups of %s.%s into %d shard groups of %s\n", len(sources), cmd.database, cmd.retentionPolicy, len(targets), d)
+
+	// Open the shards.
+	store := tsdb.NewStore(cmd.dataDir)
+	store.EngineOptions.Config.Dir = cmd.dataDir
+	store.EngineOptions.Config.WALDir = cmd.walDir
+	store.EngineOptions.IndexVersion = indexVersion
+
+	// The new shards hold the series of the old ones, so they must not be
+	// dropped by the node's limits.
+	store.EngineOptions.Config.MaxSeriesPerDatabase = 0
+	store.EngineOptions.Config.MaxValuesPerTag = 0
+
+	// Wait for the cache to be written to TSM files instead of failing when
+	// a large shard is copied.
+	store.EngineOptions.Config.CacheMaxWriteWait = toml.Duration(time.Minute)
+	if err := store.Open(); err != nil {
+		return err
+	}
+	defer store.Close()
+
+	// Create the shards of the new shard groups.
+	ids, err := client.ReserveShardIDs(len(targets))
+	if err != nil {
+		return err
+	}
+	for i := range targets {
+		targets[i].Shards = []meta.ShardInfo{{ID: ids[i]}}
+		if err := store.CreateShard(cmd.database, cmd.retentionPolicy, ids[i], true); err != nil {
+			cmd.removeShards(store, ids[:i])
+			return err
+		}
+	}
+
+	// Copy the data of the old shards into the new ones.  The old shards are
+	// left untouched until the new shard groups replace them.
+	w := newShardWriter(store, targets)
+	for _, sg := range sources {
+		for _, sh := range sg.Shards {
+			if err := w.copyShard(sh.ID); err != nil {
+				cmd.removeShards(store, ids)
+				return fmt.Errorf("copy shard %d: %s", sh.ID, err)
+			}
+		}
+	}
+
+	// Points that could not be copied would be lost once the old shards are
+	// removed.
+	if w.dropped > 0 && !cmd.force {
+		cmd.removeShards(store, ids)
+		return fmt.Errorf("%d points could not be copied, shard groups not replaced (use -force to replace them anyway)", w.dropped)
+	}
+
+	// Shard groups without data are not created.
+	var groups []meta.ShardGroupInfo
+	var empty []uint64
+	for i, sg := range targets {
+		if w.written[i] == 0 {
+			empty = append(empty, sg.Shards[0].ID)
+			continue
+		}
+		groups = append(groups, sg)
+	}
+	cmd.removeShards(store, empty)
+
+	// Swap the shard groups.
+	var sourceIDs []uint64
+	for _, sg := range sources {
+		sourceIDs = append(sourceIDs, sg.ID)
+	}
+	if err := client.ReplaceShardGroups(cmd.database, cmd.retentionPolicy, sourceIDs, groups); err != nil {
+		cmd.removeShards(store, ids)
+		return err
+	}
+
+	// The old shards are no longer referenced.
+	for _, sg := range sources {
+		for _, sh := range sg.Shards {
+			if err := store.DeleteShard(sh.ID); err != nil {
+				fmt.Fprintf(cmd.Stderr, "failed to remove shard %d: %s\n", sh.ID, err)
+			}
+		}
+	}
+
+	for _, sg := range groups {
+		fmt.Fprintf(cmd.Stdout, "Created shard %d for %s - %s\n", sg.Shards[0].ID, sg.StartTime.Format(time.RFC3339), sg.EndTime.Format(time.RFC3339))
+	}
+	if w.dropped > 0 {
+		fmt.Fprintf(cmd.Stderr, "%d points could not be copied\n", w.dropped)
+	}
+	return nil
+}
+
+// sourceIndexVersion returns the index type of the shards of groups.  It is
+// tsi1 if any of the shards has a tsi1 index directory, so partly converted
+// retention policies stay on tsi1.
+func (cmd *Command) sourceIndexVersion(groups []meta.ShardGroupInfo) (string, error) {
+	for _, sg := range groups {
+		for _, sh := range sg.Shards {
+			path := filepath.Join(cmd.dataDir, cmd.database, cmd.retentionPolicy, strconv.FormatUint(sh.ID, 10), "index")
+			if _, err := os.Stat(path); err == nil {
+				return "tsi1", nil
+			} else if !os.IsNotExist(err) {
+				return "", err
+			}
+		}
+	}
+	return "inmem", nil
+}
+
+// removeShards removes the new shards after a failed reshard.
+func (cmd *Command) removeShards(store *tsdb.Store, ids []uint64) {
+	for _, id := range ids {
+		if err := store.DeleteShard(id); err != nil {
+			fmt.Fprintf(cmd.Stderr, "failed to remove shard %d: %s\n", id, err)
+		}
+	}
+}
+
+// planShardGroups returns the shard groups that don't have a duration of d and
+// the shard groups of duration d replacing them.  New shard groups are aligned
+// like the ones created by the meta store, but only cover the time range of the
+// groups they replace.
+func planShardGroups(groups []meta.ShardGroupInfo, d time.Duration) (sources, targets []meta.ShardGroupInfo, err error) {
+	if d <= 0 {
+		return nil, nil, errors.New("shard group duration must be positive")
+	}
+
+	var others []meta.ShardGroupInfo
+	windows := make(map[time.Time]*meta.ShardGroupInfo)
+	for _, sg := range groups {
+		if sg.Deleted() {
+			continue
+		} else if sg.Truncated() || sg.EndTime.Sub(sg.StartTime) == d {
+			others = append(others, sg)
+			continue
+		}
+		sources = append(sources, sg)
+
+		for t := sg.StartTime.Truncate(d); t.Before(sg.EndTime); t = t.Add(d) {
+			start, end := t, t.Add(d)
+			if start.Before(sg.StartTime) {
+				start = sg.StartTime
+			}
+			if end.After(sg.EndTime) {
+				end = sg.EndTime
+			}
+
+			w := windows[t]
+			if w == nil {
+				windows[t] = &meta.ShardGroupInfo{StartTime: start, EndTime: end}
+				continue
+			}
+			if start.Before(w.StartTime) {
+				w.StartTime = start
+			}
+			if end.After(w.EndTime) {
+				w.EndTime = end
+			}
+		}
+	}
+
+	for _, w := range windows {
+		for _, sg := range others {
+			if w.StartTime.Before(sg.EndTime) && sg.StartTime.Before(w.EndTime) {
+				return nil, nil, fmt.Errorf("shard group %d (%s - %s) overlaps the new shard group %s - %s",
+					sg.ID, sg.StartTime.Format(time.RFC3339), sg.EndTime.Format(time.RFC3339),
+					w.StartTime.Format(time.RFC3339), w.EndTime.Format(time.RFC3339))
+			}
+		}
+		targets = append(targets, *w)
+	}
+	sort.Sort(meta.ShardGroupInfos(targets))
+
+	return sources, targets, nil
+}
+
+// shardWriter batches points by the target shard group they belong to.
+type shardWriter struct {
+	store   *tsdb.Store
+	targets []meta.ShardGroupInfo
+	batches map[int][]models.Point
+
+	// written is the number of points written to each target shard group.
+	written map[int]int
+
+	// dropped is the number of points that could not be written.
+	dropped int
+}
+
+// newShardWriter returns a shardWriter writing to the shards of targets.
+func newShardWriter(store *tsdb.Store, targets []meta.ShardGroupInfo) *shardWriter {
+	return &shardWriter{
+		store:   store,
+		targets: targets,
+		batches: make(map[int][]models.Point),
+		written: make(map[int]int),
+	}
+}
+
+// copyShard writes the data of a shard into the shards of the target shard
+// groups covering it.
+func (w *shardWriter) copyShard(id uint64) error {
+	sh := w.store.Shard(id)
+	if sh == nil {
+		// The shard has no data on this node.
+		return nil
+	}
+
+	dir, err := sh.CreateSnapshot()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	// Read the files from oldest to newest so newer values overwrite older ones.
+	var files []string
+	for _, fi := range fis {
+		if filepath.Ext(fi.Name()) == "."+tsm1.TSMFileExtension {
+			files = append(files, filepath.Join(dir, fi.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		if err := w.copyFile(path); err != nil {
+			return err
+		}
+	}
+	return w.flushAll()
+}
+
+// copyFile writes the values of a TSM file to the target shards.
+func (w *shardWriter) copyFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	for i := 0; i < r.KeyCount(); i++ {
+		key, _ := r.KeyAt(i)
+		values, err := r.ReadAll(key)
+		if err != nil {
+			return err
+		}
+
+		seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey(key)
+		name, tags := models.ParseKey(seriesKey)
+
+		for _, v := range values {
+			t := time.Unix(0, v.UnixNano()).UTC()
+			idx := sort.Search(len(w.targets), func(i int) bool { return w.targets[i].EndTime.After(t) })
+			if idx == len(w.targets) || !w.targets[idx].Contains(t) {
+				// Values outside of their shard group's time range are not copied.
+				w.dropped++
+				continue
+			}
+
+			pt, err := models.NewPoint(name, tags, models.Fields{string(field): v.Value()}, t)
+			if err != nil {
+				w.dropped++
+				continue
+			}
+
+			w.batches[idx] = append(w.batches[idx], pt)
+			if len(w.batches[idx]) >= batchSize {
+				if err := w.flush(idx); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// flush writes the batched points of a target shard group.
+func (w *shardWriter) flush(idx int) error {
+	points := w.batches[idx]
+	if len(points) == 0 {
+		return nil
+	}
+	w.batches[idx] = nil
+
+	w.written[idx] += len(points)
+	if err := w.store.WriteToShard(w.targets[idx].Shards[0].ID, points); err != nil {
+		perr, ok := err.(tsdb.PartialWriteError)
+		if !ok {
+			return err
+		}
+		w.written[idx] -= perr.Dropped
+		w.dropped += perr.Dropped
+	}
+	return nil
+}
+
+// flushAll writes the batched points of all target shard groups.
+func (w *shardWriter) flushAll() error {
+	for idx := range w.batches {
+		if err := w.flush(idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
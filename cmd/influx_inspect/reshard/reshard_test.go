@@ -0,0 +1,345 @@
+package reshard
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	_ "github.com/influxdata/influxdb/tsdb/engine"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	_ "github.com/influxdata/influxdb/tsdb/index"
+)
+
+var t0 = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestPlanShardGroups_Merge(t *testing.T) {
+	groups := []meta.ShardGroupInfo{
+		{ID: 1, StartTime: t0, EndTime: t0.Add(time.Hour)},
+		{ID: 2, StartTime: t0.Add(2 * time.Hour), EndTime: t0.Add(3 * time.Hour)},
+		{ID: 3, StartTime: t0.Add(24 * time.Hour), EndTime: t0.Add(25 * time.Hour)},
+		{ID: 4, StartTime: t0.Add(48 * time.Hour), EndTime: t0.Add(72 * time.Hour)},
+		{ID: 5, StartTime: t0.Add(25 * time.Hour), EndTime: t0.Add(26 * time.Hour), DeletedAt: t0},
+	}
+
+	sources, targets, err := planShardGroups(groups, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := sources, groups[:3]; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected sources: got %v, exp %v", got, exp)
+	}
+
+	exp := []meta.ShardGroupInfo{
+		{StartTime: t0, EndTime: t0.Add(3 * time.Hour)},
+		{StartTime: t0.Add(24 * time.Hour), EndTime: t0.Add(25 * time.Hour)},
+	}
+	if !reflect.DeepEqual(targets, exp) {
+		t.Fatalf("unexpected targets: got %v, exp %v", targets, exp)
+	}
+}
+
+func TestPlanShardGroups_Split(t *testing.T) {
+	groups := []meta.ShardGroupInfo{
+		{ID: 1, StartTime: t0, EndTime: t0.Add(7 * 24 * time.Hour)},
+	}
+
+	sources, targets, err := planShardGroups(groups, 48*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := len(sources), 1; got != exp {
+		t.Fatalf("unexpected sources: got %d, exp %d", got, exp)
+	}
+
+	// Windows are aligned to the duration and clipped to the group, so the
+	// first one is shortened to start at the group start.
+	exp := []meta.ShardGroupInfo{
+		{StartTime: t0, EndTime: t0.Add(24 * time.Hour)},
+		{StartTime: t0.Add(24 * time.Hour), EndTime: t0.Add(72 * time.Hour)},
+		{StartTime: t0.Add(72 * time.Hour), EndTime: t0.Add(120 * time.Hour)},
+		{StartTime: t0.Add(120 * time.Hour), EndTime: t0.Add(168 * time.Hour)},
+	}
+	if !reflect.DeepEqual(targets, exp) {
+		t.Fatalf("unexpected targets: got %v, exp %v", targets, exp)
+	}
+}
+
+func TestPlanShardGroups_Overlap(t *testing.T) {
+	groups := []meta.ShardGroupInfo{
+		{ID: 1, StartTime: t0, EndTime: t0.Add(time.Hour)},
+		{ID: 2, StartTime: t0.Add(time.Hour), EndTime: t0.Add(25 * time.Hour)},
+		{ID: 3, StartTime: t0.Add(2 * time.Hour), EndTime: t0.Add(3 * time.Hour)},
+	}
+
+	// The new group covering groups 1 and 3 would overlap group 2.
+	if _, _, err := planShardGroups(groups, 24*time.Hour); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCommand_Run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reshard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	metaDir, dataDir, walDir := filepath.Join(dir, "meta"), filepath.Join(dir, "data"), filepath.Join(dir, "wal")
+
+	// Create three hourly shard groups and write a point to each.
+	client := openMetaClient(t, metaDir)
+	if _, err := client.CreateDatabaseWithRetentionPolicy("db0", &meta.RetentionPolicySpec{
+		Name:               "rp0",
+		ShardGroupDuration: time.Hour,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := openStore(t, dataDir, walDir)
+	var oldIDs []uint64
+	for i := 0; i < 3; i++ {
+		ts := t0.Add(time.Duration(i) * time.Hour)
+		sg, err := client.CreateShardGroup("db0", "rp0", ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		id := sg.Shards[0].ID
+		oldIDs = append(oldIDs, id)
+		if err := store.CreateShard("db0", "rp0", id, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.WriteToShard(id, []models.Point{
+			models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": float64(i)}, ts),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Close()
+	client.Close()
+
+	cmd := NewCommand()
+	cmd.Stdout, cmd.Stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	if err := cmd.Run("-metadir", metaDir, "-datadir", dataDir, "-waldir", walDir,
+		"-database", "db0", "-retention", "rp0", "-duration", "24h"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The hourly groups are replaced by a single group.
+	client = openMetaClient(t, metaDir)
+	defer client.Close()
+
+	rpi, err := client.RetentionPolicy("db0", "rp0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var groups []meta.ShardGroupInfo
+	for _, sg := range rpi.ShardGroups {
+		if !sg.Deleted() {
+			groups = append(groups, sg)
+		}
+	}
+	if len(groups) != 1 {
+		t.Fatalf("unexpected shard groups: %v", groups)
+	} else if !groups[0].StartTime.Equal(t0) || !groups[0].EndTime.Equal(t0.Add(3*time.Hour)) {
+		t.Fatalf("unexpected shard group time range: %v", groups[0])
+	}
+
+	store = openStore(t, dataDir, walDir)
+	defer store.Close()
+
+	for _, id := range oldIDs {
+		if store.Shard(id) != nil {
+			t.Fatalf("shard %d not removed", id)
+		}
+	}
+
+	sh := store.Shard(groups[0].Shards[0].ID)
+	if sh == nil {
+		t.Fatal("new shard not found")
+	} else if got, exp := sh.SeriesN(), int64(1); got != exp {
+		t.Fatalf("unexpected series count: got %d, exp %d", got, exp)
+	}
+
+	// All values were copied.
+	snapshot, err := sh.CreateSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(snapshot)
+
+	files, err := filepath.Glob(filepath.Join(snapshot, "*."+tsm1.TSMFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("unexpected TSM files: %v", files)
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	values, err := r.ReadAll([]byte("cpu,host=A#!~#value"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(values) != 3 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+}
+
+// Ensure shards with conflicting field types are not replaced.
+func TestCommand_Run_FieldTypeConflict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reshard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	metaDir, dataDir, walDir := filepath.Join(dir, "meta"), filepath.Join(dir, "data"), filepath.Join(dir, "wal")
+
+	// Create two hourly shard groups with a field of a different type in each.
+	client := openMetaClient(t, metaDir)
+	if _, err := client.CreateDatabaseWithRetentionPolicy("db0", &meta.RetentionPolicySpec{
+		Name:               "rp0",
+		ShardGroupDuration: time.Hour,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := openStore(t, dataDir, walDir)
+	var oldIDs []uint64
+	for i, value := range []interface{}{float64(1), int64(2)} {
+		ts := t0.Add(time.Duration(i) * time.Hour)
+		sg, err := client.CreateShardGroup("db0", "rp0", ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		id := sg.Shards[0].ID
+		oldIDs = append(oldIDs, id)
+		if err := store.CreateShard("db0", "rp0", id, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.WriteToShard(id, []models.Point{
+			models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "A"}), models.Fields{"value": value}, ts),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Close()
+	client.Close()
+
+	cmd := NewCommand()
+	cmd.Stdout, cmd.Stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	if err := cmd.Run("-metadir", metaDir, "-datadir", dataDir, "-waldir", walDir,
+		"-database", "db0", "-retention", "rp0", "-duration", "24h"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// The hourly groups and their shards are kept.
+	client = openMetaClient(t, metaDir)
+	defer client.Close()
+
+	rpi, err := client.RetentionPolicy("db0", "rp0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var groups []meta.ShardGroupInfo
+	for _, sg := range rpi.ShardGroups {
+		if !sg.Deleted() {
+			groups = append(groups, sg)
+		}
+	}
+	if len(groups) != 2 {
+		t.Fatalf("unexpected shard groups: %v", groups)
+	}
+
+	store = openStore(t, dataDir, walDir)
+	defer store.Close()
+
+	for _, id := range oldIDs {
+		if sh := store.Shard(id); sh == nil {
+			t.Fatalf("shard %d removed", id)
+		} else if got, exp := sh.SeriesN(), int64(1); got != exp {
+			t.Fatalf("unexpected series count in shard %d: got %d, exp %d", id, got, exp)
+		}
+	}
+
+	// The new shard is removed.
+	if got, exp := store.ShardN(), len(oldIDs); got != exp {
+		t.Fatalf("unexpected shard count: got %d, exp %d", got, exp)
+	}
+}
+
+func TestCommand_SourceIndexVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reshard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := &Command{dataDir: dir, database: "db0", retentionPolicy: "rp0"}
+	groups := []meta.ShardGroupInfo{
+		{ID: 1, Shards: []meta.ShardInfo{{ID: 1}}},
+		{ID: 2, Shards: []meta.ShardInfo{{ID: 2}}},
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "db0", "rp0", "1"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := cmd.sourceIndexVersion(groups); err != nil {
+		t.Fatal(err)
+	} else if got != "inmem" {
+		t.Fatalf("unexpected index type: %s", got)
+	}
+
+	// A shard with a tsi1 index makes the new shards use tsi1.
+	if err := os.MkdirAll(filepath.Join(dir, "db0", "rp0", "2", "index"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := cmd.sourceIndexVersion(groups); err != nil {
+		t.Fatal(err)
+	} else if got != "tsi1" {
+		t.Fatalf("unexpected index type: %s", got)
+	}
+}
+
+func openMetaClient(t *testing.T, dir string) *meta.Client {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	config := meta.NewConfig()
+	config.Dir = dir
+	client := meta.NewClient(config)
+	if err := client.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func openStore(t *testing.T, dataDir, walDir string) *tsdb.Store {
+	store := tsdb.NewStore(dataDir)
+	store.EngineOptions.Config.WALDir = walDir
+	if err := store.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/influxdata/influxdb/coordinator"
 	"github.com/influxdata/influxdb/monitor"
 	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/services/audit"
 	"github.com/influxdata/influxdb/services/collectd"
 	"github.com/influxdata/influxdb/services/continuous_querier"
 	"github.com/influxdata/influxdb/services/graphite"
@@ -45,6 +46,7 @@ type Config struct {
 	Precreator  precreator.Config  `toml:"shard-precreation"`
 
 	Monitor        monitor.Config    `toml:"monitor"`
+	Audit          audit.Config      `toml:"audit"`
 	Subscriber     subscriber.Config `toml:"subscriber"`
 	HTTPD          httpd.Config      `toml:"http"`
 	GraphiteInputs []graphite.Config `toml:"graphite"`
@@ -70,6 +72,7 @@ func NewConfig() *Config {
 	c.Precreator = precreator.NewConfig()
 
 	c.Monitor = monitor.NewConfig()
+	c.Audit = audit.NewConfig()
 	c.Subscriber = subscriber.NewConfig()
 	c.HTTPD = httpd.NewConfig()
 
@@ -103,6 +106,7 @@ func NewDemoConfig() (*Config, error) {
 	c.Meta.Dir = filepath.Join(homeDir, ".influxdb/meta")
 	c.Data.Dir = filepath.Join(homeDir, ".influxdb/data")
 	c.Data.WALDir = filepath.Join(homeDir, ".influxdb/wal")
+	c.Audit.Path = filepath.Join(homeDir, ".influxdb/audit.log")
 
 	return c, nil
 }
@@ -153,6 +157,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Audit.Validate(); err != nil {
+		return err
+	}
+
 	if err := c.ContinuousQuery.Validate(); err != nil {
 		return err
 	}
@@ -169,6 +177,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.HTTPD.Validate(); err != nil {
+		return err
+	}
+
 	for _, graphite := range c.GraphiteInputs {
 		if err := graphite.Validate(); err != nil {
 			return fmt.Errorf("invalid graphite config: %v", err)
@@ -325,6 +337,7 @@ func (c *Config) diagnosticsClients() map[string]diagnostics.Client {
 		"config-precreator":  c.Precreator,
 
 		"config-monitor":    c.Monitor,
+		"config-audit":      c.Audit,
 		"config-subscriber": c.Subscriber,
 		"config-httpd":      c.HTTPD,
 
@@ -290,8 +290,12 @@ func TestConfig_InvalidSubsections(t *testing.T) {
 		{"meta", `dir = ""`},
 		{"data", `dir = ""`},
 		{"monitor", `store-database = ""`},
+		{"audit", "enabled = true\nmax-backups = -1"},
 		{"continuous_queries", `run-interval = "0s"`},
 		{"subscriber", `http-timeout = "0s"`},
+		{"http", `auth-provider = "ldap"`},
+		{"http", `https-client-cert-auth = true`},
+		{"subscriber", `client-private-key = "/etc/ssl/client.key"`},
 		{"retention", `check-interval = "0s"`},
 		{"shard-precreation", `advance-period = "0s"`},
 	} {
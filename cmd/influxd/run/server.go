@@ -16,6 +16,7 @@ import (
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/monitor"
+	"github.com/influxdata/influxdb/services/audit"
 	"github.com/influxdata/influxdb/services/collectd"
 	"github.com/influxdata/influxdb/services/continuous_querier"
 	"github.com/influxdata/influxdb/services/graphite"
@@ -71,6 +72,7 @@ type Server struct {
 	QueryExecutor *influxql.QueryExecutor
 	PointsWriter  *coordinator.PointsWriter
 	Subscriber    *subscriber.Service
+	Auditor       *audit.Service
 
 	Services []Service
 
@@ -178,9 +180,16 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 	s.PointsWriter.WriteTimeout = time.Duration(c.Coordinator.WriteTimeout)
 	s.PointsWriter.TSDBStore = s.TSDBStore
 
+	// Initialize the audit log.
+	if c.Audit.Enabled {
+		s.Auditor = audit.NewService(c.Audit)
+		s.Auditor.MetaClient = s.MetaClient
+		s.Auditor.PointsWriter = (*monitorPointsWriter)(s.PointsWriter)
+	}
+
 	// Initialize query executor.
 	s.QueryExecutor = influxql.NewQueryExecutor()
-	s.QueryExecutor.StatementExecutor = &coordinator.StatementExecutor{
+	executor := &coordinator.StatementExecutor{
 		MetaClient:  s.MetaClient,
 		TaskManager: s.QueryExecutor.TaskManager,
 		TSDBStore:   coordinator.LocalTSDBStore{Store: s.TSDBStore},
@@ -194,6 +203,10 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 		MaxSelectSeriesN:  c.Coordinator.MaxSelectSeriesN,
 		MaxSelectBucketsN: c.Coordinator.MaxSelectBucketsN,
 	}
+	if s.Auditor != nil {
+		executor.Auditor = s.Auditor
+	}
+	s.QueryExecutor.StatementExecutor = executor
 	s.QueryExecutor.TaskManager.QueryTimeout = time.Duration(c.Coordinator.QueryTimeout)
 	s.QueryExecutor.TaskManager.LogQueriesAfter = time.Duration(c.Coordinator.LogQueriesAfter)
 	s.QueryExecutor.TaskManager.MaxConcurrentQueries = c.Coordinator.MaxConcurrentQueries
@@ -214,6 +227,9 @@ func (s *Server) Statistics(tags map[string]string) []models.Statistic {
 	statistics = append(statistics, s.TSDBStore.Statistics(tags)...)
 	statistics = append(statistics, s.PointsWriter.Statistics(tags)...)
 	statistics = append(statistics, s.Subscriber.Statistics(tags)...)
+	if s.Auditor != nil {
+		statistics = append(statistics, s.Auditor.Statistics(tags)...)
+	}
 	for _, srv := range s.Services {
 		if m, ok := srv.(monitor.Reporter); ok {
 			statistics = append(statistics, m.Statistics(tags)...)
@@ -263,6 +279,9 @@ func (s *Server) appendHTTPDService(c httpd.Config) {
 	srv.Handler.PointsWriter = s.PointsWriter
 	srv.Handler.Version = s.buildInfo.Version
 	srv.Handler.BuildType = "OSS"
+	if s.Auditor != nil {
+		srv.Handler.Auditor = s.Auditor
+	}
 
 	s.Services = append(s.Services, srv)
 }
@@ -401,12 +420,29 @@ func (s *Server) Open() error {
 	}
 	s.PointsWriter.WithLogger(s.Logger)
 	s.Subscriber.WithLogger(s.Logger)
+	if s.Auditor != nil {
+		s.Auditor.WithLogger(s.Logger)
+	}
 	for _, svc := range s.Services {
 		svc.WithLogger(s.Logger)
 	}
 	s.SnapshotterService.WithLogger(s.Logger)
 	s.Monitor.WithLogger(s.Logger)
 
+	// Apply per-database WAL modes, duplicate policies and cardinality limits
+	// before shards are loaded.
+	for _, di := range s.MetaClient.Databases() {
+		if err := s.TSDBStore.SetDatabaseWALMode(di.Name, di.WALMode); err != nil {
+			return fmt.Errorf("set wal mode: %s", err)
+		}
+		if err := s.TSDBStore.SetDatabaseDuplicatePolicy(di.Name, di.DuplicatePolicy); err != nil {
+			return fmt.Errorf("set duplicate policy: %s", err)
+		}
+		if err := s.TSDBStore.SetDatabaseCardinalityLimits(di.Name, coordinator.CardinalityLimits(di.CardinalityLimits)); err != nil {
+			return fmt.Errorf("set cardinality limits: %s", err)
+		}
+	}
+
 	// Open TSDB store.
 	if err := s.TSDBStore.Open(); err != nil {
 		return fmt.Errorf("open tsdb store: %s", err)
@@ -424,6 +460,13 @@ func (s *Server) Open() error {
 
 	s.PointsWriter.AddWriteSubscriber(s.Subscriber.Points())
 
+	// Open the audit log before any service can execute statements.
+	if s.Auditor != nil {
+		if err := s.Auditor.Open(); err != nil {
+			return fmt.Errorf("open audit log: %s", err)
+		}
+	}
+
 	for _, service := range s.Services {
 		if err := service.Open(); err != nil {
 			return fmt.Errorf("open service: %s", err)
@@ -463,6 +506,10 @@ func (s *Server) Close() error {
 		s.QueryExecutor.Close()
 	}
 
+	if s.Auditor != nil {
+		s.Auditor.Close()
+	}
+
 	// Close the TSDBStore, no more reads or writes at this point
 	if s.TSDBStore != nil {
 		s.TSDBStore.Close()
@@ -13,23 +13,38 @@ type MetaClient interface {
 	CreateDatabase(name string) (*meta.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicy(name string, spec *meta.RetentionPolicySpec) (*meta.DatabaseInfo, error)
 	CreateRetentionPolicy(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	CreateRole(name string) error
 	CreateSubscription(database, rp, name, mode string, destinations []string) error
+	CreateToken(username, database string, privilege influxql.Privilege, expires time.Duration) (string, *meta.TokenInfo, error)
 	CreateUser(name, password string, admin bool) (meta.User, error)
 	Database(name string) *meta.DatabaseInfo
 	Databases() []meta.DatabaseInfo
+	DropCardinalityLimit(database, measurement, tagKey string) error
 	DropShard(id uint64) error
 	DropContinuousQuery(database, name string) error
 	DropDatabase(name string) error
 	DropRetentionPolicy(database, name string) error
+	DropRole(name string) error
 	DropSubscription(database, rp, name string) error
+	DropToken(id string) error
 	DropUser(name string) error
+	AddUserRole(username, role string) error
+	RemoveUserRole(username, role string) error
 	RetentionPolicy(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
+	Roles() []meta.RoleInfo
 	SetAdminPrivilege(username string, admin bool) error
+	SetCardinalityLimit(database string, limit meta.CardinalityLimitInfo) error
 	SetPrivilege(username, database string, p influxql.Privilege) error
+	SetRolePrivilege(name, database string, p influxql.Privilege) error
+	SetSeriesPrivilege(username string, grant meta.SeriesGrantInfo) error
 	ShardGroupsByTimeRange(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	Tokens() []meta.TokenInfo
+	UpdateDatabase(name string, du *meta.DatabaseUpdate) error
 	UpdateRetentionPolicy(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUser(name, password string) error
 	UserPrivilege(username, database string) (*influxql.Privilege, error)
 	UserPrivileges(username string) (map[string]influxql.Privilege, error)
+	UserRoles(username string) ([]string, error)
+	UserSeriesGrants(username string) ([]meta.SeriesGrantInfo, error)
 	Users() []meta.UserInfo
 }
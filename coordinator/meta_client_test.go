@@ -9,11 +9,14 @@ import (
 
 // MetaClient is a mockable implementation of cluster.MetaClient.
 type MetaClient struct {
+	AddUserRoleFn                       func(username, role string) error
 	CreateContinuousQueryFn             func(database, name, query string) error
 	CreateDatabaseFn                    func(name string) (*meta.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicyFn func(name string, spec *meta.RetentionPolicySpec) (*meta.DatabaseInfo, error)
 	CreateRetentionPolicyFn             func(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	CreateRoleFn                        func(name string) error
 	CreateSubscriptionFn                func(database, rp, name, mode string, destinations []string) error
+	CreateTokenFn                       func(username, database string, privilege influxql.Privilege, expires time.Duration) (string, *meta.TokenInfo, error)
 	CreateUserFn                        func(name, password string, admin bool) (meta.User, error)
 	DatabaseFn                          func(name string) *meta.DatabaseInfo
 	DatabasesFn                         func() []meta.DatabaseInfo
@@ -21,24 +24,40 @@ type MetaClient struct {
 	DataNodesFn                         func() ([]meta.NodeInfo, error)
 	DeleteDataNodeFn                    func(id uint64) error
 	DeleteMetaNodeFn                    func(id uint64) error
+	DropCardinalityLimitFn              func(database, measurement, tagKey string) error
 	DropContinuousQueryFn               func(database, name string) error
 	DropDatabaseFn                      func(name string) error
 	DropRetentionPolicyFn               func(database, name string) error
+	DropRoleFn                          func(name string) error
 	DropSubscriptionFn                  func(database, rp, name string) error
 	DropShardFn                         func(id uint64) error
+	DropTokenFn                         func(id string) error
 	DropUserFn                          func(name string) error
 	MetaNodesFn                         func() ([]meta.NodeInfo, error)
+	RemoveUserRoleFn                    func(username, role string) error
 	RetentionPolicyFn                   func(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
+	RolesFn                             func() []meta.RoleInfo
 	SetAdminPrivilegeFn                 func(username string, admin bool) error
+	SetCardinalityLimitFn               func(database string, limit meta.CardinalityLimitInfo) error
 	SetPrivilegeFn                      func(username, database string, p influxql.Privilege) error
+	SetRolePrivilegeFn                  func(name, database string, p influxql.Privilege) error
+	SetSeriesPrivilegeFn                func(username string, grant meta.SeriesGrantInfo) error
 	ShardGroupsByTimeRangeFn            func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	TokensFn                            func() []meta.TokenInfo
+	UpdateDatabaseFn                    func(name string, du *meta.DatabaseUpdate) error
 	UpdateRetentionPolicyFn             func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUserFn                        func(name, password string) error
 	UserPrivilegeFn                     func(username, database string) (*influxql.Privilege, error)
 	UserPrivilegesFn                    func(username string) (map[string]influxql.Privilege, error)
+	UserRolesFn                         func(username string) ([]string, error)
+	UserSeriesGrantsFn                  func(username string) ([]meta.SeriesGrantInfo, error)
 	UsersFn                             func() []meta.UserInfo
 }
 
+func (c *MetaClient) AddUserRole(username, role string) error {
+	return c.AddUserRoleFn(username, role)
+}
+
 func (c *MetaClient) CreateContinuousQuery(database, name, query string) error {
 	return c.CreateContinuousQueryFn(database, name, query)
 }
@@ -59,10 +78,18 @@ func (c *MetaClient) DropShard(id uint64) error {
 	return c.DropShardFn(id)
 }
 
+func (c *MetaClient) CreateRole(name string) error {
+	return c.CreateRoleFn(name)
+}
+
 func (c *MetaClient) CreateSubscription(database, rp, name, mode string, destinations []string) error {
 	return c.CreateSubscriptionFn(database, rp, name, mode, destinations)
 }
 
+func (c *MetaClient) CreateToken(username, database string, privilege influxql.Privilege, expires time.Duration) (string, *meta.TokenInfo, error) {
+	return c.CreateTokenFn(username, database, privilege, expires)
+}
+
 func (c *MetaClient) CreateUser(name, password string, admin bool) (meta.User, error) {
 	return c.CreateUserFn(name, password, admin)
 }
@@ -91,6 +118,10 @@ func (c *MetaClient) DeleteMetaNode(id uint64) error {
 	return c.DeleteMetaNodeFn(id)
 }
 
+func (c *MetaClient) DropCardinalityLimit(database, measurement, tagKey string) error {
+	return c.DropCardinalityLimitFn(database, measurement, tagKey)
+}
+
 func (c *MetaClient) DropContinuousQuery(database, name string) error {
 	return c.DropContinuousQueryFn(database, name)
 }
@@ -103,10 +134,18 @@ func (c *MetaClient) DropRetentionPolicy(database, name string) error {
 	return c.DropRetentionPolicyFn(database, name)
 }
 
+func (c *MetaClient) DropRole(name string) error {
+	return c.DropRoleFn(name)
+}
+
 func (c *MetaClient) DropSubscription(database, rp, name string) error {
 	return c.DropSubscriptionFn(database, rp, name)
 }
 
+func (c *MetaClient) DropToken(id string) error {
+	return c.DropTokenFn(id)
+}
+
 func (c *MetaClient) DropUser(name string) error {
 	return c.DropUserFn(name)
 }
@@ -115,22 +154,50 @@ func (c *MetaClient) MetaNodes() ([]meta.NodeInfo, error) {
 	return c.MetaNodesFn()
 }
 
+func (c *MetaClient) RemoveUserRole(username, role string) error {
+	return c.RemoveUserRoleFn(username, role)
+}
+
 func (c *MetaClient) RetentionPolicy(database, name string) (rpi *meta.RetentionPolicyInfo, err error) {
 	return c.RetentionPolicyFn(database, name)
 }
 
+func (c *MetaClient) Roles() []meta.RoleInfo {
+	return c.RolesFn()
+}
+
 func (c *MetaClient) SetAdminPrivilege(username string, admin bool) error {
 	return c.SetAdminPrivilegeFn(username, admin)
 }
 
+func (c *MetaClient) SetCardinalityLimit(database string, limit meta.CardinalityLimitInfo) error {
+	return c.SetCardinalityLimitFn(database, limit)
+}
+
 func (c *MetaClient) SetPrivilege(username, database string, p influxql.Privilege) error {
 	return c.SetPrivilegeFn(username, database, p)
 }
 
+func (c *MetaClient) SetRolePrivilege(name, database string, p influxql.Privilege) error {
+	return c.SetRolePrivilegeFn(name, database, p)
+}
+
+func (c *MetaClient) SetSeriesPrivilege(username string, grant meta.SeriesGrantInfo) error {
+	return c.SetSeriesPrivilegeFn(username, grant)
+}
+
 func (c *MetaClient) ShardGroupsByTimeRange(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error) {
 	return c.ShardGroupsByTimeRangeFn(database, policy, min, max)
 }
 
+func (c *MetaClient) Tokens() []meta.TokenInfo {
+	return c.TokensFn()
+}
+
+func (c *MetaClient) UpdateDatabase(name string, du *meta.DatabaseUpdate) error {
+	return c.UpdateDatabaseFn(name, du)
+}
+
 func (c *MetaClient) UpdateRetentionPolicy(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error {
 	return c.UpdateRetentionPolicyFn(database, name, rpu, makeDefault)
 }
@@ -147,6 +214,14 @@ func (c *MetaClient) UserPrivileges(username string) (map[string]influxql.Privil
 	return c.UserPrivilegesFn(username)
 }
 
+func (c *MetaClient) UserRoles(username string) ([]string, error) {
+	return c.UserRolesFn(username)
+}
+
+func (c *MetaClient) UserSeriesGrants(username string) ([]meta.SeriesGrantInfo, error) {
+	return c.UserSeriesGrantsFn(username)
+}
+
 func (c *MetaClient) Users() []meta.UserInfo {
 	return c.UsersFn()
 }
@@ -40,6 +40,24 @@ var (
 	ErrWriteFailed = errors.New("write failed")
 )
 
+// SeriesAuthorizationError is returned when a user writes points to series
+// they are not authorized to write to. None of the points are written.
+type SeriesAuthorizationError struct {
+	Database string
+
+	// The points the user is not authorized to write.
+	DroppedPoints []tsdb.DroppedPoint
+}
+
+// Error returns the reason the first point was rejected.
+func (e *SeriesAuthorizationError) Error() string {
+	return e.DroppedPoints[0].Reason.Message
+}
+
+// AuthorizationFailed returns true so the error is treated as an
+// authorization failure by callers.
+func (e *SeriesAuthorizationError) AuthorizationFailed() bool { return true }
+
 // PointsWriter handles writes across multiple local and remote data nodes.
 type PointsWriter struct {
 	mu           sync.RWMutex
@@ -279,11 +297,25 @@ func (l sgList) Append(sgi meta.ShardGroupInfo) sgList {
 // WritePointsInto is a copy of WritePoints that uses a tsdb structure instead of
 // a cluster structure for information. This is to avoid a circular dependency.
 func (w *PointsWriter) WritePointsInto(p *IntoWriteRequest) error {
-	return w.WritePointsPrivileged(p.Database, p.RetentionPolicy, models.ConsistencyLevelOne, p.Points)
+	return w.WritePoints(p.Database, p.RetentionPolicy, models.ConsistencyLevelOne, p.User, p.Points)
 }
 
 // WritePoints writes the data to the underlying storage. consitencyLevel and user are only used for clustered scenarios
 func (w *PointsWriter) WritePoints(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error {
+	if user != nil && !user.IsAdmin() {
+		var dropped []tsdb.DroppedPoint
+		for _, p := range points {
+			if !user.AuthorizeSeriesWrite(database, p.Name(), p.Tags()) {
+				dropped = append(dropped, tsdb.DroppedPoint{Point: p, Reason: tsdb.DropReason{
+					Code:    tsdb.DropReasonUnauthorizedSeries,
+					Message: fmt.Sprintf("%s not authorized to write series %s to %s", user.ID(), p.Key(), database),
+				}})
+			}
+		}
+		if len(dropped) > 0 {
+			return &SeriesAuthorizationError{Database: database, DroppedPoints: dropped}
+		}
+	}
 	return w.WritePointsPrivileged(database, retentionPolicy, consistencyLevel, points)
 }
 
@@ -292,14 +324,21 @@ func (w *PointsWriter) WritePointsPrivileged(database, retentionPolicy string, c
 	atomic.AddInt64(&w.stats.WriteReq, 1)
 	atomic.AddInt64(&w.stats.PointWriteReq, int64(len(points)))
 
+	db := w.MetaClient.Database(database)
 	if retentionPolicy == "" {
-		db := w.MetaClient.Database(database)
 		if db == nil {
 			return influxdb.ErrDatabaseNotFound(database)
 		}
 		retentionPolicy = db.DefaultRetentionPolicy
 	}
 
+	// Refuse writes to read-only databases and retention policies.
+	if db != nil {
+		if err := db.CheckWritable(retentionPolicy); err != nil {
+			return err
+		}
+	}
+
 	shardMappings, err := w.MapShards(&WritePointsRequest{Database: database, RetentionPolicy: retentionPolicy, Points: points})
 	if err != nil {
 		return err
@@ -337,8 +376,12 @@ func (w *PointsWriter) WritePointsPrivileged(database, retentionPolicy string, c
 	}
 
 	if err == nil && len(shardMappings.Dropped) > 0 {
-		err = tsdb.PartialWriteError{Reason: "points beyond retention policy", Dropped: len(shardMappings.Dropped)}
-
+		reason := tsdb.DropReason{Code: tsdb.DropReasonOutsideRetention, Message: "point beyond retention policy"}
+		dropped := make([]tsdb.DroppedPoint, len(shardMappings.Dropped))
+		for i, p := range shardMappings.Dropped {
+			dropped[i] = tsdb.DroppedPoint{Point: p, Reason: reason}
+		}
+		err = tsdb.PartialWriteError{Reason: "points beyond retention policy", Dropped: len(dropped), DroppedPoints: dropped}
 	}
 	timeout := time.NewTimer(w.WriteTimeout)
 	defer timeout.Stop()
@@ -350,15 +393,31 @@ func (w *PointsWriter) WritePointsPrivileged(database, retentionPolicy string, c
 			atomic.AddInt64(&w.stats.WriteTimeout, 1)
 			// return timeout error to caller
 			return ErrTimeout
-		case err := <-ch:
-			if err != nil {
-				return err
+		case werr := <-ch:
+			if perr, ok := werr.(tsdb.PartialWriteError); ok {
+				err = mergePartialWriteErrors(err, perr)
+			} else if werr != nil {
+				return werr
 			}
 		}
 	}
 	return err
 }
 
+// mergePartialWriteErrors combines a partial write error from a shard with the
+// errors of the write so far, so the total number of dropped points is reported.
+// The reason of the first error is kept; the reasons of each dropped point are
+// in DroppedPoints.
+func mergePartialWriteErrors(err error, perr tsdb.PartialWriteError) error {
+	prev, ok := err.(tsdb.PartialWriteError)
+	if !ok {
+		return perr
+	}
+	prev.Dropped += perr.Dropped
+	prev.DroppedPoints = append(prev.DroppedPoints, perr.DroppedPoints...)
+	return prev
+}
+
 // writeToShards writes points to a shard.
 func (w *PointsWriter) writeToShard(shard *meta.ShardInfo, database, retentionPolicy string, points []models.Point) error {
 	atomic.AddInt64(&w.stats.PointWriteReqLocal, int64(len(points)))
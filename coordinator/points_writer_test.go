@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tsdb"
@@ -423,8 +424,56 @@ func TestPointsWriter_WritePoints_Dropped(t *testing.T) {
 	defer c.Close()
 
 	err := c.WritePointsPrivileged(pr.Database, pr.RetentionPolicy, models.ConsistencyLevelOne, pr.Points)
-	if _, ok := err.(tsdb.PartialWriteError); !ok {
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
 		t.Errorf("PointsWriter.WritePoints(): got %v, exp %v", err, tsdb.PartialWriteError{})
+	} else if len(perr.DroppedPoints) != 1 || perr.DroppedPoints[0].Point != pr.Points[0] {
+		t.Errorf("unexpected dropped points: %v", perr.DroppedPoints)
+	} else if code := perr.DroppedPoints[0].Reason.Code; code != tsdb.DropReasonOutsideRetention {
+		t.Errorf("unexpected drop reason: %s", code)
+	}
+}
+
+// Ensures the partial write errors of a write are merged into one error that
+// keeps the first reason and counts every dropped point.
+func TestPointsWriter_WritePoints_MergePartialWriteErrors(t *testing.T) {
+	pr := &coordinator.WritePointsRequest{
+		Database:        "mydb",
+		RetentionPolicy: "myrp",
+	}
+
+	// The first point is beyond the one hour retention policy and the second
+	// is dropped by the shard.  The merged error keeps the first reason and
+	// adds up the dropped counts.
+	ms := NewPointsWriterMetaClient()
+	pr.AddPoint("cpu", 1.0, time.Now().Add(-24*time.Hour), nil)
+	pr.AddPoint("cpu", 2.0, time.Now(), nil)
+
+	ms.DatabaseFn = func(database string) *meta.DatabaseInfo {
+		return nil
+	}
+	ms.NodeIDFn = func() uint64 { return 1 }
+
+	store := &fakeStore{
+		WriteFn: func(shardID uint64, points []models.Point) error {
+			return tsdb.PartialWriteError{Reason: "field type conflict", Dropped: len(points)}
+		},
+	}
+
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	c.TSDBStore = store
+	c.Node = &influxdb.Node{ID: 1}
+
+	c.Open()
+	defer c.Close()
+
+	err := c.WritePointsPrivileged(pr.Database, pr.RetentionPolicy, models.ConsistencyLevelOne, pr.Points)
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("PointsWriter.WritePoints(): got %v, exp %v", err, tsdb.PartialWriteError{})
+	} else if exp := "points beyond retention policy"; perr.Reason != exp {
+		t.Errorf("unexpected reason: got %q, exp %q", perr.Reason, exp)
+	} else if perr.Dropped != 2 {
+		t.Errorf("unexpected dropped count: %d", perr.Dropped)
 	}
 }
 
@@ -436,6 +485,100 @@ func (f *fakePointsWriter) WritePointsInto(req *coordinator.IntoWriteRequest) er
 	return f.WritePointsIntoFn(req)
 }
 
+// Ensures the points writer rejects points the user has no series grant for.
+func TestPointsWriter_WritePoints_SeriesAuthorization(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("mydb"); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateUser("user1", "", false); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "mydb", Measurement: "cpu", Condition: "host = 'serverA'", Privilege: influxql.WritePrivilege}); err != nil {
+		t.Fatal(err)
+	}
+	user := data.User("user1")
+
+	ms := NewPointsWriterMetaClient()
+	ms.DatabaseFn = func(database string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{Name: database, DefaultRetentionPolicy: "myrp"}
+	}
+	ms.NodeIDFn = func() uint64 { return 1 }
+
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	c.TSDBStore = &fakeStore{
+		WriteFn: func(shardID uint64, points []models.Point) error { return nil },
+	}
+	c.Node = &influxdb.Node{ID: 1}
+	c.Open()
+	defer c.Close()
+
+	allowed := models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "serverA"}), models.Fields{"value": 1.0}, time.Now())
+	if err := c.WritePoints("mydb", "", models.ConsistencyLevelOne, user, []models.Point{allowed}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, p := range []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "serverB"}), models.Fields{"value": 1.0}, time.Now()),
+		models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "serverA"}), models.Fields{"value": 1.0}, time.Now()),
+	} {
+		if err := c.WritePoints("mydb", "", models.ConsistencyLevelOne, user, []models.Point{allowed, p}); !influxdb.IsAuthorizationError(err) {
+			t.Errorf("%s: expected authorization error, got %v", p.Key(), err)
+		} else if serr, ok := err.(*coordinator.SeriesAuthorizationError); !ok || len(serr.DroppedPoints) != 1 || serr.DroppedPoints[0].Point != p {
+			t.Errorf("%s: unexpected unauthorized points: %v", p.Key(), err)
+		} else if code := serr.DroppedPoints[0].Reason.Code; code != tsdb.DropReasonUnauthorizedSeries {
+			t.Errorf("%s: unexpected drop reason: %s", p.Key(), code)
+		}
+
+		// SELECT INTO writes are authorized the same way.
+		if err := c.WritePointsInto(&coordinator.IntoWriteRequest{Database: "mydb", User: user, Points: []models.Point{allowed, p}}); !influxdb.IsAuthorizationError(err) {
+			t.Errorf("%s: expected authorization error writing into, got %v", p.Key(), err)
+		}
+	}
+
+	if err := c.WritePointsInto(&coordinator.IntoWriteRequest{Database: "mydb", User: user, Points: []models.Point{allowed}}); err != nil {
+		t.Fatalf("unexpected error writing into: %s", err)
+	}
+}
+
+// Ensure points aren't written to read-only databases and retention policies.
+func TestPointsWriter_WritePoints_ReadOnly(t *testing.T) {
+	var readOnlyDB bool
+	ms := NewPointsWriterMetaClient()
+	ms.DatabaseFn = func(database string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name:                   database,
+			DefaultRetentionPolicy: "myrp",
+			ReadOnly:               readOnlyDB,
+			RetentionPolicies: []meta.RetentionPolicyInfo{
+				{Name: "myrp"},
+				{Name: "archive", ReadOnly: true},
+			},
+		}
+	}
+	ms.NodeIDFn = func() uint64 { return 1 }
+
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	c.TSDBStore = &fakeStore{
+		WriteFn: func(shardID uint64, points []models.Point) error { return nil },
+	}
+	c.Node = &influxdb.Node{ID: 1}
+	c.Open()
+	defer c.Close()
+
+	pt := models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "serverA"}), models.Fields{"value": 1.0}, time.Now())
+	if err := c.WritePointsPrivileged("mydb", "", models.ConsistencyLevelOne, []models.Point{pt}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if err := c.WritePointsPrivileged("mydb", "archive", models.ConsistencyLevelOne, []models.Point{pt}); !influxdb.IsReadOnlyError(err) {
+		t.Fatalf("expected read only error, got %v", err)
+	}
+
+	readOnlyDB = true
+	if err := c.WritePointsPrivileged("mydb", "myrp", models.ConsistencyLevelOne, []models.Point{pt}); err == nil || err.Error() != "database is read only: mydb" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestBufferedPointsWriter(t *testing.T) {
 	db := "db0"
 	rp := "rp0"
@@ -457,7 +600,7 @@ func TestBufferedPointsWriter(t *testing.T) {
 		},
 	}
 
-	w := coordinator.NewBufferedPointsWriter(fakeWriter, db, rp, capacity)
+	w := coordinator.NewBufferedPointsWriter(fakeWriter, db, rp, nil, capacity)
 
 	// Test that capacity and length are correct for new buffered writer.
 	if w.Cap() != capacity {
@@ -497,7 +640,7 @@ func TestBufferedPointsWriter(t *testing.T) {
 		req.AddPoint("cpu", float64(i), time.Now().Add(time.Duration(i)*time.Second), nil)
 	}
 
-	r := coordinator.IntoWriteRequest(req)
+	r := coordinator.IntoWriteRequest{Database: req.Database, RetentionPolicy: req.RetentionPolicy, Points: req.Points}
 	if err := w.WritePointsInto(&r); err != nil {
 		t.Fatal(err)
 	} else if writePointsIntoCnt != 5 {
@@ -44,6 +44,11 @@ type StatementExecutor struct {
 	// Used for rewriting points back into system for SELECT INTO statements.
 	PointsWriter pointsWriter
 
+	// Records administrative and data-modifying statements, if set.
+	Auditor interface {
+		RecordStatement(stmt influxql.Statement, user, addr, database string, err error)
+	}
+
 	// Select statement limits
 	MaxSelectPointN   int
 	MaxSelectSeriesN  int
@@ -52,6 +57,18 @@ type StatementExecutor struct {
 
 // ExecuteStatement executes the given statement with the given execution context.
 func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influxql.ExecutionContext) error {
+	err := e.executeStatement(stmt, ctx)
+	if e.Auditor != nil {
+		var user string
+		if u, ok := ctx.Authorizer.(meta.User); ok {
+			user = u.ID()
+		}
+		e.Auditor.RecordStatement(stmt, user, ctx.RemoteAddr, ctx.Database, err)
+	}
+	return err
+}
+
+func (e *StatementExecutor) executeStatement(stmt influxql.Statement, ctx influxql.ExecutionContext) error {
 	// Select statements are handled separately so that they can be streamed.
 	if stmt, ok := stmt.(*influxql.SelectStatement); ok {
 		return e.executeSelectStatement(stmt, &ctx)
@@ -61,6 +78,27 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 	var messages []*influxql.Message
 	var err error
 	switch stmt := stmt.(type) {
+	case *influxql.AlterDatabaseStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeAlterDatabaseStatement(stmt)
+	case *influxql.AlterFieldStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		var m *influxql.Message
+		if m, err = e.executeAlterFieldStatement(stmt, &ctx); m != nil {
+			messages = append(messages, m)
+		}
+	case *influxql.AlterMeasurementStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		var m *influxql.Message
+		if m, err = e.executeAlterMeasurementStatement(stmt, &ctx); m != nil {
+			messages = append(messages, m)
+		}
 	case *influxql.AlterRetentionPolicyStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -81,11 +119,21 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeCreateRetentionPolicyStatement(stmt)
+	case *influxql.CreateRoleStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeCreateRoleStatement(stmt)
 	case *influxql.CreateSubscriptionStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeCreateSubscriptionStatement(stmt)
+	case *influxql.CreateTokenStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		rows, err = e.executeCreateTokenStatement(stmt)
 	case *influxql.CreateUserStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -93,6 +141,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 		err = e.executeCreateUserStatement(stmt)
 	case *influxql.DeleteSeriesStatement:
 		err = e.executeDeleteSeriesStatement(stmt, ctx.Database)
+	case *influxql.DropCardinalityLimitStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropCardinalityLimitStatement(stmt)
 	case *influxql.DropContinuousQueryStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -103,6 +156,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropDatabaseStatement(stmt)
+	case *influxql.DropFieldStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropFieldStatement(stmt, ctx.Database)
 	case *influxql.DropMeasurementStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -118,6 +176,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropRetentionPolicyStatement(stmt)
+	case *influxql.DropRoleStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropRoleStatement(stmt)
 	case *influxql.DropShardStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -128,6 +191,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropSubscriptionStatement(stmt)
+	case *influxql.DropTokenStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropTokenStatement(stmt)
 	case *influxql.DropUserStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -143,6 +211,11 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeGrantAdminStatement(stmt)
+	case *influxql.GrantRoleStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeGrantRoleStatement(stmt)
 	case *influxql.RevokeStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -153,6 +226,13 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeRevokeAdminStatement(stmt)
+	case *influxql.RevokeRoleStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeRevokeRoleStatement(stmt)
+	case *influxql.ShowCardinalityLimitsStatement:
+		rows, err = e.executeShowCardinalityLimitsStatement(stmt)
 	case *influxql.ShowContinuousQueriesStatement:
 		rows, err = e.executeShowContinuousQueriesStatement(stmt)
 	case *influxql.ShowDatabasesStatement:
@@ -165,6 +245,8 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 		return e.executeShowMeasurementsStatement(stmt, &ctx)
 	case *influxql.ShowRetentionPoliciesStatement:
 		rows, err = e.executeShowRetentionPoliciesStatement(stmt)
+	case *influxql.ShowRolesStatement:
+		rows, err = e.executeShowRolesStatement(stmt)
 	case *influxql.ShowShardsStatement:
 		rows, err = e.executeShowShardsStatement(stmt)
 	case *influxql.ShowShardGroupsStatement:
@@ -175,8 +257,15 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 		rows, err = e.executeShowSubscriptionsStatement(stmt)
 	case *influxql.ShowTagValuesStatement:
 		return e.executeShowTagValues(stmt, &ctx)
+	case *influxql.ShowTokensStatement:
+		rows, err = e.executeShowTokensStatement(stmt)
 	case *influxql.ShowUsersStatement:
 		rows, err = e.executeShowUsersStatement(stmt)
+	case *influxql.SetCardinalityLimitStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeSetCardinalityLimitStatement(stmt)
 	case *influxql.SetPasswordUserStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, influxql.ReadOnlyWarning(stmt.String()))
@@ -200,11 +289,172 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx influx
 	})
 }
 
+func (e *StatementExecutor) executeAlterDatabaseStatement(stmt *influxql.AlterDatabaseStatement) error {
+	du := &meta.DatabaseUpdate{}
+	if stmt.WALMode != "" {
+		du.SetWALMode(stmt.WALMode)
+	}
+	if stmt.DuplicatePolicy != "" {
+		du.SetDuplicatePolicy(stmt.DuplicatePolicy)
+	}
+	if stmt.ReadOnly != nil {
+		du.SetReadOnly(*stmt.ReadOnly)
+	}
+
+	// Update the database.
+	if err := e.MetaClient.UpdateDatabase(stmt.Name, du); err != nil {
+		return err
+	}
+
+	// Apply the new settings to the local shards.
+	if du.WALMode != nil {
+		if err := e.TSDBStore.SetDatabaseWALMode(stmt.Name, *du.WALMode); err != nil {
+			return err
+		}
+	}
+	if du.DuplicatePolicy != nil {
+		return e.TSDBStore.SetDatabaseDuplicatePolicy(stmt.Name, *du.DuplicatePolicy)
+	}
+	return nil
+}
+
+func (e *StatementExecutor) executeSetCardinalityLimitStatement(stmt *influxql.SetCardinalityLimitStatement) error {
+	limit := meta.CardinalityLimitInfo{
+		Measurement: stmt.Measurement,
+		TagKey:      stmt.TagKey,
+		Max:         stmt.Max,
+		Policy:      stmt.Policy,
+	}
+	if err := e.MetaClient.SetCardinalityLimit(stmt.Database, limit); err != nil {
+		return err
+	}
+	return e.applyCardinalityLimits(stmt.Database)
+}
+
+func (e *StatementExecutor) executeDropCardinalityLimitStatement(stmt *influxql.DropCardinalityLimitStatement) error {
+	if err := e.MetaClient.DropCardinalityLimit(stmt.Database, stmt.Measurement, stmt.TagKey); err != nil {
+		return err
+	}
+	return e.applyCardinalityLimits(stmt.Database)
+}
+
+// applyCardinalityLimits sets the cardinality limits of a database on its local shards.
+func (e *StatementExecutor) applyCardinalityLimits(database string) error {
+	di := e.MetaClient.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+	return e.TSDBStore.SetDatabaseCardinalityLimits(database, CardinalityLimits(di.CardinalityLimits))
+}
+
+// CardinalityLimits converts the cardinality limits of a database to the limits
+// enforced by the shards.
+func CardinalityLimits(a []meta.CardinalityLimitInfo) []tsdb.CardinalityLimit {
+	if len(a) == 0 {
+		return nil
+	}
+
+	limits := make([]tsdb.CardinalityLimit, len(a))
+	for i, l := range a {
+		limits[i] = tsdb.CardinalityLimit{
+			Measurement: l.Measurement,
+			TagKey:      l.TagKey,
+			Max:         l.Max,
+			Policy:      l.Policy,
+		}
+	}
+	return limits
+}
+
+// backgroundTaskManager is implemented by task managers that can track
+// statements running in the background.
+type backgroundTaskManager interface {
+	AttachQuery(q *influxql.Query, database string, interrupt <-chan struct{}) (uint64, *influxql.QueryTask, error)
+	KillQuery(qid uint64) error
+}
+
+// executeAlterFieldStatement converts the values of a field to another type.
+// Converting rewrites the field in every shard, so it runs in the background
+// like executeAlterMeasurementStatement.
+func (e *StatementExecutor) executeAlterFieldStatement(stmt *influxql.AlterFieldStatement, ctx *influxql.ExecutionContext) (*influxql.Message, error) {
+	// Fields can only be converted to floats and strings.
+	if stmt.Type != influxql.Float && stmt.Type != influxql.String {
+		return nil, fmt.Errorf("cannot convert field %q to %s", stmt.Name, stmt.Type)
+	}
+
+	return e.executeInBackground(stmt, "converting", ctx, func(database string, interrupt <-chan struct{}) error {
+		return e.TSDBStore.ConvertField(database, stmt.Measurement, stmt.Name, stmt.Type, interrupt)
+	})
+}
+
+// executeAlterMeasurementStatement renames a measurement or one of its tag
+// keys.  Renaming rewrites the series in every shard, so it runs in the
+// background as its own query that is listed by SHOW QUERIES and can be
+// stopped with KILL QUERY.
+func (e *StatementExecutor) executeAlterMeasurementStatement(stmt *influxql.AlterMeasurementStatement, ctx *influxql.ExecutionContext) (*influxql.Message, error) {
+	return e.executeInBackground(stmt, "renaming", ctx, func(database string, interrupt <-chan struct{}) error {
+		if stmt.TagKey != "" {
+			return e.TSDBStore.RenameTagKey(database, stmt.Name, stmt.TagKey, stmt.NewTagKey, interrupt)
+		}
+		return e.TSDBStore.RenameMeasurement(database, stmt.Name, stmt.NewName, interrupt)
+	})
+}
+
+// executeInBackground runs fn on the current database as a background query
+// and returns a message with its query id.  fn should stop once interrupt is
+// closed.  Since fn rewrites data, it isn't run if the database or any of its
+// retention policies is read-only.
+func (e *StatementExecutor) executeInBackground(stmt influxql.Statement, verb string, ctx *influxql.ExecutionContext, fn func(database string, interrupt <-chan struct{}) error) (*influxql.Message, error) {
+	database := ctx.Database
+	if database == "" {
+		return nil, ErrDatabaseNameRequired
+	} else if dbi := e.MetaClient.Database(database); dbi == nil {
+		return nil, influxql.ErrDatabaseNotFound(database)
+	} else if err := dbi.CheckAllWritable(); err != nil {
+		return nil, err
+	}
+
+	// Run while the statement runs if background queries can't be tracked.
+	tm, ok := e.TaskManager.(backgroundTaskManager)
+	if !ok {
+		return nil, fn(database, ctx.InterruptCh)
+	}
+
+	qid, task, err := tm.AttachQuery(&influxql.Query{Statements: influxql.Statements{stmt}}, database, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// fn stops before the next shard once the query is killed.
+	interrupt := make(chan struct{})
+	task.Monitor(func(closing <-chan struct{}) error {
+		<-closing
+		close(interrupt)
+		return nil
+	})
+
+	go func() {
+		defer tm.KillQuery(qid)
+		if err := fn(database, interrupt); err != nil {
+			ctx.Log.Info(fmt.Sprintf("%s (qid: %d, database: %s) failed: %s", stmt, qid, database, err))
+			return
+		}
+		ctx.Log.Info(fmt.Sprintf("%s (qid: %d, database: %s) finished", stmt, qid, database))
+	}()
+
+	return &influxql.Message{
+		Level: influxql.InfoLevel,
+		Text:  fmt.Sprintf("%s in the background as query %d", verb, qid),
+	}, nil
+}
+
 func (e *StatementExecutor) executeAlterRetentionPolicyStatement(stmt *influxql.AlterRetentionPolicyStatement) error {
 	rpu := &meta.RetentionPolicyUpdate{
 		Duration:           stmt.Duration,
 		ReplicaN:           stmt.Replication,
 		ShardGroupDuration: stmt.ShardGroupDuration,
+		SeriesDuration:     stmt.SeriesDuration,
+		ReadOnly:           stmt.ReadOnly,
 	}
 
 	// Update the retention policy.
@@ -285,6 +535,7 @@ func (e *StatementExecutor) executeCreateRetentionPolicyStatement(stmt *influxql
 		Duration:           &stmt.Duration,
 		ReplicaN:           &stmt.Replication,
 		ShardGroupDuration: stmt.ShardGroupDuration,
+		SeriesDuration:     stmt.SeriesDuration,
 	}
 
 	// Create new retention policy.
@@ -305,9 +556,22 @@ func (e *StatementExecutor) executeCreateUserStatement(q *influxql.CreateUserSta
 	return err
 }
 
+func (e *StatementExecutor) executeCreateTokenStatement(q *influxql.CreateTokenStatement) (models.Rows, error) {
+	token, ti, err := e.MetaClient.CreateToken(q.User, q.Database, q.Privilege, q.Expires)
+	if err != nil {
+		return nil, err
+	}
+	return []*models.Row{{
+		Columns: []string{"id", "token"},
+		Values:  [][]interface{}{{ti.ID, token}},
+	}}, nil
+}
+
 func (e *StatementExecutor) executeDeleteSeriesStatement(stmt *influxql.DeleteSeriesStatement, database string) error {
 	if dbi := e.MetaClient.Database(database); dbi == nil {
 		return influxql.ErrDatabaseNotFound(database)
+	} else if err := dbi.CheckAllWritable(); err != nil {
+		return err
 	}
 
 	// Convert "now()" to current time.
@@ -338,9 +602,22 @@ func (e *StatementExecutor) executeDropDatabaseStatement(stmt *influxql.DropData
 	return e.MetaClient.DropDatabase(stmt.Name)
 }
 
+func (e *StatementExecutor) executeDropFieldStatement(stmt *influxql.DropFieldStatement, database string) error {
+	if dbi := e.MetaClient.Database(database); dbi == nil {
+		return influxql.ErrDatabaseNotFound(database)
+	} else if err := dbi.CheckAllWritable(); err != nil {
+		return err
+	}
+
+	// Remove the field from the local store.
+	return e.TSDBStore.DeleteField(database, stmt.Measurement, stmt.Name)
+}
+
 func (e *StatementExecutor) executeDropMeasurementStatement(stmt *influxql.DropMeasurementStatement, database string) error {
 	if dbi := e.MetaClient.Database(database); dbi == nil {
 		return influxql.ErrDatabaseNotFound(database)
+	} else if err := dbi.CheckAllWritable(); err != nil {
+		return err
 	}
 
 	// Locally drop the measurement
@@ -350,6 +627,8 @@ func (e *StatementExecutor) executeDropMeasurementStatement(stmt *influxql.DropM
 func (e *StatementExecutor) executeDropSeriesStatement(stmt *influxql.DropSeriesStatement, database string) error {
 	if dbi := e.MetaClient.Database(database); dbi == nil {
 		return influxql.ErrDatabaseNotFound(database)
+	} else if err := dbi.CheckAllWritable(); err != nil {
+		return err
 	}
 
 	// Check for time in WHERE clause (not supported).
@@ -397,15 +676,55 @@ func (e *StatementExecutor) executeDropUserStatement(q *influxql.DropUserStateme
 	return e.MetaClient.DropUser(q.Name)
 }
 
+func (e *StatementExecutor) executeCreateRoleStatement(q *influxql.CreateRoleStatement) error {
+	return e.MetaClient.CreateRole(q.Name)
+}
+
+func (e *StatementExecutor) executeDropTokenStatement(q *influxql.DropTokenStatement) error {
+	return e.MetaClient.DropToken(q.ID)
+}
+
+func (e *StatementExecutor) executeDropRoleStatement(q *influxql.DropRoleStatement) error {
+	return e.MetaClient.DropRole(q.Name)
+}
+
+func (e *StatementExecutor) executeGrantRoleStatement(stmt *influxql.GrantRoleStatement) error {
+	return e.MetaClient.AddUserRole(stmt.User, stmt.Role)
+}
+
+func (e *StatementExecutor) executeRevokeRoleStatement(stmt *influxql.RevokeRoleStatement) error {
+	return e.MetaClient.RemoveUserRole(stmt.User, stmt.Role)
+}
+
 func (e *StatementExecutor) executeGrantStatement(stmt *influxql.GrantStatement) error {
+	if stmt.Role != "" {
+		return e.MetaClient.SetRolePrivilege(stmt.Role, stmt.On, stmt.Privilege)
+	} else if stmt.Measurement != "" || stmt.Condition != nil {
+		return e.MetaClient.SetSeriesPrivilege(stmt.User, seriesGrant(stmt.On, stmt.Measurement, stmt.Condition, stmt.Privilege))
+	}
 	return e.MetaClient.SetPrivilege(stmt.User, stmt.On, stmt.Privilege)
 }
 
+// seriesGrant returns the series grant of a grant or revoke statement.
+func seriesGrant(database, measurement string, condition influxql.Expr, p influxql.Privilege) meta.SeriesGrantInfo {
+	grant := meta.SeriesGrantInfo{Database: database, Measurement: measurement, Privilege: p}
+	if condition != nil {
+		grant.Condition = condition.String()
+	}
+	return grant
+}
+
 func (e *StatementExecutor) executeGrantAdminStatement(stmt *influxql.GrantAdminStatement) error {
 	return e.MetaClient.SetAdminPrivilege(stmt.User, true)
 }
 
 func (e *StatementExecutor) executeRevokeStatement(stmt *influxql.RevokeStatement) error {
+	if stmt.Role != "" {
+		return e.executeRevokeRolePrivilegeStatement(stmt)
+	} else if stmt.Measurement != "" || stmt.Condition != nil {
+		return e.executeRevokeSeriesStatement(stmt)
+	}
+
 	priv := influxql.NoPrivileges
 
 	// Revoking all privileges means there's no need to look at existing user privileges.
@@ -421,6 +740,39 @@ func (e *StatementExecutor) executeRevokeStatement(stmt *influxql.RevokeStatemen
 	return e.MetaClient.SetPrivilege(stmt.User, stmt.On, priv)
 }
 
+// executeRevokeRolePrivilegeStatement revokes a privilege from a role.
+func (e *StatementExecutor) executeRevokeRolePrivilegeStatement(stmt *influxql.RevokeStatement) error {
+	for _, ri := range e.MetaClient.Roles() {
+		if ri.Name != stmt.Role {
+			continue
+		}
+
+		// Bit clear (AND NOT) the role's privilege with the revoked privilege.
+		return e.MetaClient.SetRolePrivilege(stmt.Role, stmt.On, ri.Privileges[stmt.On]&^stmt.Privilege)
+	}
+	return meta.ErrRoleNotFound
+}
+
+// executeRevokeSeriesStatement revokes a privilege from a series grant.
+func (e *StatementExecutor) executeRevokeSeriesStatement(stmt *influxql.RevokeStatement) error {
+	revoked := seriesGrant(stmt.On, stmt.Measurement, stmt.Condition, stmt.Privilege)
+
+	grants, err := e.MetaClient.UserSeriesGrants(stmt.User)
+	if err != nil {
+		return err
+	}
+	for _, g := range grants {
+		if g.Database != revoked.Database || g.Measurement != revoked.Measurement || g.Condition != revoked.Condition {
+			continue
+		}
+
+		// Bit clear (AND NOT) the granted privilege with the revoked privilege.
+		g.Privilege &^= revoked.Privilege
+		return e.MetaClient.SetSeriesPrivilege(stmt.User, g)
+	}
+	return nil
+}
+
 func (e *StatementExecutor) executeRevokeAdminStatement(stmt *influxql.RevokeAdminStatement) error {
 	return e.MetaClient.SetAdminPrivilege(stmt.User, false)
 }
@@ -450,7 +802,9 @@ func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatemen
 
 	var pointsWriter *BufferedPointsWriter
 	if stmt.Target != nil {
-		pointsWriter = NewBufferedPointsWriter(e.PointsWriter, stmt.Target.Measurement.Database, stmt.Target.Measurement.RetentionPolicy, 10000)
+		// Points are written as the user so its series grants apply.
+		user, _ := ctx.Authorizer.(meta.User)
+		pointsWriter = NewBufferedPointsWriter(e.PointsWriter, stmt.Target.Measurement.Database, stmt.Target.Measurement.RetentionPolicy, user, 10000)
 	}
 
 	for {
@@ -610,6 +964,33 @@ func (e *StatementExecutor) createIterators(stmt *influxql.SelectStatement, ctx
 	return itrs, stmt, nil
 }
 
+func (e *StatementExecutor) executeShowCardinalityLimitsStatement(stmt *influxql.ShowCardinalityLimitsStatement) (models.Rows, error) {
+	var dis []meta.DatabaseInfo
+	if stmt.Database != "" {
+		di := e.MetaClient.Database(stmt.Database)
+		if di == nil {
+			return nil, influxdb.ErrDatabaseNotFound(stmt.Database)
+		}
+		dis = []meta.DatabaseInfo{*di}
+	} else {
+		dis = e.MetaClient.Databases()
+	}
+
+	rows := []*models.Row{}
+	for _, di := range dis {
+		row := &models.Row{Columns: []string{"measurement", "tag", "limit", "policy"}, Name: di.Name}
+		for _, l := range di.CardinalityLimits {
+			policy := l.Policy
+			if policy == "" {
+				policy = tsdb.CardinalityPolicyDrop
+			}
+			row.Values = append(row.Values, []interface{}{l.Measurement, l.TagKey, l.Max, policy})
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 func (e *StatementExecutor) executeShowContinuousQueriesStatement(stmt *influxql.ShowContinuousQueriesStatement) (models.Rows, error) {
 	dis := e.MetaClient.Databases()
 
@@ -676,7 +1057,32 @@ func (e *StatementExecutor) executeShowGrantsForUserStatement(q *influxql.ShowGr
 	for d, p := range priv {
 		row.Values = append(row.Values, []interface{}{d, p.String()})
 	}
-	return []*models.Row{row}, nil
+	rows := []*models.Row{row}
+
+	// Privileges on series are listed separately.
+	grants, err := e.MetaClient.UserSeriesGrants(q.Name)
+	if err != nil {
+		return nil, err
+	} else if len(grants) > 0 {
+		row := &models.Row{Name: "series", Columns: []string{"database", "measurement", "condition", "privilege"}}
+		for _, g := range grants {
+			row.Values = append(row.Values, []interface{}{g.Database, g.Measurement, g.Condition, g.Privilege.String()})
+		}
+		rows = append(rows, row)
+	}
+
+	// Roles granted to the user are listed separately.
+	roles, err := e.MetaClient.UserRoles(q.Name)
+	if err != nil {
+		return nil, err
+	} else if len(roles) > 0 {
+		row := &models.Row{Name: "roles", Columns: []string{"role"}}
+		for _, r := range roles {
+			row.Values = append(row.Values, []interface{}{r})
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
 
 func (e *StatementExecutor) executeShowMeasurementsStatement(q *influxql.ShowMeasurementsStatement, ctx *influxql.ExecutionContext) error {
@@ -737,9 +1143,9 @@ func (e *StatementExecutor) executeShowRetentionPoliciesStatement(q *influxql.Sh
 		return nil, influxdb.ErrDatabaseNotFound(q.Database)
 	}
 
-	row := &models.Row{Columns: []string{"name", "duration", "shardGroupDuration", "replicaN", "default"}}
+	row := &models.Row{Columns: []string{"name", "duration", "shardGroupDuration", "replicaN", "default", "seriesDuration"}}
 	for _, rpi := range di.RetentionPolicies {
-		row.Values = append(row.Values, []interface{}{rpi.Name, rpi.Duration.String(), rpi.ShardGroupDuration.String(), rpi.ReplicaN, di.DefaultRetentionPolicy == rpi.Name})
+		row.Values = append(row.Values, []interface{}{rpi.Name, rpi.Duration.String(), rpi.ShardGroupDuration.String(), rpi.ReplicaN, di.DefaultRetentionPolicy == rpi.Name, rpi.SeriesDuration.String()})
 	}
 	return []*models.Row{row}, nil
 }
@@ -914,14 +1320,61 @@ func (e *StatementExecutor) executeShowTagValues(q *influxql.ShowTagValuesStatem
 	return nil
 }
 
+func (e *StatementExecutor) executeShowRolesStatement(q *influxql.ShowRolesStatement) (models.Rows, error) {
+	row := &models.Row{Columns: []string{"role", "database", "privilege"}}
+	for _, ri := range e.MetaClient.Roles() {
+		if len(ri.Privileges) == 0 {
+			row.Values = append(row.Values, []interface{}{ri.Name, nil, influxql.NoPrivileges.String()})
+			continue
+		}
+
+		databases := make([]string, 0, len(ri.Privileges))
+		for d := range ri.Privileges {
+			databases = append(databases, d)
+		}
+		sort.Strings(databases)
+
+		for _, d := range databases {
+			row.Values = append(row.Values, []interface{}{ri.Name, d, ri.Privileges[d].String()})
+		}
+	}
+	return []*models.Row{row}, nil
+}
+
+func (e *StatementExecutor) executeShowTokensStatement(q *influxql.ShowTokensStatement) (models.Rows, error) {
+	row := &models.Row{Columns: []string{"id", "user", "database", "privilege", "created", "expires", "last_used"}}
+	for _, ti := range e.MetaClient.Tokens() {
+		if q.User != "" && ti.User != q.User {
+			continue
+		}
+
+		// Tokens without a scope have the privileges of their user.
+		var database, privilege interface{}
+		if ti.Database != "" {
+			database, privilege = ti.Database, ti.Privilege.String()
+		}
+		row.Values = append(row.Values, []interface{}{ti.ID, ti.User, database, privilege, formatTime(ti.CreatedAt), formatTime(ti.Expires), formatTime(ti.LastUsed)})
+	}
+	return []*models.Row{row}, nil
+}
+
 func (e *StatementExecutor) executeShowUsersStatement(q *influxql.ShowUsersStatement) (models.Rows, error) {
-	row := &models.Row{Columns: []string{"user", "admin"}}
+	now := time.Now()
+	row := &models.Row{Columns: []string{"user", "admin", "last_login", "locked"}}
 	for _, ui := range e.MetaClient.Users() {
-		row.Values = append(row.Values, []interface{}{ui.Name, ui.Admin})
+		row.Values = append(row.Values, []interface{}{ui.Name, ui.Admin, formatTime(ui.LastLogin), ui.Locked(now)})
 	}
 	return []*models.Row{row}, nil
 }
 
+// formatTime returns nil for times that were never set.
+func formatTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 // BufferedPointsWriter adds buffering to a pointsWriter so that SELECT INTO queries
 // write their points to the destination in batches.
 type BufferedPointsWriter struct {
@@ -929,15 +1382,18 @@ type BufferedPointsWriter struct {
 	buf             []models.Point
 	database        string
 	retentionPolicy string
+	user            meta.User
 }
 
-// NewBufferedPointsWriter returns a new BufferedPointsWriter.
-func NewBufferedPointsWriter(w pointsWriter, database, retentionPolicy string, capacity int) *BufferedPointsWriter {
+// NewBufferedPointsWriter returns a new BufferedPointsWriter. The points are
+// written as user, which may be nil to write them without authorization.
+func NewBufferedPointsWriter(w pointsWriter, database, retentionPolicy string, user meta.User, capacity int) *BufferedPointsWriter {
 	return &BufferedPointsWriter{
 		w:               w,
 		buf:             make([]models.Point, 0, capacity),
 		database:        database,
 		retentionPolicy: retentionPolicy,
+		user:            user,
 	}
 }
 
@@ -984,6 +1440,7 @@ func (w *BufferedPointsWriter) Flush() error {
 	if err := w.w.WritePointsInto(&IntoWriteRequest{
 		Database:        w.database,
 		RetentionPolicy: w.retentionPolicy,
+		User:            w.user,
 		Points:          w.buf,
 	}); err != nil {
 		return err
@@ -1145,7 +1602,11 @@ func (e *StatementExecutor) normalizeMeasurement(m *influxql.Measurement, defaul
 type IntoWriteRequest struct {
 	Database        string
 	RetentionPolicy string
-	Points          []models.Point
+
+	// User writing the points, or nil to write them without authorization.
+	User meta.User
+
+	Points []models.Point
 }
 
 // TSDBStore is an interface for accessing the time series data store.
@@ -1158,10 +1619,19 @@ type TSDBStore interface {
 
 	DeleteDatabase(name string) error
 	DeleteMeasurement(database, name string) error
+	DeleteField(database, name, field string) error
+	ConvertField(database, name, field string, typ influxql.DataType, interrupt <-chan struct{}) error
 	DeleteRetentionPolicy(database, name string) error
 	DeleteSeries(database string, sources []influxql.Source, condition influxql.Expr) error
 	DeleteShard(id uint64) error
 
+	RenameMeasurement(database, name, newName string, interrupt <-chan struct{}) error
+	RenameTagKey(database, name, key, newKey string, interrupt <-chan struct{}) error
+
+	SetDatabaseWALMode(database, mode string) error
+	SetDatabaseDuplicatePolicy(database, policy string) error
+	SetDatabaseCardinalityLimits(database string, limits []tsdb.CardinalityLimit) error
+
 	MeasurementNames(database string, cond influxql.Expr) ([][]byte, error)
 	TagValues(database string, cond influxql.Expr) ([]tsdb.TagValues, error)
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/coordinator"
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/internal"
@@ -256,6 +257,85 @@ func TestQueryExecutor_ExecuteQuery_ShowDatabases(t *testing.T) {
 	}
 }
 
+// Ensure executed statements are passed to the auditor with their user and outcome.
+func TestQueryExecutor_ExecuteQuery_Auditor(t *testing.T) {
+	type record struct {
+		stmt, user, addr string
+		err              error
+	}
+	var records []record
+
+	e := NewQueryExecutor()
+	e.StatementExecutor.Auditor = auditorFunc(func(stmt influxql.Statement, user, addr, database string, err error) {
+		records = append(records, record{stmt: stmt.String(), user: user, addr: addr, err: err})
+	})
+	e.MetaClient.DatabaseFn = DefaultMetaClientDatabaseFn
+	e.MetaClient.DropDatabaseFn = func(name string) error { return nil }
+	e.MetaClient.DropUserFn = func(name string) error { return meta.ErrUserNotFound }
+	e.TSDBStore.DeleteDatabaseFn = func(name string) error { return nil }
+
+	results := ReadAllResults(e.QueryExecutor.ExecuteQuery(MustParseQuery(`DROP DATABASE db0; DROP USER jdoe`), influxql.ExecutionOptions{
+		Authorizer: &meta.UserInfo{Name: "admin", Admin: true},
+		RemoteAddr: "127.0.0.1:1234",
+	}, make(chan struct{})))
+	if len(results) != 2 || results[1].Err != meta.ErrUserNotFound {
+		t.Fatalf("unexpected results: %s", spew.Sdump(results))
+	}
+
+	exp := []record{
+		{stmt: `DROP DATABASE db0`, user: "admin", addr: "127.0.0.1:1234"},
+		{stmt: `DROP USER jdoe`, user: "admin", addr: "127.0.0.1:1234", err: meta.ErrUserNotFound},
+	}
+	if !reflect.DeepEqual(records, exp) {
+		t.Fatalf("unexpected records: exp %s, got %s", spew.Sdump(exp), spew.Sdump(records))
+	}
+}
+
+// Ensure data can't be deleted or rewritten in a database with a read-only
+// retention policy.
+func TestQueryExecutor_ExecuteQuery_ReadOnly(t *testing.T) {
+	e := NewQueryExecutor()
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name:                   name,
+			DefaultRetentionPolicy: "rp0",
+			RetentionPolicies: []meta.RetentionPolicyInfo{
+				{Name: "rp0"},
+				{Name: "rp1", ReadOnly: true},
+			},
+		}
+	}
+	e.TSDBStore.DeleteSeriesFn = func(database string, sources []influxql.Source, condition influxql.Expr) error {
+		t.Fatal("unexpected series deletion")
+		return nil
+	}
+
+	for _, q := range []string{
+		`DELETE FROM cpu`,
+		`DROP SERIES FROM cpu`,
+		`DROP MEASUREMENT cpu`,
+		`ALTER MEASUREMENT cpu RENAME TO mem`,
+		`ALTER MEASUREMENT cpu RENAME TAG host TO hostname`,
+		`ALTER FIELD value FROM cpu TYPE float`,
+	} {
+		results := ReadAllResults(e.QueryExecutor.ExecuteQuery(MustParseQuery(q), influxql.ExecutionOptions{
+			Database: DefaultDatabase,
+		}, make(chan struct{})))
+		if len(results) != 1 {
+			t.Fatalf("unexpected results: %s", spew.Sdump(results))
+		} else if !influxdb.IsReadOnlyError(results[0].Err) {
+			t.Fatalf("%s: unexpected error: %v", q, results[0].Err)
+		}
+	}
+}
+
+// auditorFunc is a function that implements the statement executor's Auditor.
+type auditorFunc func(stmt influxql.Statement, user, addr, database string, err error)
+
+func (fn auditorFunc) RecordStatement(stmt influxql.Statement, user, addr, database string, err error) {
+	fn(stmt, user, addr, database, err)
+}
+
 // QueryExecutor is a test wrapper for coordinator.QueryExecutor.
 type QueryExecutor struct {
 	*influxql.QueryExecutor
@@ -317,12 +397,19 @@ type TSDBStore struct {
 	RestoreShardFn func(id uint64, r io.Reader) error
 	BackupShardFn  func(id uint64, since time.Time, w io.Writer) error
 
-	DeleteDatabaseFn        func(name string) error
-	DeleteMeasurementFn     func(database, name string) error
-	DeleteRetentionPolicyFn func(database, name string) error
-	DeleteShardFn           func(id uint64) error
-	DeleteSeriesFn          func(database string, sources []influxql.Source, condition influxql.Expr) error
-	ShardGroupFn            func(ids []uint64) tsdb.ShardGroup
+	DeleteDatabaseFn               func(name string) error
+	DeleteMeasurementFn            func(database, name string) error
+	DeleteFieldFn                  func(database, name, field string) error
+	ConvertFieldFn                 func(database, name, field string, typ influxql.DataType, interrupt <-chan struct{}) error
+	DeleteRetentionPolicyFn        func(database, name string) error
+	DeleteShardFn                  func(id uint64) error
+	DeleteSeriesFn                 func(database string, sources []influxql.Source, condition influxql.Expr) error
+	RenameMeasurementFn            func(database, name, newName string, interrupt <-chan struct{}) error
+	RenameTagKeyFn                 func(database, name, key, newKey string, interrupt <-chan struct{}) error
+	SetDatabaseWALModeFn           func(database, mode string) error
+	SetDatabaseDuplicatePolicyFn   func(database, policy string) error
+	SetDatabaseCardinalityLimitsFn func(database string, limits []tsdb.CardinalityLimit) error
+	ShardGroupFn                   func(ids []uint64) tsdb.ShardGroup
 }
 
 func (s *TSDBStore) CreateShard(database, policy string, shardID uint64, enabled bool) error {
@@ -364,6 +451,34 @@ func (s *TSDBStore) DeleteSeries(database string, sources []influxql.Source, con
 	return s.DeleteSeriesFn(database, sources, condition)
 }
 
+func (s *TSDBStore) DeleteField(database, name, field string) error {
+	return s.DeleteFieldFn(database, name, field)
+}
+
+func (s *TSDBStore) ConvertField(database, name, field string, typ influxql.DataType, interrupt <-chan struct{}) error {
+	return s.ConvertFieldFn(database, name, field, typ, interrupt)
+}
+
+func (s *TSDBStore) RenameMeasurement(database, name, newName string, interrupt <-chan struct{}) error {
+	return s.RenameMeasurementFn(database, name, newName, interrupt)
+}
+
+func (s *TSDBStore) RenameTagKey(database, name, key, newKey string, interrupt <-chan struct{}) error {
+	return s.RenameTagKeyFn(database, name, key, newKey, interrupt)
+}
+
+func (s *TSDBStore) SetDatabaseWALMode(database, mode string) error {
+	return s.SetDatabaseWALModeFn(database, mode)
+}
+
+func (s *TSDBStore) SetDatabaseDuplicatePolicy(database, policy string) error {
+	return s.SetDatabaseDuplicatePolicyFn(database, policy)
+}
+
+func (s *TSDBStore) SetDatabaseCardinalityLimits(database string, limits []tsdb.CardinalityLimit) error {
+	return s.SetDatabaseCardinalityLimitsFn(database, limits)
+}
+
 func (s *TSDBStore) ShardGroup(ids []uint64) tsdb.ShardGroup {
 	return s.ShardGroupFn(ids)
 }
@@ -20,6 +20,32 @@ func ErrRetentionPolicyNotFound(name string) error {
 	return fmt.Errorf("retention policy not found: %s", name)
 }
 
+// ErrReadOnly indicates that data can't be written to or deleted from a
+// database or retention policy because it is read only. The retention policy
+// is empty if the whole database is read only.
+func ErrReadOnly(database, retentionPolicy string) error {
+	return &readOnlyError{database: database, retentionPolicy: retentionPolicy}
+}
+
+type readOnlyError struct {
+	database        string
+	retentionPolicy string
+}
+
+func (e *readOnlyError) Error() string {
+	if e.retentionPolicy == "" {
+		return fmt.Sprintf("database is read only: %s", e.database)
+	}
+	return fmt.Sprintf("retention policy is read only: %s.%s", e.database, e.retentionPolicy)
+}
+
+// IsReadOnlyError indicates whether an error is due to a read-only database
+// or retention policy.
+func IsReadOnlyError(err error) bool {
+	_, ok := err.(*readOnlyError)
+	return ok
+}
+
 // IsAuthorizationError indicates whether an error is due to an authorization failure
 func IsAuthorizationError(err error) bool {
 	e, ok := err.(interface {
@@ -121,30 +121,43 @@ type Node interface {
 func (*Query) node()     {}
 func (Statements) node() {}
 
+func (*AlterDatabaseStatement) node()         {}
+func (*AlterFieldStatement) node()            {}
+func (*AlterMeasurementStatement) node()      {}
 func (*AlterRetentionPolicyStatement) node()  {}
 func (*CreateContinuousQueryStatement) node() {}
 func (*CreateDatabaseStatement) node()        {}
 func (*CreateRetentionPolicyStatement) node() {}
+func (*CreateRoleStatement) node()            {}
+func (*CreateTokenStatement) node()           {}
 func (*CreateSubscriptionStatement) node()    {}
 func (*CreateUserStatement) node()            {}
 func (*Distinct) node()                       {}
 func (*DeleteSeriesStatement) node()          {}
 func (*DeleteStatement) node()                {}
+func (*DropCardinalityLimitStatement) node()  {}
 func (*DropContinuousQueryStatement) node()   {}
 func (*DropDatabaseStatement) node()          {}
+func (*DropFieldStatement) node()             {}
 func (*DropMeasurementStatement) node()       {}
 func (*DropRetentionPolicyStatement) node()   {}
+func (*DropRoleStatement) node()              {}
+func (*DropTokenStatement) node()             {}
 func (*DropSeriesStatement) node()            {}
 func (*DropShardStatement) node()             {}
 func (*DropSubscriptionStatement) node()      {}
 func (*DropUserStatement) node()              {}
 func (*GrantStatement) node()                 {}
 func (*GrantAdminStatement) node()            {}
+func (*GrantRoleStatement) node()             {}
 func (*KillQueryStatement) node()             {}
 func (*RevokeStatement) node()                {}
 func (*RevokeAdminStatement) node()           {}
+func (*RevokeRoleStatement) node()            {}
 func (*SelectStatement) node()                {}
+func (*SetCardinalityLimitStatement) node()   {}
 func (*SetPasswordUserStatement) node()       {}
+func (*ShowCardinalityLimitsStatement) node() {}
 func (*ShowContinuousQueriesStatement) node() {}
 func (*ShowGrantsForUserStatement) node()     {}
 func (*ShowDatabasesStatement) node()         {}
@@ -152,6 +165,8 @@ func (*ShowFieldKeysStatement) node()         {}
 func (*ShowRetentionPoliciesStatement) node() {}
 func (*ShowMeasurementsStatement) node()      {}
 func (*ShowQueriesStatement) node()           {}
+func (*ShowRolesStatement) node()             {}
+func (*ShowTokensStatement) node()            {}
 func (*ShowSeriesStatement) node()            {}
 func (*ShowShardGroupsStatement) node()       {}
 func (*ShowShardsStatement) node()            {}
@@ -242,30 +257,43 @@ type ExecutionPrivilege struct {
 // ExecutionPrivileges is a list of privileges required to execute a statement.
 type ExecutionPrivileges []ExecutionPrivilege
 
+func (*AlterDatabaseStatement) stmt()         {}
+func (*AlterFieldStatement) stmt()            {}
+func (*AlterMeasurementStatement) stmt()      {}
 func (*AlterRetentionPolicyStatement) stmt()  {}
 func (*CreateContinuousQueryStatement) stmt() {}
 func (*CreateDatabaseStatement) stmt()        {}
 func (*CreateRetentionPolicyStatement) stmt() {}
+func (*CreateRoleStatement) stmt()            {}
+func (*CreateTokenStatement) stmt()           {}
 func (*CreateSubscriptionStatement) stmt()    {}
 func (*CreateUserStatement) stmt()            {}
 func (*DeleteSeriesStatement) stmt()          {}
 func (*DeleteStatement) stmt()                {}
+func (*DropCardinalityLimitStatement) stmt()  {}
 func (*DropContinuousQueryStatement) stmt()   {}
 func (*DropDatabaseStatement) stmt()          {}
+func (*DropFieldStatement) stmt()             {}
 func (*DropMeasurementStatement) stmt()       {}
 func (*DropRetentionPolicyStatement) stmt()   {}
+func (*DropRoleStatement) stmt()              {}
+func (*DropTokenStatement) stmt()             {}
 func (*DropSeriesStatement) stmt()            {}
 func (*DropSubscriptionStatement) stmt()      {}
 func (*DropUserStatement) stmt()              {}
 func (*GrantStatement) stmt()                 {}
 func (*GrantAdminStatement) stmt()            {}
+func (*GrantRoleStatement) stmt()             {}
 func (*KillQueryStatement) stmt()             {}
+func (*ShowCardinalityLimitsStatement) stmt() {}
 func (*ShowContinuousQueriesStatement) stmt() {}
 func (*ShowGrantsForUserStatement) stmt()     {}
 func (*ShowDatabasesStatement) stmt()         {}
 func (*ShowFieldKeysStatement) stmt()         {}
 func (*ShowMeasurementsStatement) stmt()      {}
 func (*ShowQueriesStatement) stmt()           {}
+func (*ShowRolesStatement) stmt()             {}
+func (*ShowTokensStatement) stmt()            {}
 func (*ShowRetentionPoliciesStatement) stmt() {}
 func (*ShowSeriesStatement) stmt()            {}
 func (*ShowShardGroupsStatement) stmt()       {}
@@ -279,7 +307,9 @@ func (*ShowTagValuesStatement) stmt()         {}
 func (*ShowUsersStatement) stmt()             {}
 func (*RevokeStatement) stmt()                {}
 func (*RevokeAdminStatement) stmt()           {}
+func (*RevokeRoleStatement) stmt()            {}
 func (*SelectStatement) stmt()                {}
+func (*SetCardinalityLimitStatement) stmt()   {}
 func (*SetPasswordUserStatement) stmt()       {}
 
 // Expr represents an expression that can be evaluated to a value.
@@ -650,6 +680,148 @@ func (s *DropUserStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
 	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
 }
 
+// CreateRoleStatement represents a command for creating a new role.
+type CreateRoleStatement struct {
+	// Name of the role to be created.
+	Name string
+}
+
+// String returns a string representation of the create role statement.
+func (s *CreateRoleStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("CREATE ROLE ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a CreateRoleStatement.
+func (s *CreateRoleStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// DropRoleStatement represents a command for dropping a role.
+type DropRoleStatement struct {
+	// Name of the role to drop.
+	Name string
+}
+
+// String returns a string representation of the drop role statement.
+func (s *DropRoleStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("DROP ROLE ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a DropRoleStatement.
+func (s *DropRoleStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// GrantRoleStatement represents a command for granting a role to a user.
+type GrantRoleStatement struct {
+	// Role to grant.
+	Role string
+
+	// Who to grant the role to.
+	User string
+}
+
+// String returns a string representation of the grant role statement.
+func (s *GrantRoleStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("GRANT ROLE ")
+	_, _ = buf.WriteString(QuoteIdent(s.Role))
+	_, _ = buf.WriteString(" TO ")
+	_, _ = buf.WriteString(QuoteIdent(s.User))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a GrantRoleStatement.
+func (s *GrantRoleStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// RevokeRoleStatement represents a command for revoking a role from a user.
+type RevokeRoleStatement struct {
+	// Role to revoke.
+	Role string
+
+	// Who to revoke the role from.
+	User string
+}
+
+// String returns a string representation of the revoke role statement.
+func (s *RevokeRoleStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("REVOKE ROLE ")
+	_, _ = buf.WriteString(QuoteIdent(s.Role))
+	_, _ = buf.WriteString(" FROM ")
+	_, _ = buf.WriteString(QuoteIdent(s.User))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a RevokeRoleStatement.
+func (s *RevokeRoleStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// CreateTokenStatement represents a command for creating an API token.
+type CreateTokenStatement struct {
+	// User the token authenticates as.
+	User string
+
+	// Privilege and database the token is limited to.
+	// The token has all the privileges of the user if the database is empty.
+	Privilege Privilege
+	Database  string
+
+	// Duration after which the token expires. Zero means it never expires.
+	Expires time.Duration
+}
+
+// String returns a string representation of the create token statement.
+func (s *CreateTokenStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("CREATE TOKEN FOR ")
+	_, _ = buf.WriteString(QuoteIdent(s.User))
+	if s.Database != "" {
+		_, _ = buf.WriteString(" WITH SCOPE ")
+		_, _ = buf.WriteString(s.Privilege.String())
+		_, _ = buf.WriteString(" ON ")
+		_, _ = buf.WriteString(QuoteIdent(s.Database))
+	}
+	if s.Expires > 0 {
+		_, _ = buf.WriteString(" EXPIRES ")
+		_, _ = buf.WriteString(FormatDuration(s.Expires))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a CreateTokenStatement.
+func (s *CreateTokenStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// DropTokenStatement represents a command for revoking an API token.
+type DropTokenStatement struct {
+	// ID of the token to drop.
+	ID string
+}
+
+// String returns a string representation of the drop token statement.
+func (s *DropTokenStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("DROP TOKEN ")
+	_, _ = buf.WriteString(QuoteString(s.ID))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a DropTokenStatement.
+func (s *DropTokenStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
 // Privilege is a type of action a user can be granted the right to use.
 type Privilege int
 
@@ -690,8 +862,16 @@ type GrantStatement struct {
 	// Database to grant the privilege to.
 	On string
 
+	// Measurement and condition on the tags of the series to grant the
+	// privilege to, if the privilege is only granted on some series.
+	Measurement string
+	Condition   Expr
+
 	// Who to grant the privilege to.
 	User string
+
+	// Role to grant the privilege to, instead of a user.
+	Role string
 }
 
 // String returns a string representation of the grant statement.
@@ -700,9 +880,14 @@ func (s *GrantStatement) String() string {
 	_, _ = buf.WriteString("GRANT ")
 	_, _ = buf.WriteString(s.Privilege.String())
 	_, _ = buf.WriteString(" ON ")
-	_, _ = buf.WriteString(QuoteIdent(s.On))
-	_, _ = buf.WriteString(" TO ")
-	_, _ = buf.WriteString(QuoteIdent(s.User))
+	writeGrantTarget(&buf, s.On, s.Measurement, s.Condition)
+	if s.Role != "" {
+		_, _ = buf.WriteString(" TO ROLE ")
+		_, _ = buf.WriteString(QuoteIdent(s.Role))
+	} else {
+		_, _ = buf.WriteString(" TO ")
+		_, _ = buf.WriteString(QuoteIdent(s.User))
+	}
 	return buf.String()
 }
 
@@ -716,6 +901,20 @@ func (s *GrantStatement) DefaultDatabase() string {
 	return s.On
 }
 
+// writeGrantTarget writes the database, measurement and condition of a grant
+// or revoke statement to buf.
+func writeGrantTarget(buf *bytes.Buffer, database, measurement string, condition Expr) {
+	_, _ = buf.WriteString(QuoteIdent(database))
+	if measurement != "" {
+		_ = buf.WriteByte('.')
+		_, _ = buf.WriteString(QuoteIdent(measurement))
+	}
+	if condition != nil {
+		_, _ = buf.WriteString(" WHERE ")
+		_, _ = buf.WriteString(condition.String())
+	}
+}
+
 // GrantAdminStatement represents a command for granting admin privilege.
 type GrantAdminStatement struct {
 	// Who to grant the privilege to.
@@ -793,8 +992,16 @@ type RevokeStatement struct {
 	// Database to revoke the privilege from.
 	On string
 
+	// Measurement and condition of the series grant to revoke the privilege
+	// from, if the privilege was only granted on some series.
+	Measurement string
+	Condition   Expr
+
 	// Who to revoke privilege from.
 	User string
+
+	// Role to revoke privilege from, instead of a user.
+	Role string
 }
 
 // String returns a string representation of the revoke statement.
@@ -803,9 +1010,14 @@ func (s *RevokeStatement) String() string {
 	_, _ = buf.WriteString("REVOKE ")
 	_, _ = buf.WriteString(s.Privilege.String())
 	_, _ = buf.WriteString(" ON ")
-	_, _ = buf.WriteString(QuoteIdent(s.On))
-	_, _ = buf.WriteString(" FROM ")
-	_, _ = buf.WriteString(QuoteIdent(s.User))
+	writeGrantTarget(&buf, s.On, s.Measurement, s.Condition)
+	if s.Role != "" {
+		_, _ = buf.WriteString(" FROM ROLE ")
+		_, _ = buf.WriteString(QuoteIdent(s.Role))
+	} else {
+		_, _ = buf.WriteString(" FROM ")
+		_, _ = buf.WriteString(QuoteIdent(s.User))
+	}
 	return buf.String()
 }
 
@@ -857,6 +1069,9 @@ type CreateRetentionPolicyStatement struct {
 
 	// Shard Duration.
 	ShardGroupDuration time.Duration
+
+	// Duration a series is kept in the index without new points.
+	SeriesDuration time.Duration
 }
 
 // String returns a string representation of the create retention policy.
@@ -874,6 +1089,10 @@ func (s *CreateRetentionPolicyStatement) String() string {
 		_, _ = buf.WriteString(" SHARD DURATION ")
 		_, _ = buf.WriteString(FormatDuration(s.ShardGroupDuration))
 	}
+	if s.SeriesDuration > 0 {
+		_, _ = buf.WriteString(" SERIES DURATION ")
+		_, _ = buf.WriteString(FormatDuration(s.SeriesDuration))
+	}
 	if s.Default {
 		_, _ = buf.WriteString(" DEFAULT")
 	}
@@ -890,6 +1109,237 @@ func (s *CreateRetentionPolicyStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// AlterDatabaseStatement represents a command to alter an existing database.
+type AlterDatabaseStatement struct {
+	// Name of the database to alter.
+	Name string
+
+	// Durability mode of the database's write-ahead log ("fsync", "group" or "none").
+	WALMode string
+
+	// Value kept when a point is written with the timestamp of an existing
+	// point ("last", "first" or "reject").
+	DuplicatePolicy string
+
+	// Whether writes and deletes of data are refused (READ ONLY) or allowed
+	// again (READ WRITE).
+	ReadOnly *bool
+}
+
+// String returns a string representation of the alter database statement.
+func (s *AlterDatabaseStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("ALTER DATABASE ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+
+	if s.WALMode != "" {
+		_, _ = buf.WriteString(" WAL ")
+		_, _ = buf.WriteString(strings.ToUpper(s.WALMode))
+	}
+
+	if s.DuplicatePolicy != "" {
+		_, _ = buf.WriteString(" DUPLICATE POLICY ")
+		_, _ = buf.WriteString(strings.ToUpper(s.DuplicatePolicy))
+	}
+
+	if s.ReadOnly != nil {
+		_, _ = buf.WriteString(formatReadOnly(*s.ReadOnly))
+	}
+
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute an AlterDatabaseStatement.
+func (s *AlterDatabaseStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *AlterDatabaseStatement) DefaultDatabase() string {
+	return s.Name
+}
+
+// AlterFieldStatement represents a command to convert the values of a field
+// to another type.
+type AlterFieldStatement struct {
+	// Name of the field to convert.
+	Name string
+
+	// Measurement of the field.
+	Measurement string
+
+	// Type the field is converted to.
+	Type DataType
+}
+
+// String returns a string representation of the alter field statement.
+func (s *AlterFieldStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("ALTER FIELD ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	_, _ = buf.WriteString(" FROM ")
+	_, _ = buf.WriteString(QuoteIdent(s.Measurement))
+	_, _ = buf.WriteString(" TYPE ")
+	_, _ = buf.WriteString(s.Type.String())
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute an AlterFieldStatement.
+func (s *AlterFieldStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// AlterMeasurementStatement represents a command to rename a measurement or
+// one of its tag keys.
+type AlterMeasurementStatement struct {
+	// Name of the measurement to alter.
+	Name string
+
+	// New name of the measurement, if the measurement is renamed.
+	NewName string
+
+	// Tag key and its new name, if a tag key is renamed.
+	TagKey    string
+	NewTagKey string
+}
+
+// String returns a string representation of the alter measurement statement.
+func (s *AlterMeasurementStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("ALTER MEASUREMENT ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	_, _ = buf.WriteString(" RENAME ")
+	if s.TagKey != "" {
+		_, _ = buf.WriteString("TAG ")
+		_, _ = buf.WriteString(QuoteIdent(s.TagKey))
+		_, _ = buf.WriteString(" TO ")
+		_, _ = buf.WriteString(QuoteIdent(s.NewTagKey))
+	} else {
+		_, _ = buf.WriteString("TO ")
+		_, _ = buf.WriteString(QuoteIdent(s.NewName))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute an AlterMeasurementStatement.
+func (s *AlterMeasurementStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// SetCardinalityLimitStatement represents a command to limit the number of
+// series in a measurement or the number of values of a tag key.
+type SetCardinalityLimitStatement struct {
+	// Name of the database the limit belongs to.
+	Database string
+
+	// Measurement to limit.
+	Measurement string
+
+	// Tag key to limit the values of.  If empty, the number of series in
+	// the measurement is limited.
+	TagKey string
+
+	// Maximum cardinality.
+	Max int
+
+	// What happens to a write exceeding the limit ("reject", "drop" or "log").
+	Policy string
+}
+
+// String returns a string representation of the set cardinality limit statement.
+func (s *SetCardinalityLimitStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SET CARDINALITY LIMIT ")
+	_, _ = buf.WriteString(strconv.Itoa(s.Max))
+	_, _ = buf.WriteString(" ON ")
+	_, _ = buf.WriteString(QuoteIdent(s.Database))
+	_, _ = buf.WriteString(" MEASUREMENT ")
+	_, _ = buf.WriteString(QuoteIdent(s.Measurement))
+
+	if s.TagKey != "" {
+		_, _ = buf.WriteString(" TAG ")
+		_, _ = buf.WriteString(QuoteIdent(s.TagKey))
+	}
+
+	if s.Policy != "" {
+		_, _ = buf.WriteString(" POLICY ")
+		_, _ = buf.WriteString(strings.ToUpper(s.Policy))
+	}
+
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a SetCardinalityLimitStatement.
+func (s *SetCardinalityLimitStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *SetCardinalityLimitStatement) DefaultDatabase() string {
+	return s.Database
+}
+
+// DropCardinalityLimitStatement represents a command to remove a cardinality limit.
+type DropCardinalityLimitStatement struct {
+	// Name of the database the limit belongs to.
+	Database string
+
+	// Measurement the limit applies to.
+	Measurement string
+
+	// Tag key the limit applies to, if any.
+	TagKey string
+}
+
+// String returns a string representation of the drop cardinality limit statement.
+func (s *DropCardinalityLimitStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("DROP CARDINALITY LIMIT ON ")
+	_, _ = buf.WriteString(QuoteIdent(s.Database))
+	_, _ = buf.WriteString(" MEASUREMENT ")
+	_, _ = buf.WriteString(QuoteIdent(s.Measurement))
+
+	if s.TagKey != "" {
+		_, _ = buf.WriteString(" TAG ")
+		_, _ = buf.WriteString(QuoteIdent(s.TagKey))
+	}
+
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a DropCardinalityLimitStatement.
+func (s *DropCardinalityLimitStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// DefaultDatabase returns the default database from the statement.
+func (s *DropCardinalityLimitStatement) DefaultDatabase() string {
+	return s.Database
+}
+
+// ShowCardinalityLimitsStatement represents a command for listing cardinality limits.
+type ShowCardinalityLimitsStatement struct {
+	// Database to list the limits of.  If blank, the limits of all
+	// databases are listed.
+	Database string
+}
+
+// String returns a string representation of the show cardinality limits statement.
+func (s *ShowCardinalityLimitsStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW CARDINALITY LIMITS")
+	if s.Database != "" {
+		_, _ = buf.WriteString(" ON ")
+		_, _ = buf.WriteString(QuoteIdent(s.Database))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowCardinalityLimitsStatement.
+func (s *ShowCardinalityLimitsStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
 // AlterRetentionPolicyStatement represents a command to alter an existing retention policy.
 type AlterRetentionPolicyStatement struct {
 	// Name of policy to alter.
@@ -909,6 +1359,13 @@ type AlterRetentionPolicyStatement struct {
 
 	// Duration of the Shard.
 	ShardGroupDuration *time.Duration
+
+	// Duration a series is kept in the index without new points.
+	SeriesDuration *time.Duration
+
+	// Whether writes and deletes of data are refused (READ ONLY) or allowed
+	// again (READ WRITE).
+	ReadOnly *bool
 }
 
 // String returns a string representation of the alter retention policy statement.
@@ -934,6 +1391,15 @@ func (s *AlterRetentionPolicyStatement) String() string {
 		_, _ = buf.WriteString(FormatDuration(*s.ShardGroupDuration))
 	}
 
+	if s.SeriesDuration != nil {
+		_, _ = buf.WriteString(" SERIES DURATION ")
+		_, _ = buf.WriteString(FormatDuration(*s.SeriesDuration))
+	}
+
+	if s.ReadOnly != nil {
+		_, _ = buf.WriteString(formatReadOnly(*s.ReadOnly))
+	}
+
 	if s.Default {
 		_, _ = buf.WriteString(" DEFAULT")
 	}
@@ -941,6 +1407,14 @@ func (s *AlterRetentionPolicyStatement) String() string {
 	return buf.String()
 }
 
+// formatReadOnly returns the READ ONLY or READ WRITE option of an ALTER statement.
+func formatReadOnly(readOnly bool) string {
+	if readOnly {
+		return " READ ONLY"
+	}
+	return " READ WRITE"
+}
+
 // RequiredPrivileges returns the privilege required to execute an AlterRetentionPolicyStatement.
 func (s *AlterRetentionPolicyStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
 	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
@@ -2992,6 +3466,30 @@ func (s *DropMeasurementStatement) RequiredPrivileges() (ExecutionPrivileges, er
 	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
 }
 
+// DropFieldStatement represents a command to drop a field of a measurement.
+type DropFieldStatement struct {
+	// Name of the field to be dropped.
+	Name string
+
+	// Measurement of the field.
+	Measurement string
+}
+
+// String returns a string representation of the drop field statement.
+func (s *DropFieldStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("DROP FIELD ")
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	_, _ = buf.WriteString(" FROM ")
+	_, _ = buf.WriteString(QuoteIdent(s.Measurement))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a DropFieldStatement.
+func (s *DropFieldStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
 // ShowQueriesStatement represents a command for listing all running queries.
 type ShowQueriesStatement struct{}
 
@@ -3331,6 +3829,38 @@ func (s *ShowTagValuesStatement) DefaultDatabase() string {
 	return s.Database
 }
 
+// ShowRolesStatement represents a command for listing roles and their privileges.
+type ShowRolesStatement struct{}
+
+// String returns a string representation of the ShowRolesStatement.
+func (s *ShowRolesStatement) String() string {
+	return "SHOW ROLES"
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a ShowRolesStatement
+func (s *ShowRolesStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
+// ShowTokensStatement represents a command for listing API tokens.
+type ShowTokensStatement struct {
+	// Optional user to list the tokens of.
+	User string
+}
+
+// String returns a string representation of the ShowTokensStatement.
+func (s *ShowTokensStatement) String() string {
+	if s.User != "" {
+		return "SHOW TOKENS FOR " + QuoteIdent(s.User)
+	}
+	return "SHOW TOKENS"
+}
+
+// RequiredPrivileges returns the privilege(s) required to execute a ShowTokensStatement
+func (s *ShowTokensStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Privilege: AllPrivileges}}, nil
+}
+
 // ShowUsersStatement represents a command for listing users.
 type ShowUsersStatement struct{}
 
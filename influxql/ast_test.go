@@ -1731,10 +1731,13 @@ func Test_EnforceHasDefaultDatabase(t *testing.T) {
 
 	// this is a list of statements that do not have a database context
 	exemptStatements := []string{
+		"AlterFieldStatement",
+		"AlterMeasurementStatement",
 		"CreateDatabaseStatement",
 		"CreateUserStatement",
 		"DeleteSeriesStatement",
 		"DropDatabaseStatement",
+		"DropFieldStatement",
 		"DropMeasurementStatement",
 		"DropSeriesStatement",
 		"DropShardStatement",
@@ -1744,6 +1747,7 @@ func Test_EnforceHasDefaultDatabase(t *testing.T) {
 		"RevokeAdminStatement",
 		"SelectStatement",
 		"SetPasswordUserStatement",
+		"ShowCardinalityLimitsStatement",
 		"ShowContinuousQueriesStatement",
 		"ShowDatabasesStatement",
 		"ShowDiagnosticsStatement",
@@ -1784,11 +1788,13 @@ func Test_EnforceHasDefaultDatabase(t *testing.T) {
 		&influxql.CreateRetentionPolicyStatement{},
 		&influxql.CreateSubscriptionStatement{},
 		&influxql.DeleteStatement{},
+		&influxql.DropCardinalityLimitStatement{},
 		&influxql.DropContinuousQueryStatement{},
 		&influxql.DropRetentionPolicyStatement{},
 		&influxql.DropSubscriptionStatement{},
 		&influxql.GrantStatement{},
 		&influxql.RevokeStatement{},
+		&influxql.SetCardinalityLimitStatement{},
 		&influxql.ShowFieldKeysStatement{},
 		&influxql.ShowMeasurementsStatement{},
 		&influxql.ShowRetentionPoliciesStatement{},
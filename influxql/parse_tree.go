@@ -1,6 +1,9 @@
 package influxql
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 var Language = &ParseTree{}
 
@@ -8,6 +11,12 @@ type ParseTree struct {
 	Handlers map[Token]func(*Parser) (Statement, error)
 	Tokens   map[Token]*ParseTree
 	Keys     []string
+
+	// IdentHandlers and Idents hold the handlers and subtrees of words that
+	// are not keywords, keyed by the upper case word.  They are matched
+	// against identifiers so the words can still be used as identifiers.
+	IdentHandlers map[string]func(*Parser) (Statement, error)
+	Idents        map[string]*ParseTree
 }
 
 // With passes the current parse tree to a function to allow nested functions.
@@ -61,6 +70,52 @@ func (t *ParseTree) Handle(tok Token, fn func(*Parser) (Statement, error)) {
 	t.Keys = append(t.Keys, tok.String())
 }
 
+// GroupIdent groups together a set of related handlers with a common prefix of
+// words that are not keywords.
+func (t *ParseTree) GroupIdent(words ...string) *ParseTree {
+	for _, word := range words {
+		// Look for the parse tree for this word.
+		if subtree, ok := t.Idents[word]; ok {
+			t = subtree
+			continue
+		}
+
+		// No subtree exists yet. Verify that we don't have a conflicting
+		// statement.
+		if _, conflict := t.IdentHandlers[word]; conflict {
+			panic(fmt.Sprintf("conflict for word %s", word))
+		}
+
+		newT := &ParseTree{}
+		if t.Idents == nil {
+			t.Idents = make(map[string]*ParseTree)
+		}
+		t.Idents[word] = newT
+		t.Keys = append(t.Keys, word)
+		t = newT
+	}
+	return t
+}
+
+// HandleIdent registers a handler to be invoked when seeing the given word,
+// which is not a keyword.
+func (t *ParseTree) HandleIdent(word string, fn func(*Parser) (Statement, error)) {
+	// Verify that there is no conflict for this word in this parse tree.
+	if _, conflict := t.Idents[word]; conflict {
+		panic(fmt.Sprintf("conflict for word %s", word))
+	}
+
+	if _, conflict := t.IdentHandlers[word]; conflict {
+		panic(fmt.Sprintf("conflict for word %s", word))
+	}
+
+	if t.IdentHandlers == nil {
+		t.IdentHandlers = make(map[string]func(*Parser) (Statement, error))
+	}
+	t.IdentHandlers[word] = fn
+	t.Keys = append(t.Keys, word)
+}
+
 // Parse parses a statement using the language defined in the parse tree.
 func (t *ParseTree) Parse(p *Parser) (Statement, error) {
 	for {
@@ -74,6 +129,18 @@ func (t *ParseTree) Parse(p *Parser) (Statement, error) {
 			return stmt(p)
 		}
 
+		if tok == IDENT {
+			word := strings.ToUpper(lit)
+			if subtree, ok := t.Idents[word]; ok {
+				t = subtree
+				continue
+			}
+
+			if stmt, ok := t.IdentHandlers[word]; ok {
+				return stmt(p)
+			}
+		}
+
 		// There were no registered handlers. Return the valid tokens in the order they were added.
 		return nil, newParseError(tokstr(tok, lit), t.Keys, pos)
 	}
@@ -94,6 +161,20 @@ func (t *ParseTree) Clone() *ParseTree {
 			newT.Tokens[tok] = subtree.Clone()
 		}
 	}
+
+	if t.IdentHandlers != nil {
+		newT.IdentHandlers = make(map[string]func(*Parser) (Statement, error), len(t.IdentHandlers))
+		for word, handler := range t.IdentHandlers {
+			newT.IdentHandlers[word] = handler
+		}
+	}
+
+	if t.Idents != nil {
+		newT.Idents = make(map[string]*ParseTree, len(t.Idents))
+		for word, subtree := range t.Idents {
+			newT.Idents[word] = subtree.Clone()
+		}
+	}
 	return newT
 }
 
@@ -105,6 +186,9 @@ func init() {
 		return p.parseDeleteStatement()
 	})
 	Language.Group(SHOW).With(func(show *ParseTree) {
+		show.GroupIdent("CARDINALITY").HandleIdent("LIMITS", func(p *Parser) (Statement, error) {
+			return p.parseShowCardinalityLimitsStatement()
+		})
 		show.Group(CONTINUOUS).Handle(QUERIES, func(p *Parser) (Statement, error) {
 			return p.parseShowContinuousQueriesStatement()
 		})
@@ -129,6 +213,9 @@ func init() {
 		show.Group(RETENTION).Handle(POLICIES, func(p *Parser) (Statement, error) {
 			return p.parseShowRetentionPoliciesStatement()
 		})
+		show.HandleIdent("ROLES", func(p *Parser) (Statement, error) {
+			return p.parseShowRolesStatement()
+		})
 		show.Handle(SERIES, func(p *Parser) (Statement, error) {
 			return p.parseShowSeriesStatement()
 		})
@@ -152,6 +239,9 @@ func init() {
 				return p.parseShowTagValuesStatement()
 			})
 		})
+		show.HandleIdent("TOKENS", func(p *Parser) (Statement, error) {
+			return p.parseShowTokensStatement()
+		})
 		show.Handle(USERS, func(p *Parser) (Statement, error) {
 			return p.parseShowUsersStatement()
 		})
@@ -169,23 +259,38 @@ func init() {
 		create.Group(RETENTION).Handle(POLICY, func(p *Parser) (Statement, error) {
 			return p.parseCreateRetentionPolicyStatement()
 		})
+		create.HandleIdent("ROLE", func(p *Parser) (Statement, error) {
+			return p.parseCreateRoleStatement()
+		})
 		create.Handle(SUBSCRIPTION, func(p *Parser) (Statement, error) {
 			return p.parseCreateSubscriptionStatement()
 		})
+		create.HandleIdent("TOKEN", func(p *Parser) (Statement, error) {
+			return p.parseCreateTokenStatement()
+		})
 	})
 	Language.Group(DROP).With(func(drop *ParseTree) {
+		drop.GroupIdent("CARDINALITY").Handle(LIMIT, func(p *Parser) (Statement, error) {
+			return p.parseDropCardinalityLimitStatement()
+		})
 		drop.Group(CONTINUOUS).Handle(QUERY, func(p *Parser) (Statement, error) {
 			return p.parseDropContinuousQueryStatement()
 		})
 		drop.Handle(DATABASE, func(p *Parser) (Statement, error) {
 			return p.parseDropDatabaseStatement()
 		})
+		drop.Handle(FIELD, func(p *Parser) (Statement, error) {
+			return p.parseDropFieldStatement()
+		})
 		drop.Handle(MEASUREMENT, func(p *Parser) (Statement, error) {
 			return p.parseDropMeasurementStatement()
 		})
 		drop.Group(RETENTION).Handle(POLICY, func(p *Parser) (Statement, error) {
 			return p.parseDropRetentionPolicyStatement()
 		})
+		drop.HandleIdent("ROLE", func(p *Parser) (Statement, error) {
+			return p.parseDropRoleStatement()
+		})
 		drop.Handle(SERIES, func(p *Parser) (Statement, error) {
 			return p.parseDropSeriesStatement()
 		})
@@ -195,6 +300,9 @@ func init() {
 		drop.Handle(SUBSCRIPTION, func(p *Parser) (Statement, error) {
 			return p.parseDropSubscriptionStatement()
 		})
+		drop.HandleIdent("TOKEN", func(p *Parser) (Statement, error) {
+			return p.parseDropTokenStatement()
+		})
 		drop.Handle(USER, func(p *Parser) (Statement, error) {
 			return p.parseDropUserStatement()
 		})
@@ -205,11 +313,27 @@ func init() {
 	Language.Handle(REVOKE, func(p *Parser) (Statement, error) {
 		return p.parseRevokeStatement()
 	})
-	Language.Group(ALTER, RETENTION).Handle(POLICY, func(p *Parser) (Statement, error) {
-		return p.parseAlterRetentionPolicyStatement()
+	Language.Group(ALTER).With(func(alter *ParseTree) {
+		alter.Group(RETENTION).Handle(POLICY, func(p *Parser) (Statement, error) {
+			return p.parseAlterRetentionPolicyStatement()
+		})
+		alter.Handle(DATABASE, func(p *Parser) (Statement, error) {
+			return p.parseAlterDatabaseStatement()
+		})
+		alter.Handle(FIELD, func(p *Parser) (Statement, error) {
+			return p.parseAlterFieldStatement()
+		})
+		alter.Handle(MEASUREMENT, func(p *Parser) (Statement, error) {
+			return p.parseAlterMeasurementStatement()
+		})
 	})
-	Language.Group(SET, PASSWORD).Handle(FOR, func(p *Parser) (Statement, error) {
-		return p.parseSetPasswordUserStatement()
+	Language.Group(SET).With(func(set *ParseTree) {
+		set.GroupIdent("CARDINALITY").Handle(LIMIT, func(p *Parser) (Statement, error) {
+			return p.parseSetCardinalityLimitStatement()
+		})
+		set.Group(PASSWORD).Handle(FOR, func(p *Parser) (Statement, error) {
+			return p.parseSetPasswordUserStatement()
+		})
 	})
 	Language.Group(KILL).Handle(QUERY, func(p *Parser) (Statement, error) {
 		return p.parseKillQueryStatement()
@@ -30,3 +30,26 @@ func TestParseTree_Clone(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestParseTree_HandleIdent(t *testing.T) {
+	// Register a statement starting with words that are not keywords.
+	language := influxql.Language.Clone()
+	language.Group(influxql.SHOW).GroupIdent("FOO").HandleIdent("BAR", func(p *influxql.Parser) (influxql.Statement, error) {
+		return &influxql.ShowStatsStatement{}, nil
+	})
+
+	// The words are matched case insensitively.
+	parser := influxql.NewParser(strings.NewReader(`SHOW foo Bar`))
+	stmt, err := language.Parse(parser)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(stmt, &influxql.ShowStatsStatement{}) {
+		t.Fatalf("unexpected statement returned from parser: %s", stmt)
+	}
+
+	// The words can still be used as identifiers.
+	parser = influxql.NewParser(strings.NewReader(`SELECT foo FROM bar`))
+	if _, err := language.Parse(parser); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
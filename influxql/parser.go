@@ -270,6 +270,21 @@ func (p *Parser) parseCreateRetentionPolicyStatement() (*CreateRetentionPolicySt
 		p.Unscan()
 	}
 
+	// Parse optional SERIES DURATION.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == SERIES {
+		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != DURATION {
+			return nil, newParseError(tokstr(tok, lit), []string{"DURATION"}, pos)
+		}
+
+		d, err := p.ParseDuration()
+		if err != nil {
+			return nil, err
+		}
+		stmt.SeriesDuration = d
+	} else {
+		p.Unscan()
+	}
+
 	// Parse optional DEFAULT token.
 	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == DEFAULT {
 		stmt.Default = true
@@ -280,6 +295,252 @@ func (p *Parser) parseCreateRetentionPolicyStatement() (*CreateRetentionPolicySt
 	return stmt, nil
 }
 
+// parseAlterDatabaseStatement parses a string and returns an AlterDatabaseStatement.
+// This function assumes the ALTER DATABASE tokens have already been consumed.
+func (p *Parser) parseAlterDatabaseStatement() (*AlterDatabaseStatement, error) {
+	stmt := &AlterDatabaseStatement{}
+
+	// Parse the name of the database to be altered.
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = lit
+
+	// Loop through options (WAL, DUPLICATE POLICY, READ ONLY).  WAL and
+	// DUPLICATE are not keywords so they can still be used as identifiers.
+	found := make(map[string]struct{})
+Loop:
+	for {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		option := tok.String()
+		if tok == IDENT {
+			option = strings.ToUpper(lit)
+		}
+		if _, ok := found[option]; ok {
+			return nil, &ParseError{
+				Message: fmt.Sprintf("found duplicate %s option", option),
+				Pos:     pos,
+			}
+		}
+
+		switch {
+		case option == "WAL" && tok == IDENT:
+			// Parse the WAL mode. GROUP is a keyword so it is not returned as an identifier.
+			tok, pos, lit := p.ScanIgnoreWhitespace()
+			switch {
+			case tok == GROUP:
+				stmt.WALMode = "group"
+			case tok == IDENT && (strings.EqualFold(lit, "fsync") || strings.EqualFold(lit, "none")):
+				stmt.WALMode = strings.ToLower(lit)
+			default:
+				return nil, newParseError(tokstr(tok, lit), []string{"FSYNC", "GROUP", "NONE"}, pos)
+			}
+		case option == "DUPLICATE" && tok == IDENT:
+			if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != POLICY {
+				return nil, newParseError(tokstr(tok, lit), []string{"POLICY"}, pos)
+			}
+
+			tok, pos, lit := p.ScanIgnoreWhitespace()
+			if tok != IDENT || !(strings.EqualFold(lit, "first") || strings.EqualFold(lit, "last") || strings.EqualFold(lit, "reject")) {
+				return nil, newParseError(tokstr(tok, lit), []string{"FIRST", "LAST", "REJECT"}, pos)
+			}
+			stmt.DuplicatePolicy = strings.ToLower(lit)
+		case tok == READ:
+			readOnly, err := p.parseReadOnly()
+			if err != nil {
+				return nil, err
+			}
+			stmt.ReadOnly = &readOnly
+		default:
+			if len(found) == 0 {
+				return nil, newParseError(tokstr(tok, lit), []string{"WAL", "DUPLICATE", "READ"}, pos)
+			}
+			p.Unscan()
+			break Loop
+		}
+		found[option] = struct{}{}
+	}
+
+	return stmt, nil
+}
+
+// parseReadOnly parses the ONLY or WRITE token following READ in an ALTER
+// statement and returns true for READ ONLY.
+// This function assumes the READ token has already been consumed.
+func (p *Parser) parseReadOnly() (bool, error) {
+	// ONLY is not a keyword so it can still be used as an identifier.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch {
+	case tok == IDENT && strings.ToLower(lit) == "only":
+		return true, nil
+	case tok == WRITE:
+		return false, nil
+	}
+	return false, newParseError(tokstr(tok, lit), []string{"ONLY", "WRITE"}, pos)
+}
+
+// parseAlterFieldStatement parses a string and returns an AlterFieldStatement.
+// This function assumes the ALTER FIELD tokens have already been consumed.
+func (p *Parser) parseAlterFieldStatement() (*AlterFieldStatement, error) {
+	stmt := &AlterFieldStatement{}
+
+	// Parse the field and its measurement.
+	var err error
+	if stmt.Name, stmt.Measurement, err = p.parseFieldTarget(); err != nil {
+		return nil, err
+	}
+
+	// TYPE is not a keyword so it can still be used as an identifier.
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != IDENT || strings.ToLower(lit) != "type" {
+		return nil, newParseError(tokstr(tok, lit), []string{"TYPE"}, pos)
+	}
+
+	// Parse the type to convert the field to.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok == IDENT {
+		switch strings.ToLower(lit) {
+		case "float":
+			stmt.Type = Float
+			return stmt, nil
+		case "integer":
+			stmt.Type = Integer
+			return stmt, nil
+		case "string":
+			stmt.Type = String
+			return stmt, nil
+		case "boolean":
+			stmt.Type = Boolean
+			return stmt, nil
+		}
+	}
+	return nil, newParseError(tokstr(tok, lit), []string{"float", "integer", "string", "boolean"}, pos)
+}
+
+// parseFieldTarget parses the field and measurement of a field statement.
+func (p *Parser) parseFieldTarget() (field, measurement string, err error) {
+	if field, err = p.ParseIdent(); err != nil {
+		return "", "", err
+	} else if err := p.parseTokens([]Token{FROM}); err != nil {
+		return "", "", err
+	} else if measurement, err = p.ParseIdent(); err != nil {
+		return "", "", err
+	}
+	return field, measurement, nil
+}
+
+// parseAlterMeasurementStatement parses a string and returns an AlterMeasurementStatement.
+// This function assumes the ALTER MEASUREMENT tokens have already been consumed.
+func (p *Parser) parseAlterMeasurementStatement() (*AlterMeasurementStatement, error) {
+	stmt := &AlterMeasurementStatement{}
+
+	// Parse the name of the measurement to be altered.
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = lit
+
+	// RENAME is not a keyword so it can still be used as an identifier.
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != IDENT || strings.ToUpper(lit) != "RENAME" {
+		return nil, newParseError(tokstr(tok, lit), []string{"RENAME"}, pos)
+	}
+
+	// Parse either a tag key and its new name or the new measurement name.
+	switch tok, pos, lit := p.ScanIgnoreWhitespace(); tok {
+	case TAG:
+		if stmt.TagKey, err = p.ParseIdent(); err != nil {
+			return nil, err
+		} else if err := p.parseTokens([]Token{TO}); err != nil {
+			return nil, err
+		} else if stmt.NewTagKey, err = p.ParseIdent(); err != nil {
+			return nil, err
+		}
+	case TO:
+		if stmt.NewName, err = p.ParseIdent(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, newParseError(tokstr(tok, lit), []string{"TO", "TAG"}, pos)
+	}
+
+	return stmt, nil
+}
+
+// parseSetCardinalityLimitStatement parses a string and returns a SetCardinalityLimitStatement.
+// This function assumes the SET CARDINALITY LIMIT tokens have already been consumed.
+func (p *Parser) parseSetCardinalityLimitStatement() (*SetCardinalityLimitStatement, error) {
+	stmt := &SetCardinalityLimitStatement{}
+
+	// Parse the maximum cardinality.
+	n, err := p.ParseInt(1, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Max = n
+
+	// Parse the database, measurement and optional tag key.
+	if stmt.Database, stmt.Measurement, stmt.TagKey, err = p.parseCardinalityLimitTarget(); err != nil {
+		return nil, err
+	}
+
+	// Parse the optional policy. DROP is a keyword so it is not returned as an identifier.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == POLICY {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		switch {
+		case tok == DROP:
+			stmt.Policy = "drop"
+		case tok == IDENT && (strings.EqualFold(lit, "reject") || strings.EqualFold(lit, "log")):
+			stmt.Policy = strings.ToLower(lit)
+		default:
+			return nil, newParseError(tokstr(tok, lit), []string{"REJECT", "DROP", "LOG"}, pos)
+		}
+	} else {
+		p.Unscan()
+	}
+
+	return stmt, nil
+}
+
+// parseDropCardinalityLimitStatement parses a string and returns a DropCardinalityLimitStatement.
+// This function assumes the DROP CARDINALITY LIMIT tokens have already been consumed.
+func (p *Parser) parseDropCardinalityLimitStatement() (*DropCardinalityLimitStatement, error) {
+	stmt := &DropCardinalityLimitStatement{}
+
+	var err error
+	if stmt.Database, stmt.Measurement, stmt.TagKey, err = p.parseCardinalityLimitTarget(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseCardinalityLimitTarget parses the "ON db MEASUREMENT name [TAG key]"
+// clause of a cardinality limit statement.
+func (p *Parser) parseCardinalityLimitTarget() (database, measurement, tagKey string, err error) {
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != ON {
+		return "", "", "", newParseError(tokstr(tok, lit), []string{"ON"}, pos)
+	}
+	if database, err = p.ParseIdent(); err != nil {
+		return "", "", "", err
+	}
+
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != MEASUREMENT {
+		return "", "", "", newParseError(tokstr(tok, lit), []string{"MEASUREMENT"}, pos)
+	}
+	if measurement, err = p.ParseIdent(); err != nil {
+		return "", "", "", err
+	}
+
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == TAG {
+		if tagKey, err = p.ParseIdent(); err != nil {
+			return "", "", "", err
+		}
+	} else {
+		p.Unscan()
+	}
+	return database, measurement, tagKey, nil
+}
+
 // parseAlterRetentionPolicyStatement parses a string and returns an alter retention policy statement.
 // This function assumes the ALTER RETENTION POLICY tokens have already been consumed.
 func (p *Parser) parseAlterRetentionPolicyStatement() (*AlterRetentionPolicyStatement, error) {
@@ -353,11 +614,27 @@ Loop:
 			} else {
 				return nil, newParseError(tokstr(tok, lit), []string{"DURATION"}, pos)
 			}
+		case SERIES:
+			if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != DURATION {
+				return nil, newParseError(tokstr(tok, lit), []string{"DURATION"}, pos)
+			}
+
+			d, err := p.ParseDuration()
+			if err != nil {
+				return nil, err
+			}
+			stmt.SeriesDuration = &d
+		case READ:
+			readOnly, err := p.parseReadOnly()
+			if err != nil {
+				return nil, err
+			}
+			stmt.ReadOnly = &readOnly
 		case DEFAULT:
 			stmt.Default = true
 		default:
 			if len(found) == 0 {
-				return nil, newParseError(tokstr(tok, lit), []string{"DURATION", "REPLICATION", "SHARD", "DEFAULT"}, pos)
+				return nil, newParseError(tokstr(tok, lit), []string{"DURATION", "REPLICATION", "SHARD", "SERIES", "READ", "DEFAULT"}, pos)
 			}
 			p.Unscan()
 			break Loop
@@ -540,14 +817,22 @@ func (p *Parser) parseStringList() ([]string, error) {
 // parseRevokeStatement parses a string and returns a revoke statement.
 // This function assumes the REVOKE token has already been consumed.
 func (p *Parser) parseRevokeStatement() (Statement, error) {
+	// Check for a role to revoke from a user.
+	// ROLE is not a keyword so it can still be used as an identifier.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok == IDENT && strings.ToUpper(lit) == "ROLE" {
+		return p.parseRevokeRoleStatement()
+	}
+	p.Unscan()
+
 	// Parse the privilege to be revoked.
 	priv, err := p.parsePrivilege()
 	if err != nil {
-		return nil, err
+		return nil, newParseError(tokstr(tok, lit), []string{"READ", "WRITE", "ALL [PRIVILEGES]", "ROLE"}, pos)
 	}
 
 	// Check for ON or FROM clauses.
-	tok, pos, lit := p.ScanIgnoreWhitespace()
+	tok, pos, lit = p.ScanIgnoreWhitespace()
 	if tok == ON {
 		stmt, err := p.parseRevokeOnStatement()
 		if err != nil {
@@ -575,12 +860,11 @@ func (p *Parser) parseRevokeStatement() (Statement, error) {
 func (p *Parser) parseRevokeOnStatement() (*RevokeStatement, error) {
 	stmt := &RevokeStatement{}
 
-	// Parse the name of the database.
-	lit, err := p.ParseIdent()
-	if err != nil {
+	// Parse the name of the database and the optional series.
+	var err error
+	if stmt.On, stmt.Measurement, stmt.Condition, err = p.parseGrantTarget(); err != nil {
 		return nil, err
 	}
-	stmt.On = lit
 
 	// Parse FROM clause.
 	tok, pos, lit := p.ScanIgnoreWhitespace()
@@ -590,6 +874,31 @@ func (p *Parser) parseRevokeOnStatement() (*RevokeStatement, error) {
 		return nil, newParseError(tokstr(tok, lit), []string{"FROM"}, pos)
 	}
 
+	// Parse the name of the user or role.
+	if stmt.User, stmt.Role, err = p.parseGrantee(stmt.Measurement, stmt.Condition); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseRevokeRoleStatement parses a string and returns a RevokeRoleStatement.
+// This function assumes the REVOKE ROLE tokens have already been consumed.
+func (p *Parser) parseRevokeRoleStatement() (*RevokeRoleStatement, error) {
+	stmt := &RevokeRoleStatement{}
+
+	// Parse the name of the role.
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Role = lit
+
+	// Parse FROM clause.
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != FROM {
+		return nil, newParseError(tokstr(tok, lit), []string{"FROM"}, pos)
+	}
+
 	// Parse the name of the user.
 	lit, err = p.ParseIdent()
 	if err != nil {
@@ -619,14 +928,22 @@ func (p *Parser) parseRevokeAdminStatement() (*RevokeAdminStatement, error) {
 // parseGrantStatement parses a string and returns a grant statement.
 // This function assumes the GRANT token has already been consumed.
 func (p *Parser) parseGrantStatement() (Statement, error) {
+	// Check for a role to grant to a user.
+	// ROLE is not a keyword so it can still be used as an identifier.
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok == IDENT && strings.ToUpper(lit) == "ROLE" {
+		return p.parseGrantRoleStatement()
+	}
+	p.Unscan()
+
 	// Parse the privilege to be granted.
 	priv, err := p.parsePrivilege()
 	if err != nil {
-		return nil, err
+		return nil, newParseError(tokstr(tok, lit), []string{"READ", "WRITE", "ALL [PRIVILEGES]", "ROLE"}, pos)
 	}
 
 	// Check for ON or TO clauses.
-	tok, pos, lit := p.ScanIgnoreWhitespace()
+	tok, pos, lit = p.ScanIgnoreWhitespace()
 	if tok == ON {
 		stmt, err := p.parseGrantOnStatement()
 		if err != nil {
@@ -654,12 +971,11 @@ func (p *Parser) parseGrantStatement() (Statement, error) {
 func (p *Parser) parseGrantOnStatement() (*GrantStatement, error) {
 	stmt := &GrantStatement{}
 
-	// Parse the name of the database.
-	lit, err := p.ParseIdent()
-	if err != nil {
+	// Parse the name of the database and the optional series.
+	var err error
+	if stmt.On, stmt.Measurement, stmt.Condition, err = p.parseGrantTarget(); err != nil {
 		return nil, err
 	}
-	stmt.On = lit
 
 	// Parse TO clause.
 	tok, pos, lit := p.ScanIgnoreWhitespace()
@@ -669,6 +985,54 @@ func (p *Parser) parseGrantOnStatement() (*GrantStatement, error) {
 		return nil, newParseError(tokstr(tok, lit), []string{"TO"}, pos)
 	}
 
+	// Parse the name of the user or role.
+	if stmt.User, stmt.Role, err = p.parseGrantee(stmt.Measurement, stmt.Condition); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseGrantee parses the name of the user, or ROLE and the name of the
+// role, that a privilege is granted to or revoked from.  Roles can only hold
+// privileges on whole databases.
+func (p *Parser) parseGrantee(measurement string, condition Expr) (user, role string, err error) {
+	user, err = p.ParseIdent()
+	if err != nil || strings.ToUpper(user) != "ROLE" {
+		return user, "", err
+	}
+
+	// ROLE is not a keyword, so it is the name of a user unless the name of
+	// a role follows.
+	tok, _, lit := p.ScanIgnoreWhitespace()
+	if tok != IDENT {
+		p.Unscan()
+		return user, "", nil
+	}
+
+	if measurement != "" || condition != nil {
+		return "", "", errors.New("roles can only be granted privileges on databases")
+	}
+	return "", lit, nil
+}
+
+// parseGrantRoleStatement parses a string and returns a GrantRoleStatement.
+// This function assumes the GRANT ROLE tokens have already been consumed.
+func (p *Parser) parseGrantRoleStatement() (*GrantRoleStatement, error) {
+	stmt := &GrantRoleStatement{}
+
+	// Parse the name of the role.
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Role = lit
+
+	// Parse TO clause.
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != TO {
+		return nil, newParseError(tokstr(tok, lit), []string{"TO"}, pos)
+	}
+
 	// Parse the name of the user.
 	lit, err = p.ParseIdent()
 	if err != nil {
@@ -679,6 +1043,29 @@ func (p *Parser) parseGrantOnStatement() (*GrantStatement, error) {
 	return stmt, nil
 }
 
+// parseGrantTarget parses the database of a grant or revoke statement,
+// followed by an optional measurement and condition on the tags of series.
+func (p *Parser) parseGrantTarget() (database, measurement string, condition Expr, err error) {
+	if database, err = p.ParseIdent(); err != nil {
+		return "", "", nil, err
+	}
+
+	if tok, _, _ := p.Scan(); tok == DOT {
+		if measurement, err = p.ParseIdent(); err != nil {
+			return "", "", nil, err
+		}
+	} else {
+		p.Unscan()
+	}
+
+	if condition, err = p.parseCondition(); err != nil {
+		return "", "", nil, err
+	} else if condition != nil && HasTimeExpr(condition) {
+		return "", "", nil, errors.New("grant conditions can only filter on tags")
+	}
+	return database, measurement, condition, nil
+}
+
 // parseGrantAdminStatement parses a string and returns a grant admin statement.
 // This function assumes the ALL [PRVILEGES] TO tokens have already been consumed.
 func (p *Parser) parseGrantAdminStatement() (*GrantAdminStatement, error) {
@@ -1038,6 +1425,25 @@ func (p *Parser) parseShowRetentionPoliciesStatement() (*ShowRetentionPoliciesSt
 	return stmt, nil
 }
 
+// parseShowCardinalityLimitsStatement parses a string and returns a ShowCardinalityLimitsStatement.
+// This function assumes the "SHOW CARDINALITY LIMITS" tokens have been consumed.
+func (p *Parser) parseShowCardinalityLimitsStatement() (*ShowCardinalityLimitsStatement, error) {
+	stmt := &ShowCardinalityLimitsStatement{}
+
+	// Parse optional ON clause.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == ON {
+		ident, err := p.ParseIdent()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Database = ident
+	} else {
+		p.Unscan()
+	}
+
+	return stmt, nil
+}
+
 // parseShowTagKeysStatement parses a string and returns a ShowSeriesStatement.
 // This function assumes the "SHOW TAG KEYS" tokens have already been consumed.
 func (p *Parser) parseShowTagKeysStatement() (*ShowTagKeysStatement, error) {
@@ -1200,6 +1606,31 @@ func (p *Parser) parseTagKeyExpr() (Token, Literal, error) {
 	return 0, nil, newParseError(tokstr(tok, lit), []string{"IN", "=", "=~"}, pos)
 }
 
+// parseShowRolesStatement parses a string and returns a ShowRolesStatement.
+// This function assumes the "SHOW ROLES" tokens have been consumed.
+func (p *Parser) parseShowRolesStatement() (*ShowRolesStatement, error) {
+	return &ShowRolesStatement{}, nil
+}
+
+// parseShowTokensStatement parses a string and returns a ShowTokensStatement.
+// This function assumes the "SHOW TOKENS" tokens have been consumed.
+func (p *Parser) parseShowTokensStatement() (*ShowTokensStatement, error) {
+	stmt := &ShowTokensStatement{}
+
+	// Parse optional FOR clause.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == FOR {
+		lit, err := p.ParseIdent()
+		if err != nil {
+			return nil, err
+		}
+		stmt.User = lit
+	} else {
+		p.Unscan()
+	}
+
+	return stmt, nil
+}
+
 // parseShowUsersStatement parses a string and returns a ShowUsersStatement.
 // This function assumes the "SHOW USERS" tokens have been consumed.
 func (p *Parser) parseShowUsersStatement() (*ShowUsersStatement, error) {
@@ -1272,6 +1703,19 @@ func (p *Parser) parseDropMeasurementStatement() (*DropMeasurementStatement, err
 	return stmt, nil
 }
 
+// parseDropFieldStatement parses a string and returns a DropFieldStatement.
+// This function assumes the "DROP FIELD" tokens have already been consumed.
+func (p *Parser) parseDropFieldStatement() (*DropFieldStatement, error) {
+	stmt := &DropFieldStatement{}
+
+	// Parse the field to be dropped and its measurement.
+	var err error
+	if stmt.Name, stmt.Measurement, err = p.parseFieldTarget(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
 // parseDropSeriesStatement parses a string and returns a DropSeriesStatement.
 // This function assumes the "DROP SERIES" tokens have already been consumed.
 func (p *Parser) parseDropSeriesStatement() (*DropSeriesStatement, error) {
@@ -1625,6 +2069,98 @@ func (p *Parser) parseCreateUserStatement() (*CreateUserStatement, error) {
 	return stmt, nil
 }
 
+// parseCreateRoleStatement parses a string and returns a CreateRoleStatement.
+// This function assumes the CREATE ROLE tokens have already been consumed.
+func (p *Parser) parseCreateRoleStatement() (*CreateRoleStatement, error) {
+	stmt := &CreateRoleStatement{}
+
+	// Parse the name of the role to be created.
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = lit
+
+	return stmt, nil
+}
+
+// parseDropRoleStatement parses a string and returns a DropRoleStatement.
+// This function assumes the DROP ROLE tokens have already been consumed.
+func (p *Parser) parseDropRoleStatement() (*DropRoleStatement, error) {
+	stmt := &DropRoleStatement{}
+
+	// Parse the name of the role to be dropped.
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Name = lit
+
+	return stmt, nil
+}
+
+// parseCreateTokenStatement parses a string and returns a CreateTokenStatement.
+// This function assumes the CREATE TOKEN tokens have already been consumed.
+func (p *Parser) parseCreateTokenStatement() (*CreateTokenStatement, error) {
+	stmt := &CreateTokenStatement{}
+
+	// Parse the name of the user the token is for.
+	if err := p.parseTokens([]Token{FOR}); err != nil {
+		return nil, err
+	}
+	lit, err := p.ParseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt.User = lit
+
+	// Parse optional WITH SCOPE clause.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == WITH {
+		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != IDENT || !strings.EqualFold(lit, "scope") {
+			return nil, newParseError(tokstr(tok, lit), []string{"SCOPE"}, pos)
+		}
+		priv, err := p.parsePrivilege()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Privilege = priv
+
+		if err := p.parseTokens([]Token{ON}); err != nil {
+			return nil, err
+		}
+		lit, err := p.ParseIdent()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Database = lit
+	} else {
+		p.Unscan()
+	}
+
+	// Parse optional EXPIRES clause.
+	if tok, _, lit := p.ScanIgnoreWhitespace(); tok == IDENT && strings.EqualFold(lit, "expires") {
+		d, err := p.ParseDuration()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Expires = d
+	} else {
+		p.Unscan()
+	}
+
+	return stmt, nil
+}
+
+// parseDropTokenStatement parses a string and returns a DropTokenStatement.
+// This function assumes the DROP TOKEN tokens have already been consumed.
+func (p *Parser) parseDropTokenStatement() (*DropTokenStatement, error) {
+	id, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+	return &DropTokenStatement{ID: id}, nil
+}
+
 // parseDropUserStatement parses a string and returns a DropUserStatement.
 // This function assumes the DROP USER tokens have already been consumed.
 func (p *Parser) parseDropUserStatement() (*DropUserStatement, error) {
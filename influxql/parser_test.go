@@ -640,6 +640,31 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// SELECT statement with a field named like an unreserved keyword
+		{
+			s: `SELECT wal FROM m`,
+			stmt: &influxql.SelectStatement{
+				IsRawQuery: true,
+				Fields:     []*influxql.Field{{Expr: &influxql.VarRef{Val: "wal"}}},
+				Sources:    []influxql.Source{&influxql.Measurement{Name: "m"}},
+			},
+		},
+
+		// SELECT statement with a tag named like an unreserved keyword
+		{
+			s: `SELECT value FROM cpu WHERE token = 'abc'`,
+			stmt: &influxql.SelectStatement{
+				IsRawQuery: true,
+				Fields:     []*influxql.Field{{Expr: &influxql.VarRef{Val: "value"}}},
+				Sources:    []influxql.Source{&influxql.Measurement{Name: "cpu"}},
+				Condition: &influxql.BinaryExpr{
+					Op:  influxql.EQ,
+					LHS: &influxql.VarRef{Val: "token"},
+					RHS: &influxql.StringLiteral{Val: "abc"},
+				},
+			},
+		},
+
 		// SELECT statement (lowercase) with quoted field
 		{
 			s: `select 'my_field' from myseries`,
@@ -2216,6 +2241,12 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// DROP FIELD statement
+		{
+			s:    `DROP FIELD value FROM cpu`,
+			stmt: &influxql.DropFieldStatement{Name: "value", Measurement: "cpu"},
+		},
+
 		// DROP MEASUREMENT statement
 		{
 			s:    `DROP MEASUREMENT cpu`,
@@ -2247,6 +2278,31 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// GRANT READ on series
+		{
+			s: `GRANT READ ON testdb.cpu WHERE team = 'payments' TO jdoe`,
+			stmt: &influxql.GrantStatement{
+				Privilege:   influxql.ReadPrivilege,
+				On:          "testdb",
+				Measurement: "cpu",
+				Condition: &influxql.BinaryExpr{
+					Op:  influxql.EQ,
+					LHS: &influxql.VarRef{Val: "team"},
+					RHS: &influxql.StringLiteral{Val: "payments"},
+				},
+				User: "jdoe",
+			},
+		},
+		{
+			s: `GRANT WRITE ON testdb."cpu load" TO jdoe`,
+			stmt: &influxql.GrantStatement{
+				Privilege:   influxql.WritePrivilege,
+				On:          "testdb",
+				Measurement: "cpu load",
+				User:        "jdoe",
+			},
+		},
+
 		// GRANT WRITE
 		{
 			s: `GRANT WRITE ON testdb TO jdoe`,
@@ -2303,6 +2359,21 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// REVOKE READ on series
+		{
+			s: `REVOKE READ ON testdb WHERE team = 'payments' FROM jdoe`,
+			stmt: &influxql.RevokeStatement{
+				Privilege: influxql.ReadPrivilege,
+				On:        "testdb",
+				Condition: &influxql.BinaryExpr{
+					Op:  influxql.EQ,
+					LHS: &influxql.VarRef{Val: "team"},
+					RHS: &influxql.StringLiteral{Val: "payments"},
+				},
+				User: "jdoe",
+			},
+		},
+
 		// REVOKE WRITE
 		{
 			s: `REVOKE WRITE ON testdb FROM jdoe`,
@@ -2349,6 +2420,123 @@ func TestParser_ParseStatement(t *testing.T) {
 			},
 		},
 
+		// GRANT ON database TO ROLE
+		{
+			s: `GRANT READ ON testdb TO ROLE readers`,
+			stmt: &influxql.GrantStatement{
+				Privilege: influxql.ReadPrivilege,
+				On:        "testdb",
+				Role:      "readers",
+			},
+		},
+
+		// GRANT ON database TO a user named role
+		{
+			s: `GRANT READ ON testdb TO role`,
+			stmt: &influxql.GrantStatement{
+				Privilege: influxql.ReadPrivilege,
+				On:        "testdb",
+				User:      "role",
+			},
+		},
+
+		// REVOKE ON database FROM ROLE
+		{
+			s: `REVOKE ALL ON testdb FROM ROLE readers`,
+			stmt: &influxql.RevokeStatement{
+				Privilege: influxql.AllPrivileges,
+				On:        "testdb",
+				Role:      "readers",
+			},
+		},
+
+		// GRANT ROLE
+		{
+			s: `GRANT ROLE readers TO jdoe`,
+			stmt: &influxql.GrantRoleStatement{
+				Role: "readers",
+				User: "jdoe",
+			},
+		},
+
+		// GRANT ROLE named role
+		{
+			s: `GRANT ROLE role TO role`,
+			stmt: &influxql.GrantRoleStatement{
+				Role: "role",
+				User: "role",
+			},
+		},
+
+		// REVOKE ROLE
+		{
+			s: `REVOKE ROLE readers FROM jdoe`,
+			stmt: &influxql.RevokeRoleStatement{
+				Role: "readers",
+				User: "jdoe",
+			},
+		},
+
+		// CREATE ROLE
+		{
+			s:    `CREATE ROLE readers`,
+			stmt: &influxql.CreateRoleStatement{Name: "readers"},
+		},
+
+		// DROP ROLE
+		{
+			s:    `DROP ROLE readers`,
+			stmt: &influxql.DropRoleStatement{Name: "readers"},
+		},
+
+		// SHOW ROLES
+		{
+			s:    `SHOW ROLES`,
+			stmt: &influxql.ShowRolesStatement{},
+		},
+
+		// CREATE TOKEN
+		{
+			s:    `CREATE TOKEN FOR jdoe`,
+			stmt: &influxql.CreateTokenStatement{User: "jdoe"},
+		},
+		{
+			s: `CREATE TOKEN FOR jdoe WITH SCOPE read ON db0 EXPIRES 30d`,
+			stmt: &influxql.CreateTokenStatement{
+				User:      "jdoe",
+				Privilege: influxql.ReadPrivilege,
+				Database:  "db0",
+				Expires:   30 * 24 * time.Hour,
+			},
+		},
+		{
+			s: `CREATE TOKEN FOR jdoe EXPIRES 1h`,
+			stmt: &influxql.CreateTokenStatement{
+				User:    "jdoe",
+				Expires: time.Hour,
+			},
+		},
+
+		// DROP TOKEN
+		{
+			s:    `DROP TOKEN '0123456789abcdef'`,
+			stmt: &influxql.DropTokenStatement{ID: "0123456789abcdef"},
+		},
+
+		// SHOW TOKENS
+		{
+			s:    `SHOW TOKENS`,
+			stmt: &influxql.ShowTokensStatement{},
+		},
+		{
+			s:    `SHOW TOKENS FOR jdoe`,
+			stmt: &influxql.ShowTokensStatement{User: "jdoe"},
+		},
+		{
+			s:    `SHOW TOKENS FOR tokens`,
+			stmt: &influxql.ShowTokensStatement{User: "tokens"},
+		},
+
 		// CREATE RETENTION POLICY
 		{
 			s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION 2`,
@@ -2413,6 +2601,122 @@ func TestParser_ParseStatement(t *testing.T) {
 				ShardGroupDuration: time.Second,
 			},
 		},
+		{
+			s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION 2 SHARD DURATION 30m SERIES DURATION 10m DEFAULT`,
+			stmt: &influxql.CreateRetentionPolicyStatement{
+				Name:               "policy1",
+				Database:           "testdb",
+				Duration:           time.Hour,
+				Replication:        2,
+				ShardGroupDuration: 30 * time.Minute,
+				SeriesDuration:     10 * time.Minute,
+				Default:            true,
+			},
+		},
+
+		// ALTER DATABASE
+		{
+			s:    `ALTER DATABASE testdb WAL NONE`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "testdb", WALMode: "none"},
+		},
+		{
+			s:    `ALTER DATABASE testdb WAL fsync`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "testdb", WALMode: "fsync"},
+		},
+		{
+			s:    `ALTER DATABASE "test db" WAL GROUP`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "test db", WALMode: "group"},
+		},
+		{
+			s:    `ALTER DATABASE wal WAL none`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "wal", WALMode: "none"},
+		},
+		{
+			s:    `ALTER DATABASE testdb DUPLICATE POLICY reject`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "testdb", DuplicatePolicy: "reject"},
+		},
+		{
+			s:    `ALTER DATABASE testdb DUPLICATE POLICY FIRST WAL FSYNC`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "testdb", WALMode: "fsync", DuplicatePolicy: "first"},
+		},
+		{
+			s:    `ALTER DATABASE duplicate DUPLICATE POLICY last`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "duplicate", DuplicatePolicy: "last"},
+		},
+		{
+			s:    `ALTER DATABASE testdb READ ONLY`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "testdb", ReadOnly: boolptr(true)},
+		},
+		{
+			s:    `ALTER DATABASE testdb WAL NONE read write`,
+			stmt: &influxql.AlterDatabaseStatement{Name: "testdb", WALMode: "none", ReadOnly: boolptr(false)},
+		},
+
+		// ALTER FIELD
+		{
+			s:    `ALTER FIELD value FROM cpu TYPE float`,
+			stmt: &influxql.AlterFieldStatement{Name: "value", Measurement: "cpu", Type: influxql.Float},
+		},
+		{
+			s:    `ALTER FIELD "type" FROM cpu type STRING`,
+			stmt: &influxql.AlterFieldStatement{Name: "type", Measurement: "cpu", Type: influxql.String},
+		},
+
+		// ALTER MEASUREMENT
+		{
+			s:    `ALTER MEASUREMENT cpu RENAME TO "cpu load"`,
+			stmt: &influxql.AlterMeasurementStatement{Name: "cpu", NewName: "cpu load"},
+		},
+		{
+			s:    `ALTER MEASUREMENT cpu RENAME TAG hots TO host`,
+			stmt: &influxql.AlterMeasurementStatement{Name: "cpu", TagKey: "hots", NewTagKey: "host"},
+		},
+		{
+			s:    `ALTER MEASUREMENT rename RENAME TAG rename TO host`,
+			stmt: &influxql.AlterMeasurementStatement{Name: "rename", TagKey: "rename", NewTagKey: "host"},
+		},
+
+		// SET CARDINALITY LIMIT
+		{
+			s:    `SET CARDINALITY LIMIT 1000 ON testdb MEASUREMENT cpu`,
+			stmt: &influxql.SetCardinalityLimitStatement{Database: "testdb", Measurement: "cpu", Max: 1000},
+		},
+		{
+			s:    `SET CARDINALITY LIMIT 50 ON testdb MEASUREMENT "http requests" TAG path POLICY REJECT`,
+			stmt: &influxql.SetCardinalityLimitStatement{Database: "testdb", Measurement: "http requests", TagKey: "path", Max: 50, Policy: "reject"},
+		},
+		{
+			s:    `SET CARDINALITY LIMIT 50 ON testdb MEASUREMENT cpu TAG host POLICY drop`,
+			stmt: &influxql.SetCardinalityLimitStatement{Database: "testdb", Measurement: "cpu", TagKey: "host", Max: 50, Policy: "drop"},
+		},
+		{
+			s:    `SET CARDINALITY LIMIT 50 ON testdb MEASUREMENT cpu POLICY log`,
+			stmt: &influxql.SetCardinalityLimitStatement{Database: "testdb", Measurement: "cpu", Max: 50, Policy: "log"},
+		},
+
+		// DROP CARDINALITY LIMIT
+		{
+			s:    `DROP CARDINALITY LIMIT ON testdb MEASUREMENT cpu`,
+			stmt: &influxql.DropCardinalityLimitStatement{Database: "testdb", Measurement: "cpu"},
+		},
+		{
+			s:    `drop cardinality limit on cardinality measurement limits`,
+			stmt: &influxql.DropCardinalityLimitStatement{Database: "cardinality", Measurement: "limits"},
+		},
+		{
+			s:    `DROP CARDINALITY LIMIT ON testdb MEASUREMENT cpu TAG host`,
+			stmt: &influxql.DropCardinalityLimitStatement{Database: "testdb", Measurement: "cpu", TagKey: "host"},
+		},
+
+		// SHOW CARDINALITY LIMITS
+		{
+			s:    `SHOW CARDINALITY LIMITS`,
+			stmt: &influxql.ShowCardinalityLimitsStatement{},
+		},
+		{
+			s:    `SHOW CARDINALITY LIMITS ON testdb`,
+			stmt: &influxql.ShowCardinalityLimitsStatement{Database: "testdb"},
+		},
 
 		// ALTER RETENTION POLICY
 		{
@@ -2469,6 +2773,25 @@ func TestParser_ParseStatement(t *testing.T) {
 			s:    `ALTER RETENTION POLICY default ON testdb DURATION 0s REPLICATION 1 SHARD DURATION 0s`,
 			stmt: newAlterRetentionPolicyStatement("default", "testdb", time.Duration(0), 0, 1, false),
 		},
+		// ALTER RETENTION POLICY with series duration
+		{
+			s: `ALTER RETENTION POLICY policy1 ON testdb SERIES DURATION 1d`,
+			stmt: func() influxql.Statement {
+				stmt := newAlterRetentionPolicyStatement("policy1", "testdb", -1, -1, -1, false)
+				d := 24 * time.Hour
+				stmt.SeriesDuration = &d
+				return stmt
+			}(),
+		},
+		// ALTER RETENTION POLICY with read only
+		{
+			s: `ALTER RETENTION POLICY policy1 ON testdb READ ONLY DEFAULT`,
+			stmt: func() influxql.Statement {
+				stmt := newAlterRetentionPolicyStatement("policy1", "testdb", -1, -1, -1, true)
+				stmt.ReadOnly = boolptr(true)
+				return stmt
+			}(),
+		},
 
 		// SHOW STATS
 		{
@@ -2693,17 +3016,27 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `DELETE FROM "foo".myseries`, err: `retention policy not supported at line 1, char 1`},
 		{s: `DELETE FROM foo..myseries`, err: `database not supported at line 1, char 1`},
 		{s: `DROP MEASUREMENT`, err: `found EOF, expected identifier at line 1, char 18`},
+		{s: `DROP FIELD`, err: `found EOF, expected identifier at line 1, char 12`},
+		{s: `DROP FIELD value`, err: `found EOF, expected FROM at line 1, char 18`},
+		{s: `DROP FIELD value FROM`, err: `found EOF, expected identifier at line 1, char 23`},
 		{s: `DROP SERIES`, err: `found EOF, expected FROM, WHERE at line 1, char 13`},
 		{s: `DROP SERIES FROM`, err: `found EOF, expected identifier at line 1, char 18`},
 		{s: `DROP SERIES FROM src WHERE`, err: `found EOF, expected identifier, string, number, bool at line 1, char 28`},
 		{s: `DROP SERIES FROM "foo".myseries`, err: `retention policy not supported at line 1, char 1`},
 		{s: `DROP SERIES FROM foo..myseries`, err: `database not supported at line 1, char 1`},
+		{s: `CREATE TOKEN`, err: `found EOF, expected FOR at line 1, char 14`},
+		{s: `CREATE TOKEN FOR jdoe WITH`, err: `found EOF, expected SCOPE at line 1, char 28`},
+		{s: `CREATE TOKEN FOR jdoe WITH SCOPE read`, err: `found EOF, expected ON at line 1, char 39`},
+		{s: `CREATE TOKEN FOR jdoe WITH SCOPE bogus`, err: `found bogus, expected READ, WRITE, ALL [PRIVILEGES] at line 1, char 34`},
+		{s: `CREATE TOKEN FOR jdoe EXPIRES`, err: `found EOF, expected duration at line 1, char 31`},
+		{s: `DROP TOKEN`, err: `found EOF, expected string at line 1, char 12`},
+		{s: `SHOW TOKENS FOR`, err: `found EOF, expected identifier at line 1, char 17`},
 		{s: `SHOW CONTINUOUS`, err: `found EOF, expected QUERIES at line 1, char 17`},
 		{s: `SHOW RETENTION`, err: `found EOF, expected POLICIES at line 1, char 16`},
 		{s: `SHOW RETENTION ON`, err: `found ON, expected POLICIES at line 1, char 16`},
 		{s: `SHOW RETENTION POLICIES ON`, err: `found EOF, expected identifier at line 1, char 28`},
 		{s: `SHOW SHARD`, err: `found EOF, expected GROUPS at line 1, char 12`},
-		{s: `SHOW FOO`, err: `found FOO, expected CONTINUOUS, DATABASES, DIAGNOSTICS, FIELD, GRANTS, MEASUREMENTS, QUERIES, RETENTION, SERIES, SHARD, SHARDS, STATS, SUBSCRIPTIONS, TAG, USERS at line 1, char 6`},
+		{s: `SHOW FOO`, err: `found FOO, expected CARDINALITY, CONTINUOUS, DATABASES, DIAGNOSTICS, FIELD, GRANTS, MEASUREMENTS, QUERIES, RETENTION, ROLES, SERIES, SHARD, SHARDS, STATS, SUBSCRIPTIONS, TAG, TOKENS, USERS at line 1, char 6`},
 		{s: `SHOW STATS FOR`, err: `found EOF, expected string at line 1, char 16`},
 		{s: `SHOW DIAGNOSTICS FOR`, err: `found EOF, expected string at line 1, char 22`},
 		{s: `SHOW GRANTS`, err: `found EOF, expected FOR at line 1, char 13`},
@@ -2716,8 +3049,8 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `CREATE CONTINUOUS QUERY`, err: `found EOF, expected identifier at line 1, char 25`},
 		{s: `CREATE CONTINUOUS QUERY cq ON db RESAMPLE FOR 5s BEGIN SELECT mean(value) INTO cpu_mean FROM cpu GROUP BY time(10s) END`, err: `FOR duration must be >= GROUP BY time duration: must be a minimum of 10s, got 5s`},
 		{s: `CREATE CONTINUOUS QUERY cq ON db RESAMPLE EVERY 10s FOR 5s BEGIN SELECT mean(value) INTO cpu_mean FROM cpu GROUP BY time(5s) END`, err: `FOR duration must be >= GROUP BY time duration: must be a minimum of 10s, got 5s`},
-		{s: `DROP FOO`, err: `found FOO, expected CONTINUOUS, DATABASE, MEASUREMENT, RETENTION, SERIES, SHARD, SUBSCRIPTION, USER at line 1, char 6`},
-		{s: `CREATE FOO`, err: `found FOO, expected CONTINUOUS, DATABASE, USER, RETENTION, SUBSCRIPTION at line 1, char 8`},
+		{s: `DROP FOO`, err: `found FOO, expected CARDINALITY, CONTINUOUS, DATABASE, FIELD, MEASUREMENT, RETENTION, ROLE, SERIES, SHARD, SUBSCRIPTION, TOKEN, USER at line 1, char 6`},
+		{s: `CREATE FOO`, err: `found FOO, expected CONTINUOUS, DATABASE, USER, RETENTION, ROLE, SUBSCRIPTION, TOKEN at line 1, char 8`},
 		{s: `CREATE DATABASE`, err: `found EOF, expected identifier at line 1, char 17`},
 		{s: `CREATE DATABASE "testdb" WITH`, err: `found EOF, expected DURATION, NAME, REPLICATION, SHARD at line 1, char 31`},
 		{s: `CREATE DATABASE "testdb" WITH DURATION`, err: `found EOF, expected duration at line 1, char 40`},
@@ -2748,14 +3081,17 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `CREATE SUBSCRIPTION "name" ON "db"."rp"`, err: `found EOF, expected DESTINATIONS at line 1, char 40`},
 		{s: `CREATE SUBSCRIPTION "name" ON "db"."rp" DESTINATIONS`, err: `found EOF, expected ALL, ANY at line 1, char 54`},
 		{s: `CREATE SUBSCRIPTION "name" ON "db"."rp" DESTINATIONS ALL `, err: `found EOF, expected string at line 1, char 59`},
-		{s: `GRANT`, err: `found EOF, expected READ, WRITE, ALL [PRIVILEGES] at line 1, char 7`},
-		{s: `GRANT BOGUS`, err: `found BOGUS, expected READ, WRITE, ALL [PRIVILEGES] at line 1, char 7`},
+		{s: `GRANT`, err: `found EOF, expected READ, WRITE, ALL [PRIVILEGES], ROLE at line 1, char 7`},
+		{s: `GRANT BOGUS`, err: `found BOGUS, expected READ, WRITE, ALL [PRIVILEGES], ROLE at line 1, char 7`},
 		{s: `GRANT READ`, err: `found EOF, expected ON at line 1, char 12`},
 		{s: `GRANT READ FROM`, err: `found FROM, expected ON at line 1, char 12`},
 		{s: `GRANT READ ON`, err: `found EOF, expected identifier at line 1, char 15`},
 		{s: `GRANT READ ON TO`, err: `found TO, expected identifier at line 1, char 15`},
 		{s: `GRANT READ ON testdb`, err: `found EOF, expected TO at line 1, char 22`},
 		{s: `GRANT READ ON testdb TO`, err: `found EOF, expected identifier at line 1, char 25`},
+		{s: `GRANT READ ON testdb. TO jdoe`, err: `found TO, expected identifier at line 1, char 23`},
+		{s: `GRANT READ ON testdb.cpu WHERE TO jdoe`, err: `found TO, expected identifier, string, number, bool at line 1, char 32`},
+		{s: `GRANT READ ON testdb.cpu WHERE time > now() TO jdoe`, err: `grant conditions can only filter on tags`},
 		{s: `GRANT READ TO`, err: `found TO, expected ON at line 1, char 12`},
 		{s: `GRANT WRITE`, err: `found EOF, expected ON at line 1, char 13`},
 		{s: `GRANT WRITE FROM`, err: `found FROM, expected ON at line 1, char 13`},
@@ -2783,8 +3119,8 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `KILL`, err: `found EOF, expected QUERY at line 1, char 6`},
 		{s: `KILL QUERY 10s`, err: `found 10s, expected integer at line 1, char 12`},
 		{s: `KILL QUERY 4 ON 'host'`, err: `found host, expected identifier at line 1, char 16`},
-		{s: `REVOKE`, err: `found EOF, expected READ, WRITE, ALL [PRIVILEGES] at line 1, char 8`},
-		{s: `REVOKE BOGUS`, err: `found BOGUS, expected READ, WRITE, ALL [PRIVILEGES] at line 1, char 8`},
+		{s: `REVOKE`, err: `found EOF, expected READ, WRITE, ALL [PRIVILEGES], ROLE at line 1, char 8`},
+		{s: `REVOKE BOGUS`, err: `found BOGUS, expected READ, WRITE, ALL [PRIVILEGES], ROLE at line 1, char 8`},
 		{s: `REVOKE READ`, err: `found EOF, expected ON at line 1, char 13`},
 		{s: `REVOKE READ TO`, err: `found TO, expected ON at line 1, char 13`},
 		{s: `REVOKE READ ON`, err: `found EOF, expected identifier at line 1, char 16`},
@@ -2815,6 +3151,14 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `REVOKE ALL PRIVILEGES ON testdb FROM`, err: `found EOF, expected identifier at line 1, char 38`},
 		{s: `REVOKE ALL FROM`, err: `found EOF, expected identifier at line 1, char 17`},
 		{s: `REVOKE ALL PRIVILEGES FROM`, err: `found EOF, expected identifier at line 1, char 28`},
+		{s: `GRANT ROLE`, err: `found EOF, expected identifier at line 1, char 12`},
+		{s: `GRANT ROLE readers`, err: `found EOF, expected TO at line 1, char 20`},
+		{s: `GRANT ROLE readers TO`, err: `found EOF, expected identifier at line 1, char 23`},
+		{s: `GRANT READ ON testdb.cpu TO ROLE readers`, err: `roles can only be granted privileges on databases`},
+		{s: `REVOKE ROLE readers`, err: `found EOF, expected FROM at line 1, char 21`},
+		{s: `REVOKE ROLE readers FROM`, err: `found EOF, expected identifier at line 1, char 26`},
+		{s: `CREATE ROLE`, err: `found EOF, expected identifier at line 1, char 13`},
+		{s: `DROP ROLE`, err: `found EOF, expected identifier at line 1, char 11`},
 		{s: `CREATE RETENTION`, err: `found EOF, expected POLICY at line 1, char 18`},
 		{s: `CREATE RETENTION POLICY`, err: `found EOF, expected identifier at line 1, char 25`},
 		{s: `CREATE RETENTION POLICY policy1`, err: `found EOF, expected ON at line 1, char 33`},
@@ -2828,15 +3172,43 @@ func TestParser_ParseStatement(t *testing.T) {
 		{s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION 0`, err: `invalid value 0: must be 1 <= n <= 2147483647 at line 1, char 67`},
 		{s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION bad`, err: `found bad, expected integer at line 1, char 67`},
 		{s: `CREATE RETENTION POLICY policy1 ON testdb DURATION 1h REPLICATION 2 SHARD DURATION INF`, err: `invalid duration INF for shard duration at line 1, char 84`},
-		{s: `ALTER`, err: `found EOF, expected RETENTION at line 1, char 7`},
+		{s: `ALTER`, err: `found EOF, expected RETENTION, DATABASE, FIELD, MEASUREMENT at line 1, char 7`},
+		{s: `ALTER DATABASE`, err: `found EOF, expected identifier at line 1, char 16`},
+		{s: `ALTER MEASUREMENT`, err: `found EOF, expected identifier at line 1, char 19`},
+		{s: `ALTER FIELD value FROM cpu`, err: `found EOF, expected TYPE at line 1, char 28`},
+		{s: `ALTER FIELD value FROM cpu TYPE`, err: `found EOF, expected float, integer, string, boolean at line 1, char 33`},
+		{s: `ALTER FIELD value FROM cpu TYPE time`, err: `found time, expected float, integer, string, boolean at line 1, char 33`},
+		{s: `ALTER MEASUREMENT cpu`, err: `found EOF, expected RENAME at line 1, char 23`},
+		{s: `ALTER MEASUREMENT cpu RENAME`, err: `found EOF, expected TO, TAG at line 1, char 30`},
+		{s: `ALTER MEASUREMENT cpu RENAME TO`, err: `found EOF, expected identifier at line 1, char 33`},
+		{s: `ALTER MEASUREMENT cpu RENAME TAG host`, err: `found EOF, expected TO at line 1, char 39`},
+		{s: `ALTER DATABASE testdb`, err: `found EOF, expected WAL, DUPLICATE, READ at line 1, char 23`},
+		{s: `ALTER DATABASE testdb READ`, err: `found EOF, expected ONLY, WRITE at line 1, char 28`},
+		{s: `ALTER DATABASE testdb WAL`, err: `found EOF, expected FSYNC, GROUP, NONE at line 1, char 27`},
+		{s: `ALTER DATABASE testdb WAL sometimes`, err: `found sometimes, expected FSYNC, GROUP, NONE at line 1, char 27`},
+		{s: `ALTER DATABASE testdb WAL NONE WAL FSYNC`, err: `found duplicate WAL option at line 1, char 32`},
+		{s: `ALTER DATABASE testdb DUPLICATE`, err: `found EOF, expected POLICY at line 1, char 33`},
+		{s: `ALTER DATABASE testdb DUPLICATE POLICY`, err: `found EOF, expected FIRST, LAST, REJECT at line 1, char 40`},
+		{s: `ALTER DATABASE testdb DUPLICATE POLICY newest`, err: `found newest, expected FIRST, LAST, REJECT at line 1, char 40`},
 		{s: `ALTER RETENTION`, err: `found EOF, expected POLICY at line 1, char 17`},
 		{s: `ALTER RETENTION POLICY`, err: `found EOF, expected identifier at line 1, char 24`},
 		{s: `ALTER RETENTION POLICY policy1`, err: `found EOF, expected ON at line 1, char 32`}, {s: `ALTER RETENTION POLICY policy1 ON`, err: `found EOF, expected identifier at line 1, char 35`},
-		{s: `ALTER RETENTION POLICY policy1 ON testdb`, err: `found EOF, expected DURATION, REPLICATION, SHARD, DEFAULT at line 1, char 42`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb`, err: `found EOF, expected DURATION, REPLICATION, SHARD, SERIES, READ, DEFAULT at line 1, char 42`},
+		{s: `ALTER RETENTION POLICY policy1 ON testdb READ ONLY READ WRITE`, err: `found duplicate READ option at line 1, char 52`},
 		{s: `ALTER RETENTION POLICY policy1 ON testdb REPLICATION 1 REPLICATION 2`, err: `found duplicate REPLICATION option at line 1, char 56`},
 		{s: `ALTER RETENTION POLICY policy1 ON testdb DURATION 15251w`, err: `overflowed duration 15251w: choose a smaller duration or INF at line 1, char 51`},
 		{s: `ALTER RETENTION POLICY policy1 ON testdb DURATION INF SHARD DURATION INF`, err: `invalid duration INF for shard duration at line 1, char 70`},
-		{s: `SET`, err: `found EOF, expected PASSWORD at line 1, char 5`},
+		{s: `SET`, err: `found EOF, expected CARDINALITY, PASSWORD at line 1, char 5`},
+		{s: `SET CARDINALITY`, err: `found EOF, expected LIMIT at line 1, char 17`},
+		{s: `SET CARDINALITY LIMIT`, err: `found EOF, expected integer at line 1, char 23`},
+		{s: `SET CARDINALITY LIMIT 0 ON testdb MEASUREMENT cpu`, err: `invalid value 0: must be 1 <= n <= 2147483647 at line 1, char 23`},
+		{s: `SET CARDINALITY LIMIT 10`, err: `found EOF, expected ON at line 1, char 25`},
+		{s: `SET CARDINALITY LIMIT 10 ON testdb`, err: `found EOF, expected MEASUREMENT at line 1, char 36`},
+		{s: `SET CARDINALITY LIMIT 10 ON testdb MEASUREMENT cpu TAG`, err: `found EOF, expected identifier at line 1, char 56`},
+		{s: `SET CARDINALITY LIMIT 10 ON testdb MEASUREMENT cpu POLICY`, err: `found EOF, expected REJECT, DROP, LOG at line 1, char 59`},
+		{s: `SET CARDINALITY LIMIT 10 ON testdb MEASUREMENT cpu POLICY ignore`, err: `found ignore, expected REJECT, DROP, LOG at line 1, char 59`},
+		{s: `DROP CARDINALITY LIMIT`, err: `found EOF, expected ON at line 1, char 24`},
+		{s: `DROP CARDINALITY LIMIT ON testdb MEASUREMENT`, err: `found EOF, expected identifier at line 1, char 46`},
 		{s: `SET PASSWORD`, err: `found EOF, expected FOR at line 1, char 14`},
 		{s: `SET PASSWORD something`, err: `found something, expected FOR at line 1, char 14`},
 		{s: `SET PASSWORD FOR`, err: `found EOF, expected identifier at line 1, char 18`},
@@ -3550,3 +3922,7 @@ func duration(v time.Duration) *time.Duration {
 func intptr(v int) *int {
 	return &v
 }
+
+func boolptr(v bool) *bool {
+	return &v
+}
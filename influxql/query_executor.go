@@ -115,6 +115,9 @@ type ExecutionOptions struct {
 	// Node to execute on.
 	NodeID uint64
 
+	// Address of the client that issued the query, if any.
+	RemoteAddr string
+
 	// Quiet suppresses non-essential output from the query executor.
 	Quiet bool
 
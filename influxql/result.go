@@ -9,6 +9,9 @@ import (
 )
 
 const (
+	// InfoLevel is the message level for an informational message.
+	InfoLevel = "info"
+
 	// WarningLevel is the message level for a warning.
 	WarningLevel = "warning"
 )
@@ -9,46 +9,66 @@ import (
 
 // MetaClientMock is a mockable implementation of meta.MetaClient.
 type MetaClientMock struct {
+	AddUserRoleFn                       func(username, role string) error
 	CloseFn                             func() error
 	CreateContinuousQueryFn             func(database, name, query string) error
 	CreateDatabaseFn                    func(name string) (*meta.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicyFn func(name string, spec *meta.RetentionPolicySpec) (*meta.DatabaseInfo, error)
 	CreateRetentionPolicyFn             func(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	CreateRoleFn                        func(name string) error
 	CreateShardGroupFn                  func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error)
 	CreateSubscriptionFn                func(database, rp, name, mode string, destinations []string) error
+	CreateTokenFn                       func(username, database string, privilege influxql.Privilege, expires time.Duration) (string, *meta.TokenInfo, error)
 	CreateUserFn                        func(name, password string, admin bool) (meta.User, error)
 
 	DatabaseFn  func(name string) *meta.DatabaseInfo
 	DatabasesFn func() []meta.DatabaseInfo
 
-	DataFn                func() meta.Data
-	DeleteShardGroupFn    func(database string, policy string, id uint64) error
-	DropContinuousQueryFn func(database, name string) error
-	DropDatabaseFn        func(name string) error
-	DropRetentionPolicyFn func(database, name string) error
-	DropSubscriptionFn    func(database, rp, name string) error
-	DropShardFn           func(id uint64) error
-	DropUserFn            func(name string) error
+	DataFn                 func() meta.Data
+	DeleteShardGroupFn     func(database string, policy string, id uint64) error
+	DropCardinalityLimitFn func(database, measurement, tagKey string) error
+	DropContinuousQueryFn  func(database, name string) error
+	DropDatabaseFn         func(name string) error
+	DropRetentionPolicyFn  func(database, name string) error
+	DropSubscriptionFn     func(database, rp, name string) error
+	DropRoleFn             func(name string) error
+	DropShardFn            func(id uint64) error
+	DropTokenFn            func(id string) error
+	DropUserFn             func(name string) error
 
 	OpenFn func() error
 
+	RemoveUserRoleFn  func(username, role string) error
 	RetentionPolicyFn func(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
+	RolesFn           func() []meta.RoleInfo
 
 	AuthenticateFn           func(username, password string) (ui meta.User, err error)
+	AuthenticateTokenFn      func(token string) (meta.User, error)
 	AdminUserExistsFn        func() bool
 	SetAdminPrivilegeFn      func(username string, admin bool) error
+	SetCardinalityLimitFn    func(database string, limit meta.CardinalityLimitInfo) error
 	SetDataFn                func(*meta.Data) error
 	SetPrivilegeFn           func(username, database string, p influxql.Privilege) error
+	SetRolePrivilegeFn       func(name, database string, p influxql.Privilege) error
+	SetSeriesPrivilegeFn     func(username string, grant meta.SeriesGrantInfo) error
 	ShardGroupsByTimeRangeFn func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
 	ShardOwnerFn             func(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
+	TokensFn                 func() []meta.TokenInfo
+	UpdateDatabaseFn         func(name string, du *meta.DatabaseUpdate) error
 	UpdateRetentionPolicyFn  func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
 	UpdateUserFn             func(name, password string) error
 	UserPrivilegeFn          func(username, database string) (*influxql.Privilege, error)
 	UserPrivilegesFn         func(username string) (map[string]influxql.Privilege, error)
+	UserRolesFn              func(username string) ([]string, error)
+	UserSeriesGrantsFn       func(username string) ([]meta.SeriesGrantInfo, error)
 	UserFn                   func(username string) (meta.User, error)
 	UsersFn                  func() []meta.UserInfo
 }
 
+func (c *MetaClientMock) AddUserRole(username, role string) error {
+	return c.AddUserRoleFn(username, role)
+}
+
 func (c *MetaClientMock) Close() error {
 	return c.CloseFn()
 }
@@ -69,6 +89,10 @@ func (c *MetaClientMock) CreateRetentionPolicy(database string, spec *meta.Reten
 	return c.CreateRetentionPolicyFn(database, spec, makeDefault)
 }
 
+func (c *MetaClientMock) CreateRole(name string) error {
+	return c.CreateRoleFn(name)
+}
+
 func (c *MetaClientMock) CreateShardGroup(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
 	return c.CreateShardGroupFn(database, policy, timestamp)
 }
@@ -77,6 +101,10 @@ func (c *MetaClientMock) CreateSubscription(database, rp, name, mode string, des
 	return c.CreateSubscriptionFn(database, rp, name, mode, destinations)
 }
 
+func (c *MetaClientMock) CreateToken(username, database string, privilege influxql.Privilege, expires time.Duration) (string, *meta.TokenInfo, error) {
+	return c.CreateTokenFn(username, database, privilege, expires)
+}
+
 func (c *MetaClientMock) CreateUser(name, password string, admin bool) (meta.User, error) {
 	return c.CreateUserFn(name, password, admin)
 }
@@ -93,6 +121,10 @@ func (c *MetaClientMock) DeleteShardGroup(database string, policy string, id uin
 	return c.DeleteShardGroupFn(database, policy, id)
 }
 
+func (c *MetaClientMock) DropCardinalityLimit(database, measurement, tagKey string) error {
+	return c.DropCardinalityLimitFn(database, measurement, tagKey)
+}
+
 func (c *MetaClientMock) DropContinuousQuery(database, name string) error {
 	return c.DropContinuousQueryFn(database, name)
 }
@@ -105,6 +137,10 @@ func (c *MetaClientMock) DropRetentionPolicy(database, name string) error {
 	return c.DropRetentionPolicyFn(database, name)
 }
 
+func (c *MetaClientMock) DropRole(name string) error {
+	return c.DropRoleFn(name)
+}
+
 func (c *MetaClientMock) DropShard(id uint64) error {
 	return c.DropShardFn(id)
 }
@@ -113,22 +149,46 @@ func (c *MetaClientMock) DropSubscription(database, rp, name string) error {
 	return c.DropSubscriptionFn(database, rp, name)
 }
 
+func (c *MetaClientMock) DropToken(id string) error {
+	return c.DropTokenFn(id)
+}
+
 func (c *MetaClientMock) DropUser(name string) error {
 	return c.DropUserFn(name)
 }
 
+func (c *MetaClientMock) RemoveUserRole(username, role string) error {
+	return c.RemoveUserRoleFn(username, role)
+}
+
 func (c *MetaClientMock) RetentionPolicy(database, name string) (rpi *meta.RetentionPolicyInfo, err error) {
 	return c.RetentionPolicyFn(database, name)
 }
 
+func (c *MetaClientMock) Roles() []meta.RoleInfo {
+	return c.RolesFn()
+}
+
 func (c *MetaClientMock) SetAdminPrivilege(username string, admin bool) error {
 	return c.SetAdminPrivilegeFn(username, admin)
 }
 
+func (c *MetaClientMock) SetCardinalityLimit(database string, limit meta.CardinalityLimitInfo) error {
+	return c.SetCardinalityLimitFn(database, limit)
+}
+
 func (c *MetaClientMock) SetPrivilege(username, database string, p influxql.Privilege) error {
 	return c.SetPrivilegeFn(username, database, p)
 }
 
+func (c *MetaClientMock) SetRolePrivilege(name, database string, p influxql.Privilege) error {
+	return c.SetRolePrivilegeFn(name, database, p)
+}
+
+func (c *MetaClientMock) SetSeriesPrivilege(username string, grant meta.SeriesGrantInfo) error {
+	return c.SetSeriesPrivilegeFn(username, grant)
+}
+
 func (c *MetaClientMock) ShardGroupsByTimeRange(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error) {
 	return c.ShardGroupsByTimeRangeFn(database, policy, min, max)
 }
@@ -137,6 +197,14 @@ func (c *MetaClientMock) ShardOwner(shardID uint64) (database, policy string, sg
 	return c.ShardOwnerFn(shardID)
 }
 
+func (c *MetaClientMock) Tokens() []meta.TokenInfo {
+	return c.TokensFn()
+}
+
+func (c *MetaClientMock) UpdateDatabase(name string, du *meta.DatabaseUpdate) error {
+	return c.UpdateDatabaseFn(name, du)
+}
+
 func (c *MetaClientMock) UpdateRetentionPolicy(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error {
 	return c.UpdateRetentionPolicyFn(database, name, rpu, makeDefault)
 }
@@ -153,9 +221,21 @@ func (c *MetaClientMock) UserPrivileges(username string) (map[string]influxql.Pr
 	return c.UserPrivilegesFn(username)
 }
 
+func (c *MetaClientMock) UserRoles(username string) ([]string, error) {
+	return c.UserRolesFn(username)
+}
+
+func (c *MetaClientMock) UserSeriesGrants(username string) ([]meta.SeriesGrantInfo, error) {
+	return c.UserSeriesGrantsFn(username)
+}
+
 func (c *MetaClientMock) Authenticate(username, password string) (meta.User, error) {
 	return c.AuthenticateFn(username, password)
 }
+
+func (c *MetaClientMock) AuthenticateToken(token string) (meta.User, error) {
+	return c.AuthenticateTokenFn(token)
+}
 func (c *MetaClientMock) AdminUserExists() bool { return c.AdminUserExistsFn() }
 
 func (c *MetaClientMock) User(username string) (meta.User, error) { return c.UserFn(username) }
@@ -283,16 +283,62 @@ func ParseName(buf []byte) ([]byte, error) {
 // NOTE: to minimize heap allocations, the returned Points will refer to subslices of buf.
 // This can have the unintended effect preventing buf from being garbage collected.
 func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision string) ([]Point, error) {
+	points, _, err := parsePoints(buf, defaultTime, precision, false)
+	return points, err
+}
+
+// ParsePointsWithLineNumbers is similar to ParsePointsWithPrecision, but also
+// returns the line number of each point in buf. If any points fail to parse,
+// the error is a ParseErrors with the line number of each failed point.
+func ParsePointsWithLineNumbers(buf []byte, defaultTime time.Time, precision string) ([]Point, []int, error) {
+	return parsePoints(buf, defaultTime, precision, true)
+}
+
+// LineError is an error parsing a line of line protocol.
+type LineError struct {
+	Line int // The line number, starting at 1. Zero if unknown.
+	Text string
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("unable to parse '%s': %v", e.Text, e.Err)
+}
+
+// ParseErrors is the errors of all lines that failed to parse.
+type ParseErrors []*LineError
+
+func (a ParseErrors) Error() string {
+	failed := make([]string, len(a))
+	for i, e := range a {
+		failed[i] = e.Error()
+	}
+	return strings.Join(failed, "\n")
+}
+
+func parsePoints(buf []byte, defaultTime time.Time, precision string, withLines bool) ([]Point, []int, error) {
 	points := make([]Point, 0, bytes.Count(buf, []byte{'\n'})+1)
 	var (
 		pos    int
 		block  []byte
-		failed []string
+		failed ParseErrors
+		lines  []int
+		line   int
+		next   = 1
 	)
+	if withLines {
+		lines = make([]int, 0, cap(points))
+	}
 	for pos < len(buf) {
 		pos, block = scanLine(buf, pos)
 		pos++
 
+		// A line may span several lines of buf if it has quoted newlines.
+		if withLines {
+			line = next
+			next += bytes.Count(block, []byte{'\n'}) + 1
+		}
+
 		if len(block) == 0 {
 			continue
 		}
@@ -316,16 +362,19 @@ func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision strin
 
 		pt, err := parsePoint(block[start:], defaultTime, precision)
 		if err != nil {
-			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block[start:]), err))
+			failed = append(failed, &LineError{Line: line, Text: string(block[start:]), Err: err})
 		} else {
 			points = append(points, pt)
+			if withLines {
+				lines = append(lines, line)
+			}
 		}
 
 	}
 	if len(failed) > 0 {
-		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
+		return points, lines, failed
 	}
-	return points, nil
+	return points, lines, nil
 
 }
 
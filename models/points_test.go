@@ -1780,6 +1780,42 @@ cpu,host=serverA,region=us-east value=1.0 946730096789012345`,
 	}
 }
 
+func TestParsePointsWithLineNumbers(t *testing.T) {
+	batch := `# comment
+cpu value=1 1
+
+cpu value= 2
+cpu value="a
+b" 3
+cpu,host=a value=4 4
+mem value=x 5`
+
+	points, lines, err := models.ParsePointsWithLineNumbers([]byte(batch), time.Now().UTC(), "n")
+	if len(points) != 3 {
+		t.Fatalf("unexpected number of points: %d", len(points))
+	} else if exp := []int{2, 5, 7}; !reflect.DeepEqual(lines, exp) {
+		t.Fatalf("unexpected line numbers: exp=%v got=%v", exp, lines)
+	}
+
+	perrs, ok := err.(models.ParseErrors)
+	if !ok {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(perrs) != 2 {
+		t.Fatalf("unexpected number of parse errors: %d", len(perrs))
+	} else if perrs[0].Line != 4 || perrs[0].Text != "cpu value= 2" {
+		t.Fatalf("unexpected parse error: line=%d text=%q", perrs[0].Line, perrs[0].Text)
+	} else if perrs[1].Line != 8 || perrs[1].Text != "mem value=x 5" {
+		t.Fatalf("unexpected parse error: line=%d text=%q", perrs[1].Line, perrs[1].Text)
+	}
+
+	// The error message is the same as the one of ParsePointsWithPrecision.
+	if _, perr := models.ParsePointsWithPrecision([]byte(batch), time.Now().UTC(), "n"); perr == nil || perr.Error() != err.Error() {
+		t.Fatalf("unexpected error: exp=%v got=%v", err, perr)
+	} else if !strings.HasPrefix(err.Error(), "unable to parse 'cpu value= 2': ") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestNewPointEscaped(t *testing.T) {
 	// commas
 	pt := models.MustNewPoint("cpu,main", models.NewTags(map[string]string{"tag,bar": "value"}), models.Fields{"name,bar": 1.0}, time.Unix(0, 0))
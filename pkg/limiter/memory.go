@@ -0,0 +1,89 @@
+package limiter
+
+import "sync"
+
+// Memory is a byte budget shared by several consumers.  Consumers acquire
+// bytes before using them and release them once freed.  Callers that could not
+// acquire enough bytes can wait on Freed to be notified when bytes are released.
+type Memory struct {
+	mu    sync.Mutex
+	limit uint64
+	used  uint64
+	freed chan struct{}
+}
+
+// NewMemory returns a Memory budget of limit bytes.  A limit of 0 is unlimited.
+func NewMemory(limit uint64) *Memory {
+	return &Memory{
+		limit: limit,
+		freed: make(chan struct{}),
+	}
+}
+
+// TryAcquire acquires n bytes if they fit in the budget.  It returns false,
+// without acquiring anything, if the budget would be exceeded.
+func (m *Memory) TryAcquire(n uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.limit > 0 && m.used+n > m.limit {
+		return false
+	}
+	m.used += n
+	return true
+}
+
+// Acquire acquires n bytes even if the budget is exceeded.
+func (m *Memory) Acquire(n uint64) {
+	m.mu.Lock()
+	m.used += n
+	m.mu.Unlock()
+}
+
+// Release returns n bytes to the budget and wakes up any waiters.
+func (m *Memory) Release(n uint64) {
+	if n == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	if n > m.used {
+		n = m.used
+	}
+	m.used -= n
+	close(m.freed)
+	m.freed = make(chan struct{})
+	m.mu.Unlock()
+}
+
+// Freed returns a channel that is closed the next time bytes are released.
+func (m *Memory) Freed() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.freed
+}
+
+// Fits returns true if n more bytes can be acquired without exceeding the limit.
+func (m *Memory) Fits(n uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.limit == 0 || m.used+n <= m.limit
+}
+
+// Exceeded returns true if the bytes in use have reached the limit.
+func (m *Memory) Exceeded() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.limit > 0 && m.used >= m.limit
+}
+
+// Used returns the number of bytes acquired.
+func (m *Memory) Used() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.used
+}
+
+// Limit returns the size of the budget in bytes.
+func (m *Memory) Limit() uint64 {
+	return m.limit
+}
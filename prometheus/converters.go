@@ -0,0 +1,210 @@
+// Package prometheus converts the requests of the Prometheus remote storage
+// protocol to points and queries.
+package prometheus // import "github.com/influxdata/influxdb/prometheus"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/remote"
+)
+
+//go:generate protoc --gogo_out=. remote/remote.proto
+
+const (
+	// MetricNameLabel is the label that holds the name of a metric. It is
+	// stored as the measurement name.
+	MetricNameLabel = "__name__"
+
+	// FieldName is the field that holds the value of samples.
+	FieldName = "value"
+)
+
+// ErrNaNDropped is returned when samples are dropped from a write request
+// because their value is NaN or infinite, which can't be stored.
+var ErrNaNDropped = errors.New("dropped NaN or infinite values from Prometheus write request")
+
+// WriteRequestToPoints converts the samples of a write request to points.
+// Samples whose value can't be stored are skipped and ErrNaNDropped is
+// returned with the other points.
+func WriteRequestToPoints(req *remote.WriteRequest) ([]models.Point, error) {
+	var n int
+	for _, ts := range req.Timeseries {
+		n += len(ts.Samples)
+	}
+	points := make([]models.Point, 0, n)
+
+	var dropped bool
+	for _, ts := range req.Timeseries {
+		var name string
+		tags := make(map[string]string, len(ts.Labels))
+		for _, l := range ts.Labels {
+			if l.Name == MetricNameLabel {
+				name = l.Value
+				continue
+			}
+			tags[l.Name] = l.Value
+		}
+		if name == "" {
+			return nil, fmt.Errorf("time series is missing the %s label", MetricNameLabel)
+		}
+
+		for _, s := range ts.Samples {
+			if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+				dropped = true
+				continue
+			}
+
+			pt, err := models.NewPoint(name, models.NewTags(tags), models.Fields{FieldName: s.Value}, time.Unix(0, s.Timestamp*int64(time.Millisecond)))
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, pt)
+		}
+	}
+
+	if dropped {
+		return points, ErrNaNDropped
+	}
+	return points, nil
+}
+
+// ReadRequestToInfluxQLQuery converts a read request to a query selecting the
+// samples of the matching series in a database and retention policy. Only a
+// single query is supported per request.
+func ReadRequestToInfluxQLQuery(req *remote.ReadRequest, db, rp string) (*influxql.Query, error) {
+	if len(req.Queries) != 1 {
+		return nil, errors.New("Prometheus read request must contain exactly one query")
+	}
+	q := req.Queries[0]
+
+	m := &influxql.Measurement{Database: db, RetentionPolicy: rp}
+	cond := influxql.Expr(&influxql.BinaryExpr{
+		Op:  influxql.AND,
+		LHS: &influxql.BinaryExpr{Op: influxql.GTE, LHS: &influxql.VarRef{Val: "time"}, RHS: &influxql.TimeLiteral{Val: time.Unix(0, q.StartTimestampMs*int64(time.Millisecond)).UTC()}},
+		RHS: &influxql.BinaryExpr{Op: influxql.LTE, LHS: &influxql.VarRef{Val: "time"}, RHS: &influxql.TimeLiteral{Val: time.Unix(0, q.EndTimestampMs*int64(time.Millisecond)).UTC()}},
+	})
+
+	for _, matcher := range q.Matchers {
+		if matcher.Name == MetricNameLabel {
+			switch matcher.Type {
+			case remote.MatchType_EQUAL:
+				m.Name = matcher.Value
+			case remote.MatchType_REGEX_MATCH:
+				re, err := anchoredRegex(matcher.Value)
+				if err != nil {
+					return nil, err
+				}
+				m.Regex = &influxql.RegexLiteral{Val: re}
+			default:
+				return nil, fmt.Errorf("unsupported match type for the %s label: %s", MetricNameLabel, matcher.Type)
+			}
+			continue
+		}
+
+		expr, err := matcherToExpr(matcher)
+		if err != nil {
+			return nil, err
+		}
+		cond = &influxql.BinaryExpr{Op: influxql.AND, LHS: cond, RHS: expr}
+	}
+
+	// Select from all measurements if the metric name isn't matched.
+	if m.Name == "" && m.Regex == nil {
+		m.Regex = &influxql.RegexLiteral{Val: regexp.MustCompile(".+")}
+	}
+
+	return &influxql.Query{
+		Statements: influxql.Statements{
+			&influxql.SelectStatement{
+				Fields:     influxql.Fields{{Expr: &influxql.VarRef{Val: FieldName}}},
+				Sources:    influxql.Sources{m},
+				Condition:  cond,
+				Dimensions: influxql.Dimensions{{Expr: &influxql.Wildcard{}}},
+			},
+		},
+	}, nil
+}
+
+// matcherToExpr converts a label matcher to a tag condition.
+func matcherToExpr(matcher *remote.LabelMatcher) (influxql.Expr, error) {
+	expr := &influxql.BinaryExpr{LHS: &influxql.VarRef{Val: matcher.Name}}
+	switch matcher.Type {
+	case remote.MatchType_EQUAL, remote.MatchType_NOT_EQUAL:
+		expr.Op = influxql.EQ
+		if matcher.Type == remote.MatchType_NOT_EQUAL {
+			expr.Op = influxql.NEQ
+		}
+		expr.RHS = &influxql.StringLiteral{Val: matcher.Value}
+	case remote.MatchType_REGEX_MATCH, remote.MatchType_REGEX_NO_MATCH:
+		expr.Op = influxql.EQREGEX
+		if matcher.Type == remote.MatchType_REGEX_NO_MATCH {
+			expr.Op = influxql.NEQREGEX
+		}
+		re, err := anchoredRegex(matcher.Value)
+		if err != nil {
+			return nil, err
+		}
+		expr.RHS = &influxql.RegexLiteral{Val: re}
+	default:
+		return nil, fmt.Errorf("unknown match type: %d", matcher.Type)
+	}
+	return expr, nil
+}
+
+// anchoredRegex compiles a regular expression of a label matcher. Prometheus
+// matches regular expressions against the whole label value.
+func anchoredRegex(s string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + s + ")$")
+}
+
+// RowToTimeSeries converts a row of a query result to a time series. The
+// columns of the row must be the time and the value of the samples.
+func RowToTimeSeries(row *models.Row) (*remote.TimeSeries, error) {
+	ts := &remote.TimeSeries{
+		Labels:  make([]*remote.LabelPair, 0, len(row.Tags)+1),
+		Samples: make([]*remote.Sample, 0, len(row.Values)),
+	}
+	ts.Labels = append(ts.Labels, &remote.LabelPair{Name: MetricNameLabel, Value: row.Name})
+	keys := make([]string, 0, len(row.Tags))
+	for k := range row.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// Empty tags are series that don't have the tag.
+		if v := row.Tags[k]; v != "" {
+			ts.Labels = append(ts.Labels, &remote.LabelPair{Name: k, Value: v})
+		}
+	}
+
+	for _, values := range row.Values {
+		if len(values) != 2 {
+			return nil, fmt.Errorf("unexpected number of columns: %d", len(values))
+		}
+		t, ok := values[0].(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("unexpected time value: %v", values[0])
+		}
+
+		s := &remote.Sample{Timestamp: t.UnixNano() / int64(time.Millisecond)}
+		switch v := values[1].(type) {
+		case float64:
+			s.Value = v
+		case int64:
+			s.Value = float64(v)
+		case nil:
+			continue
+		default:
+			return nil, fmt.Errorf("unsupported value type for field %s: %T", FieldName, v)
+		}
+		ts.Samples = append(ts.Samples, s)
+	}
+	return ts, nil
+}
@@ -0,0 +1,144 @@
+package prometheus_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus"
+	"github.com/influxdata/influxdb/prometheus/remote"
+)
+
+func TestWriteRequestToPoints(t *testing.T) {
+	req := &remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			{
+				Labels: []*remote.LabelPair{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "code", Value: "200"},
+					{Name: "job", Value: "api"},
+				},
+				Samples: []*remote.Sample{
+					{Value: 10, Timestamp: 1000},
+					{Value: math.Inf(1), Timestamp: 2000},
+					{Value: 12, Timestamp: 3000},
+				},
+			},
+		},
+	}
+
+	points, err := prometheus.WriteRequestToPoints(req)
+	if err != prometheus.ErrNaNDropped {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, p := range points {
+		got = append(got, p.String())
+	}
+	exp := []string{
+		`http_requests_total,code=200,job=api value=10 1000000000`,
+		`http_requests_total,code=200,job=api value=12 3000000000`,
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points:\n\nexp=%v\n\ngot=%v", exp, got)
+	}
+
+	// Time series must be named.
+	req.Timeseries[0].Labels = req.Timeseries[0].Labels[1:]
+	if _, err := prometheus.WriteRequestToPoints(req); err == nil || err.Error() != "time series is missing the __name__ label" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReadRequestToInfluxQLQuery(t *testing.T) {
+	for _, tt := range []struct {
+		matchers []*remote.LabelMatcher
+		s        string
+		err      string
+	}{
+		{
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchType_EQUAL, Name: "__name__", Value: "cpu"},
+				{Type: remote.MatchType_NOT_EQUAL, Name: "host", Value: "a"},
+			},
+			s: `SELECT value FROM db0.rp0.cpu WHERE time >= '1970-01-01T00:00:01Z' AND time <= '1970-01-01T00:00:02Z' AND host != 'a' GROUP BY *`,
+		},
+		{
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchType_REGEX_MATCH, Name: "__name__", Value: "cpu.*"},
+				{Type: remote.MatchType_REGEX_NO_MATCH, Name: "host", Value: "a|b"},
+			},
+			s: `SELECT value FROM db0.rp0./^(?:cpu.*)$/ WHERE time >= '1970-01-01T00:00:01Z' AND time <= '1970-01-01T00:00:02Z' AND host !~ /^(?:a|b)$/ GROUP BY *`,
+		},
+		{
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchType_EQUAL, Name: "job", Value: "api"},
+			},
+			s: `SELECT value FROM db0.rp0./.+/ WHERE time >= '1970-01-01T00:00:01Z' AND time <= '1970-01-01T00:00:02Z' AND job = 'api' GROUP BY *`,
+		},
+		{
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchType_NOT_EQUAL, Name: "__name__", Value: "cpu"},
+			},
+			err: `unsupported match type for the __name__ label: NOT_EQUAL`,
+		},
+		{
+			matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchType_REGEX_MATCH, Name: "host", Value: "("},
+			},
+			err: "error parsing regexp: missing closing ): `^(?:()$`",
+		},
+	} {
+		req := &remote.ReadRequest{
+			Queries: []*remote.Query{{StartTimestampMs: 1000, EndTimestampMs: 2000, Matchers: tt.matchers}},
+		}
+		q, err := prometheus.ReadRequestToInfluxQLQuery(req, "db0", "rp0")
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("unexpected error: exp=%s got=%v", tt.err, err)
+			}
+		} else if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		} else if s := q.String(); s != tt.s {
+			t.Errorf("unexpected query:\n\nexp=%s\n\ngot=%s", tt.s, s)
+		}
+	}
+
+	if _, err := prometheus.ReadRequestToInfluxQLQuery(&remote.ReadRequest{}, "db0", ""); err == nil {
+		t.Fatal("expected error for read request without queries")
+	}
+}
+
+func TestRowToTimeSeries(t *testing.T) {
+	ts, err := prometheus.RowToTimeSeries(&models.Row{
+		Name:    "cpu",
+		Tags:    map[string]string{"region": "west", "host": "a", "dc": ""},
+		Columns: []string{"time", "value"},
+		Values: [][]interface{}{
+			{time.Unix(1, 0), 1.5},
+			{time.Unix(2, 0), int64(2)},
+			{time.Unix(3, 0), nil},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := &remote.TimeSeries{
+		Labels: []*remote.LabelPair{
+			{Name: "__name__", Value: "cpu"},
+			{Name: "host", Value: "a"},
+			{Name: "region", Value: "west"},
+		},
+		Samples: []*remote.Sample{
+			{Value: 1.5, Timestamp: 1000},
+			{Value: 2, Timestamp: 2000},
+		},
+	}
+	if !reflect.DeepEqual(ts, exp) {
+		t.Fatalf("unexpected time series:\n\nexp=%s\n\ngot=%s", exp, ts)
+	}
+}
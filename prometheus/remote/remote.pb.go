@@ -0,0 +1,307 @@
+// Code generated by protoc-gen-gogo.
+// source: remote/remote.proto
+// DO NOT EDIT!
+
+/*
+Package remote is a generated protocol buffer package.
+
+It is generated from these files:
+	remote/remote.proto
+
+It has these top-level messages:
+	Sample
+	LabelPair
+	TimeSeries
+	WriteRequest
+	ReadRequest
+	ReadResponse
+	Query
+	LabelMatcher
+	QueryResult
+*/
+package remote
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type MatchType int32
+
+const (
+	MatchType_EQUAL          MatchType = 0
+	MatchType_NOT_EQUAL      MatchType = 1
+	MatchType_REGEX_MATCH    MatchType = 2
+	MatchType_REGEX_NO_MATCH MatchType = 3
+)
+
+var MatchType_name = map[int32]string{
+	0: "EQUAL",
+	1: "NOT_EQUAL",
+	2: "REGEX_MATCH",
+	3: "REGEX_NO_MATCH",
+}
+var MatchType_value = map[string]int32{
+	"EQUAL":          0,
+	"NOT_EQUAL":      1,
+	"REGEX_MATCH":    2,
+	"REGEX_NO_MATCH": 3,
+}
+
+func (x MatchType) String() string {
+	return proto.EnumName(MatchType_name, int32(x))
+}
+func (MatchType) EnumDescriptor() ([]byte, []int) { return fileDescriptorRemote, []int{0} }
+
+type Sample struct {
+	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *Sample) Reset()                    { *m = Sample{} }
+func (m *Sample) String() string            { return proto.CompactTextString(m) }
+func (*Sample) ProtoMessage()               {}
+func (*Sample) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{0} }
+
+func (m *Sample) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Sample) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type LabelPair struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *LabelPair) Reset()                    { *m = LabelPair{} }
+func (m *LabelPair) String() string            { return proto.CompactTextString(m) }
+func (*LabelPair) ProtoMessage()               {}
+func (*LabelPair) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{1} }
+
+func (m *LabelPair) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LabelPair) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type TimeSeries struct {
+	Labels  []*LabelPair `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+	Samples []*Sample    `protobuf:"bytes,2,rep,name=samples" json:"samples,omitempty"`
+}
+
+func (m *TimeSeries) Reset()                    { *m = TimeSeries{} }
+func (m *TimeSeries) String() string            { return proto.CompactTextString(m) }
+func (*TimeSeries) ProtoMessage()               {}
+func (*TimeSeries) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{2} }
+
+func (m *TimeSeries) GetLabels() []*LabelPair {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *TimeSeries) GetSamples() []*Sample {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
+type WriteRequest struct {
+	Timeseries []*TimeSeries `protobuf:"bytes,1,rep,name=timeseries" json:"timeseries,omitempty"`
+}
+
+func (m *WriteRequest) Reset()                    { *m = WriteRequest{} }
+func (m *WriteRequest) String() string            { return proto.CompactTextString(m) }
+func (*WriteRequest) ProtoMessage()               {}
+func (*WriteRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{3} }
+
+func (m *WriteRequest) GetTimeseries() []*TimeSeries {
+	if m != nil {
+		return m.Timeseries
+	}
+	return nil
+}
+
+type ReadRequest struct {
+	Queries []*Query `protobuf:"bytes,1,rep,name=queries" json:"queries,omitempty"`
+}
+
+func (m *ReadRequest) Reset()                    { *m = ReadRequest{} }
+func (m *ReadRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()               {}
+func (*ReadRequest) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{4} }
+
+func (m *ReadRequest) GetQueries() []*Query {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
+}
+
+type ReadResponse struct {
+	Results []*QueryResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *ReadResponse) Reset()                    { *m = ReadResponse{} }
+func (m *ReadResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReadResponse) ProtoMessage()               {}
+func (*ReadResponse) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{5} }
+
+func (m *ReadResponse) GetResults() []*QueryResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type Query struct {
+	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers" json:"matchers,omitempty"`
+}
+
+func (m *Query) Reset()                    { *m = Query{} }
+func (m *Query) String() string            { return proto.CompactTextString(m) }
+func (*Query) ProtoMessage()               {}
+func (*Query) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{6} }
+
+func (m *Query) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
+}
+
+func (m *Query) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
+}
+
+func (m *Query) GetMatchers() []*LabelMatcher {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+type LabelMatcher struct {
+	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=remote.MatchType" json:"type,omitempty"`
+	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *LabelMatcher) Reset()                    { *m = LabelMatcher{} }
+func (m *LabelMatcher) String() string            { return proto.CompactTextString(m) }
+func (*LabelMatcher) ProtoMessage()               {}
+func (*LabelMatcher) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{7} }
+
+func (m *LabelMatcher) GetType() MatchType {
+	if m != nil {
+		return m.Type
+	}
+	return MatchType_EQUAL
+}
+
+func (m *LabelMatcher) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LabelMatcher) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type QueryResult struct {
+	Timeseries []*TimeSeries `protobuf:"bytes,1,rep,name=timeseries" json:"timeseries,omitempty"`
+}
+
+func (m *QueryResult) Reset()                    { *m = QueryResult{} }
+func (m *QueryResult) String() string            { return proto.CompactTextString(m) }
+func (*QueryResult) ProtoMessage()               {}
+func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptorRemote, []int{8} }
+
+func (m *QueryResult) GetTimeseries() []*TimeSeries {
+	if m != nil {
+		return m.Timeseries
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Sample)(nil), "remote.Sample")
+	proto.RegisterType((*LabelPair)(nil), "remote.LabelPair")
+	proto.RegisterType((*TimeSeries)(nil), "remote.TimeSeries")
+	proto.RegisterType((*WriteRequest)(nil), "remote.WriteRequest")
+	proto.RegisterType((*ReadRequest)(nil), "remote.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "remote.ReadResponse")
+	proto.RegisterType((*Query)(nil), "remote.Query")
+	proto.RegisterType((*LabelMatcher)(nil), "remote.LabelMatcher")
+	proto.RegisterType((*QueryResult)(nil), "remote.QueryResult")
+	proto.RegisterEnum("remote.MatchType", MatchType_name, MatchType_value)
+}
+
+func init() { proto.RegisterFile("remote/remote.proto", fileDescriptorRemote) }
+
+var fileDescriptorRemote = []byte{
+	// 381 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0x8b, 0xdb, 0x30,
+	0x14, 0xac, 0xed, 0x8d, 0x53, 0x3f, 0x3b, 0x69, 0xf6, 0x6d, 0x0f, 0xa6, 0x87, 0x6e, 0x2a, 0xca,
+	0x62, 0x16, 0xba, 0x85, 0x7e, 0xdd, 0x97, 0x12, 0x5a, 0x4a, 0xf6, 0x23, 0x8e, 0x4b, 0x7b, 0x33,
+	0x4a, 0xf3, 0xa0, 0x06, 0x2b, 0x76, 0x24, 0xb9, 0x90, 0x7f, 0x5f, 0x22, 0xc5, 0x71, 0x7c, 0xdb,
+	0x93, 0x79, 0x7a, 0x33, 0x9a, 0xf1, 0x8c, 0xe0, 0x42, 0x92, 0xa8, 0x34, 0xbd, 0xb7, 0x9f, 0x9b,
+	0x5a, 0x56, 0xba, 0x42, 0xdf, 0x4e, 0xec, 0x1a, 0xfc, 0x25, 0x17, 0x75, 0x49, 0x38, 0x82, 0xc1,
+	0x3f, 0x5e, 0x36, 0x14, 0x3b, 0x53, 0x27, 0x71, 0xf0, 0x1c, 0x02, 0x5d, 0x08, 0x52, 0x9a, 0x8b,
+	0x3a, 0x76, 0xa7, 0x4e, 0xe2, 0xb1, 0x04, 0x82, 0x39, 0x5f, 0x51, 0xf9, 0xc8, 0x0b, 0x89, 0x11,
+	0x9c, 0x6d, 0xb8, 0xb0, 0xe8, 0xa0, 0x23, 0xef, 0x91, 0x01, 0x7b, 0x04, 0xc8, 0x0a, 0x41, 0x4b,
+	0x92, 0x05, 0x29, 0x7c, 0x03, 0x7e, 0xb9, 0xe7, 0xa9, 0xd8, 0x99, 0x7a, 0x49, 0xf8, 0xe1, 0xfc,
+	0xe6, 0x60, 0xa5, 0xbb, 0xed, 0x12, 0x86, 0xca, 0xd8, 0x50, 0xb1, 0x6b, 0x30, 0xe3, 0x16, 0x63,
+	0xdd, 0xb1, 0x2f, 0x10, 0xfd, 0x92, 0x85, 0xa6, 0x94, 0xb6, 0x0d, 0x29, 0x8d, 0x57, 0x00, 0xc6,
+	0x9e, 0x51, 0x38, 0xdc, 0x8b, 0x2d, 0xa7, 0xd3, 0x66, 0xef, 0x20, 0x4c, 0x89, 0xaf, 0x5b, 0xda,
+	0x6b, 0x18, 0x6e, 0x9b, 0x53, 0xce, 0xa8, 0xe5, 0x2c, 0x1a, 0x92, 0x3b, 0xf6, 0x09, 0x22, 0x0b,
+	0x57, 0x75, 0xb5, 0x51, 0x84, 0x6f, 0x61, 0x28, 0x49, 0x35, 0xa5, 0x6e, 0xf1, 0x17, 0x3d, 0x7c,
+	0x6a, 0x76, 0x8c, 0x60, 0x60, 0x46, 0x7c, 0x05, 0xa8, 0x34, 0x97, 0x3a, 0x3f, 0x46, 0x97, 0x0b,
+	0x65, 0x22, 0xf2, 0x30, 0x86, 0x09, 0x6d, 0xd6, 0xfd, 0x8d, 0xc9, 0x15, 0xaf, 0xe0, 0xb9, 0xe0,
+	0xfa, 0xcf, 0x5f, 0x92, 0x2a, 0xf6, 0x8c, 0xca, 0xcb, 0x5e, 0x42, 0x77, 0x76, 0xc9, 0xe6, 0x10,
+	0x9d, 0xce, 0x78, 0x09, 0x67, 0x7a, 0x57, 0xdb, 0x0a, 0xc6, 0x5d, 0xaa, 0x66, 0x9d, 0xed, 0x6a,
+	0x3a, 0x76, 0xe4, 0xf6, 0x3b, 0xf2, 0x4c, 0x47, 0x9f, 0x21, 0x3c, 0xf9, 0x87, 0xa7, 0x06, 0x7a,
+	0xfd, 0x03, 0x82, 0x4e, 0x20, 0x80, 0xc1, 0x6c, 0xf1, 0xf3, 0x76, 0x3e, 0x79, 0x86, 0x23, 0x08,
+	0xee, 0x1f, 0xb2, 0xdc, 0x8e, 0x0e, 0xbe, 0x80, 0x30, 0x9d, 0x7d, 0x9b, 0xfd, 0xce, 0xef, 0x6e,
+	0xb3, 0xaf, 0xdf, 0x27, 0x2e, 0x22, 0x8c, 0xed, 0xc1, 0xfd, 0xc3, 0xe1, 0xcc, 0x5b, 0xf9, 0xe6,
+	0x2d, 0x7e, 0xfc, 0x3f, 0x00, 0xb9, 0x07, 0xea, 0x27, 0xa2, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"errors"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	// DefaultPath is the default file the audit log is written to.
+	DefaultPath = "/var/log/influxdb/audit.log"
+
+	// DefaultMaxSize is the size at which the audit log file is rotated.
+	DefaultMaxSize = 100 * 1024 * 1024
+
+	// DefaultMaxBackups is the number of rotated audit log files that are kept.
+	DefaultMaxBackups = 10
+
+	// DefaultBufferSize is the number of events buffered before new events
+	// are dropped.
+	DefaultBufferSize = 10000
+
+	// DefaultStoreDatabase is the name of the database audit events are written to.
+	DefaultStoreDatabase = "_audit"
+)
+
+// Config represents the configuration for the audit log.
+type Config struct {
+	Enabled    bool      `toml:"enabled"`
+	Path       string    `toml:"path"`
+	MaxSize    toml.Size `toml:"max-size"`
+	MaxBackups int       `toml:"max-backups"`
+	BufferSize int       `toml:"buffer-size"`
+
+	StoreEnabled  bool   `toml:"store-enabled"`
+	StoreDatabase string `toml:"store-database"`
+}
+
+// NewConfig returns an instance of Config with defaults.
+func NewConfig() Config {
+	return Config{
+		Enabled:       false,
+		Path:          DefaultPath,
+		MaxSize:       DefaultMaxSize,
+		MaxBackups:    DefaultMaxBackups,
+		BufferSize:    DefaultBufferSize,
+		StoreEnabled:  false,
+		StoreDatabase: DefaultStoreDatabase,
+	}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Path == "" && !c.StoreEnabled {
+		return errors.New("audit path must not be empty unless store-enabled is set")
+	}
+	if c.MaxSize < 0 {
+		return errors.New("audit max-size must not be negative")
+	}
+	if c.MaxBackups < 0 {
+		return errors.New("audit max-backups must not be negative")
+	}
+	if c.BufferSize <= 0 {
+		return errors.New("audit buffer-size must be positive")
+	}
+	if c.StoreEnabled && c.StoreDatabase == "" {
+		return errors.New("audit store database name must not be empty")
+	}
+	return nil
+}
+
+// Diagnostics returns a diagnostics representation of a subset of the Config.
+func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
+	if !c.Enabled {
+		return diagnostics.RowFromMap(map[string]interface{}{
+			"enabled": false,
+		}), nil
+	}
+
+	return diagnostics.RowFromMap(map[string]interface{}{
+		"enabled":        true,
+		"path":           c.Path,
+		"max-size":       c.MaxSize,
+		"max-backups":    c.MaxBackups,
+		"buffer-size":    c.BufferSize,
+		"store-enabled":  c.StoreEnabled,
+		"store-database": c.StoreDatabase,
+	}), nil
+}
@@ -0,0 +1,79 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/audit"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c audit.Config
+	if _, err := toml.Decode(`
+enabled = true
+path = "/tmp/audit.log"
+max-size = "10m"
+max-backups = 3
+buffer-size = 100
+store-enabled = true
+store-database = "audit"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if !c.Enabled {
+		t.Fatalf("unexpected enabled state: %v", c.Enabled)
+	} else if c.Path != "/tmp/audit.log" {
+		t.Fatalf("unexpected path: %s", c.Path)
+	} else if c.MaxSize != 10*1024*1024 {
+		t.Fatalf("unexpected max size: %d", c.MaxSize)
+	} else if c.MaxBackups != 3 {
+		t.Fatalf("unexpected max backups: %d", c.MaxBackups)
+	} else if c.BufferSize != 100 {
+		t.Fatalf("unexpected buffer size: %d", c.BufferSize)
+	} else if !c.StoreEnabled {
+		t.Fatalf("unexpected store enabled state: %v", c.StoreEnabled)
+	} else if c.StoreDatabase != "audit" {
+		t.Fatalf("unexpected store database: %s", c.StoreDatabase)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := audit.NewConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from NewConfig: %s", err)
+	}
+
+	c = audit.NewConfig()
+	c.Enabled = true
+	c.Path = ""
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for empty path, got nil")
+	}
+
+	c.StoreEnabled = true
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected error for empty path with store enabled: %s", err)
+	}
+
+	c.StoreDatabase = ""
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for empty store database, got nil")
+	}
+
+	c = audit.NewConfig()
+	c.Enabled = true
+	c.MaxBackups = -1
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for negative max-backups, got nil")
+	}
+
+	c = audit.NewConfig()
+	c.Enabled = true
+	c.BufferSize = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for zero buffer-size, got nil")
+	}
+}
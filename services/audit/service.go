@@ -0,0 +1,461 @@
+// Package audit provides a log of administrative and data-modifying statements
+// and of failed authentication attempts.
+package audit // import "github.com/influxdata/influxdb/services/audit"
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/uber-go/zap"
+)
+
+// Event types.
+const (
+	// StatementEvent is the type of events recording the execution of a statement.
+	StatementEvent = "statement"
+
+	// AuthenticationEvent is the type of events recording a failed authentication.
+	AuthenticationEvent = "authentication"
+)
+
+// Event outcomes.
+const (
+	Success = "success"
+	Failure = "failure"
+)
+
+// Measurement is the name of the measurement events are stored in.
+const Measurement = "audit"
+
+// backupTimeFormat is the format of the suffix of rotated log files.
+// It sorts in chronological order.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// Event represents a single entry in the audit log.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	User      string    `json:"user,omitempty"`
+	Addr      string    `json:"addr,omitempty"`
+	Database  string    `json:"database,omitempty"`
+	Statement string    `json:"statement,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// point returns the event as a point to be stored in a database.
+func (e *Event) point() (models.Point, error) {
+	tags := map[string]string{"type": e.Type, "outcome": e.Outcome}
+	if e.User != "" {
+		tags["user"] = e.User
+	}
+
+	fields := make(map[string]interface{})
+	for k, v := range map[string]string{
+		"addr":      e.Addr,
+		"database":  e.Database,
+		"statement": e.Statement,
+		"error":     e.Error,
+	} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	return models.NewPoint(Measurement, models.NewTags(tags), fields, e.Time)
+}
+
+// Audited returns true if stmt is an administrative or data-modifying
+// statement that is recorded in the audit log.
+func Audited(stmt influxql.Statement) bool {
+	switch stmt.(type) {
+	case *influxql.AlterDatabaseStatement,
+		*influxql.AlterFieldStatement,
+		*influxql.AlterMeasurementStatement,
+		*influxql.AlterRetentionPolicyStatement,
+		*influxql.CreateContinuousQueryStatement,
+		*influxql.CreateDatabaseStatement,
+		*influxql.CreateRetentionPolicyStatement,
+		*influxql.CreateRoleStatement,
+		*influxql.CreateSubscriptionStatement,
+		*influxql.CreateTokenStatement,
+		*influxql.CreateUserStatement,
+		*influxql.DeleteSeriesStatement,
+		*influxql.DeleteStatement,
+		*influxql.DropCardinalityLimitStatement,
+		*influxql.DropContinuousQueryStatement,
+		*influxql.DropDatabaseStatement,
+		*influxql.DropFieldStatement,
+		*influxql.DropMeasurementStatement,
+		*influxql.DropRetentionPolicyStatement,
+		*influxql.DropRoleStatement,
+		*influxql.DropSeriesStatement,
+		*influxql.DropShardStatement,
+		*influxql.DropSubscriptionStatement,
+		*influxql.DropTokenStatement,
+		*influxql.DropUserStatement,
+		*influxql.GrantAdminStatement,
+		*influxql.GrantRoleStatement,
+		*influxql.GrantStatement,
+		*influxql.KillQueryStatement,
+		*influxql.RevokeAdminStatement,
+		*influxql.RevokeRoleStatement,
+		*influxql.RevokeStatement,
+		*influxql.SetCardinalityLimitStatement,
+		*influxql.SetPasswordUserStatement:
+		return true
+	}
+	return false
+}
+
+// Statistics for the audit service.
+const (
+	statEventsWritten = "eventsWritten"
+	statEventsDropped = "eventsDropped"
+)
+
+// Service writes audit events to a rotating JSON lines file and, optionally,
+// to a database.  Events are buffered and written in batches by a background
+// goroutine, so recording an event never waits for a write.
+type Service struct {
+	mu     sync.RWMutex
+	events chan *Event
+	wg     sync.WaitGroup
+
+	bufferSize int
+	stats      *Statistics
+
+	// The following fields are only used by the goroutine writing events
+	// while the service is open.
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+
+	storeEnabled  bool
+	storeDatabase string
+	storeCreated  bool
+
+	MetaClient interface {
+		CreateDatabase(name string) (*meta.DatabaseInfo, error)
+	}
+
+	PointsWriter interface {
+		WritePoints(database, retentionPolicy string, points models.Points) error
+	}
+
+	Logger zap.Logger
+}
+
+// NewService returns a new instance of Service.
+func NewService(c Config) *Service {
+	return &Service{
+		bufferSize:    c.BufferSize,
+		stats:         &Statistics{},
+		path:          c.Path,
+		maxSize:       int64(c.MaxSize),
+		maxBackups:    c.MaxBackups,
+		storeEnabled:  c.StoreEnabled,
+		storeDatabase: c.StoreDatabase,
+		Logger:        zap.New(zap.NullEncoder()),
+	}
+}
+
+// WithLogger sets the logger for the service.
+func (s *Service) WithLogger(log zap.Logger) {
+	s.Logger = log.With(zap.String("service", "audit"))
+}
+
+// Open opens the audit log file and starts writing recorded events.
+func (s *Service) Open() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.events != nil {
+		return nil
+	}
+
+	if s.path != "" {
+		s.Logger.Info(fmt.Sprintf("Starting audit service, logging to %s", s.path))
+		if err := s.openFile(); err != nil {
+			return err
+		}
+	}
+
+	bufferSize := s.bufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	s.events = make(chan *Event, bufferSize)
+
+	s.wg.Add(1)
+	go s.writeEvents(s.events)
+	return nil
+}
+
+// Close writes the buffered events and closes the audit log file.
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.events == nil {
+		return nil
+	}
+	close(s.events)
+	s.events = nil
+	s.wg.Wait()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Statistics maintains the statistics for the audit service.
+type Statistics struct {
+	EventsWritten int64
+	EventsDropped int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "audit",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statEventsWritten: atomic.LoadInt64(&s.stats.EventsWritten),
+			statEventsDropped: atomic.LoadInt64(&s.stats.EventsDropped),
+		},
+	}}
+}
+
+// RecordStatement records the execution of stmt by user if it is an audited
+// statement. The statement text has any passwords redacted.
+func (s *Service) RecordStatement(stmt influxql.Statement, user, addr, database string, err error) {
+	if !Audited(stmt) {
+		return
+	}
+
+	e := &Event{
+		Time:      time.Now().UTC(),
+		Type:      StatementEvent,
+		User:      user,
+		Addr:      addr,
+		Database:  database,
+		Statement: influxql.Sanitize(stmt.String()),
+		Outcome:   Success,
+	}
+	if err != nil {
+		e.Outcome, e.Error = Failure, err.Error()
+	}
+	s.Record(e)
+}
+
+// RecordAuthenticationFailure records a failed attempt to authenticate as user.
+func (s *Service) RecordAuthenticationFailure(user, addr, reason string) {
+	s.Record(&Event{
+		Time:    time.Now().UTC(),
+		Type:    AuthenticationEvent,
+		User:    user,
+		Addr:    addr,
+		Outcome: Failure,
+		Error:   reason,
+	})
+}
+
+// Record queues e to be written to the audit log. If the buffer is full, e is
+// dropped and counted, so that auditing never slows down the audited
+// operation. Events recorded while the service is closed are ignored.
+func (s *Service) Record(e *Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.events == nil {
+		return
+	}
+
+	select {
+	case s.events <- e:
+	default:
+		atomic.AddInt64(&s.stats.EventsDropped, 1)
+	}
+}
+
+// writeEvents writes the events received on events until it is closed.  The
+// events that are buffered when one is received are written with it.
+func (s *Service) writeEvents(events <-chan *Event) {
+	defer s.wg.Done()
+
+	var batch []*Event
+	for e := range events {
+		batch = append(batch[:0], e)
+	drain:
+		for len(batch) < cap(events) {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, e)
+			default:
+				break drain
+			}
+		}
+		s.writeBatch(batch)
+	}
+}
+
+// writeBatch writes events to the log file and the audit database.  Errors are
+// logged, not returned, so that failing to audit does not fail the audited
+// operation.
+func (s *Service) writeBatch(events []*Event) {
+	written := true
+	if s.file != nil {
+		if err := s.writeFile(events); err != nil {
+			s.Logger.Info(fmt.Sprintf("failed to write audit log: %s", err))
+			written = false
+		}
+	}
+
+	if s.storeEnabled {
+		if err := s.store(events); err != nil {
+			s.Logger.Info(fmt.Sprintf("failed to store audit events: %s", err))
+			written = false
+		}
+	}
+
+	if written {
+		atomic.AddInt64(&s.stats.EventsWritten, int64(len(events)))
+	}
+}
+
+// openFile opens the log file for appending, creating it if necessary.
+func (s *Service) openFile() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0777); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.file, s.size = f, fi.Size()
+	return nil
+}
+
+// writeFile appends events to the log file as lines of JSON, rotating the
+// file first whenever it would grow past the maximum size.
+func (s *Service) writeFile(events []*Event) error {
+	var buf []byte
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+
+		if size := s.size + int64(len(buf)); s.maxSize > 0 && size > 0 && size+int64(len(line)) > s.maxSize {
+			if err := s.write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+
+			if err := s.rotate(); err != nil {
+				return err
+			}
+			if err := s.removeBackups(); err != nil {
+				s.Logger.Info(fmt.Sprintf("failed to remove rotated audit logs: %s", err))
+			}
+		}
+		buf = append(buf, line...)
+	}
+	return s.write(buf)
+}
+
+// write appends buf to the log file.
+func (s *Service) write(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	n, err := s.file.Write(buf)
+	s.size += int64(n)
+	return err
+}
+
+// rotate moves the current log file aside and opens a new one.
+func (s *Service) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+
+	if err := os.Rename(s.path, s.path+"."+time.Now().UTC().Format(backupTimeFormat)); err != nil {
+		return err
+	}
+	return s.openFile()
+}
+
+// removeBackups removes the oldest rotated log files to keep at most
+// maxBackups of them.
+func (s *Service) removeBackups() error {
+	if s.maxBackups == 0 {
+		return nil
+	}
+
+	backups, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return err
+	}
+	sort.Strings(backups)
+	for len(backups) > s.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// store writes events to the audit database, creating the database if
+// necessary.
+func (s *Service) store(events []*Event) error {
+	if !s.storeCreated {
+		if _, err := s.MetaClient.CreateDatabase(s.storeDatabase); err != nil {
+			return err
+		}
+		s.storeCreated = true
+	}
+
+	points := make(models.Points, 0, len(events))
+	for _, e := range events {
+		pt, err := e.point()
+		if err != nil {
+			return err
+		}
+		points = append(points, pt)
+	}
+	if err := s.PointsWriter.WritePoints(s.storeDatabase, "", points); err != nil {
+		// The database may have been dropped, so try to create it again next time.
+		s.storeCreated = false
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/audit"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+func TestService_RecordStatement(t *testing.T) {
+	t.Parallel()
+
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	c := audit.NewConfig()
+	c.Enabled = true
+	c.Path = filepath.Join(dir, "audit.log")
+	s := audit.NewService(c)
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	s.RecordStatement(MustParseStatement(`SET PASSWORD FOR jdoe = 'secret'`), "admin", "127.0.0.1:1234", "", nil)
+	s.RecordStatement(MustParseStatement(`SELECT * FROM cpu`), "admin", "127.0.0.1:1234", "db0", nil)
+	s.RecordStatement(MustParseStatement(`DROP SERIES FROM cpu`), "jdoe", "127.0.0.1:1234", "db0", errors.New("denied"))
+	s.RecordAuthenticationFailure("jdoe", "127.0.0.1:1234", "authorization failed")
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	events := MustReadEvents(c.Path)
+	if len(events) != 3 {
+		t.Fatalf("unexpected number of events: %d", len(events))
+	}
+
+	// Passwords are redacted from statements.
+	if e := events[0]; e.Type != audit.StatementEvent || e.User != "admin" || e.Addr != "127.0.0.1:1234" || e.Outcome != audit.Success {
+		t.Fatalf("unexpected event: %+v", e)
+	} else if strings.Contains(e.Statement, "secret") {
+		t.Fatalf("password not redacted: %s", e.Statement)
+	}
+
+	// SELECT statements are not audited.
+	if e := events[1]; e.Statement != `DROP SERIES FROM cpu` || e.Database != "db0" || e.Outcome != audit.Failure || e.Error != "denied" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+
+	if e := events[2]; e.Type != audit.AuthenticationEvent || e.User != "jdoe" || e.Outcome != audit.Failure || e.Error != "authorization failed" {
+		t.Fatalf("unexpected event: %+v", e)
+	}
+}
+
+func TestService_Rotate(t *testing.T) {
+	t.Parallel()
+
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	c := audit.NewConfig()
+	c.Enabled = true
+	c.Path = filepath.Join(dir, "audit.log")
+	c.MaxSize = 1
+	c.MaxBackups = 2
+	s := audit.NewService(c)
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// Every event goes to a new file as each exceeds the maximum size.
+	for i := 0; i < 5; i++ {
+		s.RecordAuthenticationFailure("jdoe", "127.0.0.1:1234", "authorization failed")
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if backups, err := filepath.Glob(c.Path + ".*"); err != nil {
+		t.Fatal(err)
+	} else if len(backups) != 2 {
+		t.Fatalf("unexpected backups: %v", backups)
+	} else if events := MustReadEvents(c.Path); len(events) != 1 {
+		t.Fatalf("unexpected number of events: %d", len(events))
+	}
+}
+
+func TestService_Store(t *testing.T) {
+	t.Parallel()
+
+	c := audit.NewConfig()
+	c.Enabled = true
+	c.Path = ""
+	c.StoreEnabled = true
+	s := audit.NewService(c)
+
+	var created []string
+	s.MetaClient = &MetaClient{
+		CreateDatabaseFn: func(name string) (*meta.DatabaseInfo, error) {
+			created = append(created, name)
+			return &meta.DatabaseInfo{Name: name}, nil
+		},
+	}
+	var points models.Points
+	s.PointsWriter = &PointsWriter{
+		WritePointsFn: func(database, retentionPolicy string, pts models.Points) error {
+			if database != audit.DefaultStoreDatabase {
+				t.Fatalf("unexpected database: %s", database)
+			}
+			points = append(points, pts...)
+			return nil
+		},
+	}
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	s.RecordStatement(MustParseStatement(`DROP DATABASE db0`), "admin", "127.0.0.1:1234", "", nil)
+	s.RecordAuthenticationFailure("jdoe", "127.0.0.1:1234", "authorization failed")
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(created, []string{audit.DefaultStoreDatabase}) {
+		t.Fatalf("unexpected databases created: %v", created)
+	} else if len(points) != 2 {
+		t.Fatalf("unexpected number of points: %d", len(points))
+	}
+
+	if p := points[0]; string(p.Name()) != audit.Measurement {
+		t.Fatalf("unexpected measurement: %s", p.Name())
+	} else if tags := p.Tags(); tags.GetString("type") != audit.StatementEvent || tags.GetString("user") != "admin" || tags.GetString("outcome") != audit.Success {
+		t.Fatalf("unexpected tags: %s", tags)
+	}
+	if tags := points[1].Tags(); tags.GetString("type") != audit.AuthenticationEvent || tags.GetString("outcome") != audit.Failure {
+		t.Fatalf("unexpected tags: %s", tags)
+	}
+}
+
+func TestService_Record_BufferFull(t *testing.T) {
+	t.Parallel()
+
+	c := audit.NewConfig()
+	c.Enabled = true
+	c.Path = ""
+	c.StoreEnabled = true
+	c.BufferSize = 1
+	s := audit.NewService(c)
+
+	s.MetaClient = &MetaClient{
+		CreateDatabaseFn: func(name string) (*meta.DatabaseInfo, error) {
+			return &meta.DatabaseInfo{Name: name}, nil
+		},
+	}
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	var n int
+	s.PointsWriter = &PointsWriter{
+		WritePointsFn: func(database, retentionPolicy string, pts models.Points) error {
+			started <- struct{}{}
+			<-release
+			n += len(pts)
+			return nil
+		},
+	}
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// The first event is being written, the second is buffered and the
+	// third is dropped.
+	s.RecordAuthenticationFailure("jdoe", "127.0.0.1:1234", "authorization failed")
+	<-started
+	s.RecordAuthenticationFailure("jdoe", "127.0.0.1:1234", "authorization failed")
+	s.RecordAuthenticationFailure("jdoe", "127.0.0.1:1234", "authorization failed")
+	close(release)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 2 {
+		t.Fatalf("unexpected number of points: %d", n)
+	}
+	stats := s.Statistics(nil)[0].Values
+	if v := stats["eventsWritten"]; v != int64(2) {
+		t.Fatalf("unexpected events written: %v", v)
+	} else if v := stats["eventsDropped"]; v != int64(1) {
+		t.Fatalf("unexpected events dropped: %v", v)
+	}
+}
+
+// MetaClient is a mock implementation of the audit service's MetaClient.
+type MetaClient struct {
+	CreateDatabaseFn func(name string) (*meta.DatabaseInfo, error)
+}
+
+func (c *MetaClient) CreateDatabase(name string) (*meta.DatabaseInfo, error) {
+	return c.CreateDatabaseFn(name)
+}
+
+// PointsWriter is a mock implementation of the audit service's PointsWriter.
+type PointsWriter struct {
+	WritePointsFn func(database, retentionPolicy string, points models.Points) error
+}
+
+func (w *PointsWriter) WritePoints(database, retentionPolicy string, points models.Points) error {
+	return w.WritePointsFn(database, retentionPolicy, points)
+}
+
+// MustTempDir returns a temporary directory. Panic on error.
+func MustTempDir() string {
+	dir, err := ioutil.TempDir("", "audit-")
+	if err != nil {
+		panic(err)
+	}
+	return dir
+}
+
+// MustParseStatement parses a statement. Panic on error.
+func MustParseStatement(s string) influxql.Statement {
+	stmt, err := influxql.ParseStatement(s)
+	if err != nil {
+		panic(err)
+	}
+	return stmt
+}
+
+// MustReadEvents reads the events in an audit log file. Panic on error.
+func MustReadEvents(path string) []audit.Event {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	var events []audit.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e audit.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			panic(err)
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+	return events
+}
@@ -0,0 +1,454 @@
+package httpd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/services/meta"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Supported authentication providers.
+const (
+	// AuthProviderMeta authenticates the users in the meta store.
+	AuthProviderMeta = "meta"
+
+	// AuthProviderHtpasswd authenticates the users in an htpasswd file.
+	AuthProviderHtpasswd = "htpasswd"
+
+	// AuthProviderHTTP authenticates users by calling out to an HTTP endpoint.
+	AuthProviderHTTP = "http"
+)
+
+// AuthenticationProvider authenticates users with a username and password.
+type AuthenticationProvider interface {
+	Authenticate(username, password string) (meta.User, error)
+}
+
+// NewAuthenticationProvider returns the authentication provider set in c.
+// It returns nil for AuthProviderMeta, in which case the handler
+// authenticates the users in the meta store.
+func NewAuthenticationProvider(c Config) (AuthenticationProvider, error) {
+	groups, err := NewGroupMapping(c.AuthGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	var provider AuthenticationProvider
+	switch c.AuthProvider {
+	case "", AuthProviderMeta:
+		return nil, nil
+	case AuthProviderHtpasswd:
+		provider = NewHtpasswdProvider(c.AuthHtpasswdFile, c.AuthHtgroupFile, groups)
+	case AuthProviderHTTP:
+		provider = NewHTTPProvider(c.AuthCalloutURL, time.Duration(c.AuthCalloutTimeout), time.Duration(c.AuthCalloutCacheTTL), groups)
+	default:
+		return nil, fmt.Errorf("unknown auth-provider: %q", c.AuthProvider)
+	}
+
+	if c.AuthMaxFailures > 0 {
+		provider = NewLockoutProvider(provider, c.AuthMaxFailures, time.Duration(c.AuthLockoutDuration))
+	}
+	return provider, nil
+}
+
+// LockoutProvider locks the users of an authentication provider after too
+// many consecutive failed logins. The failures and locks are kept in memory
+// and are forgotten once they are older than the lockout duration.
+type LockoutProvider struct {
+	provider    AuthenticationProvider
+	maxFailures int
+	lockout     time.Duration
+
+	mu       sync.Mutex // protects the entries below
+	failures map[string]loginFailures
+	locked   map[string]time.Time
+	pruned   time.Time // last time expired failures and locks were removed
+}
+
+// loginFailures are the consecutive failed logins of a user.
+type loginFailures struct {
+	n    int
+	last time.Time
+}
+
+// NewLockoutProvider returns a LockoutProvider that locks the users of
+// provider for lockout after maxFailures consecutive failed logins.
+func NewLockoutProvider(provider AuthenticationProvider, maxFailures int, lockout time.Duration) *LockoutProvider {
+	return &LockoutProvider{
+		provider:    provider,
+		maxFailures: maxFailures,
+		lockout:     lockout,
+		failures:    make(map[string]loginFailures),
+		locked:      make(map[string]time.Time),
+	}
+}
+
+// Authenticate authenticates a user with the wrapped provider unless the
+// user is locked, in which case meta.ErrUserLocked is returned. Only wrong
+// credentials of existing users count as failures, not unknown users or
+// errors of the provider.
+func (p *LockoutProvider) Authenticate(username, password string) (meta.User, error) {
+	now := time.Now()
+	p.mu.Lock()
+	p.prune(now)
+	if until, ok := p.locked[username]; ok {
+		if now.Before(until) {
+			p.mu.Unlock()
+			return nil, meta.ErrUserLocked
+		}
+		delete(p.locked, username)
+	}
+	p.mu.Unlock()
+
+	u, err := p.provider.Authenticate(username, password)
+	if err != nil && err != meta.ErrAuthenticate {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The user may have been locked by a concurrent login.
+	if until, ok := p.locked[username]; ok && now.Before(until) {
+		return nil, meta.ErrUserLocked
+	} else if err == nil {
+		delete(p.failures, username)
+		return u, nil
+	}
+
+	// Failures older than the lockout duration are no longer consecutive.
+	f := p.failures[username]
+	if now.Sub(f.last) >= p.lockout {
+		f.n = 0
+	}
+	f.n++
+	f.last = now
+	if f.n >= p.maxFailures {
+		delete(p.failures, username)
+		p.locked[username] = now.Add(p.lockout)
+		return nil, err
+	}
+	p.failures[username] = f
+	return nil, err
+}
+
+// prune removes the expired locks and the failures older than the lockout
+// duration, at most once per lockout duration. p.mu must be held.
+func (p *LockoutProvider) prune(now time.Time) {
+	if now.Sub(p.pruned) < p.lockout {
+		return
+	}
+	p.pruned = now
+
+	for username, until := range p.locked {
+		if !now.Before(until) {
+			delete(p.locked, username)
+		}
+	}
+	for username, f := range p.failures {
+		if now.Sub(f.last) >= p.lockout {
+			delete(p.failures, username)
+		}
+	}
+}
+
+// GroupMapping maps the groups of externally authenticated users to
+// privileges.
+type GroupMapping map[string]groupPrivileges
+
+type groupPrivileges struct {
+	admin      bool
+	privileges map[string]influxql.Privilege
+}
+
+// NewGroupMapping returns a GroupMapping of the groups in a.
+func NewGroupMapping(a []AuthGroup) (GroupMapping, error) {
+	m := make(GroupMapping, len(a))
+	for _, g := range a {
+		if g.Name == "" {
+			return nil, fmt.Errorf("auth group name required")
+		}
+
+		gp := groupPrivileges{admin: g.Admin, privileges: make(map[string]influxql.Privilege)}
+		for db, s := range g.Privileges {
+			p, err := parsePrivilege(s)
+			if err != nil {
+				return nil, fmt.Errorf("auth group %q: %s", g.Name, err)
+			}
+			gp.privileges[db] = p
+		}
+		m[g.Name] = gp
+	}
+	return m, nil
+}
+
+// User returns an external user that has the combined privileges of groups.
+func (m GroupMapping) User(name string, groups []string) *meta.ExternalUser {
+	u := &meta.ExternalUser{UserInfo: &meta.UserInfo{Name: name, Privileges: make(map[string]influxql.Privilege)}}
+	for _, g := range groups {
+		gp, ok := m[g]
+		if !ok {
+			continue
+		}
+		u.Admin = u.Admin || gp.admin
+		for db, p := range gp.privileges {
+			u.Privileges[db] |= p
+		}
+	}
+	return u
+}
+
+// parsePrivilege parses a privilege as written in GRANT statements.
+func parsePrivilege(s string) (influxql.Privilege, error) {
+	switch strings.ToUpper(s) {
+	case "READ":
+		return influxql.ReadPrivilege, nil
+	case "WRITE":
+		return influxql.WritePrivilege, nil
+	case "ALL", "ALL PRIVILEGES":
+		return influxql.AllPrivileges, nil
+	}
+	return influxql.NoPrivileges, fmt.Errorf("unknown privilege: %q", s)
+}
+
+// HtpasswdProvider authenticates the users in an htpasswd file. Passwords
+// must be hashed with bcrypt or SHA1; entries hashed with apr1 (MD5), crypt
+// or stored in plain text are rejected. The groups of users are read from an
+// optional group file with lines of the form "group: user1 user2".
+//
+// The files are read again when they are modified.
+type HtpasswdProvider struct {
+	path      string
+	groupPath string
+	groups    GroupMapping
+
+	mu           sync.Mutex // protects the entries below
+	modTime      time.Time
+	groupModTime time.Time
+	hashes       map[string]string
+	userGroups   map[string][]string
+}
+
+// NewHtpasswdProvider returns a new instance of HtpasswdProvider.
+func NewHtpasswdProvider(path, groupPath string, groups GroupMapping) *HtpasswdProvider {
+	return &HtpasswdProvider{
+		path:      path,
+		groupPath: groupPath,
+		groups:    groups,
+	}
+}
+
+// Authenticate authenticates a user with the password in the htpasswd file.
+func (p *HtpasswdProvider) Authenticate(username, password string) (meta.User, error) {
+	hashes, userGroups, err := p.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, ok := hashes[username]
+	if !ok {
+		return nil, meta.ErrUserNotFound
+	}
+
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			return nil, meta.ErrAuthenticate
+		}
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(password))
+		if subtle.ConstantTimeCompare([]byte(hash[len("{SHA}"):]), []byte(base64.StdEncoding.EncodeToString(sum[:]))) != 1 {
+			return nil, meta.ErrAuthenticate
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash for user %q in %s: only bcrypt and SHA1 are supported", username, p.path)
+	}
+
+	return p.groups.User(username, userGroups[username]), nil
+}
+
+// entries returns the password hashes and groups of the users, reading the
+// htpasswd and group files again if they were modified since they were last
+// read. The lock is only held to read and swap the entries, so authentications
+// don't wait for the files to be read.
+func (p *HtpasswdProvider) entries() (map[string]string, map[string][]string, error) {
+	p.mu.Lock()
+	hashes, modTime := p.hashes, p.modTime
+	userGroups, groupModTime := p.userGroups, p.groupModTime
+	p.mu.Unlock()
+
+	var modified bool
+	fi, err := os.Stat(p.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hashes == nil || !fi.ModTime().Equal(modTime) {
+		hashes = make(map[string]string)
+		if err := readLines(p.path, func(line string) {
+			if i := strings.Index(line, ":"); i > 0 {
+				hashes[line[:i]] = line[i+1:]
+			}
+		}); err != nil {
+			return nil, nil, err
+		}
+		modTime, modified = fi.ModTime(), true
+	}
+
+	if p.groupPath != "" {
+		fi, err := os.Stat(p.groupPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if userGroups == nil || !fi.ModTime().Equal(groupModTime) {
+			userGroups = make(map[string][]string)
+			if err := readLines(p.groupPath, func(line string) {
+				if i := strings.Index(line, ":"); i > 0 {
+					group := strings.TrimSpace(line[:i])
+					for _, u := range strings.Fields(line[i+1:]) {
+						userGroups[u] = append(userGroups[u], group)
+					}
+				}
+			}); err != nil {
+				return nil, nil, err
+			}
+			groupModTime, modified = fi.ModTime(), true
+		}
+	}
+
+	if modified {
+		p.mu.Lock()
+		p.hashes, p.modTime = hashes, modTime
+		p.userGroups, p.groupModTime = userGroups, groupModTime
+		p.mu.Unlock()
+	}
+	return hashes, userGroups, nil
+}
+
+// readLines calls fn with each line of the file at path, skipping empty lines
+// and comments.
+func readLines(path string, fn func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fn(line)
+	}
+	return scanner.Err()
+}
+
+// HTTPProvider authenticates users by posting their credentials to an HTTP
+// endpoint. The request body is a JSON object with "username" and "password"
+// keys. The endpoint responds with 200 and a JSON object with a "groups" key
+// listing the groups of the user if the credentials are valid, and with 401
+// or 403 if they are not.
+//
+// Successful authentications are cached for cacheTTL, keyed by a hash of the
+// credentials, so the endpoint isn't called on every request.
+type HTTPProvider struct {
+	url      string
+	client   *http.Client
+	groups   GroupMapping
+	cacheTTL time.Duration
+
+	mu    sync.Mutex // protects cache
+	cache map[[sha256.Size]byte]cachedUser
+}
+
+// cachedUser is a user authenticated by the HTTP endpoint.
+type cachedUser struct {
+	user    meta.User
+	expires time.Time
+}
+
+// NewHTTPProvider returns a new instance of HTTPProvider. A cacheTTL of 0
+// disables the cache.
+func NewHTTPProvider(url string, timeout, cacheTTL time.Duration, groups GroupMapping) *HTTPProvider {
+	return &HTTPProvider{
+		url:      url,
+		client:   &http.Client{Timeout: timeout},
+		groups:   groups,
+		cacheTTL: cacheTTL,
+		cache:    make(map[[sha256.Size]byte]cachedUser),
+	}
+}
+
+// Authenticate validates the credentials of a user with the HTTP endpoint.
+func (p *HTTPProvider) Authenticate(username, password string) (meta.User, error) {
+	key := sha256.Sum256([]byte(username + "\x00" + password))
+	now := time.Now()
+	p.mu.Lock()
+	c, ok := p.cache[key]
+	p.mu.Unlock()
+	if ok && now.Before(c.expires) {
+		return c.user, nil
+	}
+
+	u, err := p.authenticate(username, password)
+	if err != nil || p.cacheTTL <= 0 {
+		return u, err
+	}
+
+	p.mu.Lock()
+	for k, c := range p.cache {
+		if !now.Before(c.expires) {
+			delete(p.cache, k)
+		}
+	}
+	p.cache[key] = cachedUser{user: u, expires: now.Add(p.cacheTTL)}
+	p.mu.Unlock()
+	return u, nil
+}
+
+// authenticate posts the credentials of a user to the HTTP endpoint.
+func (p *HTTPProvider) authenticate(username, password string) (meta.User, error) {
+	body, err := json.Marshal(struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{username, password})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Post(p.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, meta.ErrAuthenticate
+	default:
+		return nil, fmt.Errorf("unexpected status from authentication endpoint: %s", resp.Status)
+	}
+
+	var result struct {
+		Groups []string `json:"groups"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response from authentication endpoint: %s", err)
+	}
+	return p.groups.User(username, result.Groups), nil
+}
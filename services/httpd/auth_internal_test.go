@@ -0,0 +1,60 @@
+package httpd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+type authenticationProviderFunc func(username, password string) (meta.User, error)
+
+func (fn authenticationProviderFunc) Authenticate(username, password string) (meta.User, error) {
+	return fn(username, password)
+}
+
+// Ensure logins of unknown users are not recorded.
+func TestLockoutProvider_Authenticate_UserNotFound(t *testing.T) {
+	p := NewLockoutProvider(authenticationProviderFunc(func(username, password string) (meta.User, error) {
+		return nil, meta.ErrUserNotFound
+	}), 2, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		if _, err := p.Authenticate(fmt.Sprintf("user%d", i), "wrong"); err != meta.ErrUserNotFound {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(p.failures) != 0 || len(p.locked) != 0 {
+		t.Fatalf("unexpected failures %v and locks %v", p.failures, p.locked)
+	}
+}
+
+// Ensure expired failures and locks are removed.
+func TestLockoutProvider_Authenticate_Prune(t *testing.T) {
+	p := NewLockoutProvider(authenticationProviderFunc(func(username, password string) (meta.User, error) {
+		return nil, meta.ErrAuthenticate
+	}), 2, 50*time.Millisecond)
+
+	// Lock one user and record a failure of another.
+	for _, username := range []string{"alice", "alice", "bob"} {
+		if _, err := p.Authenticate(username, "wrong"); err != meta.ErrAuthenticate {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(p.failures) != 1 || len(p.locked) != 1 {
+		t.Fatalf("unexpected failures %v and locks %v", p.failures, p.locked)
+	}
+
+	// Both are removed by the next login once they expire.
+	time.Sleep(100 * time.Millisecond)
+	if _, err := p.Authenticate("carol", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := p.failures["bob"]; ok {
+		t.Fatalf("unexpected failures %v", p.failures)
+	} else if len(p.locked) != 0 {
+		t.Fatalf("unexpected locks %v", p.locked)
+	}
+}
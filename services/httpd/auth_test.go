@@ -0,0 +1,209 @@
+package httpd_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/services/httpd"
+	"github.com/influxdata/influxdb/services/meta"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGroupMapping_User(t *testing.T) {
+	m, err := httpd.NewGroupMapping([]httpd.AuthGroup{
+		{Name: "ops", Admin: true},
+		{Name: "readers", Privileges: map[string]string{"db0": "READ", "db1": "READ"}},
+		{Name: "writers", Privileges: map[string]string{"db0": "WRITE"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u := m.User("jdoe", []string{"readers", "writers", "unknown"}); u.Admin {
+		t.Fatal("expected non-admin user")
+	} else if !u.AuthorizeDatabase(influxql.AllPrivileges, "db0") {
+		t.Fatal("expected all privileges on db0")
+	} else if !u.AuthorizeDatabase(influxql.ReadPrivilege, "db1") || u.AuthorizeDatabase(influxql.WritePrivilege, "db1") {
+		t.Fatal("expected read privilege on db1")
+	}
+
+	if u := m.User("root", []string{"ops"}); !u.Admin {
+		t.Fatal("expected admin user")
+	}
+}
+
+func TestHtpasswdProvider_Authenticate(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("bcryptpass"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	htpasswd := filepath.Join(dir, "htpasswd")
+	MustWriteFile(htpasswd, "# users\n"+
+		"alice:"+string(hash)+"\n"+
+		"bob:{SHA}z0jT3TdveclVlHs5WCpg5cPeIe8=\n"+ // shapass
+		"carol:plaintext\n"+
+		"erin:$apr1$Pv0Ws2Xr$Pn8ZRw0ypxZcESBa2HzHm/\n")
+	htgroup := filepath.Join(dir, "htgroup")
+	MustWriteFile(htgroup, "ops: alice\nreaders: alice bob\n")
+
+	groups, err := httpd.NewGroupMapping([]httpd.AuthGroup{
+		{Name: "ops", Admin: true},
+		{Name: "readers", Privileges: map[string]string{"db0": "READ"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := httpd.NewHtpasswdProvider(htpasswd, htgroup, groups)
+
+	if u, err := p.Authenticate("alice", "bcryptpass"); err != nil {
+		t.Fatal(err)
+	} else if u.ID() != "alice" || !u.AuthorizeDatabase(influxql.ReadPrivilege, "db0") || !u.IsAdmin() {
+		t.Fatalf("unexpected user: %+v", u)
+	}
+
+	if u, err := p.Authenticate("bob", "shapass"); err != nil {
+		t.Fatal(err)
+	} else if u.ID() != "bob" || !u.AuthorizeDatabase(influxql.ReadPrivilege, "db0") || u.AuthorizeDatabase(influxql.WritePrivilege, "db0") {
+		t.Fatalf("unexpected user: %+v", u)
+	}
+
+	if _, err := p.Authenticate("alice", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("bob", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("dave", "bcryptpass"); err != meta.ErrUserNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("carol", "plaintext"); err == nil {
+		t.Fatal("expected error for unsupported hash")
+	} else if _, err := p.Authenticate("erin", "apr1pass"); err == nil || !strings.Contains(err.Error(), "unsupported password hash") {
+		t.Fatalf("unexpected error for apr1 hash: %v", err)
+	}
+
+	// Users removed from the htpasswd file can no longer authenticate.
+	MustWriteFile(htpasswd, "bob:{SHA}z0jT3TdveclVlHs5WCpg5cPeIe8=\n")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(htpasswd, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Authenticate("alice", "bcryptpass"); err != meta.ErrUserNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestHTTPProvider_Authenticate(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var creds struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case creds.Username == "jdoe" && creds.Password == "secret":
+			w.Write([]byte(`{"groups":["writers"]}`))
+		case creds.Username == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+
+	groups, err := httpd.NewGroupMapping([]httpd.AuthGroup{
+		{Name: "writers", Privileges: map[string]string{"db0": "WRITE"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := httpd.NewHTTPProvider(ts.URL, time.Second, time.Minute, groups)
+
+	// The second authentication is served from the cache.
+	for i := 0; i < 2; i++ {
+		if u, err := p.Authenticate("jdoe", "secret"); err != nil {
+			t.Fatal(err)
+		} else if u.ID() != "jdoe" || !u.AuthorizeDatabase(influxql.WritePrivilege, "db0") || u.AuthorizeDatabase(influxql.ReadPrivilege, "db0") {
+			t.Fatalf("unexpected user: %+v", u)
+		} else if calls != 1 {
+			t.Fatalf("unexpected endpoint calls: %d", calls)
+		}
+	}
+
+	// Failed authentications aren't cached.
+	if _, err := p.Authenticate("jdoe", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("jdoe", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if calls != 3 {
+		t.Fatalf("unexpected endpoint calls: %d", calls)
+	} else if _, err := p.Authenticate("broken", "secret"); err == nil || err == meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLockoutProvider_Authenticate(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	htpasswd := filepath.Join(dir, "htpasswd")
+	MustWriteFile(htpasswd, "bob:{SHA}z0jT3TdveclVlHs5WCpg5cPeIe8=\n") // shapass
+	p := httpd.NewLockoutProvider(httpd.NewHtpasswdProvider(htpasswd, "", nil), 2, 100*time.Millisecond)
+
+	// A successful login resets the failures.
+	if _, err := p.Authenticate("bob", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("bob", "shapass"); err != nil {
+		t.Fatal(err)
+	} else if _, err := p.Authenticate("bob", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("bob", "shapass"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The user is locked after too many failures, even with the right password.
+	if _, err := p.Authenticate("bob", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("bob", "wrong"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := p.Authenticate("bob", "shapass"); err != meta.ErrUserLocked {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The lock expires.
+	time.Sleep(150 * time.Millisecond)
+	if _, err := p.Authenticate("bob", "shapass"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// MustTempDir returns a temporary directory. Panic on error.
+func MustTempDir() string {
+	dir, err := ioutil.TempDir("", "httpd-")
+	if err != nil {
+		panic(err)
+	}
+	return dir
+}
+
+// MustWriteFile writes data to the file at path. Panic on error.
+func MustWriteFile(path, data string) {
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		panic(err)
+	}
+}
@@ -1,6 +1,12 @@
 package httpd
 
-import "github.com/influxdata/influxdb/monitor/diagnostics"
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
 
 const (
 	// DefaultBindAddress is the default address to bind to.
@@ -14,6 +20,15 @@ const (
 
 	// DefaultMaxBodySize is the default maximum size of a client request body, in bytes. Specify 0 for no limit.
 	DefaultMaxBodySize = 25e6
+
+	// DefaultAuthCalloutTimeout is the default timeout of requests to the HTTP authentication provider.
+	DefaultAuthCalloutTimeout = 5 * time.Second
+
+	// DefaultAuthCalloutCacheTTL is the default time users authenticated by the HTTP authentication provider are cached.
+	DefaultAuthCalloutCacheTTL = time.Minute
+
+	// DefaultAuthLockoutDuration is the default duration users of external authentication providers are locked.
+	DefaultAuthLockoutDuration = 15 * time.Minute
 )
 
 // Config represents a configuration for a HTTP service.
@@ -34,23 +49,91 @@ type Config struct {
 	UnixSocketEnabled  bool   `toml:"unix-socket-enabled"`
 	BindSocket         string `toml:"bind-socket"`
 	MaxBodySize        int    `toml:"max-body-size"`
+
+	HTTPSClientCA           string            `toml:"https-client-ca"`
+	HTTPSClientCertRequired bool              `toml:"https-client-cert-required"`
+	HTTPSClientCertAuth     bool              `toml:"https-client-cert-auth"`
+	HTTPSClientCertUsers    map[string]string `toml:"https-client-cert-users"`
+
+	AuthProvider        string        `toml:"auth-provider"`
+	AuthHtpasswdFile    string        `toml:"auth-htpasswd-file"`
+	AuthHtgroupFile     string        `toml:"auth-htgroup-file"`
+	AuthCalloutURL      string        `toml:"auth-callout-url"`
+	AuthCalloutTimeout  toml.Duration `toml:"auth-callout-timeout"`
+	AuthCalloutCacheTTL toml.Duration `toml:"auth-callout-cache-ttl"`
+	AuthGroups          []AuthGroup   `toml:"auth-groups"`
+
+	// AuthMaxFailures locks users of external providers after this number of
+	// consecutive failed logins. Zero disables the lockout.
+	AuthMaxFailures     int           `toml:"auth-max-failures"`
+	AuthLockoutDuration toml.Duration `toml:"auth-lockout-duration"`
+}
+
+// AuthGroup maps a group of users authenticated by an external provider to
+// privileges. Privileges map database names to READ, WRITE or ALL.
+type AuthGroup struct {
+	Name       string            `toml:"name"`
+	Admin      bool              `toml:"admin"`
+	Privileges map[string]string `toml:"privileges"`
 }
 
 // NewConfig returns a new Config with default settings.
 func NewConfig() Config {
 	return Config{
-		Enabled:           true,
-		BindAddress:       DefaultBindAddress,
-		LogEnabled:        true,
-		PprofEnabled:      true,
-		HTTPSEnabled:      false,
-		HTTPSCertificate:  "/etc/ssl/influxdb.pem",
-		MaxRowLimit:       0,
-		Realm:             DefaultRealm,
-		UnixSocketEnabled: false,
-		BindSocket:        DefaultBindSocket,
-		MaxBodySize:       DefaultMaxBodySize,
+		Enabled:             true,
+		BindAddress:         DefaultBindAddress,
+		LogEnabled:          true,
+		PprofEnabled:        true,
+		HTTPSEnabled:        false,
+		HTTPSCertificate:    "/etc/ssl/influxdb.pem",
+		MaxRowLimit:         0,
+		Realm:               DefaultRealm,
+		UnixSocketEnabled:   false,
+		BindSocket:          DefaultBindSocket,
+		MaxBodySize:         DefaultMaxBodySize,
+		AuthProvider:        AuthProviderMeta,
+		AuthCalloutTimeout:  toml.Duration(DefaultAuthCalloutTimeout),
+		AuthCalloutCacheTTL: toml.Duration(DefaultAuthCalloutCacheTTL),
+		AuthLockoutDuration: toml.Duration(DefaultAuthLockoutDuration),
+	}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if c.HTTPSClientCA != "" && !c.HTTPSEnabled {
+		return errors.New("https-client-ca requires https-enabled")
+	} else if (c.HTTPSClientCertRequired || c.HTTPSClientCertAuth) && c.HTTPSClientCA == "" {
+		return errors.New("https-client-ca must be set to verify client certificates")
+	}
+
+	switch c.AuthProvider {
+	case "", AuthProviderMeta:
+	case AuthProviderHtpasswd:
+		if c.AuthHtpasswdFile == "" {
+			return errors.New("auth-htpasswd-file must be set for the htpasswd auth-provider")
+		}
+	case AuthProviderHTTP:
+		if c.AuthCalloutURL == "" {
+			return errors.New("auth-callout-url must be set for the http auth-provider")
+		} else if c.AuthCalloutTimeout <= 0 {
+			return errors.New("auth-callout-timeout must be positive")
+		} else if c.AuthCalloutCacheTTL < 0 {
+			return errors.New("auth-callout-cache-ttl must not be negative")
+		}
+	default:
+		return errors.New("auth-provider must be one of meta, htpasswd or http")
+	}
+
+	if c.AuthMaxFailures < 0 {
+		return errors.New("auth-max-failures must not be negative")
+	} else if c.AuthMaxFailures > 0 && c.AuthLockoutDuration <= 0 {
+		return errors.New("auth-lockout-duration must be positive")
+	}
+
+	if _, err := NewGroupMapping(c.AuthGroups); err != nil {
+		return err
 	}
+	return nil
 }
 
 // Diagnostics returns a diagnostics representation of a subset of the Config.
@@ -67,5 +150,7 @@ func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 		"https-enabled":        c.HTTPSEnabled,
 		"max-row-limit":        c.MaxRowLimit,
 		"max-connection-limit": c.MaxConnectionLimit,
+		"https-client-cert":    c.HTTPSClientCA != "",
+		"auth-provider":        c.AuthProvider,
 	}), nil
 }
@@ -2,6 +2,7 @@ package httpd_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/influxdata/influxdb/services/httpd"
@@ -21,6 +22,21 @@ https-certificate = "/dev/null"
 unix-socket-enabled = true
 bind-socket = "/var/run/influxdb.sock"
 max-body-size = 100
+auth-provider = "htpasswd"
+auth-htpasswd-file = "/etc/influxdb/htpasswd"
+auth-htgroup-file = "/etc/influxdb/htgroup"
+auth-callout-timeout = "10s"
+
+[[auth-groups]]
+name = "ops"
+admin = true
+
+[[auth-groups]]
+name = "analysts"
+
+  [auth-groups.privileges]
+  db0 = "READ"
+  db1 = "ALL"
 `, &c); err != nil {
 		t.Fatal(err)
 	}
@@ -46,6 +62,53 @@ max-body-size = 100
 		t.Fatalf("unexpected bind unix socket: %v", c.BindSocket)
 	} else if c.MaxBodySize != 100 {
 		t.Fatalf("unexpected max-body-size: %v", c.MaxBodySize)
+	} else if c.AuthProvider != httpd.AuthProviderHtpasswd {
+		t.Fatalf("unexpected auth-provider: %v", c.AuthProvider)
+	} else if c.AuthHtpasswdFile != "/etc/influxdb/htpasswd" {
+		t.Fatalf("unexpected auth-htpasswd-file: %v", c.AuthHtpasswdFile)
+	} else if c.AuthHtgroupFile != "/etc/influxdb/htgroup" {
+		t.Fatalf("unexpected auth-htgroup-file: %v", c.AuthHtgroupFile)
+	} else if time.Duration(c.AuthCalloutTimeout) != 10*time.Second {
+		t.Fatalf("unexpected auth-callout-timeout: %v", c.AuthCalloutTimeout)
+	} else if len(c.AuthGroups) != 2 {
+		t.Fatalf("unexpected auth-groups: %v", c.AuthGroups)
+	} else if g := c.AuthGroups[0]; g.Name != "ops" || !g.Admin {
+		t.Fatalf("unexpected auth group: %+v", g)
+	} else if g := c.AuthGroups[1]; g.Name != "analysts" || g.Admin || g.Privileges["db0"] != "READ" || g.Privileges["db1"] != "ALL" {
+		t.Fatalf("unexpected auth group: %+v", g)
+	}
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		s   string
+		err string
+	}{
+		{s: `auth-provider = "meta"`},
+		{s: "https-enabled = true\nhttps-client-ca = \"/etc/ssl/ca.pem\"\nhttps-client-cert-required = true\nhttps-client-cert-auth = true"},
+		{s: `https-client-ca = "/etc/ssl/ca.pem"`, err: "https-client-ca requires https-enabled"},
+		{s: "https-enabled = true\nhttps-client-cert-auth = true", err: "https-client-ca must be set to verify client certificates"},
+		{s: `auth-provider = "ldap"`, err: "auth-provider must be one of meta, htpasswd or http"},
+		{s: `auth-provider = "htpasswd"`, err: "auth-htpasswd-file must be set for the htpasswd auth-provider"},
+		{s: `auth-provider = "http"`, err: "auth-callout-url must be set for the http auth-provider"},
+		{s: "auth-provider = \"http\"\nauth-callout-url = \"http://localhost\"\nauth-callout-timeout = \"0s\"", err: "auth-callout-timeout must be positive"},
+		{s: "[[auth-groups]]\nadmin = true", err: "auth group name required"},
+		{s: "[[auth-groups]]\nname = \"g\"\n[auth-groups.privileges]\ndb0 = \"OWNER\"", err: `auth group "g": unknown privilege: "OWNER"`},
+	} {
+		c := httpd.NewConfig()
+		if _, err := toml.Decode(tt.s, &c); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := c.Validate(); tt.err == "" && err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.s, err)
+		} else if tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%q: unexpected error: exp=%s got=%v", tt.s, tt.err, err)
+		}
 	}
 }
 
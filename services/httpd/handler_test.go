@@ -2,27 +2,216 @@ package httpd_test
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/influxdata/influxdb/coordinator"
 	"github.com/influxdata/influxdb/internal"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/monitor"
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/prometheus/remote"
 	"github.com/influxdata/influxdb/services/httpd"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
 )
 
+// Ensure users of an authentication provider are authorized to write with
+// the privileges of their groups, not those of a meta user with the same name.
+func TestHandler_Write_AuthenticationProvider(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	config := meta.NewConfig()
+	config.Dir = dir
+	c := meta.NewClient(config)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.CreateDatabase("db0"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.CreateDatabase("db1"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.CreateUser("ext", "metapass", false); err != nil {
+		t.Fatal(err)
+	} else if err := c.SetPrivilege("ext", "db1", influxql.WritePrivilege); err != nil {
+		t.Fatal(err)
+	}
+
+	groups, err := httpd.NewGroupMapping([]httpd.AuthGroup{
+		{Name: "writers", Privileges: map[string]string{"db0": "WRITE"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	htpasswd, htgroup := filepath.Join(dir, "htpasswd"), filepath.Join(dir, "htgroup")
+	MustWriteFile(htpasswd, "ext:{SHA}z0jT3TdveclVlHs5WCpg5cPeIe8=\n") // shapass
+	MustWriteFile(htgroup, "writers: ext\n")
+
+	h := NewHandler(true)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.Handler.AuthenticationProvider = httpd.NewHtpasswdProvider(htpasswd, htgroup, groups)
+	h.Handler.WriteAuthorizer = meta.NewWriteAuthorizer(c)
+	h.PointsWriter.WritePointsFn = func(_, _ string, _ models.ConsistencyLevel, _ meta.User, _ []models.Point) error {
+		return nil
+	}
+
+	promReq := MustEncodePromRequest(&remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{{
+			Labels:  []*remote.LabelPair{{Name: "__name__", Value: "cpu"}},
+			Samples: []*remote.Sample{{Value: 1, Timestamp: 1000}},
+		}},
+	})
+
+	for _, tt := range []struct {
+		db   string
+		code int
+	}{
+		{db: "db0", code: http.StatusNoContent},
+		{db: "db1", code: http.StatusForbidden},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, MustNewRequest("POST", "/write?u=ext&p=shapass&db="+tt.db, strings.NewReader("cpu value=1")))
+		if w.Code != tt.code {
+			t.Fatalf("write to %s: unexpected status: %d: %s", tt.db, w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/write?u=ext&p=shapass&db="+tt.db, bytes.NewReader(promReq)))
+		if w.Code != tt.code {
+			t.Fatalf("prometheus write to %s: unexpected status: %d: %s", tt.db, w.Code, w.Body.String())
+		}
+	}
+}
+
+// Ensure the handler authenticates users with the authentication provider,
+// even if no users exist in the meta store.
+func TestHandler_Query_AuthenticationProvider(t *testing.T) {
+	h := NewHandler(true)
+	h.MetaClient.AdminUserExistsFn = func() bool { return false }
+	h.Handler.AuthenticationProvider = &HandlerAuthenticationProvider{
+		AuthenticateFn: func(u, p string) (meta.User, error) {
+			if u != "jdoe" || p != "secret" {
+				return nil, meta.ErrAuthenticate
+			}
+			return &meta.UserInfo{Name: u}, nil
+		},
+	}
+	h.QueryAuthorizer.AuthorizeQueryFn = func(u meta.User, query *influxql.Query, database string) error {
+		if u.ID() != "jdoe" {
+			t.Fatalf("unexpected user: %s", u.ID())
+		}
+		return nil
+	}
+	h.StatementExecutor.ExecuteStatementFn = func(stmt influxql.Statement, ctx influxql.ExecutionContext) error {
+		ctx.Results <- &influxql.Result{StatementID: 0}
+		return nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/query?u=jdoe&p=secret&db=foo&q=SELECT+*+FROM+bar", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/query?u=jdoe&p=wrong&db=foo&q=SELECT+*+FROM+bar", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/query?db=foo&q=SELECT+*+FROM+bar", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// Ensure the handler authenticates users with verified client certificates.
+func TestHandler_Query_ClientCertificate(t *testing.T) {
+	h := NewHandler(true)
+	h.Config.HTTPSClientCertAuth = true
+	h.Config.HTTPSClientCertUsers = map[string]string{"collector.example.com": "collector"}
+	h.MetaClient.AdminUserExistsFn = func() bool { return true }
+	h.MetaClient.UserFn = func(username string) (meta.User, error) {
+		if username != "collector" {
+			return nil, meta.ErrUserNotFound
+		}
+		return &meta.UserInfo{Name: username}, nil
+	}
+	h.QueryAuthorizer.AuthorizeQueryFn = func(u meta.User, query *influxql.Query, database string) error {
+		if u.ID() != "collector" {
+			t.Fatalf("unexpected user: %s", u.ID())
+		}
+		return nil
+	}
+	h.StatementExecutor.ExecuteStatementFn = func(stmt influxql.Statement, ctx influxql.ExecutionContext) error {
+		ctx.Results <- &influxql.Result{StatementID: 0}
+		return nil
+	}
+
+	newRequest := func(cert *x509.Certificate) *http.Request {
+		r := MustNewRequest("GET", "/query?db=foo&q=SELECT+*+FROM+bar", nil)
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		return r
+	}
+
+	// The certificate's DNS name maps to a user.
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(&x509.Certificate{Subject: pkix.Name{CommonName: "host1"}, DNSNames: []string{"collector.example.com"}}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// The certificate doesn't map to a user.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(&x509.Certificate{Subject: pkix.Name{CommonName: "collector"}}))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// Without a mapping, the common name is the user name.
+	h.Config.HTTPSClientCertUsers = nil
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(&x509.Certificate{Subject: pkix.Name{CommonName: "collector"}}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+
+	// Certificates are ignored unless certificate authentication is enabled.
+	h.Config.HTTPSClientCertAuth = false
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(&x509.Certificate{Subject: pkix.Name{CommonName: "collector"}}))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
 // Ensure the handler returns results from a query (including nil results).
 func TestHandler_Query(t *testing.T) {
 	h := NewHandler(false)
@@ -112,6 +301,13 @@ func TestHandler_Query_Auth(t *testing.T) {
 		return h.MetaClient.User(u)
 	}
 
+	h.MetaClient.AuthenticateTokenFn = func(token string) (meta.User, error) {
+		if token != "0123.abcd" {
+			return nil, meta.ErrAuthenticate
+		}
+		return h.MetaClient.User("user1")
+	}
+
 	// Set mock query authorizer for handler to use.
 	h.QueryAuthorizer.AuthorizeQueryFn = func(u meta.User, query *influxql.Query, database string) error {
 		return nil
@@ -217,6 +413,27 @@ func TestHandler_Query_Auth(t *testing.T) {
 		t.Fatalf("unexpected body: %s", body)
 	}
 
+	// Test the handler with a valid API token.
+	req = MustNewJSONRequest("GET", "/query?db=foo&q=SELECT+*+FROM+bar", nil)
+	req.Header.Set("Authorization", "Token 0123.abcd")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if body := strings.TrimSpace(w.Body.String()); body != `{"results":[{"statement_id":1,"series":[{"name":"series0"}]},{"statement_id":2,"series":[{"name":"series1"}]}]}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
+	// Test the handler with an invalid API token.
+	req.Header.Set("Authorization", "Token 0123.efgh")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if body := strings.TrimSpace(w.Body.String()); body != `{"error":"authorization failed"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
 	// Test the handler with valid user and password in the url and invalid in
 	// basic auth (prioritize url).
 	w = httptest.NewRecorder()
@@ -230,6 +447,41 @@ func TestHandler_Query_Auth(t *testing.T) {
 	}
 }
 
+// Ensure the handler records failed authentications and unauthorized statements.
+func TestHandler_Query_Audit(t *testing.T) {
+	h := NewHandler(true)
+	h.MetaClient.AdminUserExistsFn = func() bool { return true }
+	h.MetaClient.AuthenticateFn = func(u, p string) (meta.User, error) {
+		if p != "abcd" {
+			return nil, meta.ErrAuthenticate
+		}
+		return &meta.UserInfo{Name: u}, nil
+	}
+	h.QueryAuthorizer.AuthorizeQueryFn = func(u meta.User, query *influxql.Query, database string) error {
+		return &meta.ErrAuthorize{Query: query, User: u.ID(), Database: database, Message: "denied"}
+	}
+
+	var auditor HandlerAuditor
+	h.Handler.Auditor = &auditor
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/query?u=user1&p=efgh&q=DROP+DATABASE+db0", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if len(auditor.Records) != 1 || auditor.Records[0] != "user1: authorization failed" {
+		t.Fatalf("unexpected records: %v", auditor.Records)
+	}
+
+	auditor.Records = nil
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/query?u=user1&p=abcd&q=DROP+DATABASE+db0", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if len(auditor.Records) != 1 || !strings.HasPrefix(auditor.Records[0], "user1: DROP DATABASE db0: ") {
+		t.Fatalf("unexpected records: %v", auditor.Records)
+	}
+}
+
 // Ensure the handler returns results from a query (including nil results).
 func TestHandler_QueryRegex(t *testing.T) {
 	h := NewHandler(false)
@@ -681,6 +933,409 @@ func TestHandler_Write_NegativeMaxBodySize(t *testing.T) {
 	}
 }
 
+// Ensure writes to a read-only database return 423 Locked.
+func TestHandler_Write_ReadOnly(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.PointsWriter.WritePointsFn = func(database, _ string, _ models.ConsistencyLevel, _ meta.User, _ []models.Point) error {
+		return influxdb.ErrReadOnly(database, "")
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/write?db=foo", strings.NewReader(`foo n=1`)))
+	if w.Code != http.StatusLocked {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if body := strings.TrimSpace(w.Body.String()); body != `{"error":"database is read only: foo"}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+// Ensure points can be written in the JSON format.
+func TestHandler_Write_JSON(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	var points []models.Point
+	h.PointsWriter.WritePointsFn = func(_, _ string, _ models.ConsistencyLevel, _ meta.User, pts []models.Point) error {
+		points = pts
+		return nil
+	}
+
+	body := `[
+		{"measurement": "cpu", "tags": {"host": "a"}, "fields": {"value": 1.5, "count": {"integer": 2}, "big": {"integer": "9223372036854775807"}, "ok": true, "msg": "x y"}, "time": 1},
+		{"measurement": "cpu", "fields": {"value": 2}, "time": "1970-01-01T00:00:02Z"},
+		{"measurement": "cpu", "fields": {"value": 3}}
+	]`
+	req := MustNewRequest("POST", "/write?db=foo&precision=s", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if len(points) != 3 {
+		t.Fatalf("unexpected number of points: %d", len(points))
+	}
+
+	if got, exp := points[0].String(), `cpu,host=a big=9223372036854775807i,count=2i,msg="x y",ok=true,value=1.5 1000000000`; got != exp {
+		t.Fatalf("unexpected point:\n\nexp=%s\n\ngot=%s", exp, got)
+	} else if got, exp := points[1].String(), `cpu value=2 2000000000`; got != exp {
+		t.Fatalf("unexpected point:\n\nexp=%s\n\ngot=%s", exp, got)
+	} else if fields, err := points[1].Fields(); err != nil {
+		t.Fatal(err)
+	} else if _, ok := fields["value"].(float64); !ok {
+		t.Fatalf("unexpected type of a number without fraction: %T", fields["value"])
+	} else if ts := points[2].UnixNano(); ts%int64(time.Second) != 0 || ts == 0 {
+		t.Fatalf("unexpected default time: %d", ts)
+	}
+
+	// Invalid points are rejected with their position in the array.
+	body = `[{"measurement": "cpu", "fields": {"value": 1}}, {"measurement": "cpu", "fields": {}}, {"fields": {"value": 1}}, {"measurement": "cpu", "fields": {"value": {"integer": 1.5}}}]`
+	req = MustNewRequest("POST", "/write?db=foo", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if !strings.Contains(w.Body.String(), `"lines":[{"line":2,"reason":"parse_error","error":"missing fields"},{"line":3,"reason":"parse_error","error":"missing measurement"},`+
+		`{"line":4,"reason":"parse_error","error":"invalid value for field value: unable to parse integer 1.5: strconv.ParseInt: parsing \"1.5\": invalid syntax"}]`) {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+
+	req = MustNewRequest("POST", "/write?db=foo", strings.NewReader(`{"measurement": "cpu"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if body := strings.TrimSpace(w.Body.String()); !strings.HasPrefix(body, `{"error":"unable to parse JSON points: `) {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+// Ensure points can be written in the CSV format.
+func TestHandler_Write_CSV(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	var points []models.Point
+	h.PointsWriter.WritePointsFn = func(_, _ string, _ models.ConsistencyLevel, _ meta.User, pts []models.Point) error {
+		points = pts
+		return nil
+	}
+
+	body := "\xef\xbb\xbfname|measurement,host|tag,value|float,count|integer,ok|boolean,msg|string,note|ignore,time|time\n" +
+		"cpu,a,1.5,2,true,\"x, y\",skip,1\n" +
+		"cpu,,2,,,,,1970-01-01T00:00:02Z\n" +
+		"cpu,b,NaN,,,,,3\n" +
+		"cpu,b,3,,maybe,,,3\n" +
+		"cpu,b,3\n"
+	req := MustNewRequest("POST", "/write?db=foo&precision=s", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got, exp := strings.TrimSpace(w.Body.String()), `{"error":"partial write: `+
+		`unable to parse 'cpu,b,NaN,,,,,3': invalid float for field value: NaN\n`+
+		`unable to parse 'cpu,b,3,,maybe,,,3': invalid boolean\n`+
+		`unable to parse 'cpu,b,3': expected 8 columns, got 3 dropped=0","dropped":3,"reasons":{"parse_error":3},"lines":[`+
+		`{"line":4,"reason":"parse_error","error":"invalid float for field value: NaN"},`+
+		`{"line":5,"reason":"parse_error","error":"invalid boolean"},`+
+		`{"line":6,"reason":"parse_error","error":"expected 8 columns, got 3"}]}`; got != exp {
+		t.Fatalf("unexpected body:\n\nexp=%s\n\ngot=%s", exp, got)
+	}
+
+	var got []string
+	for _, p := range points {
+		got = append(got, p.String())
+	}
+	if exp := []string{
+		`cpu,host=a count=2i,msg="x, y",ok=true,value=1.5 1000000000`,
+		`cpu value=2 2000000000`,
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points:\n\nexp=%v\n\ngot=%v", exp, got)
+	}
+
+	// Columns must be annotated with their type.
+	req = MustNewRequest("POST", "/write?db=foo", strings.NewReader("name|measurement,value\ncpu,1\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if body := strings.TrimSpace(w.Body.String()); body != `{"error":"missing type annotation for column \"value\""}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+// Ensure the lines rejected from a write are returned with the reason each
+// line was rejected.
+func TestHandler_Write_RejectedLines(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.PointsWriter.WritePointsFn = func(database, _ string, _ models.ConsistencyLevel, _ meta.User, points []models.Point) error {
+		return tsdb.PartialWriteError{
+			Reason:  "points beyond retention policy",
+			Dropped: 2,
+			DroppedPoints: []tsdb.DroppedPoint{
+				{Point: points[2], Reason: tsdb.DropReason{Code: tsdb.DropReasonOutsideRetention, Message: "point beyond retention policy"}},
+				{Point: points[1], Reason: tsdb.DropReason{Code: tsdb.DropReasonFieldTypeConflict, Message: "field type conflict"}},
+			},
+		}
+	}
+
+	body := "cpu value=1 1\ncpu value= 2\ncpu,host=b value=3 3\nmem value=4 4\n"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/write?db=foo", strings.NewReader(body)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got, exp := strings.TrimSpace(w.Body.String()), `{"error":"partial write: points beyond retention policy dropped=2","dropped":3,`+
+		`"reasons":{"field_type_conflict":1,"outside_retention":1,"parse_error":1},"lines":[`+
+		`{"line":2,"reason":"parse_error","error":"missing field value"},`+
+		`{"line":3,"reason":"field_type_conflict","error":"field type conflict"},`+
+		`{"line":4,"reason":"outside_retention","error":"point beyond retention policy"}]}`; got != exp {
+		t.Fatalf("unexpected body:\n\nexp=%s\n\ngot=%s", exp, got)
+	}
+
+	// The rejected lines follow the error in CSV responses.
+	req := MustNewRequest("POST", "/write?db=foo", strings.NewReader(body))
+	req.Header.Set("Accept", "text/csv")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got, exp := w.Body.String(), "error\npartial write: points beyond retention policy dropped=2\n\n"+
+		"line,reason,error\n"+
+		"2,parse_error,missing field value\n"+
+		"3,field_type_conflict,field type conflict\n"+
+		"4,outside_retention,point beyond retention policy\n"; got != exp {
+		t.Fatalf("unexpected body:\n\nexp=%s\n\ngot=%s", exp, got)
+	}
+
+	// Points to unauthorized series reject the whole write.
+	h.PointsWriter.WritePointsFn = func(database, _ string, _ models.ConsistencyLevel, _ meta.User, points []models.Point) error {
+		return &coordinator.SeriesAuthorizationError{
+			Database: database,
+			DroppedPoints: []tsdb.DroppedPoint{
+				{Point: points[1], Reason: tsdb.DropReason{Code: tsdb.DropReasonUnauthorizedSeries, Message: "user1 not authorized to write series mem to foo"}},
+			},
+		}
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/write?db=foo", strings.NewReader("cpu value=1 1\nmem value=2 2")))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got, exp := strings.TrimSpace(w.Body.String()), `{"error":"user1 not authorized to write series mem to foo","dropped":2,`+
+		`"reasons":{"unauthorized_series":1},"lines":[`+
+		`{"line":2,"reason":"unauthorized_series","error":"user1 not authorized to write series mem to foo"}]}`; got != exp {
+		t.Fatalf("unexpected body:\n\nexp=%s\n\ngot=%s", exp, got)
+	}
+}
+
+// Ensure samples sent with the Prometheus remote write protocol are written.
+func TestHandler_PromWrite(t *testing.T) {
+	req := &remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			{
+				Labels: []*remote.LabelPair{
+					{Name: "host", Value: "a"},
+					{Name: "__name__", Value: "cpu"},
+				},
+				Samples: []*remote.Sample{
+					{Value: 1.5, Timestamp: 1000},
+					{Value: math.NaN(), Timestamp: 2000},
+				},
+			},
+		},
+	}
+
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	var points []models.Point
+	h.PointsWriter.WritePointsFn = func(database, retentionPolicy string, _ models.ConsistencyLevel, _ meta.User, pts []models.Point) error {
+		if database != "foo" || retentionPolicy != "bar" {
+			t.Fatalf("unexpected target: %s.%s", database, retentionPolicy)
+		}
+		points = pts
+		return nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/write?db=foo&rp=bar", bytes.NewReader(MustEncodePromRequest(req))))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if len(points) != 1 || points[0].String() != `cpu,host=a value=1.5 1000000000` {
+		t.Fatalf("unexpected points: %v", points)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/write?db=foo", strings.NewReader("not snappy")))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+
+	// Dropped samples are returned as rejected lines without line numbers.
+	h.PointsWriter.WritePointsFn = func(_, _ string, _ models.ConsistencyLevel, _ meta.User, pts []models.Point) error {
+		return tsdb.PartialWriteError{
+			Reason:  "points beyond retention policy",
+			Dropped: 1,
+			DroppedPoints: []tsdb.DroppedPoint{
+				{Point: pts[0], Reason: tsdb.DropReason{Code: tsdb.DropReasonOutsideRetention, Message: "point beyond retention policy"}},
+			},
+		}
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/write?db=foo", bytes.NewReader(MustEncodePromRequest(req))))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got, exp := strings.TrimSpace(w.Body.String()), `{"error":"partial write: points beyond retention policy dropped=1","dropped":1,`+
+		`"reasons":{"outside_retention":1},"lines":[`+
+		`{"reason":"outside_retention","error":"point beyond retention policy"}]}`; got != exp {
+		t.Fatalf("unexpected body:\n\nexp=%s\n\ngot=%s", exp, got)
+	}
+}
+
+// Ensure a Prometheus write that decodes to more than max-body-size is rejected
+// before it is decoded.
+func TestHandler_PromWrite_DecodedBodyTooLarge(t *testing.T) {
+	h := NewHandler(false)
+	h.Config.MaxBodySize = 4096
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.PointsWriter.WritePointsFn = func(_, _ string, _ models.ConsistencyLevel, _ meta.User, _ []models.Point) error {
+		t.Fatal("unexpected write")
+		return nil
+	}
+
+	// Zeroes compress to a body well under the limit.
+	body := snappy.Encode(nil, make([]byte, 64*1024))
+	if len(body) > h.Config.MaxBodySize {
+		t.Fatalf("compressed body too large: %d", len(body))
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/write?db=foo", bytes.NewReader(body)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// Ensure series are queried with the Prometheus remote read protocol.
+func TestHandler_PromRead(t *testing.T) {
+	req := &remote.ReadRequest{
+		Queries: []*remote.Query{{
+			StartTimestampMs: 1000,
+			EndTimestampMs:   2000,
+			Matchers: []*remote.LabelMatcher{
+				{Type: remote.MatchType_EQUAL, Name: "__name__", Value: "cpu"},
+				{Type: remote.MatchType_REGEX_MATCH, Name: "host", Value: "a|b"},
+			},
+		}},
+	}
+
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{}
+	}
+	h.StatementExecutor.ExecuteStatementFn = func(stmt influxql.Statement, ctx influxql.ExecutionContext) error {
+		if s := stmt.String(); s != `SELECT value FROM foo..cpu WHERE time >= '1970-01-01T00:00:01Z' AND time <= '1970-01-01T00:00:02Z' AND host =~ /^(?:a|b)$/ GROUP BY *` {
+			t.Fatalf("unexpected statement: %s", s)
+		}
+		ctx.Results <- &influxql.Result{StatementID: 0, Series: models.Rows([]*models.Row{{
+			Name:    "cpu",
+			Tags:    map[string]string{"host": "a"},
+			Columns: []string{"time", "value"},
+			Values:  [][]interface{}{{time.Unix(1, 0).UTC(), 1.5}},
+		}})}
+		return nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/read?db=foo", bytes.NewReader(MustEncodePromRequest(req))))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	} else if enc := w.Header().Get("Content-Encoding"); enc != "snappy" {
+		t.Fatalf("unexpected content encoding: %s", enc)
+	}
+
+	data, err := snappy.Decode(nil, w.Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp remote.ReadResponse
+	if err := proto.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	exp := remote.ReadResponse{
+		Results: []*remote.QueryResult{{
+			Timeseries: []*remote.TimeSeries{{
+				Labels:  []*remote.LabelPair{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "a"}},
+				Samples: []*remote.Sample{{Value: 1.5, Timestamp: 1000}},
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(resp, exp) {
+		t.Fatalf("unexpected response: %s", resp.String())
+	}
+}
+
+// Ensure internal statistics are served in the Prometheus text format.
+func TestHandler_Metrics(t *testing.T) {
+	h := NewHandler(false)
+	h.Handler.Monitor = &HandlerMonitor{
+		StatisticsFn: func(tags map[string]string) ([]*monitor.Statistic, error) {
+			return []*monitor.Statistic{
+				{Statistic: models.Statistic{
+					Name:   "httpd",
+					Tags:   map[string]string{"bind": ":8086"},
+					Values: map[string]interface{}{"req": int64(10), "reqActive": int64(1)},
+				}},
+				{Statistic: models.Statistic{
+					Name:   "tsm1_wal",
+					Tags:   map[string]string{"path": `C:\wal\"1"`, "database": "db0"},
+					Values: map[string]interface{}{"WALCompactionTimeMs": 1.5, "name": "ignored"},
+				}},
+				{Statistic: models.Statistic{
+					Name:   "queryExecutor",
+					Values: map[string]interface{}{"queriesActive": int64(0)},
+				}},
+			}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if ct := w.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Fatalf("unexpected content type: %s", ct)
+	}
+
+	exp := `# TYPE influxdb_httpd_req counter
+influxdb_httpd_req{bind=":8086"} 10
+# TYPE influxdb_httpd_req_active gauge
+influxdb_httpd_req_active{bind=":8086"} 1
+# TYPE influxdb_query_executor_queries_active gauge
+influxdb_query_executor_queries_active 0
+# TYPE influxdb_tsm1_wal_wal_compaction_time_ms counter
+influxdb_tsm1_wal_wal_compaction_time_ms{database="db0",path="C:\\wal\\\"1\""} 1.5
+`
+	if body := w.Body.String(); body != exp {
+		t.Fatalf("unexpected body:\n\nexp=%s\n\ngot=%s", exp, body)
+	}
+}
+
 // Ensure X-Forwarded-For header writes the correct log message.
 func TestHandler_XForwardedFor(t *testing.T) {
 	var buf bytes.Buffer
@@ -704,6 +1359,7 @@ type Handler struct {
 	MetaClient        *internal.MetaClientMock
 	StatementExecutor HandlerStatementExecutor
 	QueryAuthorizer   HandlerQueryAuthorizer
+	WriteAuthorizer   HandlerWriteAuthorizer
 	PointsWriter      HandlerPointsWriter
 }
 
@@ -723,6 +1379,13 @@ func NewHandler(requireAuthentication bool) *Handler {
 	h.Handler.QueryExecutor = influxql.NewQueryExecutor()
 	h.Handler.QueryExecutor.StatementExecutor = &h.StatementExecutor
 	h.Handler.QueryAuthorizer = &h.QueryAuthorizer
+	h.Handler.WriteAuthorizer = &h.WriteAuthorizer
+	h.WriteAuthorizer.AuthorizeWriteFn = func(u meta.User, database string) error {
+		if !u.AuthorizeDatabase(influxql.WritePrivilege, database) {
+			return errors.New("not authorized")
+		}
+		return nil
+	}
 	h.Handler.PointsWriter = &h.PointsWriter
 	h.Handler.Version = "0.0.0"
 	h.Handler.BuildType = "OSS"
@@ -738,6 +1401,42 @@ func (e *HandlerStatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx
 	return e.ExecuteStatementFn(stmt, ctx)
 }
 
+// HandlerAuditor is a mock implementation of Handler.Auditor.
+type HandlerAuditor struct {
+	Records []string
+}
+
+func (a *HandlerAuditor) RecordAuthenticationFailure(username, addr, reason string) {
+	a.Records = append(a.Records, fmt.Sprintf("%s: %s", username, reason))
+}
+
+func (a *HandlerAuditor) RecordStatement(stmt influxql.Statement, user, addr, database string, err error) {
+	a.Records = append(a.Records, fmt.Sprintf("%s: %s: %v", user, stmt, err))
+}
+
+// HandlerAuthenticationProvider is a mock implementation of Handler.AuthenticationProvider.
+type HandlerAuthenticationProvider struct {
+	AuthenticateFn func(username, password string) (meta.User, error)
+}
+
+func (p *HandlerAuthenticationProvider) Authenticate(username, password string) (meta.User, error) {
+	return p.AuthenticateFn(username, password)
+}
+
+// HandlerMonitor is a mock implementation of Handler.Monitor.
+type HandlerMonitor struct {
+	StatisticsFn  func(tags map[string]string) ([]*monitor.Statistic, error)
+	DiagnosticsFn func() (map[string]*diagnostics.Diagnostics, error)
+}
+
+func (m *HandlerMonitor) Statistics(tags map[string]string) ([]*monitor.Statistic, error) {
+	return m.StatisticsFn(tags)
+}
+
+func (m *HandlerMonitor) Diagnostics() (map[string]*diagnostics.Diagnostics, error) {
+	return m.DiagnosticsFn()
+}
+
 // HandlerQueryAuthorizer is a mock implementation of Handler.QueryAuthorizer.
 type HandlerQueryAuthorizer struct {
 	AuthorizeQueryFn func(u meta.User, query *influxql.Query, database string) error
@@ -747,6 +1446,15 @@ func (a *HandlerQueryAuthorizer) AuthorizeQuery(u meta.User, query *influxql.Que
 	return a.AuthorizeQueryFn(u, query, database)
 }
 
+// HandlerWriteAuthorizer is a mock implementation of Handler.WriteAuthorizer.
+type HandlerWriteAuthorizer struct {
+	AuthorizeWriteFn func(u meta.User, database string) error
+}
+
+func (a *HandlerWriteAuthorizer) AuthorizeWrite(u meta.User, database string) error {
+	return a.AuthorizeWriteFn(u, database)
+}
+
 type HandlerPointsWriter struct {
 	WritePointsFn func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, user meta.User, points []models.Point) error
 }
@@ -771,6 +1479,16 @@ func MustNewJSONRequest(method, urlStr string, body io.Reader) *http.Request {
 	return r
 }
 
+// MustEncodePromRequest returns a snappy-compressed protobuf message of the
+// Prometheus remote storage protocol. Panic on error.
+func MustEncodePromRequest(pb proto.Message) []byte {
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		panic(err)
+	}
+	return snappy.Encode(nil, data)
+}
+
 // MustJWTToken returns a new JWT token and signed string or panics trying.
 func MustJWTToken(username, secret string, expired bool) (*jwt.Token, string) {
 	token := jwt.New(jwt.GetSigningMethod("HS512"))
@@ -0,0 +1,193 @@
+package httpd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/influxdata/influxdb/monitor"
+)
+
+// metricsNamespace prefixes the names of all metrics served on /metrics.
+const metricsNamespace = "influxdb"
+
+// gaugeFields are the statistic fields that hold a current level rather than
+// an ever increasing count. Fields ending with "Active" are gauges as well.
+var gaugeFields = map[string]struct{}{
+	// Storage engine.
+	"cacheAgeMs":              {},
+	"diskBytes":               {},
+	"memBytes":                {},
+	"snapshotCount":           {},
+	"numFiles":                {},
+	"currentSegmentDiskBytes": {},
+	"oldSegmentsDiskBytes":    {},
+	"tombstones":              {},
+	"tombstoneFiles":          {},
+	"tombstoneBytes":          {},
+
+	// Databases.
+	"numMeasurements": {},
+	"numSeries":       {},
+
+	// Go runtime.
+	"Alloc":        {},
+	"Sys":          {},
+	"HeapAlloc":    {},
+	"HeapSys":      {},
+	"HeapIdle":     {},
+	"HeapInUse":    {},
+	"HeapReleased": {},
+	"HeapObjects":  {},
+	"NumGoroutine": {},
+}
+
+// serveMetrics serves the internal statistics in the Prometheus text
+// exposition format.
+func (h *Handler) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.Monitor.Statistics(nil)
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := writeMetrics(w, stats); err != nil {
+		// The response has already started, so the error can only be logged.
+		h.Logger.Info(fmt.Sprintf("Metrics handler unable to write metrics: %s", err))
+	}
+}
+
+// metricFamily is the samples of a metric with the same name and type.
+type metricFamily struct {
+	typ     string
+	samples []string
+}
+
+// writeMetrics writes statistics in the Prometheus text exposition format.
+// Every numeric value of a statistic is a metric named after the statistic
+// and the value's key, with the statistic's tags as labels.
+func writeMetrics(w io.Writer, stats []*monitor.Statistic) error {
+	families := make(map[string]*metricFamily)
+	for _, s := range stats {
+		labels := formatLabels(s.Tags)
+		for k, v := range s.Values {
+			value, ok := formatMetricValue(v)
+			if !ok {
+				continue
+			}
+
+			name := metricsNamespace + "_" + metricName(s.Name) + "_" + metricName(k)
+			f := families[name]
+			if f == nil {
+				f = &metricFamily{typ: "counter"}
+				if _, ok := gaugeFields[k]; ok || strings.HasSuffix(k, "Active") {
+					f.typ = "gauge"
+				}
+				families[name] = f
+			}
+			f.samples = append(f.samples, name+labels+" "+value)
+		}
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		f := families[name]
+		sort.Strings(f.samples)
+
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, f.typ)
+		for _, sample := range f.samples {
+			bw.WriteString(sample)
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// metricName converts a camel-cased statistic name or key to a snake-cased
+// metric name, e.g. "queryReqDurationNs" to "query_req_duration_ns" and
+// "WALCompactionTimeMs" to "wal_compaction_time_ms". Characters that aren't
+// allowed in metric names are replaced with underscores.
+func metricName(s string) string {
+	runes := []rune(s)
+	var buf []rune
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				buf = append(buf, '_')
+			}
+		}
+
+		switch {
+		case r >= 'A' && r <= 'Z':
+			buf = append(buf, unicode.ToLower(r))
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			buf = append(buf, r)
+		default:
+			buf = append(buf, '_')
+		}
+	}
+	return string(buf)
+}
+
+// formatLabels returns tags as the sorted label set of a sample.
+func formatLabels(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = labelName(k) + `="` + labelValueReplacer.Replace(tags[k]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelName replaces the characters that aren't allowed in label names with
+// underscores.
+func labelName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// labelValueReplacer escapes label values in the text exposition format.
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// formatMetricValue formats a statistic value as a sample value. It returns
+// false if the value isn't numeric.
+func formatMetricValue(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case int:
+		return strconv.Itoa(v), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	}
+	return "", false
+}
@@ -106,6 +106,20 @@ func (w *csvFormatter) WriteResponse(resp Response) (n int, err error) {
 	if resp.Err != nil {
 		csv.Write([]string{"error"})
 		csv.Write([]string{resp.Err.Error()})
+
+		// The lines rejected from a write follow as a second table.
+		if err, ok := resp.Err.(*rejectedLinesError); ok && len(err.Lines) > 0 {
+			csv.Flush()
+			io.WriteString(w, "\n")
+			csv.Write([]string{"line", "reason", "error"})
+			for _, l := range err.Lines {
+				var line string
+				if l.Line > 0 {
+					line = strconv.Itoa(l.Line)
+				}
+				csv.Write([]string{line, l.Reason, l.Err})
+			}
+		}
 		csv.Flush()
 		return n, csv.Error()
 	}
@@ -3,7 +3,9 @@ package httpd // import "github.com/influxdata/influxdb/services/httpd"
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
@@ -24,6 +26,8 @@ const (
 	statWriteRequest                 = "writeReq"             // Number of write requests serverd.
 	statPingRequest                  = "pingReq"              // Number of ping requests served.
 	statStatusRequest                = "statusReq"            // Number of status requests served.
+	statPromWriteRequest             = "promWriteReq"         // Number of Prometheus remote write requests served.
+	statPromReadRequest              = "promReadReq"          // Number of Prometheus remote read requests served.
 	statWriteRequestBytesReceived    = "writeReqBytes"        // Sum of all bytes in write requests.
 	statQueryRequestBytesTransmitted = "queryRespBytes"       // Sum of all bytes returned in query reponses.
 	statPointsWrittenOK              = "pointsWrittenOK"      // Number of points written OK.
@@ -48,7 +52,11 @@ type Service struct {
 	cert  string
 	key   string
 	limit int
-	err   chan error
+
+	// Client certificates are verified against the CA bundle in clientCA.
+	clientCA           string
+	clientCertRequired bool
+	err                chan error
 
 	unixSocket         bool
 	bindSocket         string
@@ -62,16 +70,18 @@ type Service struct {
 // NewService returns a new instance of Service.
 func NewService(c Config) *Service {
 	s := &Service{
-		addr:       c.BindAddress,
-		https:      c.HTTPSEnabled,
-		cert:       c.HTTPSCertificate,
-		key:        c.HTTPSPrivateKey,
-		limit:      c.MaxConnectionLimit,
-		err:        make(chan error),
-		unixSocket: c.UnixSocketEnabled,
-		bindSocket: c.BindSocket,
-		Handler:    NewHandler(c),
-		Logger:     zap.New(zap.NullEncoder()),
+		addr:               c.BindAddress,
+		https:              c.HTTPSEnabled,
+		cert:               c.HTTPSCertificate,
+		key:                c.HTTPSPrivateKey,
+		clientCA:           c.HTTPSClientCA,
+		clientCertRequired: c.HTTPSClientCertRequired,
+		limit:              c.MaxConnectionLimit,
+		err:                make(chan error),
+		unixSocket:         c.UnixSocketEnabled,
+		bindSocket:         c.BindSocket,
+		Handler:            NewHandler(c),
+		Logger:             zap.New(zap.NullEncoder()),
 	}
 	if s.key == "" {
 		s.key = s.cert
@@ -85,6 +95,15 @@ func (s *Service) Open() error {
 	s.Logger.Info("Starting HTTP service")
 	s.Logger.Info(fmt.Sprint("Authentication enabled:", s.Handler.Config.AuthEnabled))
 
+	// Set up an external authentication provider, if configured.
+	if s.Handler.AuthenticationProvider == nil {
+		provider, err := NewAuthenticationProvider(*s.Handler.Config)
+		if err != nil {
+			return err
+		}
+		s.Handler.AuthenticationProvider = provider
+	}
+
 	// Open listener.
 	if s.https {
 		cert, err := tls.LoadX509KeyPair(s.cert, s.key)
@@ -92,9 +111,28 @@ func (s *Service) Open() error {
 			return err
 		}
 
-		listener, err := tls.Listen("tcp", s.addr, &tls.Config{
+		config := &tls.Config{
 			Certificates: []tls.Certificate{cert},
-		})
+		}
+
+		// Verify client certificates against the client CA bundle.
+		if s.clientCA != "" {
+			pem, err := ioutil.ReadFile(s.clientCA)
+			if err != nil {
+				return err
+			}
+			config.ClientCAs = x509.NewCertPool()
+			if !config.ClientCAs.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("no certificates found in %s", s.clientCA)
+			}
+
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+			if s.clientCertRequired {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+		}
+
+		listener, err := tls.Listen("tcp", s.addr, config)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,329 @@
+package httpd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+// jsonPoint is a point of a write in the JSON format.
+type jsonPoint struct {
+	Measurement string                 `json:"measurement"`
+	Tags        map[string]string      `json:"tags"`
+	Fields      map[string]interface{} `json:"fields"`
+	Time        interface{}            `json:"time"`
+}
+
+// parseJSONPoints parses a JSON array of points. As in line protocol, numbers
+// are float fields and integer fields are marked explicitly, as an object like
+// {"integer": 42}. The integer may be a number or a string, since not all JSON
+// producers can represent every int64 as a number. The line number of a point
+// is its position in the array, starting at 1.
+func parseJSONPoints(buf []byte, defaultTime time.Time, precision string) ([]models.Point, []int, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(buf, &entries); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse JSON points: %s", err)
+	}
+
+	points := make([]models.Point, 0, len(entries))
+	lines := make([]int, 0, len(entries))
+	var failed models.ParseErrors
+	for i, entry := range entries {
+		pt, err := jsonToPoint(entry, defaultTime, precision)
+		if err != nil {
+			failed = append(failed, &models.LineError{Line: i + 1, Text: string(entry), Err: err})
+			continue
+		}
+		points = append(points, pt)
+		lines = append(lines, i+1)
+	}
+
+	if len(failed) > 0 {
+		return points, lines, failed
+	}
+	return points, lines, nil
+}
+
+// jsonToPoint converts an entry of a JSON write to a point.
+func jsonToPoint(entry json.RawMessage, defaultTime time.Time, precision string) (models.Point, error) {
+	var jp jsonPoint
+	dec := json.NewDecoder(bytes.NewReader(entry))
+	dec.UseNumber()
+	if err := dec.Decode(&jp); err != nil {
+		return nil, err
+	}
+
+	for k, v := range jp.Tags {
+		if k == "" {
+			return nil, errors.New("missing tag key")
+		} else if v == "" {
+			return nil, errors.New("missing tag value")
+		}
+	}
+
+	fields := make(models.Fields, len(jp.Fields))
+	for k, v := range jp.Fields {
+		switch v := v.(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid float for field %s: %s", k, v)
+			}
+			fields[k] = f
+		case map[string]interface{}:
+			n, err := jsonInteger(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for field %s: %s", k, err)
+			}
+			fields[k] = n
+		case string, bool:
+			fields[k] = v
+		case nil:
+			return nil, errors.New("missing field value")
+		default:
+			return nil, fmt.Errorf("invalid value for field %s: %v", k, v)
+		}
+	}
+
+	t := defaultTime
+	switch v := jp.Time.(type) {
+	case nil:
+	case json.Number:
+		ts, err := parseTimestamp(string(v), precision)
+		if err != nil {
+			return nil, err
+		}
+		t = ts
+	case string:
+		ts, err := parseTimestamp(v, precision)
+		if err != nil {
+			return nil, err
+		}
+		t = ts
+	default:
+		return nil, errors.New("bad timestamp")
+	}
+
+	return newWritePoint(jp.Measurement, jp.Tags, fields, t)
+}
+
+// jsonInteger returns the value of an integer field written as an object like
+// {"integer": 42} or {"integer": "42"}.
+func jsonInteger(v map[string]interface{}) (int64, error) {
+	i, ok := v["integer"]
+	if !ok || len(v) != 1 {
+		return 0, errors.New(`expected an object with a single "integer" key`)
+	}
+
+	var s string
+	switch i := i.(type) {
+	case json.Number:
+		s = string(i)
+	case string:
+		s = i
+	default:
+		return 0, fmt.Errorf("invalid integer: %v", i)
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse integer %s: %s", s, err)
+	}
+	return n, nil
+}
+
+// Types of the columns of a write in the CSV format.
+const (
+	csvMeasurement = "measurement"
+	csvTag         = "tag"
+	csvTime        = "time"
+	csvFloat       = "float"
+	csvInteger     = "integer"
+	csvBoolean     = "boolean"
+	csvString      = "string"
+	csvIgnore      = "ignore"
+)
+
+// csvColumn is a column of a write in the CSV format.
+type csvColumn struct {
+	name string
+	typ  string
+}
+
+// parseCSVPoints parses points from CSV. The header row annotates the name of
+// each column with its type after a '|', e.g. "host|tag" or "value|float".
+// The types are measurement, tag, time, ignore and the field types float,
+// integer, boolean and string. Empty tags and fields are left out of a point
+// and a point without a time is written at the time of the write. The line
+// number of a point is its row, counting the header as row 1.
+func parseCSVPoints(buf []byte, defaultTime time.Time, precision string) ([]models.Point, []int, error) {
+	// Spreadsheets often start their CSV exports with a byte order mark.
+	buf = bytes.TrimPrefix(buf, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(buf))
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse CSV header: %s", err)
+	}
+
+	columns, err := parseCSVHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		points []models.Point
+		lines  []int
+		failed models.ParseErrors
+	)
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if perr, ok := err.(*csv.ParseError); ok && perr.Err == csv.ErrFieldCount {
+			failed = append(failed, &models.LineError{Line: line, Text: strings.Join(record, ","), Err: fmt.Errorf("expected %d columns, got %d", len(columns), len(record))})
+			continue
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse CSV: %s", err)
+		}
+
+		pt, err := csvRecordToPoint(columns, record, defaultTime, precision)
+		if err != nil {
+			failed = append(failed, &models.LineError{Line: line, Text: strings.Join(record, ","), Err: err})
+			continue
+		}
+		points = append(points, pt)
+		lines = append(lines, line)
+	}
+
+	if len(failed) > 0 {
+		return points, lines, failed
+	}
+	return points, lines, nil
+}
+
+// parseCSVHeader returns the columns of a CSV header.
+func parseCSVHeader(header []string) ([]csvColumn, error) {
+	columns := make([]csvColumn, len(header))
+	var measurement bool
+	for i, h := range header {
+		j := strings.LastIndex(h, "|")
+		if j == -1 {
+			return nil, fmt.Errorf("missing type annotation for column %q", h)
+		}
+
+		c := csvColumn{name: h[:j], typ: h[j+1:]}
+		switch c.typ {
+		case csvMeasurement:
+			measurement = true
+		case csvTag, csvFloat, csvInteger, csvBoolean, csvString:
+			if c.name == "" {
+				return nil, fmt.Errorf("missing name of %s column %d", c.typ, i+1)
+			}
+		case csvTime, csvIgnore:
+		default:
+			return nil, fmt.Errorf("unknown type annotation for column %q: %s", c.name, c.typ)
+		}
+		columns[i] = c
+	}
+
+	if !measurement {
+		return nil, errors.New("missing measurement column")
+	}
+	return columns, nil
+}
+
+// csvRecordToPoint converts a row of a CSV write to a point.
+func csvRecordToPoint(columns []csvColumn, record []string, defaultTime time.Time, precision string) (models.Point, error) {
+	var name string
+	tags := make(map[string]string)
+	fields := make(models.Fields)
+	t := defaultTime
+	for i, c := range columns {
+		v := record[i]
+		if v == "" {
+			continue
+		}
+
+		switch c.typ {
+		case csvMeasurement:
+			name = v
+		case csvTag:
+			tags[c.name] = v
+		case csvTime:
+			ts, err := parseTimestamp(v, precision)
+			if err != nil {
+				return nil, err
+			}
+			t = ts
+		case csvFloat:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("invalid float for field %s: %s", c.name, v)
+			}
+			fields[c.name] = f
+		case csvInteger:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse integer %s: %s", v, err)
+			}
+			fields[c.name] = n
+		case csvBoolean:
+			b, err := parseBoolean(v)
+			if err != nil {
+				return nil, err
+			}
+			fields[c.name] = b
+		case csvString:
+			fields[c.name] = v
+		}
+	}
+	return newWritePoint(name, tags, fields, t)
+}
+
+// parseBoolean parses a boolean written as in line protocol.
+func parseBoolean(s string) (bool, error) {
+	switch s {
+	case "t", "T", "true", "True", "TRUE":
+		return true, nil
+	case "f", "F", "false", "False", "FALSE":
+		return false, nil
+	}
+	return false, errors.New("invalid boolean")
+}
+
+// parseTimestamp parses a time written as an integer in the precision of the
+// write or as an RFC3339 string.
+func parseTimestamp(s, precision string) (time.Time, error) {
+	if ts, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return models.SafeCalcTime(ts, precision)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, errors.New("bad timestamp")
+	}
+	return t.UTC(), models.CheckTime(t)
+}
+
+// newWritePoint returns a new point, checking the point the way line
+// protocol is checked when it is parsed.
+func newWritePoint(name string, tags map[string]string, fields models.Fields, t time.Time) (models.Point, error) {
+	if name == "" {
+		return nil, errors.New("missing measurement")
+	} else if len(fields) == 0 {
+		return nil, errors.New("missing fields")
+	}
+	return models.NewPoint(name, models.NewTags(tags), fields, t)
+}
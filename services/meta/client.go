@@ -6,6 +6,8 @@ import (
 	"bytes"
 	crand "crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +37,14 @@ const (
 	// ShardGroupDeletedExpiration is the amount of time before a shard group info will be removed from cached
 	// data after it has been marked deleted (2 weeks).
 	ShardGroupDeletedExpiration = -2 * 7 * 24 * time.Hour
+
+	// TokenLastUsedInterval is the minimum amount of time between two
+	// updates of the time a token was last used.
+	TokenLastUsedInterval = time.Minute
+
+	// UserLastLoginInterval is the minimum amount of time between two
+	// updates of the time a user last logged in.
+	UserLastLoginInterval = time.Minute
 )
 
 var (
@@ -57,6 +68,17 @@ type Client struct {
 	// Authentication cache.
 	authCache map[string]authUser
 
+	// Failed logins of each user since its last login or lockout. Only the
+	// lockout is persisted, so failures don't each commit the meta data.
+	loginFailures map[string]int
+
+	// Password and login policies.
+	passwordPolicy   PasswordPolicy
+	passwordHistory  int
+	hashCost         int
+	loginMaxFailures int
+	loginLockout     time.Duration
+
 	path string
 
 	retentionAutoCreate bool
@@ -70,6 +92,11 @@ type authUser struct {
 
 // NewClient returns a new *Client.
 func NewClient(config *Config) *Client {
+	hashCost := config.PasswordHashCost
+	if hashCost == 0 {
+		hashCost = bcryptCost
+	}
+
 	return &Client{
 		cacheData: &Data{
 			ClusterID: uint64(rand.Int63()),
@@ -79,6 +106,12 @@ func NewClient(config *Config) *Client {
 		changed:             make(chan struct{}),
 		logger:              zap.New(zap.NullEncoder()),
 		authCache:           make(map[string]authUser, 0),
+		loginFailures:       make(map[string]int),
+		passwordPolicy:      NewPasswordPolicy(config),
+		passwordHistory:     config.PasswordHistory,
+		hashCost:            hashCost,
+		loginMaxFailures:    config.LoginMaxFailures,
+		loginLockout:        time.Duration(config.LoginLockoutDuration),
 		path:                config.Dir,
 		retentionAutoCreate: config.RetentionAutoCreate,
 	}
@@ -284,6 +317,24 @@ func (c *Client) DropDatabase(name string) error {
 	return nil
 }
 
+// UpdateDatabase updates an existing database.
+func (c *Client) UpdateDatabase(name string, du *DatabaseUpdate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.UpdateDatabase(name, du); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateRetentionPolicy creates a retention policy on the specified database.
 func (c *Client) CreateRetentionPolicy(database string, spec *RetentionPolicySpec, makeDefault bool) (*RetentionPolicyInfo, error) {
 	c.mu.Lock()
@@ -420,8 +471,12 @@ func (c *Client) CreateUser(name, password string, admin bool) (User, error) {
 		return u, nil
 	}
 
+	if err := c.passwordPolicy.Validate(password); err != nil {
+		return nil, err
+	}
+
 	// Hash the password before serializing it.
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), c.hashCost)
 	if err != nil {
 		return nil, err
 	}
@@ -439,24 +494,52 @@ func (c *Client) CreateUser(name, password string, admin bool) (User, error) {
 	return u, nil
 }
 
-// UpdateUser updates the password of an existing user.
+// UpdateUser updates the password of an existing user. The password must
+// follow the password policy and differ from the recent passwords of the user.
 func (c *Client) UpdateUser(name, password string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	data := c.cacheData.Clone()
 
+	u := data.user(name)
+	if u == nil {
+		return ErrUserNotFound
+	}
+
+	if err := c.passwordPolicy.Validate(password); err != nil {
+		return err
+	}
+
+	// The history includes the current password.
+	if c.passwordHistory > 0 {
+		hashes := append([]string{u.Hash}, u.PasswordHistory...)
+		if len(hashes) > c.passwordHistory {
+			hashes = hashes[:c.passwordHistory]
+		}
+		for _, h := range hashes {
+			if bcrypt.CompareHashAndPassword([]byte(h), []byte(password)) == nil {
+				return ErrPasswordReused
+			}
+		}
+	}
+
 	// Hash the password before serializing it.
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), c.hashCost)
 	if err != nil {
 		return err
 	}
 
-	if err := data.UpdateUser(name, string(hash)); err != nil {
+	history := c.passwordHistory - 1
+	if history < 0 {
+		history = 0
+	}
+	if err := data.SetPassword(name, string(hash), history); err != nil {
 		return err
 	}
 
 	delete(c.authCache, name)
+	delete(c.loginFailures, name)
 
 	if err := c.commit(data); err != nil {
 		return err
@@ -475,6 +558,7 @@ func (c *Client) DropUser(name string) error {
 	if err := data.DropUser(name); err != nil {
 		return err
 	}
+	delete(c.loginFailures, name)
 
 	if err := c.commit(data); err != nil {
 		return err
@@ -501,6 +585,25 @@ func (c *Client) SetPrivilege(username, database string, p influxql.Privilege) e
 	return nil
 }
 
+// SetSeriesPrivilege sets the privilege of the given username on the series
+// matched by grant.
+func (c *Client) SetSeriesPrivilege(username string, grant SeriesGrantInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.SetSeriesPrivilege(username, grant); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SetAdminPrivilege sets or unsets admin privilege to the given username.
 func (c *Client) SetAdminPrivilege(username string, admin bool) error {
 	c.mu.Lock()
@@ -543,6 +646,249 @@ func (c *Client) UserPrivilege(username, database string) (*influxql.Privilege,
 	return p, nil
 }
 
+// UserSeriesGrants returns the series grants for a user.
+func (c *Client) UserSeriesGrants(username string) ([]SeriesGrantInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	grants, err := c.cacheData.UserSeriesGrants(username)
+	if err != nil {
+		return nil, err
+	}
+	return append([]SeriesGrantInfo(nil), grants...), nil
+}
+
+// UserRoles returns the names of the roles granted to a user.
+func (c *Client) UserRoles(username string) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	roles, err := c.cacheData.UserRoles(username)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string(nil), roles...), nil
+}
+
+// Tokens returns a list of all tokens.
+func (c *Client) Tokens() []TokenInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cacheData.CloneTokens()
+}
+
+// CreateToken creates a token that authenticates username.  If database is
+// not empty, the token is limited to privilege on database.  If expires is
+// not zero, the token expires after that amount of time.  The returned token
+// is the only copy of its secret.
+func (c *Client) CreateToken(username, database string, privilege influxql.Privilege, expires time.Duration) (string, *TokenInfo, error) {
+	// The token is a public ID and a secret, separated by a dot.
+	b := make([]byte, 8+32)
+	if _, err := io.ReadFull(crand.Reader, b); err != nil {
+		return "", nil, err
+	}
+	id, secret := hex.EncodeToString(b[:8]), hex.EncodeToString(b[8:])
+	hash := sha256.Sum256([]byte(secret))
+
+	ti := TokenInfo{
+		ID:        id,
+		User:      username,
+		Hash:      hash[:],
+		Database:  database,
+		Privilege: privilege,
+		CreatedAt: time.Now().UTC(),
+	}
+	if expires > 0 {
+		ti.Expires = ti.CreatedAt.Add(expires)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.CreateToken(ti); err != nil {
+		return "", nil, err
+	}
+
+	if err := c.commit(data); err != nil {
+		return "", nil, err
+	}
+
+	return id + "." + secret, &ti, nil
+}
+
+// DropToken revokes a token.
+func (c *Client) DropToken(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.DropToken(id); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AuthenticateToken returns the user authenticated by token, limited to
+// the scope of the token.
+func (c *Client) AuthenticateToken(token string) (User, error) {
+	i := strings.IndexByte(token, '.')
+	if i < 0 {
+		return nil, ErrAuthenticate
+	}
+	id, secret := token[:i], token[i+1:]
+
+	// Find the token and its user.
+	c.mu.RLock()
+	var ti TokenInfo
+	var userInfo *UserInfo
+	if t := c.cacheData.Token(id); t != nil {
+		ti, userInfo = *t, c.cacheData.user(t.User)
+	}
+	c.mu.RUnlock()
+	if userInfo == nil {
+		return nil, ErrAuthenticate
+	}
+
+	// Compare the secret with the token hash.
+	hash := sha256.Sum256([]byte(secret))
+	if subtle.ConstantTimeCompare(hash[:], ti.Hash) != 1 {
+		return nil, ErrAuthenticate
+	}
+
+	now := time.Now().UTC()
+	if ti.Expired(now) {
+		return nil, ErrTokenExpired
+	}
+
+	// Record when the token was used, at most once per interval.
+	if now.Sub(ti.LastUsed) >= TokenLastUsedInterval {
+		if err := c.setTokenLastUsed(id, now); err != nil {
+			c.logger.Info(fmt.Sprintf("failed to update last use of token %s: %s", id, err))
+		}
+	}
+
+	return &tokenUser{UserInfo: userInfo, token: ti}, nil
+}
+
+// setTokenLastUsed sets the time a token was last used.
+func (c *Client) setTokenLastUsed(id string, t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.SetTokenLastUsed(id, t); err != nil {
+		return err
+	}
+
+	return c.commit(data)
+}
+
+// Roles returns a list of all roles.
+func (c *Client) Roles() []RoleInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cacheData.CloneRoles()
+}
+
+// CreateRole creates a new role.
+func (c *Client) CreateRole(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.CreateRole(name); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DropRole removes a role and revokes it from its users.
+func (c *Client) DropRole(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.DropRole(name); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetRolePrivilege sets a privilege for the given role on the given database.
+func (c *Client) SetRolePrivilege(name, database string, p influxql.Privilege) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.SetRolePrivilege(name, database, p); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddUserRole grants a role to a user.
+func (c *Client) AddUserRole(username, role string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.AddUserRole(username, role); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveUserRole revokes a role from a user.
+func (c *Client) RemoveUserRole(username, role string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.RemoveUserRole(username, role); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // AdminUserExists returns true if any user has admin privilege.
 func (c *Client) AdminUserExists() bool {
 	c.mu.RLock()
@@ -560,6 +906,14 @@ func (c *Client) Authenticate(username, password string) (User, error) {
 		return nil, ErrUserNotFound
 	}
 
+	// Refuse logins of users locked after too many failures. The lock is
+	// checked again when the login is recorded, since the user may be locked
+	// by a concurrent login while the password is compared.
+	now := time.Now().UTC()
+	if userInfo.Locked(now) {
+		return nil, ErrUserLocked
+	}
+
 	// Check the local auth cache first.
 	c.mu.RLock()
 	au, ok := c.authCache[username]
@@ -567,6 +921,9 @@ func (c *Client) Authenticate(username, password string) (User, error) {
 	if ok {
 		// verify the password using the cached salt and hash
 		if bytes.Equal(c.hashWithSalt(au.salt, password), au.hash) {
+			if err := c.loginSucceeded(username, now); err != nil {
+				return nil, err
+			}
 			return userInfo, nil
 		}
 
@@ -575,7 +932,17 @@ func (c *Client) Authenticate(username, password string) (User, error) {
 
 	// Compare password with user hash.
 	if err := bcrypt.CompareHashAndPassword([]byte(userInfo.Hash), []byte(password)); err != nil {
-		return nil, ErrAuthenticate
+		return nil, c.loginFailed(username, now)
+	}
+
+	// Upgrade hashes with a lower cost than configured.
+	hash := userInfo.Hash
+	if cost, err := bcrypt.Cost([]byte(hash)); err == nil && cost < c.hashCost {
+		if upgraded, err := c.upgradeHash(username, hash, password); err != nil {
+			c.logger.Info(fmt.Sprintf("failed to upgrade password hash of user %s: %s", username, err))
+		} else if upgraded != "" {
+			hash = upgraded
+		}
 	}
 
 	// generate a salt and hash of the password for the cache
@@ -584,11 +951,114 @@ func (c *Client) Authenticate(username, password string) (User, error) {
 		return nil, err
 	}
 	c.mu.Lock()
-	c.authCache[username] = authUser{salt: salt, hash: hashed, bhash: userInfo.Hash}
+	// Don't cache the password if it was changed while it was compared.
+	if u := c.cacheData.user(username); u != nil && u.Hash == hash {
+		c.authCache[username] = authUser{salt: salt, hash: hashed, bhash: hash}
+	}
 	c.mu.Unlock()
+
+	if err := c.loginSucceeded(username, now); err != nil {
+		return nil, err
+	}
 	return userInfo, nil
 }
 
+// loginSucceeded records a successful login of a user, returning
+// ErrUserLocked if the user was locked since its lock was last checked. The
+// failed logins of the user are reset and the time of the login is persisted
+// at most once per interval.
+func (c *Client) loginSucceeded(username string, t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u := c.cacheData.user(username)
+	if u == nil {
+		return ErrUserNotFound
+	} else if u.Locked(t) {
+		return ErrUserLocked
+	}
+	delete(c.loginFailures, username)
+
+	if t.Sub(u.LastLogin) < UserLastLoginInterval {
+		return nil
+	}
+
+	data := c.cacheData.Clone()
+
+	if err := data.SetUserLogin(username, t); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		c.logger.Info(fmt.Sprintf("failed to record login of user %s: %s", username, err))
+	}
+	return nil
+}
+
+// loginFailed records a failed login of a user and returns the error of the
+// login. Failures are counted in memory and the user is locked after the
+// maximum number of failures, which is the only change persisted. Failures are
+// not counted if the lockout is disabled or while the user is locked.
+func (c *Client) loginFailed(username string, t time.Time) error {
+	if c.loginMaxFailures == 0 {
+		return ErrAuthenticate
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u := c.cacheData.user(username)
+	if u == nil || u.Locked(t) {
+		return ErrAuthenticate
+	}
+
+	c.loginFailures[username]++
+	if c.loginFailures[username] < c.loginMaxFailures {
+		return ErrAuthenticate
+	}
+	delete(c.loginFailures, username)
+
+	data := c.cacheData.Clone()
+
+	if err := data.LockUser(username, t.Add(c.loginLockout)); err != nil {
+		return ErrAuthenticate
+	}
+
+	if err := c.commit(data); err != nil {
+		c.logger.Info(fmt.Sprintf("failed to lock user %s: %s", username, err))
+	}
+	return ErrAuthenticate
+}
+
+// upgradeHash replaces the password hash of a user with a hash of password
+// using the configured cost and returns the new hash. The hash is only
+// replaced if it is still oldHash, the hash password was compared with, so a
+// password changed in the meantime is kept; an empty hash is returned then.
+func (c *Client) upgradeHash(username, oldHash, password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), c.hashCost)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if u := c.cacheData.user(username); u == nil || u.Hash != oldHash {
+		return "", nil
+	}
+
+	data := c.cacheData.Clone()
+
+	if err := data.UpdateUser(username, string(hash)); err != nil {
+		return "", err
+	}
+
+	if err := c.commit(data); err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
 // UserCount returns the number of users stored.
 func (c *Client) UserCount() int {
 	c.mu.RLock()
@@ -768,6 +1238,41 @@ func (c *Client) DeleteShardGroup(database, policy string, id uint64) error {
 	return nil
 }
 
+// ReserveShardIDs reserves n new shard IDs for shards added later with
+// ReplaceShardGroups.
+func (c *Client) ReserveShardIDs(n int) ([]uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+	ids := data.ReserveShardIDs(n)
+
+	if err := c.commit(data); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ReplaceShardGroups atomically replaces shard groups of a retention policy
+// with new groups covering the same time range.
+func (c *Client) ReplaceShardGroups(database, policy string, ids []uint64, groups []ShardGroupInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.ReplaceShardGroups(database, policy, ids, groups); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // PrecreateShardGroups creates shard groups whose endtime is before the 'to' time passed in, but
 // is yet to expire before 'from'. This is to avoid the need for these shards to be created when data
 // for the corresponding time range arrives. Shard creation involves Raft consensus, and precreation
@@ -879,6 +1384,42 @@ func (c *Client) DropContinuousQuery(database, name string) error {
 	return nil
 }
 
+// SetCardinalityLimit adds or replaces a cardinality limit on a database.
+func (c *Client) SetCardinalityLimit(database string, limit CardinalityLimitInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.SetCardinalityLimit(database, limit); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DropCardinalityLimit removes a cardinality limit from a database.
+func (c *Client) DropCardinalityLimit(database, measurement, tagKey string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := c.cacheData.Clone()
+
+	if err := data.DropCardinalityLimit(database, measurement, tagKey); err != nil {
+		return err
+	}
+
+	if err := c.commit(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateSubscription creates a subscription against the given database and retention policy.
 func (c *Client) CreateSubscription(database, rp, name, mode string, destinations []string) error {
 	c.mu.Lock()
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/toml"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestMetaClient_CreateDatabaseOnly(t *testing.T) {
@@ -253,6 +256,56 @@ func TestMetaClient_Databases(t *testing.T) {
 	}
 }
 
+func TestMetaClient_UpdateDatabase(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig()
+	defer os.RemoveAll(cfg.Dir)
+
+	c := meta.NewClient(cfg)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateDatabase("db0"); err != nil {
+		t.Fatal(err)
+	}
+
+	var du meta.DatabaseUpdate
+	du.SetWALMode("none")
+	du.SetDuplicatePolicy("reject")
+	if err := c.UpdateDatabase("db0", &du); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.UpdateDatabase("db1", &du); err == nil || err.Error() != influxdb.ErrDatabaseNotFound("db1").Error() {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Unknown WAL modes are rejected and not stored.
+	var invalid meta.DatabaseUpdate
+	invalid.SetWALMode("sometimes")
+	if err := c.UpdateDatabase("db0", &invalid); err == nil || err.Error() != `invalid wal mode: "sometimes"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Close()
+
+	// Ensure the WAL mode and duplicate policy survive a restart.
+	c = meta.NewClient(cfg)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if db := c.Database("db0"); db == nil {
+		t.Fatal("database not found")
+	} else if got, exp := db.WALMode, "none"; got != exp {
+		t.Fatalf("unexpected wal mode: got %q, exp %q", got, exp)
+	} else if got, exp := db.DuplicatePolicy, "reject"; got != exp {
+		t.Fatalf("unexpected duplicate policy: got %q, exp %q", got, exp)
+	}
+}
+
 func TestMetaClient_DropDatabase(t *testing.T) {
 	t.Parallel()
 
@@ -759,6 +812,93 @@ func TestMetaClient_UpdateUser(t *testing.T) {
 	}
 }
 
+func TestMetaClient_Tokens(t *testing.T) {
+	t.Parallel()
+
+	d, c := newClient()
+	defer os.RemoveAll(d)
+	defer c.Close()
+
+	if _, err := c.CreateUser("fred", "supersecure", false); err != nil {
+		t.Fatal(err)
+	} else if err := c.SetPrivilege("fred", "db0", influxql.AllPrivileges); err == nil {
+		t.Fatal("expected error setting privilege on missing database")
+	}
+	for _, db := range []string{"db0", "db1"} {
+		if _, err := c.CreateDatabase(db); err != nil {
+			t.Fatal(err)
+		} else if err := c.SetPrivilege("fred", db, influxql.AllPrivileges); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A token without a scope has the privileges of its user.
+	token, ti, err := c.CreateToken("fred", "", influxql.NoPrivileges, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.HasPrefix(token, ti.ID+".") {
+		t.Fatalf("unexpected token %q for ID %q", token, ti.ID)
+	}
+	u, err := c.AuthenticateToken(token)
+	if err != nil {
+		t.Fatal(err)
+	} else if u.ID() != "fred" {
+		t.Fatalf("unexpected user: %s", u.ID())
+	} else if !u.AuthorizeDatabase(influxql.WritePrivilege, "db1") {
+		t.Fatal("expected write on db1")
+	}
+
+	// The time the token was used is recorded.
+	if tokens := c.Tokens(); len(tokens) != 1 || tokens[0].LastUsed.IsZero() {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+
+	// A scoped token is limited to its scope.
+	scoped, _, err := c.CreateToken("fred", "db0", influxql.ReadPrivilege, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err = c.AuthenticateToken(scoped)
+	if err != nil {
+		t.Fatal(err)
+	} else if !u.AuthorizeDatabase(influxql.ReadPrivilege, "db0") {
+		t.Fatal("expected read on db0")
+	} else if u.AuthorizeDatabase(influxql.WritePrivilege, "db0") {
+		t.Fatal("unexpected write on db0")
+	} else if u.AuthorizeDatabase(influxql.ReadPrivilege, "db1") {
+		t.Fatal("unexpected read on db1")
+	}
+
+	// Invalid secrets and expired tokens are rejected.
+	if _, err := c.AuthenticateToken(ti.ID + ".bad"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := c.AuthenticateToken("bad"); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expired, _, err := c.CreateToken("fred", "", influxql.NoPrivileges, time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := c.AuthenticateToken(expired); err != meta.ErrTokenExpired {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Dropped tokens can't be used anymore.
+	if err := c.DropToken(ti.ID); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.AuthenticateToken(token); err != meta.ErrAuthenticate {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := c.DropToken(ti.ID); err != meta.ErrTokenNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Dropping the user drops its tokens.
+	if err := c.DropUser("fred"); err != nil {
+		t.Fatal(err)
+	} else if tokens := c.Tokens(); len(tokens) != 0 {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+}
+
 func TestMetaClient_ContinuousQueries(t *testing.T) {
 	t.Parallel()
 
@@ -1135,6 +1275,184 @@ func TestMetaClient_PersistClusterIDAfterRestart(t *testing.T) {
 	}
 }
 
+func TestMetaClient_PasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig()
+	defer os.RemoveAll(cfg.Dir)
+	cfg.PasswordMinLength = 8
+	cfg.PasswordRequireDigit = true
+	cfg.PasswordHistory = 2
+
+	c := meta.NewClient(cfg)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.CreateUser("fred", "short1", false); err == nil || err.Error() != "password must be at least 8 characters" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := c.CreateUser("fred", "supersecure", false); err == nil || err.Error() != "password must contain a digit" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := c.CreateUser("fred", "supersecure1", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The current and previous passwords can't be reused.
+	if err := c.UpdateUser("fred", "supersecure2"); err != nil {
+		t.Fatal(err)
+	} else if err := c.UpdateUser("fred", "supersecure2"); err != meta.ErrPasswordReused {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := c.UpdateUser("fred", "supersecure1"); err != meta.ErrPasswordReused {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := c.UpdateUser("fred", "secure"); err == nil {
+		t.Fatal("expected error for password violating policy")
+	} else if err := c.UpdateUser("fred", "supersecure3"); err != nil {
+		t.Fatal(err)
+	} else if err := c.UpdateUser("fred", "supersecure1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Authenticate("fred", "supersecure1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMetaClient_WriteAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	d, c := newClient()
+	defer os.RemoveAll(d)
+	defer c.Close()
+
+	if _, err := c.CreateDatabase("db0"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.CreateUser("fred", "supersecure", false); err != nil {
+		t.Fatal(err)
+	} else if err := c.SetPrivilege("fred", "db0", influxql.WritePrivilege); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := c.User("fred")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := meta.NewWriteAuthorizer(c)
+	if err := a.AuthorizeWrite(u, "db0"); err != nil {
+		t.Fatal(err)
+	} else if err := a.AuthorizeWrite(u, "db1"); err == nil {
+		t.Fatal("expected error writing to db1")
+	} else if err := a.AuthorizeWrite(nil, "db0"); err == nil {
+		t.Fatal("expected error writing without a user")
+	}
+
+	// A revoked privilege applies to a user read before it was revoked.
+	if err := c.SetPrivilege("fred", "db0", influxql.ReadPrivilege); err != nil {
+		t.Fatal(err)
+	} else if err := a.AuthorizeWrite(u, "db0"); err == nil {
+		t.Fatal("expected error after the write privilege was revoked")
+	}
+}
+
+func TestMetaClient_Authenticate_Lockout(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig()
+	defer os.RemoveAll(cfg.Dir)
+	cfg.LoginMaxFailures = 3
+	cfg.LoginLockoutDuration = toml.Duration(time.Hour)
+
+	c := meta.NewClient(cfg)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.CreateUser("fred", "supersecure", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failures are counted without changing the meta data.
+	index := c.Data().Index
+	for i := 0; i < 2; i++ {
+		if _, err := c.Authenticate("fred", "wrong"); err != meta.ErrAuthenticate {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := c.Data().Index; got != index {
+		t.Fatalf("unexpected meta data index: got %d, exp %d", got, index)
+	}
+
+	// A successful login resets the failures and records the login.
+	if _, err := c.Authenticate("fred", "supersecure"); err != nil {
+		t.Fatal(err)
+	}
+	if u := c.Users()[0]; u.LastLogin.IsZero() {
+		t.Fatalf("unexpected user: %+v", u)
+	}
+
+	// Concurrent failures lock the user once.
+	index = c.Data().Index
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Authenticate("fred", "wrong")
+		}()
+	}
+	wg.Wait()
+	if got := c.Data().Index; got != index+1 {
+		t.Fatalf("unexpected meta data index: got %d, exp %d", got, index+1)
+	}
+	if _, err := c.Authenticate("fred", "supersecure"); err != meta.ErrUserLocked {
+		t.Fatalf("unexpected error: %v", err)
+	} else if u := c.Users()[0]; !u.Locked(time.Now()) {
+		t.Fatalf("expected locked user: %+v", u)
+	}
+
+	// Setting the password unlocks the user.
+	if err := c.UpdateUser("fred", "moresecure"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.Authenticate("fred", "moresecure"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMetaClient_Authenticate_UpgradeHash(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig()
+	defer os.RemoveAll(cfg.Dir)
+
+	c := meta.NewClient(cfg)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateUser("fred", "supersecure", false); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	cfg.PasswordHashCost = bcrypt.MinCost + 1
+	c = meta.NewClient(cfg)
+	if err := c.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.Authenticate("fred", "supersecure"); err != nil {
+		t.Fatal(err)
+	} else if cost, err := bcrypt.Cost([]byte(c.Users()[0].Hash)); err != nil {
+		t.Fatal(err)
+	} else if cost != bcrypt.MinCost+1 {
+		t.Fatalf("unexpected hash cost: %d", cost)
+	} else if _, err := c.Authenticate("fred", "supersecure"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func newClient() (string, *meta.Client) {
 	cfg := newConfig()
 	c := meta.NewClient(cfg)
@@ -2,9 +2,12 @@ package meta
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -13,6 +16,10 @@ const (
 
 	// DefaultLoggingEnabled determines if log messages are printed for the meta service.
 	DefaultLoggingEnabled = true
+
+	// DefaultLoginLockoutDuration is the default duration users are locked
+	// after too many failed logins.
+	DefaultLoginLockoutDuration = 15 * time.Minute
 )
 
 // Config represents the meta configuration.
@@ -21,13 +28,31 @@ type Config struct {
 
 	RetentionAutoCreate bool `toml:"retention-autocreate"`
 	LoggingEnabled      bool `toml:"logging-enabled"`
+
+	// Rules for the passwords of users.
+	PasswordMinLength        int  `toml:"password-min-length"`
+	PasswordRequireUppercase bool `toml:"password-require-uppercase"`
+	PasswordRequireLowercase bool `toml:"password-require-lowercase"`
+	PasswordRequireDigit     bool `toml:"password-require-digit"`
+	PasswordRequireSymbol    bool `toml:"password-require-symbol"`
+	PasswordHistory          int  `toml:"password-history"`
+
+	// The bcrypt cost of password hashes. Hashes with a lower cost are
+	// upgraded when users log in. Zero uses the bcrypt default cost.
+	PasswordHashCost int `toml:"password-hash-cost"`
+
+	// Users are locked for the lockout duration after the maximum number
+	// of consecutive failed logins. Zero disables the lockout.
+	LoginMaxFailures     int           `toml:"login-max-failures"`
+	LoginLockoutDuration toml.Duration `toml:"login-lockout-duration"`
 }
 
 // NewConfig builds a new configuration with default values.
 func NewConfig() *Config {
 	return &Config{
-		RetentionAutoCreate: true,
-		LoggingEnabled:      DefaultLoggingEnabled,
+		RetentionAutoCreate:  true,
+		LoggingEnabled:       DefaultLoggingEnabled,
+		LoginLockoutDuration: toml.Duration(DefaultLoginLockoutDuration),
 	}
 }
 
@@ -36,12 +61,26 @@ func (c *Config) Validate() error {
 	if c.Dir == "" {
 		return errors.New("Meta.Dir must be specified")
 	}
+
+	if c.PasswordMinLength < 0 {
+		return errors.New("password-min-length must not be negative")
+	} else if c.PasswordHistory < 0 {
+		return errors.New("password-history must not be negative")
+	} else if c.PasswordHashCost != 0 && (c.PasswordHashCost < bcrypt.MinCost || c.PasswordHashCost > bcrypt.MaxCost) {
+		return fmt.Errorf("password-hash-cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	} else if c.LoginMaxFailures < 0 {
+		return errors.New("login-max-failures must not be negative")
+	} else if c.LoginMaxFailures > 0 && c.LoginLockoutDuration <= 0 {
+		return errors.New("login-lockout-duration must be positive")
+	}
 	return nil
 }
 
 // Diagnostics returns a diagnostics representation of a subset of the Config.
 func (c *Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	return diagnostics.RowFromMap(map[string]interface{}{
-		"dir": c.Dir,
+		"dir":                c.Dir,
+		"password-history":   c.PasswordHistory,
+		"login-max-failures": c.LoginMaxFailures,
 	}), nil
 }
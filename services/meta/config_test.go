@@ -24,3 +24,29 @@ logging-enabled = false
 		t.Fatalf("unexpected logging enabled: %v", c.LoggingEnabled)
 	}
 }
+
+func TestConfig_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		s   string
+		err string
+	}{
+		{s: "password-min-length = 8\npassword-history = 3\npassword-hash-cost = 12\nlogin-max-failures = 5"},
+		{s: "password-min-length = -1", err: "password-min-length must not be negative"},
+		{s: "password-history = -1", err: "password-history must not be negative"},
+		{s: "password-hash-cost = 50", err: "password-hash-cost must be between 4 and 31"},
+		{s: "login-max-failures = -1", err: "login-max-failures must not be negative"},
+		{s: "login-max-failures = 5\nlogin-lockout-duration = \"0s\"", err: "login-lockout-duration must be positive"},
+	} {
+		c := meta.NewConfig()
+		c.Dir = "/tmp/foo"
+		if _, err := toml.Decode(tt.s, c); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := c.Validate(); tt.err == "" && err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.s, err)
+		} else if tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%q: unexpected error: exp=%s got=%v", tt.s, tt.err, err)
+		}
+	}
+}
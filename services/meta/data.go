@@ -2,6 +2,7 @@ package meta
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"net/url"
 	"sort"
@@ -40,6 +41,8 @@ type Data struct {
 	ClusterID uint64
 	Databases []DatabaseInfo
 	Users     []UserInfo
+	Roles     []RoleInfo
+	Tokens    []TokenInfo
 
 	// adminUserExists provides a constant time mechanism for determining
 	// if there is at least one admin user.
@@ -96,13 +99,78 @@ func (data *Data) DropDatabase(name string) error {
 			// Remove all user privileges associated with this database.
 			for i := range data.Users {
 				delete(data.Users[i].Privileges, name)
+
+				grants := data.Users[i].SeriesGrants[:0]
+				for _, g := range data.Users[i].SeriesGrants {
+					if g.Database != name {
+						grants = append(grants, g)
+					}
+				}
+				data.Users[i].SeriesGrants = grants
+			}
+
+			// Remove all role privileges associated with this database.
+			for i := range data.Roles {
+				delete(data.Roles[i].Privileges, name)
+			}
+			data.resolveRolePrivileges()
+
+			// Remove all tokens scoped to this database.
+			tokens := data.Tokens[:0]
+			for _, t := range data.Tokens {
+				if t.Database != name {
+					tokens = append(tokens, t)
+				}
 			}
+			data.Tokens = tokens
 			break
 		}
 	}
 	return nil
 }
 
+// walModes are the WAL durability modes a database can be set to, the
+// tsdb.WALMode* values.  An empty mode selects the default mode.
+var walModes = map[string]struct{}{"": {}, "fsync": {}, "group": {}, "none": {}}
+
+// DatabaseUpdate represents database fields to be updated.
+type DatabaseUpdate struct {
+	WALMode         *string
+	DuplicatePolicy *string
+	ReadOnly        *bool
+}
+
+// SetWALMode sets the DatabaseUpdate.WALMode.
+func (du *DatabaseUpdate) SetWALMode(v string) { du.WALMode = &v }
+
+// SetDuplicatePolicy sets the DatabaseUpdate.DuplicatePolicy.
+func (du *DatabaseUpdate) SetDuplicatePolicy(v string) { du.DuplicatePolicy = &v }
+
+// SetReadOnly sets the DatabaseUpdate.ReadOnly.
+func (du *DatabaseUpdate) SetReadOnly(v bool) { du.ReadOnly = &v }
+
+// UpdateDatabase updates an existing database.
+func (data *Data) UpdateDatabase(name string, du *DatabaseUpdate) error {
+	di := data.Database(name)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(name)
+	}
+
+	if du.WALMode != nil {
+		if _, ok := walModes[*du.WALMode]; !ok {
+			return fmt.Errorf("invalid wal mode: %q", *du.WALMode)
+		}
+		di.WALMode = *du.WALMode
+	}
+	if du.DuplicatePolicy != nil {
+		di.DuplicatePolicy = *du.DuplicatePolicy
+	}
+	if du.ReadOnly != nil {
+		di.ReadOnly = *du.ReadOnly
+	}
+	return nil
+}
+
 // RetentionPolicy returns a retention policy for a database by name.
 func (data *Data) RetentionPolicy(database, name string) (*RetentionPolicyInfo, error) {
 	di := data.Database(database)
@@ -137,6 +205,8 @@ func (data *Data) CreateRetentionPolicy(database string, rpi *RetentionPolicyInf
 
 	if rpi.Duration > 0 && rpi.Duration < rpi.ShardGroupDuration {
 		return ErrIncompatibleDurations
+	} else if rpi.SeriesDuration < 0 {
+		return ErrSeriesDurationInvalid
 	}
 
 	// Find database.
@@ -145,7 +215,7 @@ func (data *Data) CreateRetentionPolicy(database string, rpi *RetentionPolicyInf
 		return influxdb.ErrDatabaseNotFound(database)
 	} else if rp := di.RetentionPolicy(rpi.Name); rp != nil {
 		// RP with that name already exists. Make sure they're the same.
-		if rp.ReplicaN != rpi.ReplicaN || rp.Duration != rpi.Duration || rp.ShardGroupDuration != rpi.ShardGroupDuration {
+		if rp.ReplicaN != rpi.ReplicaN || rp.Duration != rpi.Duration || rp.ShardGroupDuration != rpi.ShardGroupDuration || rp.SeriesDuration != rpi.SeriesDuration {
 			return ErrRetentionPolicyExists
 		}
 		// if they want to make it default, and it's not the default, it's not an identical command so it's an error
@@ -192,6 +262,8 @@ type RetentionPolicyUpdate struct {
 	Duration           *time.Duration
 	ReplicaN           *int
 	ShardGroupDuration *time.Duration
+	SeriesDuration     *time.Duration
+	ReadOnly           *bool
 }
 
 // SetName sets the RetentionPolicyUpdate.Name.
@@ -206,6 +278,12 @@ func (rpu *RetentionPolicyUpdate) SetReplicaN(v int) { rpu.ReplicaN = &v }
 // SetShardGroupDuration sets the RetentionPolicyUpdate.ShardGroupDuration.
 func (rpu *RetentionPolicyUpdate) SetShardGroupDuration(v time.Duration) { rpu.ShardGroupDuration = &v }
 
+// SetSeriesDuration sets the RetentionPolicyUpdate.SeriesDuration.
+func (rpu *RetentionPolicyUpdate) SetSeriesDuration(v time.Duration) { rpu.SeriesDuration = &v }
+
+// SetReadOnly sets the RetentionPolicyUpdate.ReadOnly.
+func (rpu *RetentionPolicyUpdate) SetReadOnly(v bool) { rpu.ReadOnly = &v }
+
 // UpdateRetentionPolicy updates an existing retention policy.
 func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPolicyUpdate, makeDefault bool) error {
 	// Find database.
@@ -239,6 +317,10 @@ func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPol
 		return ErrIncompatibleDurations
 	}
 
+	if rpu.SeriesDuration != nil && *rpu.SeriesDuration < 0 {
+		return ErrSeriesDurationInvalid
+	}
+
 	// Update fields.
 	if rpu.Name != nil {
 		rpi.Name = *rpu.Name
@@ -252,6 +334,12 @@ func (data *Data) UpdateRetentionPolicy(database, name string, rpu *RetentionPol
 	if rpu.ShardGroupDuration != nil {
 		rpi.ShardGroupDuration = normalisedShardDuration(*rpu.ShardGroupDuration, rpi.Duration)
 	}
+	if rpu.SeriesDuration != nil {
+		rpi.SeriesDuration = *rpu.SeriesDuration
+	}
+	if rpu.ReadOnly != nil {
+		rpi.ReadOnly = *rpu.ReadOnly
+	}
 
 	if di.DefaultRetentionPolicy != rpi.Name && makeDefault {
 		di.DefaultRetentionPolicy = rpi.Name
@@ -405,6 +493,99 @@ func (data *Data) DeleteShardGroup(database, policy string, id uint64) error {
 	return ErrShardGroupNotFound
 }
 
+// ReserveShardIDs reserves n new shard IDs and returns them.  The IDs are
+// not used by any shard until they are added with ReplaceShardGroups.
+func (data *Data) ReserveShardIDs(n int) []uint64 {
+	ids := make([]uint64, n)
+	for i := range ids {
+		data.MaxShardID++
+		ids[i] = data.MaxShardID
+	}
+	return ids
+}
+
+// ReplaceShardGroups deletes the shard groups with the given IDs from a retention
+// policy and adds groups in their place.  The shards of the new groups must use IDs
+// reserved with ReserveShardIDs.  The new groups are assigned new IDs and must not
+// overlap any remaining shard group.
+func (data *Data) ReplaceShardGroups(database, policy string, ids []uint64, groups []ShardGroupInfo) error {
+	// Find retention policy.
+	rpi, err := data.RetentionPolicy(database, policy)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(policy)
+	}
+
+	// Find the replaced shard groups.
+	replaced := make(map[uint64]int, len(ids))
+	for _, id := range ids {
+		replaced[id] = -1
+	}
+	for i, sg := range rpi.ShardGroups {
+		if _, ok := replaced[sg.ID]; ok && !sg.Deleted() {
+			replaced[sg.ID] = i
+		}
+	}
+	for _, i := range replaced {
+		if i == -1 {
+			return ErrShardGroupNotFound
+		}
+	}
+
+	// Ensure the shard IDs were reserved and are not used by any shard.
+	used := make(map[uint64]struct{})
+	for _, di := range data.Databases {
+		for _, rp := range di.RetentionPolicies {
+			for _, sg := range rp.ShardGroups {
+				for _, sh := range sg.Shards {
+					used[sh.ID] = struct{}{}
+				}
+			}
+		}
+	}
+
+	for i, g := range groups {
+		for _, sh := range g.Shards {
+			if _, ok := used[sh.ID]; ok || sh.ID == 0 || sh.ID > data.MaxShardID {
+				return ErrShardIDNotReserved
+			}
+			used[sh.ID] = struct{}{}
+		}
+
+		for _, sg := range rpi.ShardGroups {
+			if _, ok := replaced[sg.ID]; ok || sg.Deleted() {
+				continue
+			}
+			if g.StartTime.Before(sg.EndTime) && sg.StartTime.Before(g.EndTime) {
+				return ErrShardGroupOverlaps
+			}
+		}
+		for _, sg := range groups[:i] {
+			if g.StartTime.Before(sg.EndTime) && sg.StartTime.Before(g.EndTime) {
+				return ErrShardGroupOverlaps
+			}
+		}
+	}
+
+	// Mark the replaced shard groups as deleted and add the new ones.
+	now := time.Now().UTC()
+	for _, i := range replaced {
+		rpi.ShardGroups[i].DeletedAt = now
+	}
+
+	for _, g := range groups {
+		data.MaxShardGroupID++
+		g.ID = data.MaxShardGroupID
+		g.StartTime, g.EndTime = g.StartTime.UTC(), g.EndTime.UTC()
+		g.Shards = append([]ShardInfo(nil), g.Shards...)
+		rpi.ShardGroups = append(rpi.ShardGroups, g)
+	}
+	sort.Sort(ShardGroupInfos(rpi.ShardGroups))
+
+	return nil
+}
+
 // CreateContinuousQuery adds a named continuous query to a database.
 func (data *Data) CreateContinuousQuery(database, name, query string) error {
 	di := data.Database(database)
@@ -450,6 +631,44 @@ func (data *Data) DropContinuousQuery(database, name string) error {
 	return ErrContinuousQueryNotFound
 }
 
+// SetCardinalityLimit adds a cardinality limit to a database or replaces the
+// limit on the same measurement and tag key.
+func (data *Data) SetCardinalityLimit(database string, limit CardinalityLimitInfo) error {
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	} else if limit.Max <= 0 {
+		return ErrCardinalityLimitInvalid
+	}
+
+	for i := range di.CardinalityLimits {
+		l := &di.CardinalityLimits[i]
+		if l.Measurement == limit.Measurement && l.TagKey == limit.TagKey {
+			*l = limit
+			return nil
+		}
+	}
+	di.CardinalityLimits = append(di.CardinalityLimits, limit)
+	return nil
+}
+
+// DropCardinalityLimit removes the cardinality limit on a measurement, or on a
+// tag key of a measurement if tagKey is not empty.
+func (data *Data) DropCardinalityLimit(database, measurement, tagKey string) error {
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	for i, l := range di.CardinalityLimits {
+		if l.Measurement == measurement && l.TagKey == tagKey {
+			di.CardinalityLimits = append(di.CardinalityLimits[:i], di.CardinalityLimits[i+1:]...)
+			return nil
+		}
+	}
+	return ErrCardinalityLimitNotFound
+}
+
 // validateURL returns an error if the URL does not have a port or uses a scheme other than UDP or HTTP.
 func validateURL(input string) error {
 	u, err := url.Parse(input)
@@ -569,6 +788,15 @@ func (data *Data) DropUser(name string) error {
 			wasAdmin := data.Users[i].Admin
 			data.Users = append(data.Users[:i], data.Users[i+1:]...)
 
+			// Remove all tokens of the user.
+			tokens := data.Tokens[:0]
+			for _, t := range data.Tokens {
+				if t.User != name {
+					tokens = append(tokens, t)
+				}
+			}
+			data.Tokens = tokens
+
 			// Maybe we dropped the only admin user?
 			if wasAdmin {
 				data.adminUserExists = data.hasAdminUser()
@@ -591,6 +819,52 @@ func (data *Data) UpdateUser(name, hash string) error {
 	return ErrUserNotFound
 }
 
+// SetPassword replaces the password hash of an existing user, keeping up to
+// history of the previous hashes, and unlocks the user.
+func (data *Data) SetPassword(name, hash string, history int) error {
+	u := data.user(name)
+	if u == nil {
+		return ErrUserNotFound
+	}
+
+	if history > 0 {
+		u.PasswordHistory = append([]string{u.Hash}, u.PasswordHistory...)
+	}
+	if len(u.PasswordHistory) > history {
+		u.PasswordHistory = u.PasswordHistory[:history]
+	}
+	if len(u.PasswordHistory) == 0 {
+		u.PasswordHistory = nil
+	}
+
+	u.Hash = hash
+	u.LockedUntil = time.Time{}
+	return nil
+}
+
+// SetUserLogin records a successful login of a user at t.
+func (data *Data) SetUserLogin(name string, t time.Time) error {
+	u := data.user(name)
+	if u == nil {
+		return ErrUserNotFound
+	}
+
+	u.LastLogin = t
+	u.LockedUntil = time.Time{}
+	return nil
+}
+
+// LockUser refuses the logins of a user until t.
+func (data *Data) LockUser(name string, t time.Time) error {
+	u := data.user(name)
+	if u == nil {
+		return ErrUserNotFound
+	}
+
+	u.LockedUntil = t
+	return nil
+}
+
 // CloneUsers returns a copy of the user infos.
 func (data *Data) CloneUsers() []UserInfo {
 	if len(data.Users) == 0 {
@@ -623,6 +897,237 @@ func (data *Data) SetPrivilege(name, database string, p influxql.Privilege) erro
 	return nil
 }
 
+// SetSeriesPrivilege sets the privilege of a user on the series matched by a
+// grant.  The grant replaces an existing grant on the same database,
+// measurement and condition, and is removed if its privilege is
+// NoPrivileges.
+func (data *Data) SetSeriesPrivilege(name string, grant SeriesGrantInfo) error {
+	ui := data.user(name)
+	if ui == nil {
+		return ErrUserNotFound
+	}
+
+	if data.Database(grant.Database) == nil {
+		return influxdb.ErrDatabaseNotFound(grant.Database)
+	}
+
+	if grant.Condition != "" {
+		expr, err := influxql.ParseExpr(grant.Condition)
+		if err != nil {
+			return err
+		}
+		grant.Condition, grant.expr = expr.String(), expr
+	}
+
+	grants := make([]SeriesGrantInfo, 0, len(ui.SeriesGrants)+1)
+	for _, g := range ui.SeriesGrants {
+		if g.Database != grant.Database || g.Measurement != grant.Measurement || g.Condition != grant.Condition {
+			grants = append(grants, g)
+		}
+	}
+	if grant.Privilege != influxql.NoPrivileges {
+		grants = append(grants, grant)
+	}
+	ui.SeriesGrants = grants
+
+	return nil
+}
+
+// Role returns a role by name.
+func (data *Data) Role(name string) *RoleInfo {
+	for i := range data.Roles {
+		if data.Roles[i].Name == name {
+			return &data.Roles[i]
+		}
+	}
+	return nil
+}
+
+// CloneRoles returns a copy of the role infos.
+func (data *Data) CloneRoles() []RoleInfo {
+	if len(data.Roles) == 0 {
+		return nil
+	}
+	roles := make([]RoleInfo, len(data.Roles))
+	for i := range data.Roles {
+		roles[i] = data.Roles[i].clone()
+	}
+	return roles
+}
+
+// CreateRole creates a new role.
+func (data *Data) CreateRole(name string) error {
+	if name == "" {
+		return ErrRoleNameRequired
+	} else if data.Role(name) != nil {
+		return ErrRoleExists
+	}
+
+	data.Roles = append(data.Roles, RoleInfo{Name: name})
+	return nil
+}
+
+// DropRole removes an existing role by name and revokes it from its users.
+func (data *Data) DropRole(name string) error {
+	for i := range data.Roles {
+		if data.Roles[i].Name == name {
+			data.Roles = append(data.Roles[:i], data.Roles[i+1:]...)
+
+			for j := range data.Users {
+				data.Users[j].Roles = removeString(data.Users[j].Roles, name)
+			}
+			data.resolveRolePrivileges()
+			return nil
+		}
+	}
+	return ErrRoleNotFound
+}
+
+// SetRolePrivilege sets a privilege for a role on a database.
+func (data *Data) SetRolePrivilege(name, database string, p influxql.Privilege) error {
+	ri := data.Role(name)
+	if ri == nil {
+		return ErrRoleNotFound
+	}
+
+	if data.Database(database) == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	if ri.Privileges == nil {
+		ri.Privileges = make(map[string]influxql.Privilege)
+	}
+	ri.Privileges[database] = p
+
+	data.resolveRolePrivileges()
+	return nil
+}
+
+// AddUserRole grants a role to a user.
+func (data *Data) AddUserRole(username, role string) error {
+	ui := data.user(username)
+	if ui == nil {
+		return ErrUserNotFound
+	} else if data.Role(role) == nil {
+		return ErrRoleNotFound
+	}
+
+	for _, r := range ui.Roles {
+		if r == role {
+			return nil
+		}
+	}
+	ui.Roles = append(ui.Roles, role)
+
+	data.resolveRolePrivileges()
+	return nil
+}
+
+// RemoveUserRole revokes a role from a user.
+func (data *Data) RemoveUserRole(username, role string) error {
+	ui := data.user(username)
+	if ui == nil {
+		return ErrUserNotFound
+	} else if data.Role(role) == nil {
+		return ErrRoleNotFound
+	}
+
+	ui.Roles = removeString(ui.Roles, role)
+
+	data.resolveRolePrivileges()
+	return nil
+}
+
+// resolveRolePrivileges recomputes the privileges each user is granted
+// through its roles.  It must be called whenever roles or the roles of a
+// user change.
+func (data *Data) resolveRolePrivileges() {
+	for i := range data.Users {
+		ui := &data.Users[i]
+		ui.rolePrivileges = nil
+		for _, name := range ui.Roles {
+			ri := data.Role(name)
+			if ri == nil {
+				continue
+			}
+			for db, p := range ri.Privileges {
+				if ui.rolePrivileges == nil {
+					ui.rolePrivileges = make(map[string]influxql.Privilege)
+				}
+				ui.rolePrivileges[db] |= p
+			}
+		}
+	}
+}
+
+// removeString returns a with every occurrence of s removed.
+func removeString(a []string, s string) []string {
+	other := make([]string, 0, len(a))
+	for _, v := range a {
+		if v != s {
+			other = append(other, v)
+		}
+	}
+	return other
+}
+
+// Token returns a token by ID.
+func (data *Data) Token(id string) *TokenInfo {
+	for i := range data.Tokens {
+		if data.Tokens[i].ID == id {
+			return &data.Tokens[i]
+		}
+	}
+	return nil
+}
+
+// CloneTokens returns a copy of the token infos.
+func (data *Data) CloneTokens() []TokenInfo {
+	if len(data.Tokens) == 0 {
+		return nil
+	}
+	tokens := make([]TokenInfo, len(data.Tokens))
+	for i := range data.Tokens {
+		tokens[i] = data.Tokens[i].clone()
+	}
+	return tokens
+}
+
+// CreateToken adds a token for an existing user.
+func (data *Data) CreateToken(ti TokenInfo) error {
+	if data.user(ti.User) == nil {
+		return ErrUserNotFound
+	} else if ti.Database != "" && data.Database(ti.Database) == nil {
+		return influxdb.ErrDatabaseNotFound(ti.Database)
+	} else if data.Token(ti.ID) != nil {
+		return ErrTokenExists
+	}
+
+	data.Tokens = append(data.Tokens, ti)
+	return nil
+}
+
+// DropToken removes a token by ID.
+func (data *Data) DropToken(id string) error {
+	for i := range data.Tokens {
+		if data.Tokens[i].ID == id {
+			data.Tokens = append(data.Tokens[:i], data.Tokens[i+1:]...)
+			return nil
+		}
+	}
+	return ErrTokenNotFound
+}
+
+// SetTokenLastUsed sets the time a token was last used.
+func (data *Data) SetTokenLastUsed(id string, t time.Time) error {
+	ti := data.Token(id)
+	if ti == nil {
+		return ErrTokenNotFound
+	}
+	ti.LastUsed = t
+	return nil
+}
+
 // SetAdminPrivilege sets the admin privilege for a user.
 func (data *Data) SetAdminPrivilege(name string, admin bool) error {
 	ui := data.user(name)
@@ -653,6 +1158,26 @@ func (data *Data) UserPrivileges(name string) (map[string]influxql.Privilege, er
 	return ui.Privileges, nil
 }
 
+// UserSeriesGrants gets the series grants of a user.
+func (data *Data) UserSeriesGrants(name string) ([]SeriesGrantInfo, error) {
+	ui := data.user(name)
+	if ui == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return ui.SeriesGrants, nil
+}
+
+// UserRoles gets the names of the roles granted to a user.
+func (data *Data) UserRoles(name string) ([]string, error) {
+	ui := data.user(name)
+	if ui == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return ui.Roles, nil
+}
+
 // UserPrivilege gets the privilege for a user on a database.
 func (data *Data) UserPrivilege(name, database string) (*influxql.Privilege, error) {
 	ui := data.user(name)
@@ -675,6 +1200,8 @@ func (data *Data) Clone() *Data {
 
 	other.Databases = data.CloneDatabases()
 	other.Users = data.CloneUsers()
+	other.Roles = data.CloneRoles()
+	other.Tokens = data.CloneTokens()
 
 	return &other
 }
@@ -703,6 +1230,16 @@ func (data *Data) marshal() *internal.Data {
 		pb.Users[i] = data.Users[i].marshal()
 	}
 
+	pb.Roles = make([]*internal.RoleInfo, len(data.Roles))
+	for i := range data.Roles {
+		pb.Roles[i] = data.Roles[i].marshal()
+	}
+
+	pb.Tokens = make([]*internal.TokenInfo, len(data.Tokens))
+	for i := range data.Tokens {
+		pb.Tokens[i] = data.Tokens[i].marshal()
+	}
+
 	return pb
 }
 
@@ -725,6 +1262,23 @@ func (data *Data) unmarshal(pb *internal.Data) {
 		data.Users[i].unmarshal(x)
 	}
 
+	data.Roles = nil
+	if len(pb.GetRoles()) > 0 {
+		data.Roles = make([]RoleInfo, len(pb.GetRoles()))
+		for i, x := range pb.GetRoles() {
+			data.Roles[i].unmarshal(x)
+		}
+	}
+	data.resolveRolePrivileges()
+
+	data.Tokens = nil
+	if len(pb.GetTokens()) > 0 {
+		data.Tokens = make([]TokenInfo, len(pb.GetTokens()))
+		for i, x := range pb.GetTokens() {
+			data.Tokens[i].unmarshal(x)
+		}
+	}
+
 	// Exhaustively determine if there is an admin user. The marshalled cache
 	// value may not be correct.
 	data.adminUserExists = data.hasAdminUser()
@@ -781,6 +1335,22 @@ type DatabaseInfo struct {
 	DefaultRetentionPolicy string
 	RetentionPolicies      []RetentionPolicyInfo
 	ContinuousQueries      []ContinuousQueryInfo
+
+	// WALMode is the durability mode of the WAL for the database's shards.
+	// An empty value uses the default (group commit) mode.
+	WALMode string
+
+	// DuplicatePolicy decides which value is kept when a point is written
+	// with the same series and timestamp as an existing one.  An empty value
+	// uses the default (last write wins) policy.
+	DuplicatePolicy string
+
+	// CardinalityLimits limit the number of series in measurements and the
+	// number of values of tag keys.
+	CardinalityLimits []CardinalityLimitInfo
+
+	// ReadOnly refuses writes and deletes of data in the database.
+	ReadOnly bool
 }
 
 // RetentionPolicy returns a retention policy by name.
@@ -800,6 +1370,33 @@ func (di DatabaseInfo) RetentionPolicy(name string) *RetentionPolicyInfo {
 	return nil
 }
 
+// CheckWritable returns an error if data can't be written to or deleted from
+// a retention policy because it or the database is read only. An empty name
+// checks the default retention policy.
+func (di DatabaseInfo) CheckWritable(retentionPolicy string) error {
+	if di.ReadOnly {
+		return influxdb.ErrReadOnly(di.Name, "")
+	}
+	if rpi := di.RetentionPolicy(retentionPolicy); rpi != nil && rpi.ReadOnly {
+		return influxdb.ErrReadOnly(di.Name, rpi.Name)
+	}
+	return nil
+}
+
+// CheckAllWritable returns an error if data can't be deleted from all
+// retention policies of the database because any of them is read only.
+func (di DatabaseInfo) CheckAllWritable() error {
+	if di.ReadOnly {
+		return influxdb.ErrReadOnly(di.Name, "")
+	}
+	for i := range di.RetentionPolicies {
+		if di.RetentionPolicies[i].ReadOnly {
+			return influxdb.ErrReadOnly(di.Name, di.RetentionPolicies[i].Name)
+		}
+	}
+	return nil
+}
+
 // ShardInfos returns a list of all shards' info for the database.
 func (di DatabaseInfo) ShardInfos() []ShardInfo {
 	shards := map[uint64]*ShardInfo{}
@@ -844,6 +1441,12 @@ func (di DatabaseInfo) clone() DatabaseInfo {
 		}
 	}
 
+	// Copy cardinality limits.
+	if di.CardinalityLimits != nil {
+		other.CardinalityLimits = make([]CardinalityLimitInfo, len(di.CardinalityLimits))
+		copy(other.CardinalityLimits, di.CardinalityLimits)
+	}
+
 	return other
 }
 
@@ -862,6 +1465,21 @@ func (di DatabaseInfo) marshal() *internal.DatabaseInfo {
 	for i := range di.ContinuousQueries {
 		pb.ContinuousQueries[i] = di.ContinuousQueries[i].marshal()
 	}
+
+	if di.WALMode != "" {
+		pb.WALMode = proto.String(di.WALMode)
+	}
+	if di.DuplicatePolicy != "" {
+		pb.DuplicatePolicy = proto.String(di.DuplicatePolicy)
+	}
+	if di.ReadOnly {
+		pb.ReadOnly = proto.Bool(true)
+	}
+
+	pb.CardinalityLimits = make([]*internal.CardinalityLimitInfo, len(di.CardinalityLimits))
+	for i := range di.CardinalityLimits {
+		pb.CardinalityLimits[i] = di.CardinalityLimits[i].marshal()
+	}
 	return pb
 }
 
@@ -869,6 +1487,9 @@ func (di DatabaseInfo) marshal() *internal.DatabaseInfo {
 func (di *DatabaseInfo) unmarshal(pb *internal.DatabaseInfo) {
 	di.Name = pb.GetName()
 	di.DefaultRetentionPolicy = pb.GetDefaultRetentionPolicy()
+	di.WALMode = pb.GetWALMode()
+	di.DuplicatePolicy = pb.GetDuplicatePolicy()
+	di.ReadOnly = pb.GetReadOnly()
 
 	if len(pb.GetRetentionPolicies()) > 0 {
 		di.RetentionPolicies = make([]RetentionPolicyInfo, len(pb.GetRetentionPolicies()))
@@ -883,6 +1504,13 @@ func (di *DatabaseInfo) unmarshal(pb *internal.DatabaseInfo) {
 			di.ContinuousQueries[i].unmarshal(x)
 		}
 	}
+
+	if len(pb.GetCardinalityLimits()) > 0 {
+		di.CardinalityLimits = make([]CardinalityLimitInfo, len(pb.GetCardinalityLimits()))
+		for i, x := range pb.GetCardinalityLimits() {
+			di.CardinalityLimits[i].unmarshal(x)
+		}
+	}
 }
 
 // RetentionPolicySpec represents the specification for a new retention policy.
@@ -891,6 +1519,7 @@ type RetentionPolicySpec struct {
 	ReplicaN           *int
 	Duration           *time.Duration
 	ShardGroupDuration time.Duration
+	SeriesDuration     time.Duration
 }
 
 // NewRetentionPolicyInfo creates a new retention policy info from the specification.
@@ -909,6 +1538,8 @@ func (s *RetentionPolicySpec) Matches(rpi *RetentionPolicyInfo) bool {
 		return false
 	} else if s.ReplicaN != nil && *s.ReplicaN != rpi.ReplicaN {
 		return false
+	} else if s.SeriesDuration > 0 && s.SeriesDuration != rpi.SeriesDuration {
+		return false
 	}
 
 	// Normalise ShardDuration before comparing to any existing retention policies.
@@ -933,6 +1564,9 @@ func (s *RetentionPolicySpec) marshal() *internal.RetentionPolicySpec {
 	if s.ReplicaN != nil {
 		pb.ReplicaN = proto.Uint32(uint32(*s.ReplicaN))
 	}
+	if s.SeriesDuration > 0 {
+		pb.SeriesDuration = proto.Int64(int64(s.SeriesDuration))
+	}
 	return pb
 }
 
@@ -952,6 +1586,9 @@ func (s *RetentionPolicySpec) unmarshal(pb *internal.RetentionPolicySpec) {
 		replicaN := int(pb.GetReplicaN())
 		s.ReplicaN = &replicaN
 	}
+	if pb.SeriesDuration != nil {
+		s.SeriesDuration = time.Duration(pb.GetSeriesDuration())
+	}
 }
 
 // MarshalBinary encodes RetentionPolicySpec to a binary format.
@@ -975,8 +1612,12 @@ type RetentionPolicyInfo struct {
 	ReplicaN           int
 	Duration           time.Duration
 	ShardGroupDuration time.Duration
+	SeriesDuration     time.Duration
 	ShardGroups        []ShardGroupInfo
 	Subscriptions      []SubscriptionInfo
+
+	// ReadOnly refuses writes and deletes of data in the retention policy.
+	ReadOnly bool
 }
 
 // NewRetentionPolicyInfo returns a new instance of RetentionPolicyInfo
@@ -1002,6 +1643,7 @@ func (rpi *RetentionPolicyInfo) Apply(spec *RetentionPolicySpec) *RetentionPolic
 		ReplicaN:           rpi.ReplicaN,
 		Duration:           rpi.Duration,
 		ShardGroupDuration: rpi.ShardGroupDuration,
+		SeriesDuration:     rpi.SeriesDuration,
 	}
 	if spec.Name != "" {
 		rp.Name = spec.Name
@@ -1012,6 +1654,9 @@ func (rpi *RetentionPolicyInfo) Apply(spec *RetentionPolicySpec) *RetentionPolic
 	if spec.Duration != nil {
 		rp.Duration = *spec.Duration
 	}
+	if spec.SeriesDuration > 0 {
+		rp.SeriesDuration = spec.SeriesDuration
+	}
 	rp.ShardGroupDuration = normalisedShardDuration(spec.ShardGroupDuration, rp.Duration)
 	return rp
 }
@@ -1062,6 +1707,12 @@ func (rpi *RetentionPolicyInfo) marshal() *internal.RetentionPolicyInfo {
 		Duration:           proto.Int64(int64(rpi.Duration)),
 		ShardGroupDuration: proto.Int64(int64(rpi.ShardGroupDuration)),
 	}
+	if rpi.SeriesDuration > 0 {
+		pb.SeriesDuration = proto.Int64(int64(rpi.SeriesDuration))
+	}
+	if rpi.ReadOnly {
+		pb.ReadOnly = proto.Bool(true)
+	}
 
 	pb.ShardGroups = make([]*internal.ShardGroupInfo, len(rpi.ShardGroups))
 	for i, sgi := range rpi.ShardGroups {
@@ -1082,6 +1733,8 @@ func (rpi *RetentionPolicyInfo) unmarshal(pb *internal.RetentionPolicyInfo) {
 	rpi.ReplicaN = int(pb.GetReplicaN())
 	rpi.Duration = time.Duration(pb.GetDuration())
 	rpi.ShardGroupDuration = time.Duration(pb.GetShardGroupDuration())
+	rpi.SeriesDuration = time.Duration(pb.GetSeriesDuration())
+	rpi.ReadOnly = pb.GetReadOnly()
 
 	if len(pb.GetShardGroups()) > 0 {
 		rpi.ShardGroups = make([]ShardGroupInfo, len(pb.GetShardGroups()))
@@ -1432,6 +2085,42 @@ func (cqi *ContinuousQueryInfo) unmarshal(pb *internal.ContinuousQueryInfo) {
 	cqi.Query = pb.GetQuery()
 }
 
+// CardinalityLimitInfo represents metadata about a cardinality limit.  It
+// limits the number of series in a measurement or, if TagKey is set, the
+// number of values of the tag key within the measurement.
+type CardinalityLimitInfo struct {
+	Measurement string
+	TagKey      string
+	Max         int
+
+	// Policy decides what happens to writes exceeding the limit ("reject",
+	// "drop" or "log").  An empty value uses the default (drop) policy.
+	Policy string
+}
+
+// marshal serializes to a protobuf representation.
+func (cli CardinalityLimitInfo) marshal() *internal.CardinalityLimitInfo {
+	pb := &internal.CardinalityLimitInfo{
+		Measurement: proto.String(cli.Measurement),
+		Max:         proto.Int64(int64(cli.Max)),
+	}
+	if cli.TagKey != "" {
+		pb.TagKey = proto.String(cli.TagKey)
+	}
+	if cli.Policy != "" {
+		pb.Policy = proto.String(cli.Policy)
+	}
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (cli *CardinalityLimitInfo) unmarshal(pb *internal.CardinalityLimitInfo) {
+	cli.Measurement = pb.GetMeasurement()
+	cli.TagKey = pb.GetTagKey()
+	cli.Max = int(pb.GetMax())
+	cli.Policy = pb.GetPolicy()
+}
+
 var _ influxql.Authorizer = (*UserInfo)(nil)
 
 // UserInfo represents metadata about a user in the system.
@@ -1447,6 +2136,24 @@ type UserInfo struct {
 
 	// Map of database name to granted privilege.
 	Privileges map[string]influxql.Privilege
+
+	// Privileges granted on the series of a database.
+	SeriesGrants []SeriesGrantInfo
+
+	// Names of the roles granted to the user.
+	Roles []string
+
+	// Previous password hashes, most recent first.
+	PasswordHistory []string
+
+	// Time of the last successful login with a password.
+	LastLogin time.Time
+
+	// Time until which logins are refused after too many failures.
+	LockedUntil time.Time
+
+	// Map of database name to the privileges granted through roles.
+	rolePrivileges map[string]influxql.Privilege
 }
 
 type User interface {
@@ -1464,22 +2171,135 @@ func (u *UserInfo) IsAdmin() bool {
 }
 
 // AuthorizeDatabase returns true if the user is authorized for the given privilege on the given database.
+// Series grants don't authorize the database, see AuthorizeSeriesRead and AuthorizeSeriesWrite.
 func (ui *UserInfo) AuthorizeDatabase(privilege influxql.Privilege, database string) bool {
 	if ui.Admin || privilege == influxql.NoPrivileges {
 		return true
 	}
-	p, ok := ui.Privileges[database]
-	return ok && (p == privilege || p == influxql.AllPrivileges)
+	p := ui.Privileges[database] | ui.rolePrivileges[database]
+	return p == privilege || p == influxql.AllPrivileges
 }
 
-// AuthorizeSeriesRead is used to limit access per-series (enterprise only)
-func (u *UserInfo) AuthorizeSeriesRead(database string, measurement []byte, tags models.Tags) bool {
+// HasSeriesGrant returns true if the user has a series grant for privilege on
+// database.
+func (ui *UserInfo) HasSeriesGrant(privilege influxql.Privilege, database string) bool {
+	for i := range ui.SeriesGrants {
+		if ui.SeriesGrants[i].allows(privilege, database) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizeSeriesDelete returns true if the user can delete the series of
+// sources matching condition, either because it has write privilege on the
+// database or because its series grants cover every series that may match.
+// A grant covers the series of a source if it applies to the measurement of
+// the source, or to all measurements, and its condition is empty or one of
+// the conditions ANDed together in condition.
+func (ui *UserInfo) AuthorizeSeriesDelete(database string, sources influxql.Sources, condition influxql.Expr) bool {
+	if ui.AuthorizeDatabase(influxql.WritePrivilege, database) {
+		return true
+	}
+
+	// No sources delete from all measurements.
+	var names []string
+	for _, src := range sources {
+		m, ok := src.(*influxql.Measurement)
+		if !ok || m.Regex != nil {
+			names = append(names, "")
+			continue
+		}
+		names = append(names, m.Name)
+	}
+	if len(names) == 0 {
+		names = append(names, "")
+	}
+
+	conds := make(map[string]struct{})
+	for _, expr := range andExprs(condition) {
+		conds[expr.String()] = struct{}{}
+	}
+
+	for _, name := range names {
+		var covered bool
+		for i := range ui.SeriesGrants {
+			g := &ui.SeriesGrants[i]
+			if !g.allows(influxql.WritePrivilege, database) || (g.Measurement != "" && g.Measurement != name) {
+				continue
+			} else if g.Condition == "" {
+				covered = true
+			} else if g.expr != nil {
+				_, covered = conds[g.expr.String()]
+			}
+			if covered {
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
 	return true
 }
 
-// AuthorizeSeriesWrite is used to limit access per-series (enterprise only)
+// andExprs returns the expressions ANDed together in expr.
+func andExprs(expr influxql.Expr) []influxql.Expr {
+	switch e := expr.(type) {
+	case nil:
+		return nil
+	case *influxql.ParenExpr:
+		return andExprs(e.Expr)
+	case *influxql.BinaryExpr:
+		if e.Op == influxql.AND {
+			return append(andExprs(e.LHS), andExprs(e.RHS)...)
+		}
+	}
+	return []influxql.Expr{expr}
+}
+
+// AuthorizeSeriesRead returns true if the user can read the series of measurement with tags.
+func (u *UserInfo) AuthorizeSeriesRead(database string, measurement []byte, tags models.Tags) bool {
+	return u.authorizeSeries(influxql.ReadPrivilege, database, measurement, tags)
+}
+
+// AuthorizeSeriesWrite returns true if the user can write the series of measurement with tags.
 func (u *UserInfo) AuthorizeSeriesWrite(database string, measurement []byte, tags models.Tags) bool {
-	return true
+	return u.authorizeSeries(influxql.WritePrivilege, database, measurement, tags)
+}
+
+// authorizeSeries returns true if the user has privilege on the database or
+// on a series grant that matches the series.
+func (u *UserInfo) authorizeSeries(privilege influxql.Privilege, database string, measurement []byte, tags models.Tags) bool {
+	if u.AuthorizeDatabase(privilege, database) {
+		return true
+	}
+
+	var m map[string]interface{}
+	for i := range u.SeriesGrants {
+		g := &u.SeriesGrants[i]
+		if !g.allows(privilege, database) {
+			continue
+		} else if g.Measurement != "" && g.Measurement != string(measurement) {
+			continue
+		} else if g.Condition == "" {
+			return true
+		} else if g.expr == nil {
+			// Conditions that can't be parsed match no series.
+			continue
+		}
+
+		if m == nil {
+			m = make(map[string]interface{}, len(tags))
+			for _, t := range tags {
+				m[string(t.Key)] = string(t.Value)
+			}
+		}
+		if influxql.EvalBool(g.expr, m) {
+			return true
+		}
+	}
+	return false
 }
 
 // clone returns a deep copy of si.
@@ -1493,9 +2313,29 @@ func (ui UserInfo) clone() UserInfo {
 		}
 	}
 
+	if ui.SeriesGrants != nil {
+		other.SeriesGrants = make([]SeriesGrantInfo, len(ui.SeriesGrants))
+		copy(other.SeriesGrants, ui.SeriesGrants)
+	}
+
+	if ui.Roles != nil {
+		other.Roles = make([]string, len(ui.Roles))
+		copy(other.Roles, ui.Roles)
+	}
+
+	if ui.PasswordHistory != nil {
+		other.PasswordHistory = make([]string, len(ui.PasswordHistory))
+		copy(other.PasswordHistory, ui.PasswordHistory)
+	}
+
 	return other
 }
 
+// Locked returns true if logins of the user are refused at t.
+func (ui *UserInfo) Locked(t time.Time) bool {
+	return t.Before(ui.LockedUntil)
+}
+
 // marshal serializes to a protobuf representation.
 func (ui UserInfo) marshal() *internal.UserInfo {
 	pb := &internal.UserInfo{
@@ -1511,6 +2351,20 @@ func (ui UserInfo) marshal() *internal.UserInfo {
 		})
 	}
 
+	for i := range ui.SeriesGrants {
+		pb.SeriesGrants = append(pb.SeriesGrants, ui.SeriesGrants[i].marshal())
+	}
+
+	pb.Roles = append(pb.Roles, ui.Roles...)
+	pb.PasswordHistory = append(pb.PasswordHistory, ui.PasswordHistory...)
+
+	if !ui.LastLogin.IsZero() {
+		pb.LastLogin = proto.Int64(MarshalTime(ui.LastLogin))
+	}
+	if !ui.LockedUntil.IsZero() {
+		pb.LockedUntil = proto.Int64(MarshalTime(ui.LockedUntil))
+	}
+
 	return pb
 }
 
@@ -1524,6 +2378,252 @@ func (ui *UserInfo) unmarshal(pb *internal.UserInfo) {
 	for _, p := range pb.GetPrivileges() {
 		ui.Privileges[p.GetDatabase()] = influxql.Privilege(p.GetPrivilege())
 	}
+
+	ui.SeriesGrants = nil
+	for _, x := range pb.GetSeriesGrants() {
+		var g SeriesGrantInfo
+		g.unmarshal(x)
+		ui.SeriesGrants = append(ui.SeriesGrants, g)
+	}
+
+	ui.Roles = pb.GetRoles()
+	ui.PasswordHistory = pb.GetPasswordHistory()
+	ui.LastLogin = UnmarshalTime(pb.GetLastLogin())
+	ui.LockedUntil = UnmarshalTime(pb.GetLockedUntil())
+}
+
+// RoleInfo represents metadata about a role.  Users granted a role have
+// the privileges of the role in addition to their own.
+type RoleInfo struct {
+	// Role's name.
+	Name string
+
+	// Map of database name to granted privilege.
+	Privileges map[string]influxql.Privilege
+}
+
+// clone returns a deep copy of ri.
+func (ri RoleInfo) clone() RoleInfo {
+	other := ri
+
+	if ri.Privileges != nil {
+		other.Privileges = make(map[string]influxql.Privilege)
+		for k, v := range ri.Privileges {
+			other.Privileges[k] = v
+		}
+	}
+
+	return other
+}
+
+// marshal serializes to a protobuf representation.
+func (ri RoleInfo) marshal() *internal.RoleInfo {
+	pb := &internal.RoleInfo{
+		Name: proto.String(ri.Name),
+	}
+
+	for database, privilege := range ri.Privileges {
+		pb.Privileges = append(pb.Privileges, &internal.UserPrivilege{
+			Database:  proto.String(database),
+			Privilege: proto.Int32(int32(privilege)),
+		})
+	}
+
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (ri *RoleInfo) unmarshal(pb *internal.RoleInfo) {
+	ri.Name = pb.GetName()
+
+	ri.Privileges = make(map[string]influxql.Privilege)
+	for _, p := range pb.GetPrivileges() {
+		ri.Privileges[p.GetDatabase()] = influxql.Privilege(p.GetPrivilege())
+	}
+}
+
+// TokenInfo represents metadata about an API token.  Only a hash of the
+// token's secret is stored.
+type TokenInfo struct {
+	// Public identifier of the token.
+	ID string
+
+	// Name of the user the token authenticates.
+	User string
+
+	// SHA-256 hash of the token's secret.
+	Hash []byte
+
+	// Database and privilege the token is scoped to.  A token without a
+	// database has all the privileges of its user.
+	Database  string
+	Privilege influxql.Privilege
+
+	CreatedAt time.Time
+
+	// Time the token expires, or zero if it never expires.
+	Expires time.Time
+
+	// Time the token was last used to authenticate, or zero if it was
+	// never used.
+	LastUsed time.Time
+}
+
+// Expired returns true if the token expired at t.
+func (ti *TokenInfo) Expired(t time.Time) bool {
+	return !ti.Expires.IsZero() && !t.Before(ti.Expires)
+}
+
+// allows returns true if the scope of the token allows privilege on database.
+func (ti *TokenInfo) allows(privilege influxql.Privilege, database string) bool {
+	if ti.Database == "" || privilege == influxql.NoPrivileges {
+		return true
+	}
+	return ti.Database == database && (ti.Privilege == privilege || ti.Privilege == influxql.AllPrivileges)
+}
+
+// clone returns a deep copy of ti.
+func (ti TokenInfo) clone() TokenInfo {
+	other := ti
+	other.Hash = append([]byte(nil), ti.Hash...)
+	return other
+}
+
+// marshal serializes to a protobuf representation.
+func (ti TokenInfo) marshal() *internal.TokenInfo {
+	pb := &internal.TokenInfo{
+		ID:        proto.String(ti.ID),
+		User:      proto.String(ti.User),
+		Hash:      ti.Hash,
+		CreatedAt: proto.Int64(MarshalTime(ti.CreatedAt)),
+	}
+	if ti.Database != "" {
+		pb.Database = proto.String(ti.Database)
+		pb.Privilege = proto.Int32(int32(ti.Privilege))
+	}
+	if !ti.Expires.IsZero() {
+		pb.Expires = proto.Int64(MarshalTime(ti.Expires))
+	}
+	if !ti.LastUsed.IsZero() {
+		pb.LastUsed = proto.Int64(MarshalTime(ti.LastUsed))
+	}
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (ti *TokenInfo) unmarshal(pb *internal.TokenInfo) {
+	ti.ID = pb.GetID()
+	ti.User = pb.GetUser()
+	ti.Hash = pb.GetHash()
+	ti.Database = pb.GetDatabase()
+	ti.Privilege = influxql.Privilege(pb.GetPrivilege())
+	ti.CreatedAt = UnmarshalTime(pb.GetCreatedAt())
+	ti.Expires = UnmarshalTime(pb.GetExpires())
+	ti.LastUsed = UnmarshalTime(pb.GetLastUsed())
+}
+
+// tokenUser is a user authenticated with a token.  The user is limited to
+// the scope of the token.
+type tokenUser struct {
+	*UserInfo
+	token TokenInfo
+}
+
+// ExternalUser is a user authenticated by a provider other than the meta
+// store. Its privileges are set by the provider and aren't read from the meta
+// store, even if a user with the same name exists there.
+type ExternalUser struct {
+	*UserInfo
+}
+
+// IsAdmin returns true if the user is an admin and the token isn't scoped.
+func (u *tokenUser) IsAdmin() bool {
+	return u.UserInfo.Admin && u.token.Database == ""
+}
+
+// AuthorizeDatabase returns true if the user and the token are authorized
+// for the given privilege on the given database.
+func (u *tokenUser) AuthorizeDatabase(privilege influxql.Privilege, database string) bool {
+	return u.token.allows(privilege, database) && u.UserInfo.AuthorizeDatabase(privilege, database)
+}
+
+// HasSeriesGrant returns true if the token allows privilege on database and the
+// user has a series grant for it.
+func (u *tokenUser) HasSeriesGrant(privilege influxql.Privilege, database string) bool {
+	return u.token.allows(privilege, database) && u.UserInfo.HasSeriesGrant(privilege, database)
+}
+
+// AuthorizeSeriesDelete returns true if the token allows writes to database and
+// the user can delete the series of sources matching condition.
+func (u *tokenUser) AuthorizeSeriesDelete(database string, sources influxql.Sources, condition influxql.Expr) bool {
+	return u.token.allows(influxql.WritePrivilege, database) && u.UserInfo.AuthorizeSeriesDelete(database, sources, condition)
+}
+
+// AuthorizeSeriesRead returns true if the user and the token can read the series of measurement with tags.
+func (u *tokenUser) AuthorizeSeriesRead(database string, measurement []byte, tags models.Tags) bool {
+	return u.token.allows(influxql.ReadPrivilege, database) && u.UserInfo.AuthorizeSeriesRead(database, measurement, tags)
+}
+
+// AuthorizeSeriesWrite returns true if the user and the token can write the series of measurement with tags.
+func (u *tokenUser) AuthorizeSeriesWrite(database string, measurement []byte, tags models.Tags) bool {
+	return u.token.allows(influxql.WritePrivilege, database) && u.UserInfo.AuthorizeSeriesWrite(database, measurement, tags)
+}
+
+// AuthorizeQuery authorizes the user to execute query on database within
+// the scope of the token.
+func (u *tokenUser) AuthorizeQuery(database string, query *influxql.Query) error {
+	return authorizeQuery(u, database, query)
+}
+
+// SeriesGrantInfo represents a privilege granted on the series of a
+// measurement that match a condition on their tags.
+type SeriesGrantInfo struct {
+	// Database of the series.
+	Database string
+
+	// Measurement of the series, or empty for all measurements.
+	Measurement string
+
+	// Condition on the tags of the series, or empty for all series.
+	Condition string
+
+	// Privilege granted on the series.
+	Privilege influxql.Privilege
+
+	// Parsed condition.
+	expr influxql.Expr
+}
+
+// allows returns true if the grant is for privilege on database.
+func (gi *SeriesGrantInfo) allows(privilege influxql.Privilege, database string) bool {
+	return gi.Database == database && (gi.Privilege == privilege || gi.Privilege == influxql.AllPrivileges)
+}
+
+// marshal serializes to a protobuf representation.
+func (gi SeriesGrantInfo) marshal() *internal.SeriesGrantInfo {
+	pb := &internal.SeriesGrantInfo{
+		Database:  proto.String(gi.Database),
+		Privilege: proto.Int32(int32(gi.Privilege)),
+	}
+	if gi.Measurement != "" {
+		pb.Measurement = proto.String(gi.Measurement)
+	}
+	if gi.Condition != "" {
+		pb.Condition = proto.String(gi.Condition)
+	}
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (gi *SeriesGrantInfo) unmarshal(pb *internal.SeriesGrantInfo) {
+	gi.Database = pb.GetDatabase()
+	gi.Measurement = pb.GetMeasurement()
+	gi.Condition = pb.GetCondition()
+	gi.Privilege = influxql.Privilege(pb.GetPrivilege())
+	gi.expr = nil
+	if gi.Condition != "" {
+		gi.expr, _ = influxql.ParseExpr(gi.Condition)
+	}
 }
 
 // Lease represents a lease held on a resource.
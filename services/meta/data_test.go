@@ -7,6 +7,8 @@ import (
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
 
 	"github.com/influxdata/influxdb/services/meta"
 )
@@ -111,6 +113,186 @@ func Test_Data_CreateRetentionPolicy(t *testing.T) {
 	}
 }
 
+func TestData_UpdateRetentionPolicy_SeriesDuration(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateRetentionPolicy("foo", &meta.RetentionPolicyInfo{
+		Name:     "bar",
+		ReplicaN: 1,
+		Duration: 24 * time.Hour,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var rpu meta.RetentionPolicyUpdate
+	rpu.SetSeriesDuration(time.Hour)
+	if err := data.UpdateRetentionPolicy("foo", "bar", &rpu, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The series duration must survive a round trip through the protobuf format.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other meta.Data
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	if rp, err := other.RetentionPolicy("foo", "bar"); err != nil {
+		t.Fatal(err)
+	} else if got, exp := rp.SeriesDuration, time.Hour; got != exp {
+		t.Fatalf("unexpected series duration: got %s, exp %s", got, exp)
+	}
+
+	rpu.SetSeriesDuration(-time.Hour)
+	if err := data.UpdateRetentionPolicy("foo", "bar", &rpu, false); err != meta.ErrSeriesDurationInvalid {
+		t.Fatalf("unexpected error: got %v, exp %s", err, meta.ErrSeriesDurationInvalid)
+	}
+}
+
+// Ensure the WAL modes accepted for a database are the modes of the engine.
+func TestData_UpdateDatabase_WALMode(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{tsdb.WALModeFsync, tsdb.WALModeGroup, tsdb.WALModeNone, ""} {
+		if !tsdb.ValidWALMode(mode) {
+			t.Fatalf("invalid engine wal mode: %q", mode)
+		}
+
+		var du meta.DatabaseUpdate
+		du.SetWALMode(mode)
+		if err := data.UpdateDatabase("foo", &du); err != nil {
+			t.Fatalf("unexpected error for wal mode %q: %s", mode, err)
+		} else if got := data.Database("foo").WALMode; got != mode {
+			t.Fatalf("unexpected wal mode: got %q, exp %q", got, mode)
+		}
+	}
+
+	var du meta.DatabaseUpdate
+	du.SetWALMode("sometimes")
+	if err := data.UpdateDatabase("foo", &du); err == nil || err.Error() != `invalid wal mode: "sometimes"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestData_SetCardinalityLimit(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetCardinalityLimit("foo", meta.CardinalityLimitInfo{Measurement: "cpu", Max: 10}); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetCardinalityLimit("foo", meta.CardinalityLimitInfo{Measurement: "cpu", TagKey: "host", Max: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Setting a limit on the same measurement and tag key replaces it.
+	if err := data.SetCardinalityLimit("foo", meta.CardinalityLimitInfo{Measurement: "cpu", TagKey: "host", Max: 20, Policy: "reject"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The limits must survive a round trip through the protobuf format.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other meta.Data
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	exp := []meta.CardinalityLimitInfo{
+		{Measurement: "cpu", Max: 10},
+		{Measurement: "cpu", TagKey: "host", Max: 20, Policy: "reject"},
+	}
+	if got := other.Database("foo").CardinalityLimits; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected limits: got %#v, exp %#v", got, exp)
+	}
+
+	if err := data.DropCardinalityLimit("foo", "cpu", ""); err != nil {
+		t.Fatal(err)
+	} else if got, exp := len(data.Database("foo").CardinalityLimits), 1; got != exp {
+		t.Fatalf("unexpected number of limits: got %d, exp %d", got, exp)
+	} else if err := data.DropCardinalityLimit("foo", "cpu", ""); err != meta.ErrCardinalityLimitNotFound {
+		t.Fatalf("unexpected error: got %v, exp %s", err, meta.ErrCardinalityLimitNotFound)
+	}
+
+	if err := data.SetCardinalityLimit("foo", meta.CardinalityLimitInfo{Measurement: "cpu"}); err != meta.ErrCardinalityLimitInvalid {
+		t.Fatalf("unexpected error: got %v, exp %s", err, meta.ErrCardinalityLimitInvalid)
+	} else if err := data.SetCardinalityLimit("bar", meta.CardinalityLimitInfo{Measurement: "cpu", Max: 1}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestData_ReplaceShardGroups(t *testing.T) {
+	data := &meta.Data{}
+	if err := data.CreateDatabase("db0"); err != nil {
+		t.Fatal(err)
+	}
+	rpi := &meta.RetentionPolicyInfo{Name: "rp0", ReplicaN: 1, ShardGroupDuration: time.Hour}
+	if err := data.CreateRetentionPolicy("db0", rpi, true); err != nil {
+		t.Fatal(err)
+	}
+
+	t0 := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if err := data.CreateShardGroup("db0", "rp0", t0.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := data.ReserveShardIDs(1)
+	if got, exp := ids, []uint64{4}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected shard ids: got %v, exp %v", got, exp)
+	}
+
+	group := meta.ShardGroupInfo{
+		StartTime: t0,
+		EndTime:   t0.Add(2 * time.Hour),
+		Shards:    []meta.ShardInfo{{ID: ids[0]}},
+	}
+
+	// The new group must not overlap the remaining groups.
+	if err := data.ReplaceShardGroups("db0", "rp0", []uint64{1}, []meta.ShardGroupInfo{group}); err != meta.ErrShardGroupOverlaps {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The shard IDs must have been reserved.
+	bad := group
+	bad.Shards = []meta.ShardInfo{{ID: 5}}
+	if err := data.ReplaceShardGroups("db0", "rp0", []uint64{1, 2}, []meta.ShardGroupInfo{bad}); err != meta.ErrShardIDNotReserved {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The replaced groups must exist.
+	if err := data.ReplaceShardGroups("db0", "rp0", []uint64{1, 9}, []meta.ShardGroupInfo{group}); err != meta.ErrShardGroupNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := data.ReplaceShardGroups("db0", "rp0", []uint64{1, 2}, []meta.ShardGroupInfo{group}); err != nil {
+		t.Fatal(err)
+	}
+
+	var groups []meta.ShardGroupInfo
+	for _, sg := range data.Databases[0].RetentionPolicies[0].ShardGroups {
+		if !sg.Deleted() {
+			groups = append(groups, sg)
+		}
+	}
+	if got, exp := len(groups), 2; got != exp {
+		t.Fatalf("unexpected shard groups: got %d, exp %d", got, exp)
+	} else if groups[0].ID != 4 || !groups[0].StartTime.Equal(t0) || groups[0].Shards[0].ID != 4 {
+		t.Fatalf("unexpected shard group: %v", groups[0])
+	} else if groups[1].ID != 3 {
+		t.Fatalf("unexpected shard group: %v", groups[1])
+	}
+}
+
 func TestData_AdminUserExists(t *testing.T) {
 	data := meta.Data{}
 
@@ -229,3 +411,204 @@ func TestUserInfo_AuthorizeDatabase(t *testing.T) {
 		t.Fatalf("expected admin to be authorized but it wasn't")
 	}
 }
+
+func TestData_SetSeriesPrivilege(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("db0"); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateUser("user1", "", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "db0", Measurement: "cpu", Condition: "team='payments'", Privilege: influxql.ReadPrivilege}); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "db0", Condition: "team = 'ops'", Privilege: influxql.AllPrivileges}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Invalid conditions are rejected.
+	if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "db0", Condition: "team =", Privilege: influxql.ReadPrivilege}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Grants are kept when the data is marshaled.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other meta.Data
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	ui := other.User("user1").(*meta.UserInfo)
+
+	if grants, err := other.UserSeriesGrants("user1"); err != nil {
+		t.Fatal(err)
+	} else if len(grants) != 2 || grants[0].Condition != `team = 'payments'` || grants[1].Measurement != "" {
+		t.Fatalf("unexpected grants: %+v", grants)
+	}
+
+	for _, tt := range []struct {
+		privilege   influxql.Privilege
+		measurement string
+		tags        map[string]string
+		exp         bool
+	}{
+		{influxql.ReadPrivilege, "cpu", map[string]string{"team": "payments"}, true},
+		{influxql.WritePrivilege, "cpu", map[string]string{"team": "payments"}, false},
+		{influxql.ReadPrivilege, "mem", map[string]string{"team": "payments"}, false},
+		{influxql.ReadPrivilege, "cpu", map[string]string{"team": "search"}, false},
+		{influxql.ReadPrivilege, "cpu", nil, false},
+		{influxql.WritePrivilege, "mem", map[string]string{"team": "ops"}, true},
+	} {
+		var got bool
+		if tt.privilege == influxql.ReadPrivilege {
+			got = ui.AuthorizeSeriesRead("db0", []byte(tt.measurement), models.NewTags(tt.tags))
+		} else {
+			got = ui.AuthorizeSeriesWrite("db0", []byte(tt.measurement), models.NewTags(tt.tags))
+		}
+		if got != tt.exp {
+			t.Errorf("%s on %s %v: got %v, expected %v", tt.privilege, tt.measurement, tt.tags, got, tt.exp)
+		}
+	}
+
+	// Series grants don't authorize the database or other databases.
+	if ui.AuthorizeDatabase(influxql.ReadPrivilege, "db0") || ui.AuthorizeDatabase(influxql.WritePrivilege, "db0") {
+		t.Fatal("expected database not to be authorized")
+	} else if !ui.HasSeriesGrant(influxql.ReadPrivilege, "db0") || ui.HasSeriesGrant(influxql.ReadPrivilege, "db1") {
+		t.Fatal("unexpected series grants")
+	} else if ui.AuthorizeSeriesRead("db1", []byte("cpu"), models.NewTags(map[string]string{"team": "ops"})) {
+		t.Fatal("expected other database not to be authorized")
+	}
+
+	// Setting no privileges removes a grant and dropping the database removes the rest.
+	if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "db0", Measurement: "cpu", Condition: "team = 'payments'"}); err != nil {
+		t.Fatal(err)
+	} else if grants, _ := data.UserSeriesGrants("user1"); len(grants) != 1 {
+		t.Fatalf("unexpected grants: %+v", grants)
+	} else if err := data.DropDatabase("db0"); err != nil {
+		t.Fatal(err)
+	} else if grants, _ := data.UserSeriesGrants("user1"); len(grants) != 0 {
+		t.Fatalf("unexpected grants: %+v", grants)
+	}
+}
+
+func TestData_Roles(t *testing.T) {
+	data := meta.Data{}
+	for _, db := range []string{"db0", "db1"} {
+		if err := data.CreateDatabase(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := data.CreateUser("user1", "", false); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateRole("readers"); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateRole("readers"); err != meta.ErrRoleExists {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := data.SetRolePrivilege("readers", "db0", influxql.ReadPrivilege); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetRolePrivilege("writers", "db0", influxql.WritePrivilege); err != meta.ErrRoleNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := data.AddUserRole("user1", "readers"); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := data.User("user1")
+	if !ui.AuthorizeDatabase(influxql.ReadPrivilege, "db0") {
+		t.Fatal("expected read on db0 through role")
+	} else if ui.AuthorizeDatabase(influxql.WritePrivilege, "db0") {
+		t.Fatal("unexpected write on db0")
+	} else if ui.AuthorizeDatabase(influxql.ReadPrivilege, "db1") {
+		t.Fatal("unexpected read on db1")
+	}
+
+	// Privileges of the user and its roles are combined.
+	if err := data.SetPrivilege("user1", "db0", influxql.WritePrivilege); err != nil {
+		t.Fatal(err)
+	} else if ui := data.User("user1"); !ui.AuthorizeDatabase(influxql.ReadPrivilege, "db0") || !ui.AuthorizeDatabase(influxql.WritePrivilege, "db0") {
+		t.Fatal("expected read and write on db0")
+	}
+
+	// Roles are kept when the data is marshaled.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var other meta.Data
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(other.Roles, data.Roles) {
+		t.Fatalf("unexpected roles: %+v", other.Roles)
+	} else if !other.User("user1").AuthorizeDatabase(influxql.ReadPrivilege, "db0") {
+		t.Fatal("expected read on db0 after unmarshal")
+	}
+
+	// Privilege changes on the role apply to its users.
+	if err := data.SetRolePrivilege("readers", "db1", influxql.AllPrivileges); err != nil {
+		t.Fatal(err)
+	} else if !data.User("user1").AuthorizeDatabase(influxql.WritePrivilege, "db1") {
+		t.Fatal("expected write on db1 through role")
+	}
+
+	// Dropping a role revokes it from its users.
+	if err := data.DropRole("readers"); err != nil {
+		t.Fatal(err)
+	} else if ui := data.User("user1").(*meta.UserInfo); len(ui.Roles) != 0 {
+		t.Fatalf("unexpected roles: %v", ui.Roles)
+	} else if ui.AuthorizeDatabase(influxql.ReadPrivilege, "db1") {
+		t.Fatal("unexpected read on db1")
+	}
+}
+
+// Ensure series grants only authorize statements limited to their series.
+func TestUserInfo_AuthorizeQuery_SeriesGrants(t *testing.T) {
+	data := meta.Data{}
+	if err := data.CreateDatabase("db0"); err != nil {
+		t.Fatal(err)
+	} else if err := data.CreateUser("user1", "", false); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "db0", Measurement: "cpu", Condition: "team = 'payments'", Privilege: influxql.AllPrivileges}); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetSeriesPrivilege("user1", meta.SeriesGrantInfo{Database: "db0", Measurement: "disk", Privilege: influxql.AllPrivileges}); err != nil {
+		t.Fatal(err)
+	}
+	ui := data.User("user1").(*meta.UserInfo)
+
+	for _, tt := range []struct {
+		q   string
+		exp bool
+	}{
+		{`SELECT value FROM cpu`, true},
+		{`SELECT value INTO cpu_copy FROM cpu`, true},
+		{`SELECT value FROM db1..cpu`, false},
+		{`SELECT * FROM _series`, false},
+		{`SELECT count(value) FROM (SELECT value FROM _fieldKeys)`, false},
+		{`SHOW SERIES`, false},
+		{`SHOW MEASUREMENTS`, false},
+		{`SHOW TAG KEYS FROM cpu`, false},
+		{`SHOW TAG VALUES FROM cpu WITH KEY = "team"`, false},
+		{`SHOW FIELD KEYS FROM cpu`, false},
+		{`DROP SERIES FROM cpu WHERE team = 'payments'`, true},
+		{`DROP SERIES FROM cpu WHERE team = 'payments' AND host = 'a'`, true},
+		{`DELETE FROM cpu WHERE team = 'payments' AND time < 10`, true},
+		{`DROP SERIES FROM cpu`, false},
+		{`DROP SERIES FROM cpu WHERE team = 'payments' OR host = 'a'`, false},
+		{`DROP SERIES WHERE team = 'payments'`, false},
+		{`DROP SERIES FROM /cpu/ WHERE team = 'payments'`, false},
+		{`DELETE FROM cpu WHERE team = 'ops'`, false},
+		{`DELETE WHERE time < 10`, false},
+		{`DROP MEASUREMENT cpu`, false},
+		{`DROP MEASUREMENT disk`, false},
+		{`DROP SERIES FROM disk, cpu WHERE team = 'payments'`, true},
+		{`DROP SERIES FROM disk, mem`, false},
+	} {
+		q, err := influxql.ParseQuery(tt.q)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.q, err)
+		}
+		if err := ui.AuthorizeQuery("db0", q); (err == nil) != tt.exp {
+			t.Errorf("%s: unexpected authorization: %v", tt.q, err)
+		}
+	}
+}
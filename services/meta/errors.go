@@ -62,6 +62,10 @@ var (
 	// duration.
 	ErrIncompatibleDurations = errors.New("retention policy duration must be greater than the shard duration")
 
+	// ErrSeriesDurationInvalid is returned when creating or updating a
+	// retention policy with a negative series duration.
+	ErrSeriesDurationInvalid = errors.New("series duration must not be negative")
+
 	// ErrReplicationFactorTooLow is returned when the replication factor is not in an
 	// acceptable range.
 	ErrReplicationFactorTooLow = errors.New("replication factor must be greater than 0")
@@ -74,6 +78,14 @@ var (
 	// ErrShardGroupNotFound is returned when mutating a shard group that doesn't exist.
 	ErrShardGroupNotFound = errors.New("shard group not found")
 
+	// ErrShardGroupOverlaps is returned when adding a shard group whose time range
+	// overlaps an existing shard group.
+	ErrShardGroupOverlaps = errors.New("shard group overlaps an existing shard group")
+
+	// ErrShardIDNotReserved is returned when adding a shard whose ID was not
+	// reserved or is already in use.
+	ErrShardIDNotReserved = errors.New("shard id not reserved")
+
 	// ErrShardNotReplicated is returned if the node requested to be dropped has
 	// the last copy of a shard present and the force keyword was not used
 	ErrShardNotReplicated = errors.New("shard not replicated")
@@ -85,6 +97,13 @@ var (
 
 	// ErrContinuousQueryNotFound is returned when removing a continuous query that doesn't exist.
 	ErrContinuousQueryNotFound = errors.New("continuous query not found")
+
+	// ErrCardinalityLimitInvalid is returned when setting a cardinality limit
+	// that isn't positive.
+	ErrCardinalityLimitInvalid = errors.New("cardinality limit must be greater than 0")
+
+	// ErrCardinalityLimitNotFound is returned when removing a cardinality limit that doesn't exist.
+	ErrCardinalityLimitNotFound = errors.New("cardinality limit not found")
 )
 
 var (
@@ -112,4 +131,30 @@ var (
 
 	// ErrAuthenticate is returned when authentication fails.
 	ErrAuthenticate = errors.New("authentication failed")
+
+	// ErrRoleExists is returned when creating an already existing role.
+	ErrRoleExists = errors.New("role already exists")
+
+	// ErrRoleNotFound is returned when mutating a role that doesn't exist.
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrRoleNameRequired is returned when creating a role without a name.
+	ErrRoleNameRequired = errors.New("role name required")
+
+	// ErrTokenExists is returned when creating a token with an existing ID.
+	ErrTokenExists = errors.New("token already exists")
+
+	// ErrTokenNotFound is returned when mutating a token that doesn't exist.
+	ErrTokenNotFound = errors.New("token not found")
+
+	// ErrTokenExpired is returned when authenticating with an expired token.
+	ErrTokenExpired = errors.New("token expired")
+
+	// ErrUserLocked is returned when authenticating a user locked after
+	// too many failed logins.
+	ErrUserLocked = errors.New("user is locked")
+
+	// ErrPasswordReused is returned when setting a password that is the
+	// current or a recent password of the user.
+	ErrPasswordReused = errors.New("password was used recently")
 )
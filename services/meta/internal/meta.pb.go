@@ -19,8 +19,12 @@ It has these top-level messages:
 	SubscriptionInfo
 	ShardOwner
 	ContinuousQueryInfo
+	CardinalityLimitInfo
 	UserInfo
+	RoleInfo
+	TokenInfo
 	UserPrivilege
+	SeriesGrantInfo
 	Command
 	CreateNodeCommand
 	DeleteNodeCommand
@@ -183,22 +187,23 @@ func (x *Command_Type) UnmarshalJSON(data []byte) error {
 	*x = Command_Type(value)
 	return nil
 }
-func (Command_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorMeta, []int{12, 0} }
+func (Command_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorMeta, []int{16, 0} }
 
 type Data struct {
-	Term            *uint64         `protobuf:"varint,1,req,name=Term" json:"Term,omitempty"`
-	Index           *uint64         `protobuf:"varint,2,req,name=Index" json:"Index,omitempty"`
-	ClusterID       *uint64         `protobuf:"varint,3,req,name=ClusterID" json:"ClusterID,omitempty"`
-	Nodes           []*NodeInfo     `protobuf:"bytes,4,rep,name=Nodes" json:"Nodes,omitempty"`
-	Databases       []*DatabaseInfo `protobuf:"bytes,5,rep,name=Databases" json:"Databases,omitempty"`
-	Users           []*UserInfo     `protobuf:"bytes,6,rep,name=Users" json:"Users,omitempty"`
-	MaxNodeID       *uint64         `protobuf:"varint,7,req,name=MaxNodeID" json:"MaxNodeID,omitempty"`
-	MaxShardGroupID *uint64         `protobuf:"varint,8,req,name=MaxShardGroupID" json:"MaxShardGroupID,omitempty"`
-	MaxShardID      *uint64         `protobuf:"varint,9,req,name=MaxShardID" json:"MaxShardID,omitempty"`
-	// added for 0.10.0
-	DataNodes        []*NodeInfo `protobuf:"bytes,10,rep,name=DataNodes" json:"DataNodes,omitempty"`
-	MetaNodes        []*NodeInfo `protobuf:"bytes,11,rep,name=MetaNodes" json:"MetaNodes,omitempty"`
-	XXX_unrecognized []byte      `json:"-"`
+	Term             *uint64         `protobuf:"varint,1,req,name=Term" json:"Term,omitempty"`
+	Index            *uint64         `protobuf:"varint,2,req,name=Index" json:"Index,omitempty"`
+	ClusterID        *uint64         `protobuf:"varint,3,req,name=ClusterID" json:"ClusterID,omitempty"`
+	Nodes            []*NodeInfo     `protobuf:"bytes,4,rep,name=Nodes" json:"Nodes,omitempty"`
+	Databases        []*DatabaseInfo `protobuf:"bytes,5,rep,name=Databases" json:"Databases,omitempty"`
+	Users            []*UserInfo     `protobuf:"bytes,6,rep,name=Users" json:"Users,omitempty"`
+	MaxNodeID        *uint64         `protobuf:"varint,7,req,name=MaxNodeID" json:"MaxNodeID,omitempty"`
+	MaxShardGroupID  *uint64         `protobuf:"varint,8,req,name=MaxShardGroupID" json:"MaxShardGroupID,omitempty"`
+	MaxShardID       *uint64         `protobuf:"varint,9,req,name=MaxShardID" json:"MaxShardID,omitempty"`
+	DataNodes        []*NodeInfo     `protobuf:"bytes,10,rep,name=DataNodes" json:"DataNodes,omitempty"`
+	MetaNodes        []*NodeInfo     `protobuf:"bytes,11,rep,name=MetaNodes" json:"MetaNodes,omitempty"`
+	Roles            []*RoleInfo     `protobuf:"bytes,12,rep,name=Roles" json:"Roles,omitempty"`
+	Tokens           []*TokenInfo    `protobuf:"bytes,13,rep,name=Tokens" json:"Tokens,omitempty"`
+	XXX_unrecognized []byte          `json:"-"`
 }
 
 func (m *Data) Reset()                    { *m = Data{} }
@@ -283,6 +288,20 @@ func (m *Data) GetMetaNodes() []*NodeInfo {
 	return nil
 }
 
+func (m *Data) GetRoles() []*RoleInfo {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *Data) GetTokens() []*TokenInfo {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
 type NodeInfo struct {
 	ID               *uint64 `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	Host             *string `protobuf:"bytes,2,req,name=Host" json:"Host,omitempty"`
@@ -317,11 +336,15 @@ func (m *NodeInfo) GetTCPHost() string {
 }
 
 type DatabaseInfo struct {
-	Name                   *string                `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	DefaultRetentionPolicy *string                `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
-	RetentionPolicies      []*RetentionPolicyInfo `protobuf:"bytes,3,rep,name=RetentionPolicies" json:"RetentionPolicies,omitempty"`
-	ContinuousQueries      []*ContinuousQueryInfo `protobuf:"bytes,4,rep,name=ContinuousQueries" json:"ContinuousQueries,omitempty"`
-	XXX_unrecognized       []byte                 `json:"-"`
+	Name                   *string                 `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	DefaultRetentionPolicy *string                 `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
+	RetentionPolicies      []*RetentionPolicyInfo  `protobuf:"bytes,3,rep,name=RetentionPolicies" json:"RetentionPolicies,omitempty"`
+	ContinuousQueries      []*ContinuousQueryInfo  `protobuf:"bytes,4,rep,name=ContinuousQueries" json:"ContinuousQueries,omitempty"`
+	WALMode                *string                 `protobuf:"bytes,5,opt,name=WALMode" json:"WALMode,omitempty"`
+	DuplicatePolicy        *string                 `protobuf:"bytes,6,opt,name=DuplicatePolicy" json:"DuplicatePolicy,omitempty"`
+	CardinalityLimits      []*CardinalityLimitInfo `protobuf:"bytes,7,rep,name=CardinalityLimits" json:"CardinalityLimits,omitempty"`
+	ReadOnly               *bool                   `protobuf:"varint,8,opt,name=ReadOnly" json:"ReadOnly,omitempty"`
+	XXX_unrecognized       []byte                  `json:"-"`
 }
 
 func (m *DatabaseInfo) Reset()                    { *m = DatabaseInfo{} }
@@ -357,11 +380,40 @@ func (m *DatabaseInfo) GetContinuousQueries() []*ContinuousQueryInfo {
 	return nil
 }
 
+func (m *DatabaseInfo) GetWALMode() string {
+	if m != nil && m.WALMode != nil {
+		return *m.WALMode
+	}
+	return ""
+}
+
+func (m *DatabaseInfo) GetDuplicatePolicy() string {
+	if m != nil && m.DuplicatePolicy != nil {
+		return *m.DuplicatePolicy
+	}
+	return ""
+}
+
+func (m *DatabaseInfo) GetCardinalityLimits() []*CardinalityLimitInfo {
+	if m != nil {
+		return m.CardinalityLimits
+	}
+	return nil
+}
+
+func (m *DatabaseInfo) GetReadOnly() bool {
+	if m != nil && m.ReadOnly != nil {
+		return *m.ReadOnly
+	}
+	return false
+}
+
 type RetentionPolicySpec struct {
 	Name               *string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
 	Duration           *int64  `protobuf:"varint,2,opt,name=Duration" json:"Duration,omitempty"`
 	ShardGroupDuration *int64  `protobuf:"varint,3,opt,name=ShardGroupDuration" json:"ShardGroupDuration,omitempty"`
 	ReplicaN           *uint32 `protobuf:"varint,4,opt,name=ReplicaN" json:"ReplicaN,omitempty"`
+	SeriesDuration     *int64  `protobuf:"varint,5,opt,name=SeriesDuration" json:"SeriesDuration,omitempty"`
 	XXX_unrecognized   []byte  `json:"-"`
 }
 
@@ -398,6 +450,13 @@ func (m *RetentionPolicySpec) GetReplicaN() uint32 {
 	return 0
 }
 
+func (m *RetentionPolicySpec) GetSeriesDuration() int64 {
+	if m != nil && m.SeriesDuration != nil {
+		return *m.SeriesDuration
+	}
+	return 0
+}
+
 type RetentionPolicyInfo struct {
 	Name               *string             `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
 	Duration           *int64              `protobuf:"varint,2,req,name=Duration" json:"Duration,omitempty"`
@@ -405,6 +464,8 @@ type RetentionPolicyInfo struct {
 	ReplicaN           *uint32             `protobuf:"varint,4,req,name=ReplicaN" json:"ReplicaN,omitempty"`
 	ShardGroups        []*ShardGroupInfo   `protobuf:"bytes,5,rep,name=ShardGroups" json:"ShardGroups,omitempty"`
 	Subscriptions      []*SubscriptionInfo `protobuf:"bytes,6,rep,name=Subscriptions" json:"Subscriptions,omitempty"`
+	SeriesDuration     *int64              `protobuf:"varint,7,opt,name=SeriesDuration" json:"SeriesDuration,omitempty"`
+	ReadOnly           *bool               `protobuf:"varint,8,opt,name=ReadOnly" json:"ReadOnly,omitempty"`
 	XXX_unrecognized   []byte              `json:"-"`
 }
 
@@ -455,6 +516,20 @@ func (m *RetentionPolicyInfo) GetSubscriptions() []*SubscriptionInfo {
 	return nil
 }
 
+func (m *RetentionPolicyInfo) GetSeriesDuration() int64 {
+	if m != nil && m.SeriesDuration != nil {
+		return *m.SeriesDuration
+	}
+	return 0
+}
+
+func (m *RetentionPolicyInfo) GetReadOnly() bool {
+	if m != nil && m.ReadOnly != nil {
+		return *m.ReadOnly
+	}
+	return false
+}
+
 type ShardGroupInfo struct {
 	ID               *uint64      `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	StartTime        *int64       `protobuf:"varint,2,req,name=StartTime" json:"StartTime,omitempty"`
@@ -620,18 +695,65 @@ func (m *ContinuousQueryInfo) GetQuery() string {
 	return ""
 }
 
+type CardinalityLimitInfo struct {
+	Measurement      *string `protobuf:"bytes,1,req,name=Measurement" json:"Measurement,omitempty"`
+	TagKey           *string `protobuf:"bytes,2,opt,name=TagKey" json:"TagKey,omitempty"`
+	Max              *int64  `protobuf:"varint,3,req,name=Max" json:"Max,omitempty"`
+	Policy           *string `protobuf:"bytes,4,opt,name=Policy" json:"Policy,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *CardinalityLimitInfo) Reset()                    { *m = CardinalityLimitInfo{} }
+func (m *CardinalityLimitInfo) String() string            { return proto.CompactTextString(m) }
+func (*CardinalityLimitInfo) ProtoMessage()               {}
+func (*CardinalityLimitInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{10} }
+
+func (m *CardinalityLimitInfo) GetMeasurement() string {
+	if m != nil && m.Measurement != nil {
+		return *m.Measurement
+	}
+	return ""
+}
+
+func (m *CardinalityLimitInfo) GetTagKey() string {
+	if m != nil && m.TagKey != nil {
+		return *m.TagKey
+	}
+	return ""
+}
+
+func (m *CardinalityLimitInfo) GetMax() int64 {
+	if m != nil && m.Max != nil {
+		return *m.Max
+	}
+	return 0
+}
+
+func (m *CardinalityLimitInfo) GetPolicy() string {
+	if m != nil && m.Policy != nil {
+		return *m.Policy
+	}
+	return ""
+}
+
 type UserInfo struct {
-	Name             *string          `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Hash             *string          `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
-	Admin            *bool            `protobuf:"varint,3,req,name=Admin" json:"Admin,omitempty"`
-	Privileges       []*UserPrivilege `protobuf:"bytes,4,rep,name=Privileges" json:"Privileges,omitempty"`
-	XXX_unrecognized []byte           `json:"-"`
+	Name             *string            `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Hash             *string            `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
+	Admin            *bool              `protobuf:"varint,3,req,name=Admin" json:"Admin,omitempty"`
+	Privileges       []*UserPrivilege   `protobuf:"bytes,4,rep,name=Privileges" json:"Privileges,omitempty"`
+	SeriesGrants     []*SeriesGrantInfo `protobuf:"bytes,5,rep,name=SeriesGrants" json:"SeriesGrants,omitempty"`
+	Roles            []string           `protobuf:"bytes,6,rep,name=Roles" json:"Roles,omitempty"`
+	PasswordHistory  []string           `protobuf:"bytes,7,rep,name=PasswordHistory" json:"PasswordHistory,omitempty"`
+	LastLogin        *int64             `protobuf:"varint,8,opt,name=LastLogin" json:"LastLogin,omitempty"`
+	FailedLogins     *int32             `protobuf:"varint,9,opt,name=FailedLogins" json:"FailedLogins,omitempty"`
+	LockedUntil      *int64             `protobuf:"varint,10,opt,name=LockedUntil" json:"LockedUntil,omitempty"`
+	XXX_unrecognized []byte             `json:"-"`
 }
 
 func (m *UserInfo) Reset()                    { *m = UserInfo{} }
 func (m *UserInfo) String() string            { return proto.CompactTextString(m) }
 func (*UserInfo) ProtoMessage()               {}
-func (*UserInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{10} }
+func (*UserInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{11} }
 
 func (m *UserInfo) GetName() string {
 	if m != nil && m.Name != nil {
@@ -661,6 +783,146 @@ func (m *UserInfo) GetPrivileges() []*UserPrivilege {
 	return nil
 }
 
+func (m *UserInfo) GetSeriesGrants() []*SeriesGrantInfo {
+	if m != nil {
+		return m.SeriesGrants
+	}
+	return nil
+}
+
+func (m *UserInfo) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *UserInfo) GetPasswordHistory() []string {
+	if m != nil {
+		return m.PasswordHistory
+	}
+	return nil
+}
+
+func (m *UserInfo) GetLastLogin() int64 {
+	if m != nil && m.LastLogin != nil {
+		return *m.LastLogin
+	}
+	return 0
+}
+
+func (m *UserInfo) GetFailedLogins() int32 {
+	if m != nil && m.FailedLogins != nil {
+		return *m.FailedLogins
+	}
+	return 0
+}
+
+func (m *UserInfo) GetLockedUntil() int64 {
+	if m != nil && m.LockedUntil != nil {
+		return *m.LockedUntil
+	}
+	return 0
+}
+
+type RoleInfo struct {
+	Name             *string          `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Privileges       []*UserPrivilege `protobuf:"bytes,2,rep,name=Privileges" json:"Privileges,omitempty"`
+	XXX_unrecognized []byte           `json:"-"`
+}
+
+func (m *RoleInfo) Reset()                    { *m = RoleInfo{} }
+func (m *RoleInfo) String() string            { return proto.CompactTextString(m) }
+func (*RoleInfo) ProtoMessage()               {}
+func (*RoleInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{12} }
+
+func (m *RoleInfo) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *RoleInfo) GetPrivileges() []*UserPrivilege {
+	if m != nil {
+		return m.Privileges
+	}
+	return nil
+}
+
+type TokenInfo struct {
+	ID               *string `protobuf:"bytes,1,req,name=ID" json:"ID,omitempty"`
+	User             *string `protobuf:"bytes,2,req,name=User" json:"User,omitempty"`
+	Hash             []byte  `protobuf:"bytes,3,req,name=Hash" json:"Hash,omitempty"`
+	Database         *string `protobuf:"bytes,4,opt,name=Database" json:"Database,omitempty"`
+	Privilege        *int32  `protobuf:"varint,5,opt,name=Privilege" json:"Privilege,omitempty"`
+	CreatedAt        *int64  `protobuf:"varint,6,req,name=CreatedAt" json:"CreatedAt,omitempty"`
+	Expires          *int64  `protobuf:"varint,7,opt,name=Expires" json:"Expires,omitempty"`
+	LastUsed         *int64  `protobuf:"varint,8,opt,name=LastUsed" json:"LastUsed,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *TokenInfo) Reset()                    { *m = TokenInfo{} }
+func (m *TokenInfo) String() string            { return proto.CompactTextString(m) }
+func (*TokenInfo) ProtoMessage()               {}
+func (*TokenInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{13} }
+
+func (m *TokenInfo) GetID() string {
+	if m != nil && m.ID != nil {
+		return *m.ID
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetUser() string {
+	if m != nil && m.User != nil {
+		return *m.User
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *TokenInfo) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetPrivilege() int32 {
+	if m != nil && m.Privilege != nil {
+		return *m.Privilege
+	}
+	return 0
+}
+
+func (m *TokenInfo) GetCreatedAt() int64 {
+	if m != nil && m.CreatedAt != nil {
+		return *m.CreatedAt
+	}
+	return 0
+}
+
+func (m *TokenInfo) GetExpires() int64 {
+	if m != nil && m.Expires != nil {
+		return *m.Expires
+	}
+	return 0
+}
+
+func (m *TokenInfo) GetLastUsed() int64 {
+	if m != nil && m.LastUsed != nil {
+		return *m.LastUsed
+	}
+	return 0
+}
+
 type UserPrivilege struct {
 	Database         *string `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
 	Privilege        *int32  `protobuf:"varint,2,req,name=Privilege" json:"Privilege,omitempty"`
@@ -670,7 +932,7 @@ type UserPrivilege struct {
 func (m *UserPrivilege) Reset()                    { *m = UserPrivilege{} }
 func (m *UserPrivilege) String() string            { return proto.CompactTextString(m) }
 func (*UserPrivilege) ProtoMessage()               {}
-func (*UserPrivilege) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{11} }
+func (*UserPrivilege) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{14} }
 
 func (m *UserPrivilege) GetDatabase() string {
 	if m != nil && m.Database != nil {
@@ -686,6 +948,47 @@ func (m *UserPrivilege) GetPrivilege() int32 {
 	return 0
 }
 
+type SeriesGrantInfo struct {
+	Database         *string `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Measurement      *string `protobuf:"bytes,2,opt,name=Measurement" json:"Measurement,omitempty"`
+	Condition        *string `protobuf:"bytes,3,opt,name=Condition" json:"Condition,omitempty"`
+	Privilege        *int32  `protobuf:"varint,4,req,name=Privilege" json:"Privilege,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *SeriesGrantInfo) Reset()                    { *m = SeriesGrantInfo{} }
+func (m *SeriesGrantInfo) String() string            { return proto.CompactTextString(m) }
+func (*SeriesGrantInfo) ProtoMessage()               {}
+func (*SeriesGrantInfo) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{15} }
+
+func (m *SeriesGrantInfo) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *SeriesGrantInfo) GetMeasurement() string {
+	if m != nil && m.Measurement != nil {
+		return *m.Measurement
+	}
+	return ""
+}
+
+func (m *SeriesGrantInfo) GetCondition() string {
+	if m != nil && m.Condition != nil {
+		return *m.Condition
+	}
+	return ""
+}
+
+func (m *SeriesGrantInfo) GetPrivilege() int32 {
+	if m != nil && m.Privilege != nil {
+		return *m.Privilege
+	}
+	return 0
+}
+
 type Command struct {
 	Type                         *Command_Type `protobuf:"varint,1,req,name=type,enum=meta.Command_Type" json:"type,omitempty"`
 	proto.XXX_InternalExtensions `json:"-"`
@@ -695,7 +998,7 @@ type Command struct {
 func (m *Command) Reset()                    { *m = Command{} }
 func (m *Command) String() string            { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()               {}
-func (*Command) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{12} }
+func (*Command) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{16} }
 
 var extRange_Command = []proto.ExtensionRange{
 	{Start: 100, End: 536870911},
@@ -712,8 +1015,6 @@ func (m *Command) GetType() Command_Type {
 	return Command_CreateNodeCommand
 }
 
-// This isn't used in >= 0.10.0. Kept around for upgrade purposes. Instead
-// look at CreateDataNodeCommand and CreateMetaNodeCommand
 type CreateNodeCommand struct {
 	Host             *string `protobuf:"bytes,1,req,name=Host" json:"Host,omitempty"`
 	Rand             *uint64 `protobuf:"varint,2,req,name=Rand" json:"Rand,omitempty"`
@@ -723,7 +1024,7 @@ type CreateNodeCommand struct {
 func (m *CreateNodeCommand) Reset()                    { *m = CreateNodeCommand{} }
 func (m *CreateNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateNodeCommand) ProtoMessage()               {}
-func (*CreateNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{13} }
+func (*CreateNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{17} }
 
 func (m *CreateNodeCommand) GetHost() string {
 	if m != nil && m.Host != nil {
@@ -756,7 +1057,7 @@ type DeleteNodeCommand struct {
 func (m *DeleteNodeCommand) Reset()                    { *m = DeleteNodeCommand{} }
 func (m *DeleteNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*DeleteNodeCommand) ProtoMessage()               {}
-func (*DeleteNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{14} }
+func (*DeleteNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{18} }
 
 func (m *DeleteNodeCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -789,7 +1090,7 @@ type CreateDatabaseCommand struct {
 func (m *CreateDatabaseCommand) Reset()                    { *m = CreateDatabaseCommand{} }
 func (m *CreateDatabaseCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateDatabaseCommand) ProtoMessage()               {}
-func (*CreateDatabaseCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{15} }
+func (*CreateDatabaseCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{19} }
 
 func (m *CreateDatabaseCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -821,7 +1122,7 @@ type DropDatabaseCommand struct {
 func (m *DropDatabaseCommand) Reset()                    { *m = DropDatabaseCommand{} }
 func (m *DropDatabaseCommand) String() string            { return proto.CompactTextString(m) }
 func (*DropDatabaseCommand) ProtoMessage()               {}
-func (*DropDatabaseCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{16} }
+func (*DropDatabaseCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{20} }
 
 func (m *DropDatabaseCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -848,7 +1149,7 @@ func (m *CreateRetentionPolicyCommand) Reset()         { *m = CreateRetentionPol
 func (m *CreateRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
 func (*CreateRetentionPolicyCommand) ProtoMessage()    {}
 func (*CreateRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptorMeta, []int{17}
+	return fileDescriptorMeta, []int{21}
 }
 
 func (m *CreateRetentionPolicyCommand) GetDatabase() string {
@@ -882,7 +1183,7 @@ type DropRetentionPolicyCommand struct {
 func (m *DropRetentionPolicyCommand) Reset()                    { *m = DropRetentionPolicyCommand{} }
 func (m *DropRetentionPolicyCommand) String() string            { return proto.CompactTextString(m) }
 func (*DropRetentionPolicyCommand) ProtoMessage()               {}
-func (*DropRetentionPolicyCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{18} }
+func (*DropRetentionPolicyCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{22} }
 
 func (m *DropRetentionPolicyCommand) GetDatabase() string {
 	if m != nil && m.Database != nil {
@@ -916,7 +1217,7 @@ func (m *SetDefaultRetentionPolicyCommand) Reset()         { *m = SetDefaultRete
 func (m *SetDefaultRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
 func (*SetDefaultRetentionPolicyCommand) ProtoMessage()    {}
 func (*SetDefaultRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptorMeta, []int{19}
+	return fileDescriptorMeta, []int{23}
 }
 
 func (m *SetDefaultRetentionPolicyCommand) GetDatabase() string {
@@ -954,7 +1255,7 @@ func (m *UpdateRetentionPolicyCommand) Reset()         { *m = UpdateRetentionPol
 func (m *UpdateRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
 func (*UpdateRetentionPolicyCommand) ProtoMessage()    {}
 func (*UpdateRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptorMeta, []int{20}
+	return fileDescriptorMeta, []int{24}
 }
 
 func (m *UpdateRetentionPolicyCommand) GetDatabase() string {
@@ -1010,7 +1311,7 @@ type CreateShardGroupCommand struct {
 func (m *CreateShardGroupCommand) Reset()                    { *m = CreateShardGroupCommand{} }
 func (m *CreateShardGroupCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateShardGroupCommand) ProtoMessage()               {}
-func (*CreateShardGroupCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{21} }
+func (*CreateShardGroupCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{25} }
 
 func (m *CreateShardGroupCommand) GetDatabase() string {
 	if m != nil && m.Database != nil {
@@ -1051,7 +1352,7 @@ type DeleteShardGroupCommand struct {
 func (m *DeleteShardGroupCommand) Reset()                    { *m = DeleteShardGroupCommand{} }
 func (m *DeleteShardGroupCommand) String() string            { return proto.CompactTextString(m) }
 func (*DeleteShardGroupCommand) ProtoMessage()               {}
-func (*DeleteShardGroupCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{22} }
+func (*DeleteShardGroupCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{26} }
 
 func (m *DeleteShardGroupCommand) GetDatabase() string {
 	if m != nil && m.Database != nil {
@@ -1093,7 +1394,7 @@ func (m *CreateContinuousQueryCommand) Reset()         { *m = CreateContinuousQu
 func (m *CreateContinuousQueryCommand) String() string { return proto.CompactTextString(m) }
 func (*CreateContinuousQueryCommand) ProtoMessage()    {}
 func (*CreateContinuousQueryCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptorMeta, []int{23}
+	return fileDescriptorMeta, []int{27}
 }
 
 func (m *CreateContinuousQueryCommand) GetDatabase() string {
@@ -1134,7 +1435,7 @@ type DropContinuousQueryCommand struct {
 func (m *DropContinuousQueryCommand) Reset()                    { *m = DropContinuousQueryCommand{} }
 func (m *DropContinuousQueryCommand) String() string            { return proto.CompactTextString(m) }
 func (*DropContinuousQueryCommand) ProtoMessage()               {}
-func (*DropContinuousQueryCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{24} }
+func (*DropContinuousQueryCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{28} }
 
 func (m *DropContinuousQueryCommand) GetDatabase() string {
 	if m != nil && m.Database != nil {
@@ -1168,7 +1469,7 @@ type CreateUserCommand struct {
 func (m *CreateUserCommand) Reset()                    { *m = CreateUserCommand{} }
 func (m *CreateUserCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateUserCommand) ProtoMessage()               {}
-func (*CreateUserCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{25} }
+func (*CreateUserCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{29} }
 
 func (m *CreateUserCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -1207,7 +1508,7 @@ type DropUserCommand struct {
 func (m *DropUserCommand) Reset()                    { *m = DropUserCommand{} }
 func (m *DropUserCommand) String() string            { return proto.CompactTextString(m) }
 func (*DropUserCommand) ProtoMessage()               {}
-func (*DropUserCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{26} }
+func (*DropUserCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{30} }
 
 func (m *DropUserCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -1233,7 +1534,7 @@ type UpdateUserCommand struct {
 func (m *UpdateUserCommand) Reset()                    { *m = UpdateUserCommand{} }
 func (m *UpdateUserCommand) String() string            { return proto.CompactTextString(m) }
 func (*UpdateUserCommand) ProtoMessage()               {}
-func (*UpdateUserCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{27} }
+func (*UpdateUserCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{31} }
 
 func (m *UpdateUserCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -1267,7 +1568,7 @@ type SetPrivilegeCommand struct {
 func (m *SetPrivilegeCommand) Reset()                    { *m = SetPrivilegeCommand{} }
 func (m *SetPrivilegeCommand) String() string            { return proto.CompactTextString(m) }
 func (*SetPrivilegeCommand) ProtoMessage()               {}
-func (*SetPrivilegeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{28} }
+func (*SetPrivilegeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{32} }
 
 func (m *SetPrivilegeCommand) GetUsername() string {
 	if m != nil && m.Username != nil {
@@ -1306,7 +1607,7 @@ type SetDataCommand struct {
 func (m *SetDataCommand) Reset()                    { *m = SetDataCommand{} }
 func (m *SetDataCommand) String() string            { return proto.CompactTextString(m) }
 func (*SetDataCommand) ProtoMessage()               {}
-func (*SetDataCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{29} }
+func (*SetDataCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{33} }
 
 func (m *SetDataCommand) GetData() *Data {
 	if m != nil {
@@ -1332,7 +1633,7 @@ type SetAdminPrivilegeCommand struct {
 func (m *SetAdminPrivilegeCommand) Reset()                    { *m = SetAdminPrivilegeCommand{} }
 func (m *SetAdminPrivilegeCommand) String() string            { return proto.CompactTextString(m) }
 func (*SetAdminPrivilegeCommand) ProtoMessage()               {}
-func (*SetAdminPrivilegeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{30} }
+func (*SetAdminPrivilegeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{34} }
 
 func (m *SetAdminPrivilegeCommand) GetUsername() string {
 	if m != nil && m.Username != nil {
@@ -1365,7 +1666,7 @@ type UpdateNodeCommand struct {
 func (m *UpdateNodeCommand) Reset()                    { *m = UpdateNodeCommand{} }
 func (m *UpdateNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*UpdateNodeCommand) ProtoMessage()               {}
-func (*UpdateNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{31} }
+func (*UpdateNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{35} }
 
 func (m *UpdateNodeCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -1401,7 +1702,7 @@ type CreateSubscriptionCommand struct {
 func (m *CreateSubscriptionCommand) Reset()                    { *m = CreateSubscriptionCommand{} }
 func (m *CreateSubscriptionCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateSubscriptionCommand) ProtoMessage()               {}
-func (*CreateSubscriptionCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{32} }
+func (*CreateSubscriptionCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{36} }
 
 func (m *CreateSubscriptionCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -1456,7 +1757,7 @@ type DropSubscriptionCommand struct {
 func (m *DropSubscriptionCommand) Reset()                    { *m = DropSubscriptionCommand{} }
 func (m *DropSubscriptionCommand) String() string            { return proto.CompactTextString(m) }
 func (*DropSubscriptionCommand) ProtoMessage()               {}
-func (*DropSubscriptionCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{33} }
+func (*DropSubscriptionCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{37} }
 
 func (m *DropSubscriptionCommand) GetName() string {
 	if m != nil && m.Name != nil {
@@ -1496,7 +1797,7 @@ type RemovePeerCommand struct {
 func (m *RemovePeerCommand) Reset()                    { *m = RemovePeerCommand{} }
 func (m *RemovePeerCommand) String() string            { return proto.CompactTextString(m) }
 func (*RemovePeerCommand) ProtoMessage()               {}
-func (*RemovePeerCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{34} }
+func (*RemovePeerCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{38} }
 
 func (m *RemovePeerCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -1530,7 +1831,7 @@ type CreateMetaNodeCommand struct {
 func (m *CreateMetaNodeCommand) Reset()                    { *m = CreateMetaNodeCommand{} }
 func (m *CreateMetaNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateMetaNodeCommand) ProtoMessage()               {}
-func (*CreateMetaNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{35} }
+func (*CreateMetaNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{39} }
 
 func (m *CreateMetaNodeCommand) GetHTTPAddr() string {
 	if m != nil && m.HTTPAddr != nil {
@@ -1570,7 +1871,7 @@ type CreateDataNodeCommand struct {
 func (m *CreateDataNodeCommand) Reset()                    { *m = CreateDataNodeCommand{} }
 func (m *CreateDataNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*CreateDataNodeCommand) ProtoMessage()               {}
-func (*CreateDataNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{36} }
+func (*CreateDataNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{40} }
 
 func (m *CreateDataNodeCommand) GetHTTPAddr() string {
 	if m != nil && m.HTTPAddr != nil {
@@ -1604,7 +1905,7 @@ type UpdateDataNodeCommand struct {
 func (m *UpdateDataNodeCommand) Reset()                    { *m = UpdateDataNodeCommand{} }
 func (m *UpdateDataNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*UpdateDataNodeCommand) ProtoMessage()               {}
-func (*UpdateDataNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{37} }
+func (*UpdateDataNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{41} }
 
 func (m *UpdateDataNodeCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -1643,7 +1944,7 @@ type DeleteMetaNodeCommand struct {
 func (m *DeleteMetaNodeCommand) Reset()                    { *m = DeleteMetaNodeCommand{} }
 func (m *DeleteMetaNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*DeleteMetaNodeCommand) ProtoMessage()               {}
-func (*DeleteMetaNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{38} }
+func (*DeleteMetaNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{42} }
 
 func (m *DeleteMetaNodeCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -1668,7 +1969,7 @@ type DeleteDataNodeCommand struct {
 func (m *DeleteDataNodeCommand) Reset()                    { *m = DeleteDataNodeCommand{} }
 func (m *DeleteDataNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*DeleteDataNodeCommand) ProtoMessage()               {}
-func (*DeleteDataNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{39} }
+func (*DeleteDataNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{43} }
 
 func (m *DeleteDataNodeCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -1695,7 +1996,7 @@ type Response struct {
 func (m *Response) Reset()                    { *m = Response{} }
 func (m *Response) String() string            { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()               {}
-func (*Response) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{40} }
+func (*Response) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{44} }
 
 func (m *Response) GetOK() bool {
 	if m != nil && m.OK != nil {
@@ -1718,8 +2019,6 @@ func (m *Response) GetIndex() uint64 {
 	return 0
 }
 
-// SetMetaNodeCommand is for the initial metanode in a cluster or
-// if the single host restarts and its hostname changes, this will update it
 type SetMetaNodeCommand struct {
 	HTTPAddr         *string `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
 	TCPAddr          *string `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
@@ -1730,7 +2029,7 @@ type SetMetaNodeCommand struct {
 func (m *SetMetaNodeCommand) Reset()                    { *m = SetMetaNodeCommand{} }
 func (m *SetMetaNodeCommand) String() string            { return proto.CompactTextString(m) }
 func (*SetMetaNodeCommand) ProtoMessage()               {}
-func (*SetMetaNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{41} }
+func (*SetMetaNodeCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{45} }
 
 func (m *SetMetaNodeCommand) GetHTTPAddr() string {
 	if m != nil && m.HTTPAddr != nil {
@@ -1769,7 +2068,7 @@ type DropShardCommand struct {
 func (m *DropShardCommand) Reset()                    { *m = DropShardCommand{} }
 func (m *DropShardCommand) String() string            { return proto.CompactTextString(m) }
 func (*DropShardCommand) ProtoMessage()               {}
-func (*DropShardCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{42} }
+func (*DropShardCommand) Descriptor() ([]byte, []int) { return fileDescriptorMeta, []int{46} }
 
 func (m *DropShardCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
@@ -1797,8 +2096,12 @@ func init() {
 	proto.RegisterType((*SubscriptionInfo)(nil), "meta.SubscriptionInfo")
 	proto.RegisterType((*ShardOwner)(nil), "meta.ShardOwner")
 	proto.RegisterType((*ContinuousQueryInfo)(nil), "meta.ContinuousQueryInfo")
+	proto.RegisterType((*CardinalityLimitInfo)(nil), "meta.CardinalityLimitInfo")
 	proto.RegisterType((*UserInfo)(nil), "meta.UserInfo")
+	proto.RegisterType((*RoleInfo)(nil), "meta.RoleInfo")
+	proto.RegisterType((*TokenInfo)(nil), "meta.TokenInfo")
 	proto.RegisterType((*UserPrivilege)(nil), "meta.UserPrivilege")
+	proto.RegisterType((*SeriesGrantInfo)(nil), "meta.SeriesGrantInfo")
 	proto.RegisterType((*Command)(nil), "meta.Command")
 	proto.RegisterType((*CreateNodeCommand)(nil), "meta.CreateNodeCommand")
 	proto.RegisterType((*DeleteNodeCommand)(nil), "meta.DeleteNodeCommand")
@@ -1865,107 +2168,127 @@ func init() {
 func init() { proto.RegisterFile("internal/meta.proto", fileDescriptorMeta) }
 
 var fileDescriptorMeta = []byte{
-	// 1617 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x58, 0x5b, 0x6f, 0x1b, 0xc5,
-	0x17, 0xd7, 0xda, 0x6b, 0xc7, 0x7b, 0x62, 0x27, 0xf6, 0x38, 0x97, 0x4d, 0x9b, 0xa4, 0xee, 0xe8,
-	0x7f, 0xf1, 0xff, 0x2f, 0x51, 0x24, 0x2b, 0x15, 0x42, 0x5c, 0xdb, 0xb8, 0xa5, 0x11, 0x4a, 0x1a,
-	0x62, 0x17, 0xde, 0xaa, 0x6e, 0xed, 0x49, 0xb3, 0x60, 0xef, 0x9a, 0xdd, 0x75, 0xd3, 0x50, 0x68,
-	0x03, 0x12, 0x42, 0x20, 0x21, 0xc1, 0x0b, 0x2f, 0x3c, 0xf1, 0xc6, 0x37, 0x40, 0x3c, 0xf0, 0x29,
-	0xf8, 0x42, 0x68, 0x66, 0xf6, 0x32, 0xbb, 0x3b, 0xb3, 0x69, 0xfb, 0x66, 0xcf, 0x39, 0x73, 0x7e,
-	0xbf, 0x39, 0xb7, 0x39, 0xb3, 0xd0, 0xb6, 0x9d, 0x80, 0x78, 0x8e, 0x35, 0x79, 0x7d, 0x4a, 0x02,
-	0xeb, 0xda, 0xcc, 0x73, 0x03, 0x17, 0xe9, 0xf4, 0x37, 0xfe, 0xad, 0x04, 0x7a, 0xdf, 0x0a, 0x2c,
-	0x54, 0x07, 0x7d, 0x48, 0xbc, 0xa9, 0xa9, 0x75, 0x4a, 0x5d, 0x1d, 0x35, 0xa0, 0xb2, 0xe7, 0x8c,
-	0xc9, 0x13, 0xb3, 0xc4, 0xfe, 0xb6, 0xc0, 0xd8, 0x9d, 0xcc, 0xfd, 0x80, 0x78, 0x7b, 0x7d, 0xb3,
-	0xcc, 0x96, 0xb6, 0xa0, 0x72, 0xe0, 0x8e, 0x89, 0x6f, 0xea, 0x9d, 0x72, 0x77, 0xb1, 0xb7, 0x74,
-	0x8d, 0x99, 0xa6, 0x4b, 0x7b, 0xce, 0xb1, 0x8b, 0xfe, 0x0d, 0x06, 0x35, 0xfb, 0xd0, 0xf2, 0x89,
-	0x6f, 0x56, 0x98, 0x0a, 0xe2, 0x2a, 0xd1, 0x32, 0x53, 0xdb, 0x82, 0xca, 0x3d, 0x9f, 0x78, 0xbe,
-	0x59, 0x15, 0xad, 0xd0, 0x25, 0x26, 0x6e, 0x81, 0xb1, 0x6f, 0x3d, 0x61, 0x46, 0xfb, 0xe6, 0x02,
-	0xc3, 0x5d, 0x87, 0xe5, 0x7d, 0xeb, 0xc9, 0xe0, 0xc4, 0xf2, 0xc6, 0x1f, 0x78, 0xee, 0x7c, 0xb6,
-	0xd7, 0x37, 0x6b, 0x4c, 0x80, 0x00, 0x22, 0xc1, 0x5e, 0xdf, 0x34, 0xd8, 0xda, 0x55, 0xce, 0x82,
-	0x13, 0x05, 0x29, 0xd1, 0xab, 0x60, 0xec, 0x93, 0x48, 0x65, 0x51, 0xa6, 0x82, 0xaf, 0x43, 0x2d,
-	0x56, 0x07, 0x28, 0xed, 0xf5, 0x43, 0x27, 0xd5, 0x41, 0xbf, 0xe3, 0xfa, 0x01, 0xf3, 0x91, 0x81,
-	0x96, 0x61, 0x61, 0xb8, 0x7b, 0xc8, 0x16, 0xca, 0x1d, 0xad, 0x6b, 0xe0, 0xdf, 0x35, 0xa8, 0xa7,
-	0x0e, 0x5b, 0x07, 0xfd, 0xc0, 0x9a, 0x12, 0xb6, 0xdb, 0x40, 0xdb, 0xb0, 0xd6, 0x27, 0xc7, 0xd6,
-	0x7c, 0x12, 0x1c, 0x91, 0x80, 0x38, 0x81, 0xed, 0x3a, 0x87, 0xee, 0xc4, 0x1e, 0x9d, 0x85, 0xf6,
-	0x76, 0xa0, 0x95, 0x16, 0xd8, 0xc4, 0x37, 0xcb, 0x8c, 0xe0, 0x06, 0x27, 0x98, 0xd9, 0xc7, 0x30,
-	0x76, 0xa0, 0xb5, 0xeb, 0x3a, 0x81, 0xed, 0xcc, 0xdd, 0xb9, 0xff, 0xd1, 0x9c, 0x78, 0x76, 0x1c,
-	0xa2, 0x70, 0x57, 0x5a, 0xcc, 0x76, 0xe1, 0x11, 0xb4, 0x33, 0xc6, 0x06, 0x33, 0x32, 0x12, 0x08,
-	0x6b, 0x5d, 0x03, 0x35, 0xa1, 0xd6, 0x9f, 0x7b, 0x16, 0xd5, 0x31, 0x4b, 0x1d, 0xad, 0x5b, 0x46,
-	0x97, 0x00, 0x25, 0x81, 0x88, 0x65, 0x65, 0x26, 0x6b, 0x42, 0xed, 0x88, 0xcc, 0x26, 0xf6, 0xc8,
-	0x3a, 0x30, 0xf5, 0x8e, 0xd6, 0x6d, 0xe0, 0xbf, 0xb4, 0x1c, 0x8a, 0xc4, 0x2d, 0x69, 0x94, 0x52,
-	0x01, 0x4a, 0x29, 0x87, 0x52, 0xea, 0x36, 0xd0, 0xff, 0x60, 0x31, 0xd1, 0x8e, 0x52, 0x6f, 0x85,
-	0x1f, 0x5d, 0xc8, 0x1a, 0x0a, 0xfc, 0x1a, 0x34, 0x06, 0xf3, 0x87, 0xfe, 0xc8, 0xb3, 0x67, 0xd4,
-	0x64, 0x94, 0x84, 0x6b, 0xa1, 0xb2, 0x20, 0x62, 0x4e, 0xfa, 0x5e, 0x83, 0xa5, 0x8c, 0x05, 0x31,
-	0x1b, 0x5a, 0x60, 0x0c, 0x02, 0xcb, 0x0b, 0x86, 0xf6, 0x94, 0x84, 0xcc, 0x97, 0x61, 0xe1, 0x96,
-	0x33, 0x66, 0x0b, 0x9c, 0x6e, 0x0b, 0x8c, 0x3e, 0x99, 0x90, 0x80, 0x8c, 0x6f, 0x04, 0x8c, 0x6f,
-	0x19, 0x5d, 0x81, 0x2a, 0x33, 0x1a, 0x51, 0x5d, 0x16, 0xa8, 0x32, 0x8c, 0x36, 0x2c, 0x0e, 0xbd,
-	0xb9, 0x33, 0xb2, 0xf8, 0xae, 0x2a, 0xf5, 0x2e, 0xbe, 0x0b, 0x46, 0xa2, 0x21, 0xb2, 0x58, 0x81,
-	0xda, 0xdd, 0x53, 0x87, 0xd6, 0xa9, 0x6f, 0x96, 0x3a, 0xe5, 0xae, 0x7e, 0xb3, 0x64, 0x6a, 0xa8,
-	0x03, 0x55, 0xb6, 0x1a, 0x25, 0x50, 0x53, 0x00, 0x61, 0x02, 0xdc, 0x87, 0x66, 0xf6, 0xc0, 0x99,
-	0xc0, 0xd4, 0x41, 0xdf, 0x77, 0xc7, 0x24, 0xcc, 0xce, 0x15, 0xa8, 0xf7, 0x89, 0x1f, 0xd8, 0x8e,
-	0xc5, 0x5d, 0x47, 0xed, 0x1a, 0x78, 0x13, 0x20, 0xb1, 0x89, 0x96, 0xa0, 0x1a, 0x96, 0x2e, 0xe3,
-	0x86, 0x7b, 0xd0, 0x96, 0x24, 0x5f, 0x06, 0xa6, 0x01, 0x15, 0x26, 0xe2, 0x38, 0xf8, 0x3e, 0xd4,
-	0xe2, 0x6e, 0x90, 0xe3, 0x73, 0xc7, 0xf2, 0x4f, 0x42, 0x3e, 0x0d, 0xa8, 0xdc, 0x18, 0x4f, 0x6d,
-	0x9e, 0x17, 0x35, 0xf4, 0x5f, 0x80, 0x43, 0xcf, 0x7e, 0x6c, 0x4f, 0xc8, 0xa3, 0x38, 0xff, 0xdb,
-	0x49, 0x73, 0x89, 0x65, 0x78, 0x07, 0x1a, 0xa9, 0x05, 0x96, 0x7f, 0x61, 0xd1, 0x86, 0x40, 0x2d,
-	0x30, 0x62, 0x31, 0x43, 0xab, 0xe0, 0xbf, 0xab, 0xb0, 0xb0, 0xeb, 0x4e, 0xa7, 0x96, 0x33, 0x46,
-	0x1d, 0xd0, 0x83, 0xb3, 0x19, 0x57, 0x5e, 0x8a, 0x9a, 0x5c, 0x28, 0xbc, 0x36, 0x3c, 0x9b, 0x11,
-	0xfc, 0x6b, 0x15, 0x74, 0xfa, 0x03, 0xad, 0x42, 0x6b, 0xd7, 0x23, 0x56, 0x40, 0xa8, 0x5b, 0x42,
-	0x95, 0xa6, 0x46, 0x97, 0x79, 0x56, 0x88, 0xcb, 0x25, 0xb4, 0x01, 0xab, 0x5c, 0x3b, 0xe2, 0x13,
-	0x89, 0xca, 0x68, 0x1d, 0xda, 0x7d, 0xcf, 0x9d, 0x65, 0x05, 0x3a, 0xea, 0xc0, 0x26, 0xdf, 0x93,
-	0x29, 0xb4, 0x48, 0xa3, 0x82, 0xb6, 0xe1, 0x12, 0xdd, 0xaa, 0x90, 0x57, 0xd1, 0xbf, 0xa0, 0x33,
-	0x20, 0x81, 0xbc, 0x33, 0x45, 0x5a, 0x0b, 0x14, 0xe7, 0xde, 0x6c, 0xac, 0xc6, 0xa9, 0xa1, 0xcb,
-	0xb0, 0xce, 0x99, 0x24, 0x25, 0x13, 0x09, 0x0d, 0x2a, 0xe4, 0x27, 0xce, 0x0b, 0x21, 0x39, 0x43,
-	0x26, 0x59, 0x22, 0x8d, 0xc5, 0xe8, 0x0c, 0x0a, 0x79, 0x3d, 0xf1, 0x33, 0x0d, 0x6d, 0xb4, 0xdc,
-	0x40, 0x6d, 0x58, 0xa6, 0xdb, 0xc4, 0xc5, 0x25, 0xaa, 0xcb, 0x4f, 0x22, 0x2e, 0x2f, 0x53, 0x0f,
-	0x0f, 0x48, 0x10, 0xc7, 0x3d, 0x12, 0x34, 0x11, 0x82, 0x25, 0xea, 0x1f, 0x2b, 0xb0, 0xa2, 0xb5,
-	0x16, 0xda, 0x04, 0x73, 0x40, 0x02, 0x96, 0x7f, 0xb9, 0x1d, 0x28, 0x41, 0x10, 0xc3, 0xdb, 0x46,
-	0x5b, 0xb0, 0x11, 0x3a, 0x48, 0xa8, 0xbb, 0x48, 0xbc, 0xca, 0x5c, 0xe4, 0xb9, 0x33, 0x99, 0x70,
-	0x8d, 0x9a, 0x3c, 0x22, 0x53, 0xf7, 0x31, 0x39, 0x24, 0x09, 0xe9, 0xf5, 0x24, 0x63, 0xa2, 0x1b,
-	0x2d, 0x12, 0x99, 0xe9, 0x64, 0x12, 0x45, 0x1b, 0x54, 0xc4, 0xf9, 0x65, 0x45, 0x97, 0xa8, 0x88,
-	0xc7, 0x29, 0x6b, 0xf0, 0x72, 0x22, 0xca, 0xee, 0xda, 0x44, 0x6b, 0x80, 0x06, 0x24, 0xc8, 0x6e,
-	0xd9, 0x42, 0x2b, 0xd0, 0x64, 0x47, 0xa2, 0x31, 0x8f, 0x56, 0xb7, 0xff, 0x5f, 0xab, 0x8d, 0x9b,
-	0xe7, 0xe7, 0xe7, 0xe7, 0x25, 0x7c, 0x22, 0x29, 0x8f, 0xf8, 0x92, 0x8d, 0x8b, 0xfe, 0xc8, 0x72,
-	0xc6, 0x7c, 0x2c, 0xe9, 0xbd, 0x01, 0x0b, 0xa3, 0x50, 0xad, 0x91, 0xaa, 0x3b, 0x93, 0x74, 0xb4,
-	0xee, 0x62, 0x6f, 0x3d, 0x5c, 0xcc, 0x1a, 0xc5, 0x8f, 0x24, 0x15, 0x97, 0x6a, 0xa3, 0x0d, 0xa8,
-	0xdc, 0x76, 0xbd, 0x11, 0xaf, 0xf7, 0x5a, 0x01, 0xd0, 0xb1, 0x08, 0x94, 0xb3, 0x89, 0x7f, 0xd1,
-	0x14, 0x45, 0x9c, 0x69, 0x66, 0x3d, 0x58, 0xce, 0x4f, 0x01, 0x5a, 0xe1, 0x55, 0xdf, 0x7b, 0x4b,
-	0x49, 0xea, 0x11, 0xdb, 0x7a, 0x59, 0x3c, 0x7d, 0x06, 0x1e, 0xdf, 0x97, 0x76, 0x90, 0x34, 0xab,
-	0xde, 0x9b, 0x4a, 0x84, 0x13, 0x91, 0x9c, 0xc4, 0x10, 0x1d, 0x7e, 0x0a, 0x3b, 0x91, 0xa4, 0xcf,
-	0x4a, 0x7d, 0x50, 0x2a, 0xf6, 0xc1, 0x4d, 0x25, 0x43, 0x9b, 0x31, 0xc4, 0xa2, 0x0f, 0xe4, 0x4c,
-	0xf0, 0xb3, 0xa2, 0x8e, 0x28, 0xe1, 0x19, 0xf9, 0x88, 0x5d, 0x3c, 0xbd, 0xf7, 0x95, 0x0c, 0x3e,
-	0x65, 0x0c, 0x3a, 0x89, 0x8f, 0x14, 0xf8, 0x3f, 0x68, 0x17, 0xb7, 0xdc, 0x0b, 0x69, 0xdc, 0x56,
-	0xd2, 0xf8, 0x8c, 0xd1, 0xf8, 0x4f, 0x78, 0xe3, 0x5f, 0x80, 0x83, 0xff, 0xd0, 0x8a, 0x3b, 0xfb,
-	0x45, 0x44, 0xe8, 0xcc, 0x73, 0x40, 0x4e, 0xd9, 0x42, 0x39, 0x37, 0x36, 0xea, 0xb9, 0xd1, 0xb0,
-	0x42, 0x47, 0xc3, 0x82, 0x30, 0x4e, 0xc4, 0x30, 0x16, 0x11, 0xc3, 0x3f, 0x6a, 0xca, 0x1b, 0x47,
-	0x42, 0x7a, 0x09, 0xaa, 0xa9, 0x69, 0xbb, 0x05, 0x06, 0x9d, 0xd3, 0xfc, 0xc0, 0x9a, 0xce, 0xf8,
-	0xb0, 0xd6, 0x7b, 0x47, 0x49, 0x6a, 0xca, 0x48, 0x6d, 0x89, 0xb9, 0x95, 0xc3, 0xc4, 0x3f, 0x69,
-	0xca, 0x4b, 0xee, 0x05, 0xf8, 0xac, 0x40, 0x3d, 0xf5, 0xc6, 0x61, 0x8f, 0xae, 0x02, 0x4a, 0x8e,
-	0x48, 0x49, 0x01, 0x8b, 0x7f, 0xd6, 0x8a, 0xaf, 0xd6, 0x0b, 0x83, 0x1b, 0x0f, 0x67, 0x65, 0x96,
-	0x74, 0xea, 0xb0, 0xb9, 0xf9, 0xea, 0x93, 0x43, 0x46, 0xd5, 0xf7, 0x6a, 0x84, 0x0a, 0xaa, 0x6f,
-	0x96, 0xad, 0x3e, 0x05, 0xfe, 0xa9, 0x64, 0x56, 0x78, 0x89, 0x49, 0xb3, 0xe0, 0x6a, 0xf8, 0x3c,
-	0x7f, 0x07, 0x09, 0x18, 0xf8, 0xe3, 0xdc, 0x34, 0x92, 0xe9, 0xbe, 0xd7, 0x95, 0x96, 0x3d, 0x66,
-	0x79, 0x35, 0x39, 0x9b, 0x68, 0xf7, 0x44, 0x32, 0xd0, 0x14, 0x1d, 0xa8, 0xe0, 0x04, 0xbe, 0x78,
-	0x82, 0x9c, 0x51, 0xfc, 0x9d, 0x26, 0x1d, 0x92, 0x68, 0xd0, 0xa8, 0x9a, 0x93, 0x7e, 0xd4, 0x45,
-	0x61, 0x2c, 0xe5, 0x87, 0x6a, 0xea, 0xc9, 0x4a, 0xc1, 0x6d, 0x13, 0x88, 0xb7, 0x8d, 0x04, 0x11,
-	0x3f, 0xc8, 0x0e, 0x65, 0xc8, 0xe4, 0x9f, 0x35, 0x18, 0xfe, 0x62, 0x0f, 0x92, 0x4f, 0x0f, 0xbd,
-	0x1d, 0x25, 0xcc, 0x9c, 0xc1, 0xac, 0x24, 0x9d, 0x32, 0xb1, 0x87, 0x9f, 0xaa, 0x47, 0x3c, 0xc9,
-	0x79, 0xe3, 0x1c, 0xe1, 0xe3, 0xc3, 0xbb, 0x4a, 0xc8, 0xc7, 0x0c, 0x72, 0x3b, 0x86, 0x94, 0x02,
-	0xe0, 0x63, 0xc9, 0x04, 0xa9, 0xfe, 0x12, 0x51, 0x10, 0xd0, 0xd3, 0x7c, 0x40, 0xc5, 0x69, 0xe5,
-	0x4f, 0xad, 0x60, 0x26, 0x95, 0xbc, 0xd3, 0xd3, 0x21, 0x5d, 0xcf, 0xdf, 0xdf, 0xe5, 0xd4, 0xcb,
-	0x51, 0x97, 0xbe, 0x1c, 0xe9, 0xb3, 0xd7, 0xe8, 0xbd, 0xa7, 0xe4, 0x7c, 0xc6, 0x38, 0x5f, 0x49,
-	0x35, 0xdb, 0x3c, 0x3b, 0xda, 0xdb, 0x54, 0x03, 0xf3, 0x2b, 0x33, 0x2f, 0xe8, 0xb7, 0x5f, 0xa4,
-	0xfa, 0xad, 0x1c, 0x97, 0xc6, 0x2d, 0x37, 0xa6, 0xc7, 0x71, 0xd3, 0x78, 0xdc, 0x6e, 0x8c, 0xc7,
-	0xde, 0x85, 0x71, 0x7b, 0x2a, 0xc6, 0x2d, 0x67, 0x12, 0x7f, 0xab, 0x29, 0x06, 0x7f, 0x7a, 0xd6,
-	0x3b, 0xc3, 0xe1, 0x21, 0x03, 0xd1, 0x84, 0xcf, 0x54, 0x09, 0x6a, 0x3c, 0x52, 0xf3, 0x1b, 0x46,
-	0x3d, 0x54, 0x7e, 0x99, 0x1f, 0x2a, 0x33, 0x68, 0xf8, 0x54, 0xf1, 0xc8, 0x78, 0x01, 0x1a, 0x05,
-	0xc0, 0x5f, 0xc9, 0xa7, 0x59, 0x11, 0xf8, 0xb9, 0xe2, 0x09, 0xf3, 0xa2, 0x9f, 0xeb, 0x8a, 0x09,
-	0x3c, 0x13, 0x09, 0x48, 0x71, 0xf0, 0x03, 0xc5, 0x43, 0x49, 0x24, 0x50, 0x80, 0xf0, 0x5c, 0x44,
-	0x90, 0x1a, 0xc2, 0x96, 0xe2, 0xbd, 0x95, 0x42, 0x78, 0x5b, 0x89, 0x70, 0xae, 0xe5, 0x21, 0xb2,
-	0x87, 0xd8, 0xa1, 0x73, 0x99, 0x3f, 0x73, 0x1d, 0x9f, 0x50, 0xab, 0x77, 0x3f, 0x64, 0x56, 0x6b,
-	0xb4, 0x9b, 0xdd, 0xf2, 0x3c, 0xd7, 0x63, 0x4f, 0x12, 0x23, 0xf9, 0x36, 0x4c, 0xe7, 0x3b, 0x1d,
-	0x9f, 0x6b, 0xb2, 0xe7, 0xde, 0xcb, 0x67, 0x9e, 0xba, 0xfd, 0x7f, 0xcd, 0xb9, 0x9b, 0x71, 0x97,
-	0xcc, 0xfa, 0xe6, 0x93, 0xfc, 0xc3, 0x32, 0xe5, 0x16, 0x75, 0x61, 0x7d, 0xc3, 0x4d, 0xaf, 0x09,
-	0x75, 0x2c, 0x18, 0xf9, 0x27, 0x00, 0x00, 0xff, 0xff, 0x5d, 0xc6, 0xc9, 0x45, 0x39, 0x17, 0x00,
-	0x00,
+	// 1940 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x59, 0x5b, 0x6f, 0x23, 0x49,
+	0x15, 0x56, 0xb7, 0xdb, 0x97, 0x3e, 0xb6, 0xe3, 0xb8, 0x9c, 0x49, 0x7a, 0x2e, 0x99, 0xf5, 0x96,
+	0xb8, 0x18, 0x10, 0x83, 0x64, 0x65, 0x84, 0x10, 0xd7, 0x6c, 0xbc, 0xb3, 0x13, 0x6d, 0x32, 0x13,
+	0x62, 0x0f, 0xcb, 0xd3, 0x6a, 0x7b, 0xd3, 0x95, 0xa4, 0x19, 0xbb, 0xdb, 0x74, 0xb7, 0x27, 0x31,
+	0x03, 0xbb, 0x01, 0x09, 0x21, 0x90, 0x40, 0xf0, 0xc2, 0xcb, 0xfe, 0x01, 0xfe, 0x01, 0xe2, 0x77,
+	0xf0, 0x0b, 0x78, 0xe2, 0x99, 0x7f, 0x80, 0xea, 0x54, 0x5f, 0xaa, 0xaf, 0x99, 0x9d, 0x37, 0xa7,
+	0xce, 0xa9, 0xf3, 0x7d, 0xe7, 0x9c, 0x3a, 0xa7, 0x4e, 0x57, 0x60, 0x60, 0x3b, 0x01, 0xf3, 0x1c,
+	0x73, 0xfe, 0x9d, 0x05, 0x0b, 0xcc, 0x47, 0x4b, 0xcf, 0x0d, 0x5c, 0xa2, 0xf1, 0xdf, 0xf4, 0x3f,
+	0x2a, 0x68, 0x13, 0x33, 0x30, 0x49, 0x07, 0xb4, 0x19, 0xf3, 0x16, 0x86, 0x32, 0x54, 0x47, 0x1a,
+	0xe9, 0x42, 0xfd, 0xd0, 0xb1, 0xd8, 0xb5, 0xa1, 0xe2, 0x9f, 0x7d, 0xd0, 0x0f, 0xe6, 0x2b, 0x3f,
+	0x60, 0xde, 0xe1, 0xc4, 0xa8, 0xe1, 0xd2, 0x2e, 0xd4, 0x9f, 0xb9, 0x16, 0xf3, 0x0d, 0x6d, 0x58,
+	0x1b, 0xb5, 0xc7, 0x1b, 0x8f, 0xd0, 0x34, 0x5f, 0x3a, 0x74, 0xce, 0x5d, 0xf2, 0x55, 0xd0, 0xb9,
+	0xd9, 0x4f, 0x4d, 0x9f, 0xf9, 0x46, 0x1d, 0x55, 0x88, 0x50, 0x89, 0x96, 0x51, 0x6d, 0x17, 0xea,
+	0x2f, 0x7c, 0xe6, 0xf9, 0x46, 0x43, 0xb6, 0xc2, 0x97, 0x50, 0xdc, 0x07, 0xfd, 0xd8, 0xbc, 0x46,
+	0xa3, 0x13, 0xa3, 0x89, 0xb8, 0x3b, 0xd0, 0x3b, 0x36, 0xaf, 0xa7, 0x97, 0xa6, 0x67, 0x7d, 0xe0,
+	0xb9, 0xab, 0xe5, 0xe1, 0xc4, 0x68, 0xa1, 0x80, 0x00, 0x44, 0x82, 0xc3, 0x89, 0xa1, 0xe3, 0xda,
+	0xbb, 0x82, 0x85, 0x20, 0x0a, 0x85, 0x44, 0xdf, 0x05, 0xfd, 0x98, 0x45, 0x2a, 0xed, 0x42, 0x95,
+	0x5d, 0xa8, 0x9f, 0xba, 0x73, 0xe6, 0x1b, 0x1d, 0x59, 0xcc, 0x97, 0x50, 0xfc, 0x0e, 0x34, 0x66,
+	0xee, 0x4b, 0xe6, 0xf8, 0x46, 0x17, 0xe5, 0x3d, 0x21, 0xc7, 0x35, 0xae, 0x40, 0x1f, 0x43, 0x2b,
+	0xb6, 0x05, 0xa0, 0x1e, 0x4e, 0xc2, 0x20, 0x77, 0x40, 0x7b, 0xea, 0xfa, 0x01, 0xc6, 0x58, 0x27,
+	0x3d, 0x68, 0xce, 0x0e, 0x4e, 0x70, 0xa1, 0x36, 0x54, 0x46, 0x3a, 0xfd, 0x42, 0x85, 0x4e, 0x2a,
+	0x58, 0x1d, 0xd0, 0x9e, 0x99, 0x0b, 0x86, 0xbb, 0x75, 0xf2, 0x10, 0xb6, 0x27, 0xec, 0xdc, 0x5c,
+	0xcd, 0x83, 0x53, 0x16, 0x30, 0x27, 0xb0, 0x5d, 0xe7, 0xc4, 0x9d, 0xdb, 0x67, 0xeb, 0xd0, 0xde,
+	0x1e, 0xf4, 0xd3, 0x02, 0x9b, 0xf9, 0x46, 0x0d, 0x19, 0xde, 0x0d, 0x3d, 0x48, 0xef, 0x43, 0x8c,
+	0x3d, 0xe8, 0x1f, 0xb8, 0x4e, 0x60, 0x3b, 0x2b, 0x77, 0xe5, 0xff, 0x74, 0xc5, 0x3c, 0x3b, 0x4e,
+	0x71, 0xb8, 0x2b, 0x2d, 0x16, 0xbb, 0x7a, 0xd0, 0xfc, 0x68, 0xff, 0xe8, 0xd8, 0xb5, 0x98, 0x51,
+	0xe7, 0xdc, 0x79, 0x96, 0x26, 0xab, 0xe5, 0xdc, 0x3e, 0x33, 0x03, 0x16, 0xb2, 0x6a, 0xa0, 0xe0,
+	0x31, 0xf4, 0x0f, 0x4c, 0xcf, 0xb2, 0x1d, 0x73, 0x6e, 0x07, 0xeb, 0x23, 0x7b, 0x61, 0x07, 0xbe,
+	0xd1, 0x44, 0xfb, 0xf7, 0x42, 0xfb, 0x19, 0x31, 0x02, 0x6c, 0x42, 0xeb, 0x94, 0x99, 0xd6, 0x73,
+	0x67, 0xbe, 0x36, 0x5a, 0x43, 0x65, 0xd4, 0xa2, 0xaf, 0x61, 0x90, 0xe1, 0x3f, 0x5d, 0xb2, 0x33,
+	0x29, 0x46, 0x1c, 0x6d, 0x13, 0x5a, 0x93, 0x95, 0x67, 0x72, 0x1d, 0x43, 0x1d, 0x2a, 0xa3, 0x1a,
+	0xb9, 0x07, 0x24, 0x39, 0x3b, 0xb1, 0xac, 0x86, 0x32, 0x04, 0x41, 0xd2, 0xcf, 0x0c, 0x6d, 0xa8,
+	0x8c, 0xba, 0x64, 0x1b, 0x36, 0xa6, 0x18, 0x82, 0x58, 0x93, 0xbb, 0x57, 0xa3, 0xff, 0x55, 0x72,
+	0xe8, 0x05, 0x19, 0x4a, 0xa3, 0xab, 0x15, 0xe8, 0x6a, 0x0e, 0x5d, 0x1d, 0x75, 0xc9, 0x37, 0xa0,
+	0x9d, 0x68, 0x47, 0x55, 0xb4, 0x25, 0xa2, 0x24, 0x15, 0x00, 0x07, 0xfe, 0x36, 0x74, 0xa7, 0xab,
+	0x4f, 0xfd, 0x33, 0xcf, 0x5e, 0x72, 0x93, 0x51, 0x3d, 0x6d, 0x87, 0xca, 0x92, 0x08, 0xd5, 0xf3,
+	0x7e, 0x35, 0x93, 0x08, 0xa4, 0xc2, 0xfc, 0x47, 0x05, 0x36, 0x32, 0x58, 0xf2, 0x11, 0xee, 0x83,
+	0x3e, 0x0d, 0x4c, 0x2f, 0x98, 0xd9, 0x0b, 0x16, 0xfa, 0xd8, 0x83, 0xe6, 0xfb, 0x8e, 0x85, 0x0b,
+	0xc2, 0xb1, 0x3e, 0xe8, 0x13, 0x36, 0x67, 0x01, 0xb3, 0xf6, 0x03, 0xf4, 0xac, 0xc6, 0x4b, 0x06,
+	0x8d, 0x46, 0x4e, 0xf5, 0x24, 0xa7, 0x10, 0x63, 0x00, 0xed, 0x99, 0xb7, 0x72, 0xce, 0x4c, 0xb1,
+	0xab, 0x81, 0x51, 0x7f, 0x0e, 0x7a, 0xa2, 0x21, 0xb3, 0xd8, 0x82, 0xd6, 0xf3, 0x2b, 0x87, 0x37,
+	0x27, 0xdf, 0x50, 0x87, 0xb5, 0x91, 0xf6, 0x9e, 0x6a, 0x28, 0x64, 0x08, 0x0d, 0x5c, 0x8d, 0x4e,
+	0xfd, 0xa6, 0x04, 0x82, 0x02, 0x3a, 0x81, 0xcd, 0x5c, 0x68, 0xd2, 0x29, 0xec, 0x80, 0x86, 0xa7,
+	0x5a, 0x94, 0xd4, 0x16, 0x74, 0x26, 0xcc, 0x0f, 0x6c, 0xc7, 0x14, 0x41, 0xe6, 0x76, 0x75, 0xfa,
+	0x00, 0x20, 0xb1, 0x49, 0x36, 0xa0, 0x11, 0xf6, 0x2b, 0xe4, 0x46, 0xc7, 0x30, 0x28, 0xaa, 0x98,
+	0x34, 0x4c, 0x17, 0xea, 0x28, 0x12, 0x38, 0xf4, 0xe7, 0xb0, 0x55, 0x58, 0x05, 0x03, 0x68, 0x1f,
+	0x33, 0xd3, 0x5f, 0x79, 0x6c, 0xc1, 0x9c, 0x20, 0xdc, 0xbb, 0x01, 0x8d, 0x99, 0x79, 0xf1, 0x21,
+	0x5b, 0xe3, 0x09, 0xd7, 0x49, 0x1b, 0x6a, 0xc7, 0xe6, 0x75, 0x18, 0xfb, 0x0d, 0x68, 0x84, 0xe5,
+	0xa7, 0x61, 0x4f, 0xf9, 0x9f, 0x02, 0xad, 0xb8, 0xbb, 0xe6, 0x5c, 0x7d, 0x6a, 0xfa, 0x97, 0xa1,
+	0xab, 0x5d, 0xa8, 0xef, 0x5b, 0x0b, 0x5b, 0x1c, 0xce, 0x16, 0xf9, 0x3a, 0xc0, 0x89, 0x67, 0xbf,
+	0xb2, 0xe7, 0xec, 0x22, 0xee, 0x07, 0x83, 0xa4, 0x59, 0xc7, 0x32, 0xf2, 0x2d, 0xe8, 0x88, 0x93,
+	0xf5, 0x81, 0x67, 0x3a, 0x41, 0x94, 0xdf, 0x3b, 0x61, 0xe8, 0x13, 0x09, 0x12, 0xe8, 0x46, 0x8d,
+	0x95, 0x9f, 0x56, 0x6c, 0x1a, 0x27, 0xa6, 0xef, 0x5f, 0xb9, 0x9e, 0xf5, 0xd4, 0xf6, 0x03, 0xd7,
+	0x5b, 0x63, 0x67, 0xd0, 0xf9, 0x09, 0x3a, 0x32, 0xfd, 0xe0, 0xc8, 0xbd, 0xb0, 0x1d, 0x3c, 0x97,
+	0x35, 0x9e, 0x8a, 0x27, 0xa6, 0x3d, 0x67, 0x16, 0x2e, 0xfa, 0x86, 0x3e, 0x54, 0x46, 0x75, 0x1e,
+	0xa0, 0x23, 0xf7, 0xec, 0x25, 0xb3, 0x5e, 0x38, 0x81, 0x3d, 0x37, 0x00, 0x8f, 0xcd, 0x3e, 0xb4,
+	0xe2, 0x5e, 0x9d, 0x76, 0x39, 0xed, 0x95, 0x5a, 0xea, 0x15, 0xfd, 0x8b, 0x02, 0x7a, 0xdc, 0xcf,
+	0xa5, 0xa3, 0x87, 0x51, 0xe3, 0xaa, 0x86, 0x9a, 0x8a, 0x21, 0x0f, 0x5a, 0x07, 0xeb, 0x3f, 0xec,
+	0xdf, 0x22, 0xfc, 0xdc, 0x91, 0xd8, 0x28, 0xb6, 0x92, 0x3a, 0x5f, 0x3a, 0xf0, 0x58, 0x7c, 0xce,
+	0xa3, 0x0a, 0xba, 0x5e, 0xda, 0x1e, 0xf3, 0x93, 0xb2, 0xe4, 0xfe, 0xbf, 0xf0, 0x99, 0x25, 0xdc,
+	0xa7, 0x7b, 0xd0, 0x4d, 0xc7, 0x5d, 0xc6, 0x12, 0xcc, 0x52, 0x58, 0x9c, 0x5e, 0x9d, 0x7e, 0x0c,
+	0xbd, 0x6c, 0x0a, 0xf2, 0xfb, 0x32, 0x87, 0x4c, 0x8d, 0x88, 0x1f, 0xb8, 0x8e, 0x65, 0xc7, 0xdd,
+	0x32, 0x63, 0x5f, 0x43, 0xfb, 0xff, 0x6e, 0x40, 0xf3, 0xc0, 0x5d, 0x2c, 0x4c, 0xc7, 0x22, 0x43,
+	0xd0, 0x82, 0xf5, 0x52, 0x18, 0xdd, 0x88, 0xee, 0xfe, 0x50, 0xf8, 0x68, 0xb6, 0x5e, 0x32, 0xfa,
+	0x45, 0x03, 0x34, 0xfe, 0x83, 0xdc, 0x81, 0xbe, 0x08, 0x01, 0x2f, 0x9c, 0x50, 0x65, 0x53, 0xe1,
+	0xcb, 0xa2, 0x6f, 0xc8, 0xcb, 0x2a, 0xb9, 0x0b, 0x77, 0x84, 0x76, 0xc4, 0x3b, 0x12, 0xd5, 0xc8,
+	0x0e, 0x0c, 0x26, 0x9e, 0xbb, 0xcc, 0x0a, 0x34, 0x32, 0x84, 0x07, 0x62, 0x4f, 0xa6, 0x69, 0x47,
+	0x1a, 0x75, 0xf2, 0x10, 0xee, 0xf1, 0xad, 0x25, 0xf2, 0x06, 0xf9, 0x0a, 0x0c, 0xa7, 0x2c, 0x28,
+	0xbe, 0x70, 0x23, 0xad, 0x26, 0xc7, 0x79, 0xb1, 0xb4, 0xca, 0x71, 0x5a, 0xe4, 0x3e, 0xec, 0x08,
+	0x26, 0x49, 0x53, 0x8d, 0x84, 0x3a, 0x17, 0x0a, 0x8f, 0xf3, 0x42, 0x48, 0x7c, 0xc8, 0xb4, 0x93,
+	0x48, 0xa3, 0x1d, 0xf9, 0x50, 0x22, 0xef, 0x24, 0x71, 0xe6, 0x47, 0x27, 0x5a, 0xee, 0x92, 0x01,
+	0xf4, 0xf8, 0x36, 0x79, 0x71, 0x83, 0xeb, 0x0a, 0x4f, 0xe4, 0xe5, 0x1e, 0x8f, 0xf0, 0x94, 0x05,
+	0x71, 0xde, 0x23, 0xc1, 0x26, 0x21, 0xfc, 0x46, 0x09, 0x78, 0xe4, 0xa3, 0xb5, 0x3e, 0x79, 0x00,
+	0xc6, 0x94, 0x05, 0xd8, 0x46, 0x72, 0x3b, 0x48, 0x82, 0x20, 0xa7, 0x77, 0x40, 0x76, 0xe1, 0x6e,
+	0x18, 0x20, 0xa9, 0x33, 0x47, 0xe2, 0x3b, 0x18, 0x22, 0xcf, 0x5d, 0x16, 0x09, 0xb7, 0xb9, 0xc9,
+	0x53, 0xb6, 0x70, 0x5f, 0xb1, 0x13, 0x96, 0x90, 0xde, 0x49, 0x4e, 0x4c, 0x34, 0xe8, 0x45, 0x22,
+	0x23, 0x7d, 0x98, 0x64, 0xd1, 0x5d, 0x2e, 0x12, 0xfc, 0xb2, 0xa2, 0x7b, 0x5c, 0x24, 0xf2, 0x94,
+	0x35, 0x78, 0x3f, 0x11, 0x65, 0x77, 0x3d, 0x20, 0xdb, 0x40, 0xa6, 0x2c, 0xc8, 0x6e, 0xd9, 0x25,
+	0x5b, 0xb0, 0x89, 0x2e, 0xf1, 0x9c, 0x47, 0xab, 0x0f, 0xbf, 0xd9, 0x6a, 0x59, 0x9b, 0x37, 0x37,
+	0x37, 0x37, 0x2a, 0xbd, 0x2c, 0x28, 0x8f, 0x78, 0x76, 0x8c, 0xbb, 0xd0, 0xa9, 0xe9, 0x58, 0x62,
+	0x5a, 0x1f, 0x7f, 0x17, 0x9a, 0x67, 0xa1, 0x5a, 0x37, 0x55, 0x77, 0x06, 0x1b, 0x2a, 0xa3, 0xf6,
+	0x78, 0x27, 0x5c, 0xcc, 0x1a, 0xa5, 0x17, 0x05, 0x15, 0x97, 0xba, 0x68, 0xbb, 0x50, 0x7f, 0xe2,
+	0x7a, 0x67, 0xa2, 0x9f, 0xb4, 0x2a, 0x80, 0xce, 0x65, 0xa0, 0x9c, 0x4d, 0xfa, 0x77, 0xa5, 0xa4,
+	0x88, 0x33, 0x0d, 0x7a, 0x0c, 0xbd, 0xfc, 0x70, 0xab, 0x54, 0x4e, 0xb0, 0xe3, 0xef, 0x97, 0x92,
+	0xba, 0xc0, 0xad, 0xf7, 0x65, 0xef, 0x33, 0xf0, 0xf4, 0xe3, 0xc2, 0x0e, 0x92, 0x66, 0x35, 0xfe,
+	0x5e, 0x29, 0xc2, 0xa5, 0x4c, 0xae, 0xc0, 0x10, 0xfd, 0x87, 0x52, 0xdd, 0x89, 0x0a, 0xfa, 0x71,
+	0x61, 0x0c, 0xd4, 0xea, 0x18, 0xbc, 0x57, 0xca, 0xd0, 0x46, 0x86, 0x54, 0x8e, 0x41, 0x31, 0x13,
+	0xfa, 0x59, 0x55, 0x47, 0x2c, 0xe0, 0x19, 0xc5, 0x08, 0x6f, 0xc2, 0xf1, 0x4f, 0x4a, 0x19, 0xfc,
+	0x02, 0x19, 0x0c, 0x93, 0x18, 0x95, 0xe0, 0xff, 0x49, 0xb9, 0xbd, 0xe5, 0xde, 0x4a, 0xe3, 0x49,
+	0x29, 0x8d, 0x97, 0x48, 0xe3, 0x6b, 0xd1, 0x60, 0x52, 0x8d, 0x43, 0xff, 0xa9, 0x54, 0x77, 0xf6,
+	0xdb, 0x88, 0xf0, 0x3b, 0xfd, 0x19, 0xbb, 0xc2, 0x85, 0x5a, 0xee, 0xd3, 0x44, 0xcb, 0x7d, 0x7e,
+	0xf0, 0xd9, 0xa0, 0x5b, 0x91, 0xc6, 0xb9, 0x9c, 0xc6, 0x2a, 0x62, 0xf4, 0xcf, 0x4a, 0xe9, 0x8d,
+	0x53, 0x40, 0x3a, 0x99, 0x17, 0xd5, 0x68, 0x88, 0xe0, 0x93, 0xbc, 0x1f, 0x98, 0x8b, 0xa5, 0x18,
+	0x29, 0xc7, 0x3f, 0x2c, 0x25, 0xb5, 0x40, 0x52, 0xbb, 0xf2, 0xd9, 0xca, 0x61, 0xd2, 0xbf, 0x2a,
+	0xa5, 0x97, 0xdc, 0x1b, 0xf0, 0xd9, 0x82, 0x4e, 0xea, 0xd3, 0x1f, 0xdf, 0x22, 0x2a, 0x28, 0x39,
+	0x32, 0xa5, 0x12, 0x58, 0xfa, 0x37, 0xa5, 0xfa, 0x6a, 0xbd, 0x35, 0xb9, 0xf1, 0xf8, 0xce, 0xe9,
+	0xe8, 0x15, 0x69, 0x73, 0xf3, 0xd5, 0x57, 0x0c, 0x19, 0x55, 0xdf, 0xdb, 0x11, 0xaa, 0xa8, 0xbe,
+	0x65, 0xb6, 0xfa, 0x4a, 0xf0, 0xaf, 0x0a, 0x66, 0x85, 0x2f, 0xf1, 0xc1, 0x50, 0x71, 0x35, 0xfc,
+	0x32, 0x7f, 0x07, 0x49, 0x18, 0xf4, 0x67, 0xb9, 0x69, 0x24, 0xd3, 0x7d, 0x1f, 0x97, 0x5a, 0xf6,
+	0x86, 0x4a, 0xf2, 0xad, 0x91, 0x31, 0xc2, 0x6f, 0xd1, 0xdc, 0x40, 0x53, 0xe5, 0x50, 0x85, 0x07,
+	0xbe, 0xec, 0x41, 0xce, 0x28, 0xfd, 0x83, 0x52, 0x38, 0x24, 0xf1, 0xa4, 0x71, 0x35, 0x27, 0xfd,
+	0x40, 0x10, 0xa5, 0x51, 0xcd, 0x0f, 0xed, 0x3c, 0x92, 0xf5, 0x8a, 0xdb, 0x26, 0x90, 0x6f, 0x9b,
+	0x02, 0x44, 0xfa, 0x49, 0x76, 0x28, 0x23, 0x86, 0x78, 0xed, 0x43, 0xfc, 0xf6, 0x18, 0x92, 0x17,
+	0xb9, 0xf1, 0x5e, 0x29, 0xcc, 0x6a, 0xa8, 0x48, 0xef, 0x0e, 0x29, 0x7b, 0xf4, 0x75, 0xf9, 0x88,
+	0x57, 0xe0, 0x6f, 0x7c, 0x46, 0xc4, 0xf8, 0xf0, 0xa3, 0x52, 0xc8, 0x57, 0x08, 0xf9, 0x30, 0x86,
+	0x2c, 0x04, 0xa0, 0xe7, 0x05, 0x13, 0x64, 0xf9, 0x03, 0x5b, 0x45, 0x42, 0xaf, 0xf2, 0x09, 0x95,
+	0xa7, 0x95, 0x7f, 0x29, 0x15, 0x33, 0x69, 0xc1, 0x9b, 0x4f, 0x3a, 0xa5, 0x3b, 0xf9, 0xfb, 0xbb,
+	0x96, 0x7a, 0x5b, 0xd0, 0x0a, 0xdf, 0x16, 0xf8, 0x87, 0xb3, 0x3e, 0xfe, 0x71, 0x29, 0xe7, 0x35,
+	0x72, 0x7e, 0x27, 0xd5, 0x6c, 0xf3, 0xec, 0x78, 0x6f, 0x2b, 0x1b, 0x98, 0xdf, 0x9a, 0x79, 0x45,
+	0xbf, 0xfd, 0x55, 0xaa, 0xdf, 0x16, 0xe3, 0xd2, 0xf3, 0x82, 0x31, 0x3d, 0xce, 0x9b, 0x22, 0xf2,
+	0xb6, 0x6f, 0x59, 0xde, 0xad, 0x79, 0x7b, 0x2d, 0xe7, 0x2d, 0x67, 0x92, 0xfe, 0x5e, 0x29, 0x19,
+	0xfc, 0xb9, 0xaf, 0x4f, 0x67, 0xb3, 0x13, 0x04, 0x51, 0xa4, 0xd7, 0xd7, 0x04, 0x35, 0x1e, 0xa9,
+	0xc5, 0x0d, 0x53, 0x3e, 0x54, 0xfe, 0x3a, 0x3f, 0x54, 0x66, 0xd0, 0xe8, 0x55, 0xc9, 0x47, 0xc6,
+	0x1b, 0xd0, 0xa8, 0x00, 0xfe, 0x4d, 0xf1, 0x34, 0x2b, 0x03, 0x7f, 0x5e, 0xf2, 0x09, 0xf3, 0xa6,
+	0xaf, 0xd0, 0xd5, 0x04, 0x3e, 0x93, 0x09, 0x14, 0xe2, 0xd0, 0x4f, 0x4a, 0x3e, 0x94, 0x64, 0x02,
+	0x15, 0x08, 0x9f, 0xcb, 0x08, 0x85, 0x86, 0xa8, 0x59, 0xf2, 0xbd, 0x95, 0x42, 0xf8, 0x41, 0x29,
+	0xc2, 0x8d, 0x92, 0x87, 0xc8, 0x3a, 0xb1, 0xc7, 0xe7, 0x32, 0x7f, 0xe9, 0x3a, 0x3e, 0xe3, 0x56,
+	0x9f, 0x7f, 0x88, 0x56, 0x5b, 0xbc, 0x9b, 0xbd, 0xef, 0x79, 0xae, 0x17, 0x3e, 0x91, 0xc4, 0xff,
+	0x32, 0xe1, 0xf3, 0x9d, 0x46, 0x6f, 0x94, 0xa2, 0xcf, 0xbd, 0x2f, 0x7f, 0xf2, 0xca, 0xdb, 0xff,
+	0x6f, 0x05, 0x77, 0x23, 0xee, 0x92, 0xd9, 0xd8, 0x7c, 0x94, 0xff, 0xb0, 0x4c, 0x85, 0xa5, 0xbc,
+	0xb0, 0x7e, 0x27, 0x4c, 0x6f, 0x4b, 0x75, 0x2c, 0x19, 0xf9, 0xff, 0x00, 0x5c, 0x18, 0x7d, 0x1a,
+	0x50, 0x1a, 0x00, 0x00,
 }
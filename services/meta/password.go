@@ -0,0 +1,61 @@
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy represents the rules passwords must follow when users are
+// created or their password is set.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+}
+
+// NewPasswordPolicy returns the password policy of a Config.
+func NewPasswordPolicy(c *Config) PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        c.PasswordMinLength,
+		RequireUppercase: c.PasswordRequireUppercase,
+		RequireLowercase: c.PasswordRequireLowercase,
+		RequireDigit:     c.PasswordRequireDigit,
+		RequireSymbol:    c.PasswordRequireSymbol,
+	}
+}
+
+// Validate returns an error if password doesn't follow the policy.
+func (p PasswordPolicy) Validate(password string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	if p.RequireUppercase && !upper {
+		return errors.New("password must contain an uppercase letter")
+	} else if p.RequireLowercase && !lower {
+		return errors.New("password must contain a lowercase letter")
+	} else if p.RequireDigit && !digit {
+		return errors.New("password must contain a digit")
+	} else if p.RequireSymbol && !symbol {
+		return errors.New("password must contain a symbol")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	p := meta.PasswordPolicy{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}
+
+	for _, tt := range []struct {
+		password string
+		err      string
+	}{
+		{password: "Sup3r!secure"},
+		{password: "Sh0rt!", err: "password must be at least 8 characters"},
+		{password: "sup3r!secure", err: "password must contain an uppercase letter"},
+		{password: "SUP3R!SECURE", err: "password must contain a lowercase letter"},
+		{password: "Super!secure", err: "password must contain a digit"},
+		{password: "Sup3rsecure", err: "password must contain a symbol"},
+	} {
+		if err := p.Validate(tt.password); tt.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.password, err)
+		} else if tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%s: unexpected error: exp=%s got=%v", tt.password, tt.err, err)
+		}
+	}
+
+	// An empty policy accepts any password.
+	if err := (meta.PasswordPolicy{}).Validate(""); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -23,8 +23,9 @@ func NewQueryAuthorizer(c *Client) *QueryAuthorizer {
 // If no user is provided it will return an error unless the query's first statement is to create
 // a root user.
 func (a *QueryAuthorizer) AuthorizeQuery(u User, query *influxql.Query, database string) error {
-	// Special case if no users exist.
-	if n := a.Client.UserCount(); n == 0 {
+	// Special case if no users exist. Users authenticated by an external
+	// provider don't need to exist in the meta store.
+	if n := a.Client.UserCount(); n == 0 && u == nil {
 		// Ensure there is at least one statement.
 		if len(query.Statements) > 0 {
 			// First statement in the query must create a user with admin privilege.
@@ -51,10 +52,15 @@ func (a *QueryAuthorizer) AuthorizeQuery(u User, query *influxql.Query, database
 	return u.AuthorizeQuery(database, query)
 }
 
+// AuthorizeQuery authorizes u to execute query on database.
 func (u *UserInfo) AuthorizeQuery(database string, query *influxql.Query) error {
+	return authorizeQuery(u, database, query)
+}
 
+// authorizeQuery checks the privileges of u for each statement of query.
+func authorizeQuery(u User, database string, query *influxql.Query) error {
 	// Admin privilege allows the user to execute all statements.
-	if u.Admin {
+	if u.IsAdmin() {
 		return nil
 	}
 
@@ -74,7 +80,7 @@ func (u *UserInfo) AuthorizeQuery(database string, query *influxql.Query) error
 				// privilege cannot be run.
 				return &ErrAuthorize{
 					Query:    query,
-					User:     u.Name,
+					User:     u.ID(),
 					Database: database,
 					Message:  fmt.Sprintf("statement '%s', requires admin privilege", stmt),
 				}
@@ -87,10 +93,10 @@ func (u *UserInfo) AuthorizeQuery(database string, query *influxql.Query) error
 			if db == "" {
 				db = database
 			}
-			if !u.AuthorizeDatabase(p.Privilege, db) {
+			if !u.AuthorizeDatabase(p.Privilege, db) && !authorizeSeriesStatement(u, stmt, p.Privilege, db) {
 				return &ErrAuthorize{
 					Query:    query,
-					User:     u.Name,
+					User:     u.ID(),
 					Database: database,
 					Message:  fmt.Sprintf("statement '%s', requires %s on %s", stmt, p.Privilege.String(), db),
 				}
@@ -100,6 +106,43 @@ func (u *UserInfo) AuthorizeQuery(database string, query *influxql.Query) error
 	return nil
 }
 
+// seriesAuthorizer is implemented by users that may have series grants.
+type seriesAuthorizer interface {
+	HasSeriesGrant(privilege influxql.Privilege, database string) bool
+	AuthorizeSeriesDelete(database string, sources influxql.Sources, condition influxql.Expr) bool
+}
+
+// authorizeSeriesStatement returns true if the series grants of u authorize
+// stmt to use privilege on database. The series read by a SELECT are limited
+// to the grants by AuthorizeSeriesRead and the series written into by
+// AuthorizeSeriesWrite, and deleted series must be covered by the grants.
+// DROP MEASUREMENT needs admin privilege and is never authorized here. Other
+// statements, like those listing the measurements, series and tags of
+// the database, aren't limited to the grants and need privilege on the
+// database.
+func authorizeSeriesStatement(u User, stmt influxql.Statement, privilege influxql.Privilege, database string) bool {
+	su, ok := u.(seriesAuthorizer)
+	if !ok || !su.HasSeriesGrant(privilege, database) {
+		return false
+	}
+
+	switch stmt := stmt.(type) {
+	case *influxql.SelectStatement:
+		// The system sources listing series and tags aren't limited.
+		for _, m := range stmt.Sources.Measurements() {
+			if influxql.IsSystemName(m.Name) {
+				return false
+			}
+		}
+		return true
+	case *influxql.DeleteSeriesStatement:
+		return su.AuthorizeSeriesDelete(database, stmt.Sources, stmt.Condition)
+	case *influxql.DropSeriesStatement:
+		return su.AuthorizeSeriesDelete(database, stmt.Sources, stmt.Condition)
+	}
+	return false
+}
+
 // ErrAuthorize represents an authorization error.
 type ErrAuthorize struct {
 	Query    *influxql.Query
@@ -115,3 +158,7 @@ func (e ErrAuthorize) Error() string {
 	}
 	return fmt.Sprintf("%s not authorized to execute %s", e.User, e.Message)
 }
+
+// AuthorizationFailed returns true so the error is treated as an
+// authorization failure by callers.
+func (e ErrAuthorize) AuthorizationFailed() bool { return true }
@@ -17,13 +17,30 @@ func NewWriteAuthorizer(c *Client) *WriteAuthorizer {
 }
 
 // AuthorizeWrite returns nil if the user has permission to write to the database.
-func (a WriteAuthorizer) AuthorizeWrite(username, database string) error {
-	u, err := a.Client.User(username)
-	if err != nil || u == nil || !u.AuthorizeDatabase(influxql.WritePrivilege, database) {
+// The privileges of users in the meta store are read again from the store, and
+// external users and token users are checked as they are. Users
+// with a series grant may write, and the points writer limits the series they
+// write to the grants.
+func (a WriteAuthorizer) AuthorizeWrite(u User, database string) error {
+	if u == nil {
+		return &ErrAuthorize{Database: database, Message: "no user provided"}
+	}
+
+	name := u.ID()
+	if _, ok := u.(*UserInfo); ok {
+		u, _ = a.Client.User(name)
+	}
+	if u == nil || !(u.AuthorizeDatabase(influxql.WritePrivilege, database) || hasSeriesGrant(u, influxql.WritePrivilege, database)) {
 		return &ErrAuthorize{
 			Database: database,
-			Message:  fmt.Sprintf("%s not authorized to write to %s", username, database),
+			Message:  fmt.Sprintf("%s not authorized to write to %s", name, database),
 		}
 	}
 	return nil
 }
+
+// hasSeriesGrant returns true if u has a series grant for privilege on database.
+func hasSeriesGrant(u User, privilege influxql.Privilege, database string) bool {
+	su, ok := u.(seriesAuthorizer)
+	return ok && su.HasSeriesGrant(privilege, database)
+}
@@ -20,6 +20,7 @@ type Service struct {
 	TSDBStore interface {
 		ShardIDs() []uint64
 		DeleteShard(shardID uint64) error
+		ExpireInactiveSeries(shardIDs []uint64, min int64) (int, error)
 	}
 
 	checkInterval time.Duration
@@ -41,9 +42,10 @@ func NewService(c Config) *Service {
 // Open starts retention policy enforcement.
 func (s *Service) Open() error {
 	s.logger.Info(fmt.Sprint("Starting retention policy enforcement service with check interval of ", s.checkInterval))
-	s.wg.Add(2)
+	s.wg.Add(3)
 	go s.deleteShardGroups()
 	go s.deleteShards()
+	go s.expireSeries()
 	return nil
 }
 
@@ -135,3 +137,47 @@ func (s *Service) deleteShards() {
 		}
 	}
 }
+
+func (s *Service) expireSeries() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case <-ticker.C:
+			now := time.Now().UTC()
+			dbs := s.MetaClient.Databases()
+			for _, d := range dbs {
+				for _, r := range d.RetentionPolicies {
+					// Read-only databases and retention policies are not modified.
+					if r.SeriesDuration <= 0 || d.ReadOnly || r.ReadOnly {
+						continue
+					}
+
+					var shardIDs []uint64
+					for _, g := range r.ShardGroups {
+						if g.Deleted() {
+							continue
+						}
+						for _, sh := range g.Shards {
+							shardIDs = append(shardIDs, sh.ID)
+						}
+					}
+
+					n, err := s.TSDBStore.ExpireInactiveSeries(shardIDs, now.Add(-r.SeriesDuration).UnixNano())
+					if err != nil {
+						s.logger.Error(fmt.Sprintf("failed to expire series from database %s, retention policy %s: %s",
+							d.Name, r.Name, err.Error()))
+					} else if n > 0 {
+						s.logger.Info(fmt.Sprintf("expired %d series from database %s, retention policy %s",
+							n, d.Name, r.Name))
+					}
+				}
+			}
+		}
+	}
+}
@@ -37,6 +37,12 @@ type Config struct {
 	// empty string, the default system certs will be used
 	CaCerts string `toml:"ca-certs"`
 
+	// configure the paths to the PEM encoded certificate and private key
+	// presented to HTTPS destinations that verify client certificates. If
+	// the private key is empty, it is read from the certificate file
+	ClientCertificate string `toml:"client-certificate"`
+	ClientPrivateKey  string `toml:"client-private-key"`
+
 	// The number of writer goroutines processing the write channel.
 	WriteConcurrency int `toml:"write-concurrency"`
 
@@ -70,6 +76,16 @@ func (c Config) Validate() error {
 		return fmt.Errorf("ca-certs file %s does not exist", abspath)
 	}
 
+	if c.ClientPrivateKey != "" && c.ClientCertificate == "" {
+		return errors.New("client-certificate must be set with client-private-key")
+	}
+
+	for _, path := range []string{c.ClientCertificate, c.ClientPrivateKey} {
+		if path != "" && !fileExists(path) {
+			return fmt.Errorf("client certificate file %s does not exist", path)
+		}
+	}
+
 	if c.WriteBufferSize <= 0 {
 		return errors.New("write-buffer-size must be greater than 0")
 	}
@@ -109,3 +109,35 @@ write-concurrency = 10
 		t.Errorf("Expected Validation to succeed. Instead was: %v", err)
 	}
 }
+
+func TestConfig_ParseClientCertificate(t *testing.T) {
+	abspath, err := filepath.Abs("/path/to/client.pem")
+	if err != nil {
+		t.Fatalf("Could not construct absolute path. %v", err)
+	}
+
+	// Parse configuration.
+	c := subscriber.NewConfig()
+	if _, err := toml.Decode(fmt.Sprintf(`
+client-certificate = '%s'
+client-private-key = '%s'
+`, abspath, abspath), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if c.ClientCertificate != abspath {
+		t.Errorf("ClientCertificate: expected %s. got %s", abspath, c.ClientCertificate)
+	}
+	if c.ClientPrivateKey != abspath {
+		t.Errorf("ClientPrivateKey: expected %s. got %s", abspath, c.ClientPrivateKey)
+	}
+	if err := c.Validate(); err == nil || err.Error() != fmt.Sprintf("client certificate file %s does not exist", abspath) {
+		t.Errorf("Expected descriptive validation error. Instead got %v", err)
+	}
+
+	c.ClientCertificate = ""
+	if err := c.Validate(); err == nil || err.Error() != "client-certificate must be set with client-private-key" {
+		t.Errorf("Expected descriptive validation error. Instead got %v", err)
+	}
+}
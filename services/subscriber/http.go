@@ -17,14 +17,19 @@ type HTTP struct {
 
 // NewHTTP returns a new HTTP points writer with default options.
 func NewHTTP(addr string, timeout time.Duration) (*HTTP, error) {
-	return NewHTTPS(addr, timeout, false, "")
+	return NewHTTPS(addr, timeout, false, "", "", "")
 }
 
 // NewHTTPS returns a new HTTPS points writer with default options and HTTPS configured.
-func NewHTTPS(addr string, timeout time.Duration, unsafeSsl bool, caCerts string) (*HTTP, error) {
-	tlsConfig, err := createTLSConfig(caCerts)
+// If cert is not empty, the certificate and private key in cert and key are
+// presented to servers that request a client certificate.
+func NewHTTPS(addr string, timeout time.Duration, unsafeSsl bool, caCerts, cert, key string) (*HTTP, error) {
+	tlsConfig, err := createTLSConfig(caCerts, cert, key)
 	if err != nil {
 		return nil, err
+	} else if tlsConfig != nil {
+		// The TLS config overrides InsecureSkipVerify of the client config.
+		tlsConfig.InsecureSkipVerify = unsafeSsl
 	}
 
 	conf := client.HTTPConfig{
@@ -54,11 +59,31 @@ func (h *HTTP) WritePoints(p *coordinator.WritePointsRequest) (err error) {
 	return
 }
 
-func createTLSConfig(caCerts string) (*tls.Config, error) {
-	if caCerts == "" {
+func createTLSConfig(caCerts, cert, key string) (*tls.Config, error) {
+	if caCerts == "" && cert == "" {
 		return nil, nil
 	}
-	return loadCaCerts(caCerts)
+
+	config := &tls.Config{}
+	if caCerts != "" {
+		c, err := loadCaCerts(caCerts)
+		if err != nil {
+			return nil, err
+		}
+		config = c
+	}
+
+	if cert != "" {
+		if key == "" {
+			key = cert
+		}
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+	return config, nil
 }
 
 func loadCaCerts(caCerts string) (*tls.Config, error) {
@@ -0,0 +1,135 @@
+package subscriber_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/subscriber"
+)
+
+// Ensure the HTTPS writer presents its client certificate to servers that
+// require one.
+func TestHTTPS_ClientCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "subscriber-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca, caKey := MustCertificate("ca", nil, nil)
+	server, serverKey := MustCertificate("server", ca, caKey)
+	client, clientKey := MustCertificate("client", ca, caKey)
+
+	caPath := filepath.Join(dir, "ca.pem")
+	MustWritePEM(caPath, ca, nil)
+	clientPath := filepath.Join(dir, "client.pem")
+	MustWritePEM(clientPath, client, clientKey)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	var commonNames []string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commonNames = append(commonNames, r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.Raw}, PrivateKey: serverKey}},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	ts.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	ts.StartTLS()
+	defer ts.Close()
+
+	pt := models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "server01"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 0))
+	req := &coordinator.WritePointsRequest{Database: "db0", RetentionPolicy: "rp0", Points: []models.Point{pt}}
+
+	w, err := subscriber.NewHTTPS(ts.URL, time.Second, false, caPath, clientPath, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePoints(req); err != nil {
+		t.Fatal(err)
+	} else if len(commonNames) != 1 || commonNames[0] != "client" {
+		t.Fatalf("unexpected client certificates: %v", commonNames)
+	}
+
+	// Writes without a client certificate are rejected.
+	w, err = subscriber.NewHTTPS(ts.URL, time.Second, false, caPath, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WritePoints(req); err == nil {
+		t.Fatal("expected error writing without a client certificate")
+	}
+}
+
+// MustCertificate returns a new certificate and private key for 127.0.0.1
+// with commonName as the subject. The certificate is a CA certificate signed
+// by itself if parent is nil. Panic on error.
+func MustCertificate(commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return cert, key
+}
+
+// MustWritePEM writes a PEM encoded certificate and, if not nil, its private
+// key to path. Panic on error.
+func MustWritePEM(path string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	if key != nil {
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			panic(err)
+		}
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})...)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		panic(err)
+	}
+}
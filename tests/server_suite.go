@@ -393,7 +393,7 @@ func init() {
 			&Query{
 				name:    "show retention policy should succeed",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rp0","1h0m0s","1h0m0s",1,false]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","1h0m0s","1h0m0s",1,false,"0s"]]}]}]}`,
 			},
 			&Query{
 				name:    "alter retention policy should succeed",
@@ -404,12 +404,29 @@ func init() {
 			&Query{
 				name:    "show retention policy should have new altered information",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rp0","2h0m0s","1h0m0s",3,true]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","2h0m0s","1h0m0s",3,true,"0s"]]}]}]}`,
 			},
 			&Query{
 				name:    "show retention policy should still show policy",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rp0","2h0m0s","1h0m0s",3,true]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","2h0m0s","1h0m0s",3,true,"0s"]]}]}]}`,
+			},
+			&Query{
+				name:    "alter retention policy series duration should succeed",
+				command: `ALTER RETENTION POLICY rp0 ON db0 SERIES DURATION 30m`,
+				exp:     `{"results":[{"statement_id":0}]}`,
+				once:    true,
+			},
+			&Query{
+				name:    "show retention policy should have new series duration",
+				command: `SHOW RETENTION POLICIES ON db0`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","2h0m0s","1h0m0s",3,true,"30m0s"]]}]}]}`,
+			},
+			&Query{
+				name:    "alter retention policy series duration to INF should succeed",
+				command: `ALTER RETENTION POLICY rp0 ON db0 SERIES DURATION INF`,
+				exp:     `{"results":[{"statement_id":0}]}`,
+				once:    true,
 			},
 			&Query{
 				name:    "create a second non-default retention policy",
@@ -420,7 +437,7 @@ func init() {
 			&Query{
 				name:    "show retention policy should show both",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rp0","2h0m0s","1h0m0s",3,true],["rp2","1h0m0s","1h0m0s",1,false]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","2h0m0s","1h0m0s",3,true,"0s"],["rp2","1h0m0s","1h0m0s",1,false,"0s"]]}]}]}`,
 			},
 			&Query{
 				name:    "dropping non-default retention policy succeed",
@@ -443,7 +460,7 @@ func init() {
 			&Query{
 				name:    "show retention policy should show both with custom shard",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rp0","2h0m0s","1h0m0s",3,true],["rp3","1h0m0s","1h0m0s",1,false]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","2h0m0s","1h0m0s",3,true,"0s"],["rp3","1h0m0s","1h0m0s",1,false,"0s"]]}]}]}`,
 			},
 			&Query{
 				name:    "dropping non-default custom shard retention policy succeed",
@@ -454,7 +471,7 @@ func init() {
 			&Query{
 				name:    "show retention policy should show just default",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rp0","2h0m0s","1h0m0s",3,true]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rp0","2h0m0s","1h0m0s",3,true,"0s"]]}]}]}`,
 			},
 			&Query{
 				name:    "Ensure retention policy with unacceptable retention cannot be created",
@@ -502,7 +519,7 @@ func init() {
 			&Query{
 				name:    "show retention policy: validate normalized shard group durations are working",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["rpinf","0s","168h0m0s",1,false],["rpzero","1h0m0s","1h0m0s",1,false],["rponesecond","2h0m0s","1h0m0s",1,false]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["rpinf","0s","168h0m0s",1,false,"0s"],["rpzero","1h0m0s","1h0m0s",1,false,"0s"],["rponesecond","2h0m0s","1h0m0s",1,false,"0s"]]}]}]}`,
 			},
 		},
 	}
@@ -518,7 +535,7 @@ func init() {
 			&Query{
 				name:    "show retention policies should return auto-created policy",
 				command: `SHOW RETENTION POLICIES ON db0`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["autogen","0s","168h0m0s",1,true]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["autogen","0s","168h0m0s",1,true,"0s"]]}]}]}`,
 			},
 		},
 	}
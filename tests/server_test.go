@@ -1,18 +1,26 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
 	"github.com/influxdata/influxdb/coordinator"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/remote"
 )
 
 // Global server used by benchmarks
@@ -408,6 +416,521 @@ func TestServer_ShowDatabases_WithAuth(t *testing.T) {
 	}
 }
 
+// Ensure series grants restrict what a user can read and write.
+func TestServer_SeriesGrants_WithAuth(t *testing.T) {
+	t.Parallel()
+	c := NewConfig()
+	c.HTTPD.AuthEnabled = true
+	s := OpenServer(c)
+	defer s.Close()
+
+	if _, ok := s.(*RemoteServer); ok {
+		t.Skip("Skipping.  Cannot enable auth on remote server")
+	}
+
+	adminParams := map[string][]string{"u": []string{"admin"}, "p": []string{"admin"}}
+	aliceParams := map[string][]string{"u": []string{"alice"}, "p": []string{"a"}}
+
+	test := Test{
+		queries: []*Query{
+			&Query{
+				name:    "create admin",
+				command: `CREATE USER admin WITH PASSWORD 'admin' WITH ALL PRIVILEGES`,
+				exp:     `{"results":[{"statement_id":0}]}`,
+			},
+			&Query{
+				name:    "create database and user",
+				command: `CREATE DATABASE db0; CREATE USER alice WITH PASSWORD 'a'; GRANT ALL ON db0.cpu WHERE team = 'payments' TO alice`,
+				params:  adminParams,
+				exp:     `{"results":[{"statement_id":0},{"statement_id":1},{"statement_id":2}]}`,
+			},
+			&Query{
+				name:    "show grants",
+				command: `SHOW GRANTS FOR alice`,
+				params:  adminParams,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["database","privilege"]},{"name":"series","columns":["database","measurement","condition","privilege"],"values":[["db0","cpu","team = 'payments'","ALL PRIVILEGES"]]}]}]}`,
+			},
+		},
+	}
+
+	for _, query := range test.queries {
+		if err := query.Execute(s); err != nil {
+			t.Error(fmt.Sprintf("command: %s - err: %s", query.command, query.Error(err)))
+		} else if !query.success() {
+			t.Error(query.failureMessage())
+		}
+	}
+
+	if _, err := s.Write("db0", "", "cpu,team=payments value=1 0\ncpu,team=ops value=2 0", adminParams); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write("db0", "", "cpu,team=payments value=3 1", aliceParams); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write("db0", "", "cpu,team=ops value=4 1", aliceParams); err == nil {
+		t.Fatal("expected write outside of the series grant to fail")
+	} else if werr, ok := err.(WriteError); !ok || werr.StatusCode() != http.StatusForbidden {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Statements that aren't limited to the series of the grant are refused.
+	for _, q := range []string{
+		`SHOW SERIES`,
+		`SHOW MEASUREMENTS`,
+		`SHOW TAG KEYS FROM cpu`,
+		`SHOW TAG VALUES FROM cpu WITH KEY = "team"`,
+		`DROP SERIES FROM cpu`,
+		`DROP MEASUREMENT cpu`,
+	} {
+		if _, err := s.QueryWithParams(q, url.Values{"db": []string{"db0"}, "u": []string{"alice"}, "p": []string{"a"}}); err == nil || !strings.Contains(err.Error(), "code=403") {
+			t.Errorf("%s: expected authorization error, got %v", q, err)
+		}
+	}
+
+	test = Test{
+		queries: []*Query{
+			&Query{
+				name:    "select as alice",
+				command: `SELECT value FROM cpu GROUP BY team`,
+				params:  url.Values{"db": []string{"db0"}, "u": []string{"alice"}, "p": []string{"a"}},
+				exp:     `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"team":"payments"},"columns":["time","value"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:00.000000001Z",3]]}]}]}`,
+			},
+			&Query{
+				name:    "select into outside of the series grant as alice",
+				command: `SELECT value INTO cpu_copy FROM cpu`,
+				params:  url.Values{"db": []string{"db0"}, "u": []string{"alice"}, "p": []string{"a"}},
+				exp:     `{"results":[{"statement_id":0,"error":"alice not authorized to write series cpu_copy to db0"}]}`,
+			},
+			&Query{
+				name:    "delete series of the series grant as alice",
+				command: `DELETE FROM cpu WHERE team = 'payments'`,
+				params:  url.Values{"db": []string{"db0"}, "u": []string{"alice"}, "p": []string{"a"}},
+				exp:     `{"results":[{"statement_id":0}]}`,
+			},
+			&Query{
+				name:    "select remaining series",
+				command: `SELECT value FROM cpu GROUP BY team`,
+				params:  url.Values{"db": []string{"db0"}, "u": []string{"admin"}, "p": []string{"admin"}},
+				exp:     `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"team":"ops"},"columns":["time","value"],"values":[["1970-01-01T00:00:00Z",2]]}]}]}`,
+			},
+			&Query{
+				name:    "revoke series grant",
+				command: `REVOKE ALL ON db0.cpu WHERE team = 'payments' FROM alice`,
+				params:  adminParams,
+				exp:     `{"results":[{"statement_id":0}]}`,
+			},
+		},
+	}
+
+	for _, query := range test.queries {
+		if err := query.Execute(s); err != nil {
+			t.Error(fmt.Sprintf("command: %s - err: %s", query.command, query.Error(err)))
+		} else if !query.success() {
+			t.Error(query.failureMessage())
+		}
+	}
+}
+
+// Ensure users are authorized through the privileges of their roles.
+func TestServer_Roles_WithAuth(t *testing.T) {
+	t.Parallel()
+	c := NewConfig()
+	c.HTTPD.AuthEnabled = true
+	s := OpenServer(c)
+	defer s.Close()
+
+	if _, ok := s.(*RemoteServer); ok {
+		t.Skip("Skipping.  Cannot enable auth on remote server")
+	}
+
+	adminParams := map[string][]string{"u": []string{"admin"}, "p": []string{"admin"}}
+	bobParams := func(db string) url.Values {
+		return url.Values{"db": []string{db}, "u": []string{"bob"}, "p": []string{"b"}}
+	}
+
+	test := Test{
+		queries: []*Query{
+			&Query{
+				name:    "create admin",
+				command: `CREATE USER admin WITH PASSWORD 'admin' WITH ALL PRIVILEGES`,
+				exp:     `{"results":[{"statement_id":0}]}`,
+			},
+			&Query{
+				name:    "create databases, role and user",
+				command: `CREATE DATABASE db0; CREATE DATABASE db1; CREATE ROLE readers; GRANT READ ON db0 TO ROLE readers; CREATE USER bob WITH PASSWORD 'b'; GRANT ROLE readers TO bob`,
+				params:  adminParams,
+				exp:     `{"results":[{"statement_id":0},{"statement_id":1},{"statement_id":2},{"statement_id":3},{"statement_id":4},{"statement_id":5}]}`,
+			},
+			&Query{
+				name:    "show roles",
+				command: `SHOW ROLES`,
+				params:  adminParams,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["role","database","privilege"],"values":[["readers","db0","READ"]]}]}]}`,
+			},
+			&Query{
+				name:    "show grants",
+				command: `SHOW GRANTS FOR bob`,
+				params:  adminParams,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["database","privilege"]},{"name":"roles","columns":["role"],"values":[["readers"]]}]}]}`,
+			},
+			&Query{
+				name:    "read through role",
+				command: `SELECT * FROM cpu`,
+				params:  bobParams("db0"),
+				exp:     `{"results":[{"statement_id":0}]}`,
+			},
+		},
+	}
+
+	for _, query := range test.queries {
+		if err := query.Execute(s); err != nil {
+			t.Error(fmt.Sprintf("command: %s - err: %s", query.command, query.Error(err)))
+		} else if !query.success() {
+			t.Error(query.failureMessage())
+		}
+	}
+
+	// The role grants no privilege on db1.
+	if _, err := s.QueryWithParams(`SELECT * FROM cpu`, bobParams("db1")); err == nil || !strings.Contains(err.Error(), "requires READ on db1") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Revoking the role revokes its privileges.
+	if _, err := s.QueryWithParams(`REVOKE ROLE readers FROM bob`, adminParams); err != nil {
+		t.Fatal(err)
+	} else if _, err := s.QueryWithParams(`SELECT * FROM cpu`, bobParams("db0")); err == nil || !strings.Contains(err.Error(), "requires READ on db0") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure API tokens authenticate requests within their scope.
+func TestServer_Tokens_WithAuth(t *testing.T) {
+	t.Parallel()
+	c := NewConfig()
+	c.HTTPD.AuthEnabled = true
+	s := OpenServer(c)
+	defer s.Close()
+
+	if _, ok := s.(*RemoteServer); ok {
+		t.Skip("Skipping.  Cannot enable auth on remote server")
+	}
+
+	adminParams := url.Values{"u": []string{"admin"}, "p": []string{"admin"}}
+	if _, err := s.QueryWithParams(`CREATE USER admin WITH PASSWORD 'admin' WITH ALL PRIVILEGES`, nil); err != nil {
+		t.Fatal(err)
+	} else if _, err := s.QueryWithParams(`CREATE DATABASE db0; CREATE DATABASE db1; CREATE USER bob WITH PASSWORD 'b'; GRANT ALL ON db0 TO bob; GRANT ALL ON db1 TO bob`, adminParams); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a token that can only read from db0.
+	results, err := s.QueryWithParams(`CREATE TOKEN FOR bob WITH SCOPE READ ON db0 EXPIRES 1h`, adminParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp struct {
+		Results []struct {
+			Series []struct {
+				Values [][]string `json:"values"`
+			} `json:"series"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(results), &resp); err != nil {
+		t.Fatal(err)
+	} else if len(resp.Results) != 1 || len(resp.Results[0].Series) != 1 || len(resp.Results[0].Series[0].Values) != 1 {
+		t.Fatalf("unexpected results: %s", results)
+	}
+	id, token := resp.Results[0].Series[0].Values[0][0], resp.Results[0].Series[0].Values[0][1]
+
+	do := func(method, path string, body io.Reader) (int, string) {
+		req, err := http.NewRequest(method, s.URL()+path, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Token "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, strings.TrimSpace(string(MustReadAll(resp.Body)))
+	}
+
+	if code, body := do("GET", "/query?db=db0&q=SELECT+*+FROM+cpu", nil); code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", code, body)
+	} else if code, body := do("GET", "/query?db=db1&q=SELECT+*+FROM+cpu", nil); code != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d: %s", code, body)
+	} else if code, body := do("POST", "/write?db=db0", strings.NewReader("cpu value=1")); code != http.StatusForbidden {
+		t.Fatalf("unexpected status: %d: %s", code, body)
+	}
+
+	// The token lists its scope and when it was last used.
+	results, err = s.QueryWithParams(`SHOW TOKENS FOR bob`, adminParams)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(results, fmt.Sprintf(`["%s","bob","db0","READ",`, id)) || strings.HasSuffix(results, `null]]}]}]}`) {
+		t.Fatalf("unexpected results: %s", results)
+	}
+
+	// Dropped tokens are rejected.
+	if _, err := s.QueryWithParams(fmt.Sprintf(`DROP TOKEN '%s'`, id), adminParams); err != nil {
+		t.Fatal(err)
+	} else if code, body := do("GET", "/query?db=db0&q=SELECT+*+FROM+cpu", nil); code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: %d: %s", code, body)
+	}
+}
+
+// Ensure users are locked after too many failed logins and that the password
+// policy is enforced.
+func TestServer_UserLockout_WithAuth(t *testing.T) {
+	t.Parallel()
+	c := NewConfig()
+	c.HTTPD.AuthEnabled = true
+	c.Meta.PasswordMinLength = 5
+	c.Meta.LoginMaxFailures = 2
+	s := OpenServer(c)
+	defer s.Close()
+
+	if _, ok := s.(*RemoteServer); ok {
+		t.Skip("Skipping.  Cannot enable auth on remote server")
+	}
+
+	adminParams := url.Values{"u": []string{"admin"}, "p": []string{"admin123"}}
+	if _, err := s.QueryWithParams(`CREATE USER admin WITH PASSWORD 'admin123' WITH ALL PRIVILEGES`, nil); err != nil {
+		t.Fatal(err)
+	}
+	if results, err := s.QueryWithParams(`CREATE USER bob WITH PASSWORD 'b'`, adminParams); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"error":"password must be at least 5 characters"}]}`; results != exp {
+		t.Fatalf("unexpected results: exp=%s got=%s", exp, results)
+	} else if _, err := s.QueryWithParams(`CREATE USER bob WITH PASSWORD 'bob123'`, adminParams); err != nil {
+		t.Fatal(err)
+	}
+
+	query := func(password string) (int, string) {
+		resp, err := http.Get(s.URL() + "/query?" + url.Values{"u": []string{"bob"}, "p": []string{password}, "q": []string{"SHOW DATABASES"}}.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, strings.TrimSpace(string(MustReadAll(resp.Body)))
+	}
+
+	if code, body := query("bob123"); code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", code, body)
+	}
+	for i := 0; i < 2; i++ {
+		if code, body := query("wrong"); code != http.StatusUnauthorized {
+			t.Fatalf("unexpected status: %d: %s", code, body)
+		}
+	}
+	if code, body := query("bob123"); code != http.StatusUnauthorized || body != `{"error":"user is locked"}` {
+		t.Fatalf("unexpected response: %d: %s", code, body)
+	}
+
+	// SHOW USERS lists the last login and lock state of users.
+	if results, err := s.QueryWithParams(`SHOW USERS`, adminParams); err != nil {
+		t.Fatal(err)
+	} else if !strings.HasSuffix(results, `,true]]}]}]}`) || strings.Contains(results, `["bob",false,null,`) {
+		t.Fatalf("unexpected results: %s", results)
+	}
+}
+
+// Ensure writes and deletes are refused in read-only databases and retention
+// policies.
+func TestServer_ReadOnly(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 0), true); err != nil {
+		t.Fatal(err)
+	}
+	s.MustWrite("db0", "rp0", `cpu value=1 1000000000`, nil)
+
+	if _, err := s.Query(`ALTER RETENTION POLICY rp0 ON db0 READ ONLY`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write("db0", "rp0", `cpu value=2 2000000000`, nil); err == nil {
+		t.Fatal("expected error writing to a read-only retention policy")
+	} else if werr, ok := err.(WriteError); !ok || werr.StatusCode() != http.StatusLocked {
+		t.Fatalf("unexpected error: %v", err)
+	} else if exp := `{"error":"retention policy is read only: db0.rp0"}`; strings.TrimSpace(werr.Body()) != exp {
+		t.Fatalf("unexpected body: exp=%s got=%s", exp, werr.Body())
+	}
+
+	if _, err := s.Query(`ALTER RETENTION POLICY rp0 ON db0 READ WRITE; ALTER DATABASE db0 READ ONLY`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Write("db0", "", `cpu value=2 2000000000`, nil); err == nil {
+		t.Fatal("expected error writing to a read-only database")
+	} else if werr, ok := err.(WriteError); !ok || werr.StatusCode() != http.StatusLocked {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, q := range []string{`DELETE FROM cpu`, `SELECT value INTO cpu_copy FROM cpu`} {
+		exp := `{"results":[{"statement_id":0,"error":"database is read only: db0"}]}`
+		if results, err := s.QueryWithParams(q, url.Values{"db": []string{"db0"}}); err != nil {
+			t.Fatal(err)
+		} else if results != exp {
+			t.Fatalf("%s: unexpected results: exp=%s got=%s", q, exp, results)
+		}
+	}
+
+	if _, err := s.Query(`ALTER DATABASE db0 READ WRITE`); err != nil {
+		t.Fatal(err)
+	}
+	s.MustWrite("db0", "", `cpu value=2 2000000000`, nil)
+	if results, err := s.QueryWithParams(`SELECT count(value) FROM cpu`, url.Values{"db": []string{"db0"}}); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","count"],"values":[["1970-01-01T00:00:00Z",2]]}]}]}`; results != exp {
+		t.Fatalf("unexpected results: exp=%s got=%s", exp, results)
+	}
+}
+
+// Ensure samples written with the Prometheus remote write protocol can be
+// queried and read back with the remote read protocol.
+func TestServer_Prometheus(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 0), true); err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(path string, pb proto.Message) *http.Response {
+		data, err := proto.Marshal(pb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.Post(s.URL()+path, "application/x-protobuf", bytes.NewReader(snappy.Encode(nil, data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := post("/api/v1/prom/write?db=db0", &remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			{
+				Labels:  []*remote.LabelPair{{Name: "__name__", Value: "up"}, {Name: "job", Value: "api"}},
+				Samples: []*remote.Sample{{Value: 1, Timestamp: 1000}, {Value: 0, Timestamp: 2000}},
+			},
+			{
+				Labels:  []*remote.LabelPair{{Name: "__name__", Value: "up"}, {Name: "job", Value: "db"}},
+				Samples: []*remote.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	})
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", resp.StatusCode, MustReadAll(resp.Body))
+	}
+
+	if results, err := s.QueryWithParams(`SELECT value FROM up WHERE job = 'api'`, url.Values{"db": []string{"db0"}}); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"up","columns":["time","value"],"values":[["1970-01-01T00:00:01Z",1],["1970-01-01T00:00:02Z",0]]}]}]}`; results != exp {
+		t.Fatalf("unexpected results: exp=%s got=%s", exp, results)
+	}
+
+	resp = post("/api/v1/prom/read?db=db0", &remote.ReadRequest{
+		Queries: []*remote.Query{{
+			StartTimestampMs: 0,
+			EndTimestampMs:   1500,
+			Matchers:         []*remote.LabelMatcher{{Type: remote.MatchType_EQUAL, Name: "__name__", Value: "up"}},
+		}},
+	})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", resp.StatusCode, MustReadAll(resp.Body))
+	}
+	data, err := snappy.Decode(nil, MustReadAll(resp.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rr remote.ReadResponse
+	if err := proto.Unmarshal(data, &rr); err != nil {
+		t.Fatal(err)
+	} else if exp := `results:<timeseries:<labels:<name:"__name__" value:"up" > labels:<name:"job" value:"api" > samples:<value:1 timestamp:1000 > > timeseries:<labels:<name:"__name__" value:"up" > labels:<name:"job" value:"db" > samples:<value:1 timestamp:1000 > > > `; rr.String() != exp {
+		t.Fatalf("unexpected read response: %s", rr.String())
+	}
+}
+
+// Ensure internal statistics are served in the Prometheus text format.
+func TestServer_Metrics(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if _, ok := s.(*RemoteServer); ok {
+		t.Skip("Skipping.  Metrics of a remote server are not predictable")
+	}
+
+	resp, err := http.Get(s.URL() + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(MustReadAll(resp.Body))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", resp.StatusCode, body)
+	}
+
+	families := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if fields := strings.Fields(line); fields[0] == "#" {
+			if len(fields) != 4 || fields[1] != "TYPE" || (fields[3] != "counter" && fields[3] != "gauge") {
+				t.Fatalf("unexpected line: %s", line)
+			} else if _, ok := families[fields[2]]; ok {
+				t.Fatalf("duplicate metric family: %s", fields[2])
+			}
+			families[fields[2]] = fields[3]
+		}
+	}
+
+	for name, typ := range map[string]string{
+		"influxdb_httpd_req":                     "counter",
+		"influxdb_httpd_req_active":              "gauge",
+		"influxdb_runtime_heap_alloc":            "gauge",
+		"influxdb_runtime_num_gc":                "counter",
+		"influxdb_query_executor_queries_active": "gauge",
+	} {
+		if families[name] != typ {
+			t.Errorf("unexpected type of %s: exp=%s got=%s", name, typ, families[name])
+		}
+	}
+}
+
+// Ensure administrative statements are written to the audit log.
+func TestServer_AuditLog(t *testing.T) {
+	t.Parallel()
+	c := NewConfig()
+	c.Audit.Enabled = true
+	c.Audit.Path = filepath.Join(c.Data.Dir, "audit.log")
+	s := OpenServer(c)
+	defer s.Close()
+
+	if _, ok := s.(*RemoteServer); ok {
+		t.Skip("Skipping.  Cannot enable the audit log on remote server")
+	}
+
+	if _, err := s.QueryWithParams(`CREATE DATABASE db0; CREATE USER jdoe WITH PASSWORD 'secret'; SHOW DATABASES`, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing the audit log writes the buffered events.
+	if err := s.(*LocalServer).Auditor.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := ioutil.ReadFile(c.Audit.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(buf)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected audit log: %s", buf)
+	} else if !strings.Contains(lines[0], `"statement":"CREATE DATABASE db0"`) || !strings.Contains(lines[0], `"outcome":"success"`) {
+		t.Fatalf("unexpected audit log entry: %s", lines[0])
+	} else if !strings.Contains(lines[1], `"statement":"CREATE USER jdoe WITH PASSWORD [REDACTED]"`) || strings.Contains(lines[1], "secret") {
+		t.Fatalf("unexpected audit log entry: %s", lines[1])
+	}
+}
+
 // Ensure user commands work.
 func TestServer_UserCommands(t *testing.T) {
 	t.Parallel()
@@ -424,7 +947,7 @@ func TestServer_UserCommands(t *testing.T) {
 			&Query{
 				name:    "show users, no actual users",
 				command: `SHOW USERS`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin"]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin","last_login","locked"]}]}]}`,
 			},
 			&Query{
 				name:    `create user`,
@@ -434,7 +957,7 @@ func TestServer_UserCommands(t *testing.T) {
 			&Query{
 				name:    "show users, 1 existing user",
 				command: `SHOW USERS`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin"],"values":[["jdoe",false]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin","last_login","locked"],"values":[["jdoe",false,null,false]]}]}]}`,
 			},
 			&Query{
 				name:    "grant all priviledges to jdoe",
@@ -444,7 +967,7 @@ func TestServer_UserCommands(t *testing.T) {
 			&Query{
 				name:    "show users, existing user as admin",
 				command: `SHOW USERS`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin"],"values":[["jdoe",true]]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin","last_login","locked"],"values":[["jdoe",true,null,false]]}]}]}`,
 			},
 			&Query{
 				name:    "grant DB privileges to user",
@@ -479,7 +1002,7 @@ func TestServer_UserCommands(t *testing.T) {
 			&Query{
 				name:    "make sure user was dropped",
 				command: `SHOW USERS`,
-				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin"]}]}]}`,
+				exp:     `{"results":[{"statement_id":0,"series":[{"columns":["user","admin","last_login","locked"]}]}]}`,
 			},
 			&Query{
 				name:    "delete non existing user",
@@ -687,6 +1210,276 @@ func TestServer_Write_LineProtocol_Partial(t *testing.T) {
 	}
 }
 
+// Ensure the lines rejected from a write are reported with the reason each
+// line was rejected.
+func TestServer_Write_RejectedLines(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 1*time.Hour), true); err != nil {
+		t.Fatal(err)
+	}
+
+	now := now()
+	if _, err := s.Write("db0", "rp0", fmt.Sprintf("cpu value=1i %d", now.UnixNano()), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	writes := []string{
+		fmt.Sprintf("cpu value=2i %d", now.Add(1).UnixNano()),
+		fmt.Sprintf("cpu value=3 %d", now.Add(2).UnixNano()),
+		fmt.Sprintf("cpu value= %d", now.Add(3).UnixNano()),
+		fmt.Sprintf("cpu,time=a value=4i %d", now.Add(4).UnixNano()),
+		fmt.Sprintf("cpu value=5i %d", now.Add(-2*time.Hour).UnixNano()),
+	}
+	_, err := s.Write("db0", "rp0", strings.Join(writes, "\n"), nil)
+	wr, ok := err.(WriteError)
+	if !ok {
+		t.Fatalf("wrong error type %v", err)
+	} else if exp, got := http.StatusBadRequest, wr.StatusCode(); exp != got {
+		t.Fatalf("unexpected status code\nexp: %d\ngot: %d\n", exp, got)
+	}
+
+	var body struct {
+		Dropped int            `json:"dropped"`
+		Reasons map[string]int `json:"reasons"`
+		Lines   []struct {
+			Line   int    `json:"line"`
+			Reason string `json:"reason"`
+		} `json:"lines"`
+	}
+	if err := json.Unmarshal([]byte(wr.Body()), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, l := range body.Lines {
+		got = append(got, fmt.Sprintf("%d:%s", l.Line, l.Reason))
+	}
+	if exp := "2:field_type_conflict 3:parse_error 4:invalid_point 5:outside_retention"; strings.Join(got, " ") != exp {
+		t.Fatalf("unexpected rejected lines\nexp: %v\ngot: %v\nbody: %s", exp, got, wr.Body())
+	} else if body.Dropped != 4 || len(body.Reasons) != 4 {
+		t.Fatalf("unexpected rejected line counts: %s", wr.Body())
+	}
+
+	// Verify the other points were written.
+	if res, err := s.Query(`SELECT count(value) FROM db0.rp0.cpu`); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","count"],"values":[["1970-01-01T00:00:00Z",2]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+}
+
+// Ensure the server can write points sent in the JSON and CSV formats.
+func TestServer_Write_JSON_CSV(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 0), true); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		contentType string
+		body        string
+	}{
+		{
+			contentType: "application/json",
+			body:        `[{"measurement": "cpu", "tags": {"host": "a"}, "fields": {"value": 1.5}, "time": "2015-01-01T00:00:01Z"}]`,
+		},
+		{
+			contentType: "text/csv",
+			body:        "name|measurement,host|tag,value|float,time|time\ncpu,b,2.5,1420070402\n",
+		},
+	} {
+		resp, err := http.Post(s.URL()+"/write?db=db0&rp=rp0&precision=s", tt.contentType, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("%s: unexpected status: %d", tt.contentType, resp.StatusCode)
+		}
+	}
+
+	if res, err := s.Query(`SELECT * FROM db0.rp0.cpu`); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","host","value"],"values":[["2015-01-01T00:00:01Z","a",1.5],["2015-01-01T00:00:02Z","b",2.5]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+}
+
+// Ensure the server enforces cardinality limits set with InfluxQL.
+func TestServer_Write_CardinalityLimit(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 0), true); err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := s.Query(`SET CARDINALITY LIMIT 2 ON db0 MEASUREMENT cpu TAG host`); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	if res, err := s.Query(`SHOW CARDINALITY LIMITS ON db0`); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"db0","columns":["measurement","tag","limit","policy"],"values":[["cpu","host",2,"drop"]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	ts := mustParseTime(time.RFC3339Nano, "2015-01-01T00:00:01Z").UnixNano()
+	writes := []string{
+		fmt.Sprintf("cpu,host=a value=1 %d", ts),
+		fmt.Sprintf("cpu,host=b value=1 %d", ts),
+		fmt.Sprintf("cpu,host=c value=1 %d", ts),
+	}
+	if _, err := s.Write("db0", "rp0", strings.Join(writes, "\n"), nil); err == nil {
+		t.Fatal("expected error, got nil")
+	} else if exp := `partial write: cardinality limit exceeded (2/2): measurement=\"cpu\" tag=\"host\" value=\"c\" dropped=1`; !strings.Contains(err.Error(), exp) {
+		t.Fatalf("unexpected error\nexp: %s\ngot: %s\n", exp, err)
+	}
+
+	// Once the limit is dropped, new series can be written again.
+	if res, err := s.Query(`DROP CARDINALITY LIMIT ON db0 MEASUREMENT cpu TAG host`); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	if _, err := s.Write("db0", "rp0", writes[2], nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := s.Query(`SELECT count(value) FROM db0.rp0.cpu`); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","count"],"values":[["1970-01-01T00:00:00Z",3]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+}
+
+// Ensure the server can rename a measurement and a tag key in the background.
+func TestServer_Query_AlterMeasurement(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 0), true); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := mustParseTime(time.RFC3339Nano, "2015-01-01T00:00:01Z").UnixNano()
+	if _, err := s.Write("db0", "rp0", fmt.Sprintf("cpu_laod,hots=a value=1 %d\ncpu_laod,hots=b value=2 %d", ts, ts), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	params := url.Values{"db": []string{"db0"}}
+	for _, q := range []string{
+		`ALTER MEASUREMENT cpu_laod RENAME TO cpu_load`,
+		`ALTER MEASUREMENT cpu_load RENAME TAG hots TO host`,
+	} {
+		if res, err := s.QueryWithParams(q, params); err != nil {
+			t.Fatal(err)
+		} else if !strings.Contains(res, `"level":"info","text":"renaming in the background as query`) {
+			t.Fatalf("unexpected results: %s", res)
+		}
+
+		// Wait for the background query to finish.
+		for i := 0; ; i++ {
+			res, err := s.Query(`SHOW QUERIES`)
+			if err != nil {
+				t.Fatal(err)
+			} else if !strings.Contains(res, "ALTER MEASUREMENT") {
+				break
+			} else if i == 100 {
+				t.Fatalf("rename did not finish: %s", res)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	if res, err := s.QueryWithParams(`SELECT value FROM cpu_load GROUP BY host`, params); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu_load","tags":{"host":"a"},"columns":["time","value"],"values":[["2015-01-01T00:00:01Z",1]]},{"name":"cpu_load","tags":{"host":"b"},"columns":["time","value"],"values":[["2015-01-01T00:00:01Z",2]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	if res, err := s.QueryWithParams(`SHOW MEASUREMENTS`, params); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"measurements","columns":["name"],"values":[["cpu_load"]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+}
+
+// Ensure the server can drop a field and convert a field to another type.
+func TestServer_Query_DropAndAlterField(t *testing.T) {
+	t.Parallel()
+	s := OpenServer(NewConfig())
+	defer s.Close()
+
+	if err := s.CreateDatabaseAndRetentionPolicy("db0", newRetentionPolicySpec("rp0", 1, 0), true); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := mustParseTime(time.RFC3339Nano, "2015-01-01T00:00:01Z").UnixNano()
+	if _, err := s.Write("db0", "rp0", fmt.Sprintf("cpu,host=a value=1i,junk=1 %d", ts), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	params := url.Values{"db": []string{"db0"}}
+	if res, err := s.QueryWithParams(`DROP FIELD junk FROM cpu`, params); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	if res, err := s.QueryWithParams(`ALTER FIELD value FROM cpu TYPE boolean`, params); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"error":"cannot convert field \"value\" to boolean"}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	if res, err := s.QueryWithParams(`ALTER FIELD value FROM cpu TYPE float`, params); err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(res, `"level":"info","text":"converting in the background as query`) {
+		t.Fatalf("unexpected results: %s", res)
+	}
+
+	// Wait for the background query to finish.
+	for i := 0; ; i++ {
+		res, err := s.Query(`SHOW QUERIES`)
+		if err != nil {
+			t.Fatal(err)
+		} else if !strings.Contains(res, "ALTER FIELD") {
+			break
+		} else if i == 100 {
+			t.Fatalf("conversion did not finish: %s", res)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if res, err := s.QueryWithParams(`SHOW FIELD KEYS FROM cpu`, params); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["value","float"]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+
+	// Floats can be written to the converted field.
+	if _, err := s.Write("db0", "rp0", fmt.Sprintf("cpu,host=a value=2.5 %d", ts+1), nil); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := s.QueryWithParams(`SELECT sum(value) FROM cpu`, params); err != nil {
+		t.Fatal(err)
+	} else if exp := `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","sum"],"values":[["1970-01-01T00:00:00Z",3.5]]}]}]}`; exp != res {
+		t.Fatalf("unexpected results\nexp: %s\ngot: %s\n", exp, res)
+	}
+}
+
 // Ensure the server can query with default databases (via param) and default retention policy
 func TestServer_Query_DefaultDBAndRP(t *testing.T) {
 	t.Parallel()
@@ -708,7 +1501,7 @@ func TestServer_Query_DefaultDBAndRP(t *testing.T) {
 		&Query{
 			name:    "default rp exists",
 			command: `show retention policies ON db0`,
-			exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["autogen","0s","168h0m0s",1,false],["rp0","0s","168h0m0s",1,true]]}]}]}`,
+			exp:     `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default","seriesDuration"],"values":[["autogen","0s","168h0m0s",1,false,"0s"],["rp0","0s","168h0m0s",1,true,"0s"]]}]}]}`,
 		},
 		&Query{
 			name:    "default rp",
@@ -0,0 +1,396 @@
+package tsdb
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/uber-go/zap"
+)
+
+// CardinalityLimiter enforces the cardinality limits of a database across all
+// of its shards, whichever index they use.  The series and tag values of a
+// limited measurement are counted from the indexes of the database's open
+// shards the first time the measurement is written and are then updated as
+// series are created and shards are opened, closed or have series removed.
+// Shards reserve the new series of limited measurements before creating them
+// so concurrent writes can't exceed a limit together.
+type CardinalityLimiter struct {
+	mu      sync.Mutex
+	limits  map[string][]CardinalityLimit
+	indexes map[uint64]Index
+
+	// limited holds the limits so writes can check if they need the lock
+	// without taking it.
+	limited atomic.Value // map[string][]CardinalityLimit
+
+	// measurements holds the series and tag values of the limited
+	// measurements counted so far.
+	measurements map[string]*measurementCardinality
+}
+
+// measurementCardinality holds the series of a measurement in each shard and
+// the values of its limited tag keys.
+type measurementCardinality struct {
+	shards    map[uint64]map[string]struct{}
+	series    map[string]int            // number of shards with each series
+	tagValues map[string]map[string]int // number of series with each value
+}
+
+// NewCardinalityLimiter returns a new CardinalityLimiter without limits.
+func NewCardinalityLimiter() *CardinalityLimiter {
+	l := &CardinalityLimiter{
+		limits:       make(map[string][]CardinalityLimit),
+		indexes:      make(map[uint64]Index),
+		measurements: make(map[string]*measurementCardinality),
+	}
+	l.limited.Store(l.limits)
+	return l
+}
+
+// SetLimits replaces the limits enforced by the limiter.
+func (l *CardinalityLimiter) SetLimits(limits []CardinalityLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits = make(map[string][]CardinalityLimit)
+	for _, lim := range limits {
+		l.limits[lim.Measurement] = append(l.limits[lim.Measurement], lim)
+	}
+	l.limited.Store(l.limits)
+
+	// The limited tag keys may have changed so the measurements are counted
+	// again.
+	l.measurements = make(map[string]*measurementCardinality)
+}
+
+// limitedAny returns true if any of the measurements in names is limited.
+func (l *CardinalityLimiter) limitedAny(names [][]byte) bool {
+	limits := l.limited.Load().(map[string][]CardinalityLimit)
+	if len(limits) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if _, ok := limits[string(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setIndex sets the index of a shard of the database and counts its series.
+func (l *CardinalityLimiter) setIndex(id uint64, idx Index) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// An index replacing the shard's index has the same series.
+	_, ok := l.indexes[id]
+	l.indexes[id] = idx
+	if ok {
+		return nil
+	}
+
+	for name, m := range l.measurements {
+		if err := m.count(id, idx, []byte(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeIndex removes the index of a shard that is closed and the series
+// only the shard had.
+func (l *CardinalityLimiter) removeIndex(id uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.indexes, id)
+	for _, m := range l.measurements {
+		for key := range m.shards[id] {
+			m.remove(id, key)
+		}
+		delete(m.shards, id)
+	}
+}
+
+// recount counts the series of the measurements in names in the index of a
+// shard again, or of all measurements if names is nil.  It is called after
+// series are removed from the shard.
+func (l *CardinalityLimiter) recount(id uint64, names [][]byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idx := l.indexes[id]
+	if idx == nil {
+		return nil
+	}
+
+	if names == nil {
+		for name := range l.measurements {
+			names = append(names, []byte(name))
+		}
+	}
+	for _, name := range names {
+		if m := l.measurements[string(name)]; m != nil {
+			if err := m.count(id, idx, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// measurement returns the series and tag values of measurement name, counting
+// them from the shard indexes if they haven't been counted yet.  l.mu must be
+// held.
+func (l *CardinalityLimiter) measurement(name []byte) (*measurementCardinality, error) {
+	if m := l.measurements[string(name)]; m != nil {
+		return m, nil
+	}
+
+	m := &measurementCardinality{
+		shards:    make(map[uint64]map[string]struct{}),
+		series:    make(map[string]int),
+		tagValues: make(map[string]map[string]int),
+	}
+	for _, lim := range l.limits[string(name)] {
+		if lim.TagKey != "" {
+			m.tagValues[lim.TagKey] = make(map[string]int)
+		}
+	}
+
+	for id, idx := range l.indexes {
+		if err := m.count(id, idx, name); err != nil {
+			return nil, err
+		}
+	}
+	l.measurements[string(name)] = m
+	return m, nil
+}
+
+// shardSeriesIndex is implemented by indexes shared by the shards of a
+// database to return the series of a single shard.
+type shardSeriesIndex interface {
+	ShardMeasurementSeriesKeys(name []byte) ([][]byte, error)
+}
+
+// count replaces the series of shard id with the series of measurement name
+// in idx.
+func (m *measurementCardinality) count(id uint64, idx Index, name []byte) error {
+	var keys [][]byte
+	var err error
+	if sidx, ok := idx.(shardSeriesIndex); ok {
+		keys, err = sidx.ShardMeasurementSeriesKeys(name)
+	} else {
+		keys, err = idx.MeasurementSeriesKeysByExpr(name, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		current[string(key)] = struct{}{}
+		m.add(id, key)
+	}
+	for key := range m.shards[id] {
+		if _, ok := current[key]; !ok {
+			m.remove(id, key)
+		}
+	}
+	return nil
+}
+
+// add adds a series of shard id to the measurement.
+func (m *measurementCardinality) add(id uint64, key []byte) {
+	keys := m.shards[id]
+	if keys == nil {
+		keys = make(map[string]struct{})
+		m.shards[id] = keys
+	} else if _, ok := keys[string(key)]; ok {
+		return
+	}
+	keys[string(key)] = struct{}{}
+
+	m.series[string(key)]++
+	if m.series[string(key)] > 1 {
+		return
+	}
+	_, tags := models.ParseKey(key)
+	for k, values := range m.tagValues {
+		if v := tags.Get([]byte(k)); v != nil {
+			values[string(v)]++
+		}
+	}
+}
+
+// remove removes a series of shard id from the measurement.
+func (m *measurementCardinality) remove(id uint64, key string) {
+	if _, ok := m.shards[id][key]; !ok {
+		return
+	}
+	delete(m.shards[id], key)
+
+	m.series[key]--
+	if m.series[key] > 0 {
+		return
+	}
+	delete(m.series, key)
+	_, tags := models.ParseKey([]byte(key))
+	for k, values := range m.tagValues {
+		if v := tags.Get([]byte(k)); v != nil {
+			if values[string(v)]--; values[string(v)] <= 0 {
+				delete(values, string(v))
+			}
+		}
+	}
+}
+
+// reserve checks the new series of a write to shard id against the limits and
+// counts the series that may be created.  It returns the indexes of the points
+// to drop with the limit each exceeded, and the reason for the first limit
+// exceeded.  If a limit with the reject policy is exceeded then reject is true,
+// no series are counted and no points should be written.
+func (l *CardinalityLimiter) reserve(id uint64, keys, names [][]byte, tagsSlice []models.Tags, logger zap.Logger, database string) (drop map[int]string, reason string, reject bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	drop, reason, reject, err = l.check(keys, names, tagsSlice, logger, database)
+	if err != nil || reject {
+		return nil, reason, reject, err
+	}
+	for i := range keys {
+		if _, ok := drop[i]; ok {
+			continue
+		} else if m := l.measurements[string(names[i])]; m != nil {
+			m.add(id, keys[i])
+		}
+	}
+	return drop, reason, false, nil
+}
+
+// check checks the new series of a write against the limits.  It returns the
+// indexes of the points to drop with the limit each exceeded, and the reason
+// for the first limit exceeded.  If a limit with the reject policy is exceeded
+// then reject is true and no points should be written.  l.mu must be held.
+func (l *CardinalityLimiter) check(keys, names [][]byte, tagsSlice []models.Tags, logger zap.Logger, database string) (drop map[int]string, reason string, reject bool, err error) {
+	// Cardinalities are incremented for each series & tag value this write
+	// would create.
+	counts := make(map[string]int)
+	created := make(map[string]struct{})
+	logged := make(map[string]struct{})
+
+	for i := range keys {
+		a := l.limits[string(names[i])]
+		if len(a) == 0 {
+			continue
+		}
+
+		m, err := l.measurement(names[i])
+		if err != nil {
+			return nil, "", false, err
+		} else if _, ok := m.series[string(keys[i])]; ok {
+			continue
+		} else if _, ok := created[string(keys[i])]; ok {
+			continue
+		}
+
+		var exceeded string
+		var counted, values []string
+		for _, lim := range a {
+			countKey := lim.Measurement + "\x00" + lim.TagKey
+
+			var msg string
+			if lim.TagKey == "" {
+				n, ok := counts[countKey]
+				if !ok {
+					n = len(m.series)
+					counts[countKey] = n
+				}
+				if n < lim.Max {
+					counted = append(counted, countKey)
+					continue
+				}
+				msg = fmt.Sprintf("cardinality limit exceeded (%d/%d): measurement=%q series=%q",
+					n, lim.Max, names[i], keys[i])
+			} else {
+				value := tagsSlice[i].Get([]byte(lim.TagKey))
+				if value == nil {
+					continue
+				}
+
+				valueKey := countKey + "\x00" + string(value)
+				if _, ok := m.tagValues[lim.TagKey][string(value)]; ok {
+					continue
+				} else if _, ok := created[valueKey]; ok {
+					continue
+				}
+
+				n, ok := counts[countKey]
+				if !ok {
+					n = len(m.tagValues[lim.TagKey])
+					counts[countKey] = n
+				}
+				if n < lim.Max {
+					counted, values = append(counted, countKey), append(values, valueKey)
+					continue
+				}
+				msg = fmt.Sprintf("cardinality limit exceeded (%d/%d): measurement=%q tag=%q value=%q",
+					n, lim.Max, names[i], lim.TagKey, value)
+			}
+
+			switch lim.Policy {
+			case CardinalityPolicyReject:
+				return nil, msg, true, nil
+			case CardinalityPolicyLog:
+				// Log once per limit and write so a flood of new series
+				// doesn't flood the log.
+				if _, ok := logged[countKey]; !ok {
+					logged[countKey] = struct{}{}
+					logger.Warn(msg, zap.String("db", database))
+				}
+			default:
+				if exceeded == "" {
+					exceeded = msg
+				}
+				if reason == "" {
+					reason = msg
+				}
+			}
+		}
+
+		if exceeded != "" {
+			if drop == nil {
+				drop = make(map[int]string)
+			}
+			drop[i] = exceeded
+			continue
+		}
+
+		// Count the new series and tag values against the limits.
+		created[string(keys[i])] = struct{}{}
+		for _, k := range counted {
+			counts[k]++
+		}
+		for _, k := range values {
+			created[k] = struct{}{}
+		}
+	}
+	return drop, reason, false, nil
+}
+
+// release stops counting the series reserved for shard id that the index
+// didn't create.
+func (l *CardinalityLimiter) release(id uint64, keys, names [][]byte, dropped map[string]DropReason) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range keys {
+		if _, ok := dropped[string(keys[i])]; !ok {
+			continue
+		} else if m := l.measurements[string(names[i])]; m != nil {
+			m.remove(id, string(keys[i]))
+		}
+	}
+}
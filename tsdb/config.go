@@ -24,6 +24,14 @@ const (
 	// reach before it starts rejecting writes.
 	DefaultCacheMaxMemorySize = 1024 * 1024 * 1024 // 1GB
 
+	// DefaultCacheMaxMemorySizeTotal is the maximum size the caches of all shards
+	// can reach combined before writes are rejected.  A value of 0 disables the limit.
+	DefaultCacheMaxMemorySizeTotal = 0
+
+	// DefaultCacheMaxWriteWait is the maximum time a write waits for the cache to
+	// be snapshotted when it is full.  A value of 0 rejects writes immediately.
+	DefaultCacheMaxWriteWait = time.Duration(0)
+
 	// DefaultCacheSnapshotMemorySize is the size at which the engine will
 	// snapshot the cache and write it to a TSM file, freeing up memory
 	DefaultCacheSnapshotMemorySize = 25 * 1024 * 1024 // 25MB
@@ -37,6 +45,10 @@ const (
 	// will compact all TSM files in a shard if it hasn't received a write or delete
 	DefaultCompactFullWriteColdDuration = time.Duration(4 * time.Hour)
 
+	// DefaultCompactTombstoneThreshold is the fraction of a TSM file's data that
+	// must be deleted before the file is rewritten on its own to remove it.
+	DefaultCompactTombstoneThreshold = 0.25
+
 	// DefaultMaxPointsPerBlock is the maximum number of points in an encoded
 	// block in a TSM file
 	DefaultMaxPointsPerBlock = 1000
@@ -59,6 +71,10 @@ type Config struct {
 	Engine string `toml:"-"`
 	Index  string `toml:"index-version"`
 
+	// IndexConvertInmem converts the indexes of existing inmem shards to tsi1 in the
+	// background when the index version is "tsi1".
+	IndexConvertInmem bool `toml:"index-convert-inmem"`
+
 	// General WAL configuration options
 	WALDir string `toml:"wal-dir"`
 
@@ -72,10 +88,17 @@ type Config struct {
 
 	// Compaction options for tsm1 (descriptions above with defaults)
 	CacheMaxMemorySize             uint64        `toml:"cache-max-memory-size"`
+	CacheMaxMemorySizeTotal        uint64        `toml:"cache-max-memory-size-total"`
+	CacheMaxWriteWait              toml.Duration `toml:"cache-max-write-wait"`
 	CacheSnapshotMemorySize        uint64        `toml:"cache-snapshot-memory-size"`
 	CacheSnapshotWriteColdDuration toml.Duration `toml:"cache-snapshot-write-cold-duration"`
 	CompactFullWriteColdDuration   toml.Duration `toml:"compact-full-write-cold-duration"`
 
+	// CompactTombstoneThreshold is the fraction of a TSM file's data that must be
+	// covered by tombstones before the file is rewritten to remove the deleted data.
+	// A value of 0 disables tombstone compactions.
+	CompactTombstoneThreshold float64 `toml:"compact-tombstone-threshold"`
+
 	// Limits
 
 	// MaxSeriesPerDatabase is the maximum number of series a node can hold per database.
@@ -106,9 +129,12 @@ func NewConfig() Config {
 		QueryLogEnabled: true,
 
 		CacheMaxMemorySize:             DefaultCacheMaxMemorySize,
+		CacheMaxMemorySizeTotal:        DefaultCacheMaxMemorySizeTotal,
+		CacheMaxWriteWait:              toml.Duration(DefaultCacheMaxWriteWait),
 		CacheSnapshotMemorySize:        DefaultCacheSnapshotMemorySize,
 		CacheSnapshotWriteColdDuration: toml.Duration(DefaultCacheSnapshotWriteColdDuration),
 		CompactFullWriteColdDuration:   toml.Duration(DefaultCompactFullWriteColdDuration),
+		CompactTombstoneThreshold:      DefaultCompactTombstoneThreshold,
 
 		MaxSeriesPerDatabase:     DefaultMaxSeriesPerDatabase,
 		MaxValuesPerTag:          DefaultMaxValuesPerTag,
@@ -130,6 +156,10 @@ func (c *Config) Validate() error {
 		return errors.New("max-concurrent-compactions must be greater than 0")
 	}
 
+	if c.CompactTombstoneThreshold < 0 || c.CompactTombstoneThreshold > 1 {
+		return errors.New("compact-tombstone-threshold must be between 0 and 1")
+	}
+
 	valid := false
 	for _, e := range RegisteredEngines() {
 		if e == c.Engine {
@@ -159,12 +189,16 @@ func (c *Config) Validate() error {
 func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	return diagnostics.RowFromMap(map[string]interface{}{
 		"dir":                                c.Dir,
+		"index-convert-inmem":                c.IndexConvertInmem,
 		"wal-dir":                            c.WALDir,
 		"wal-fsync-delay":                    c.WALFsyncDelay,
 		"cache-max-memory-size":              c.CacheMaxMemorySize,
+		"cache-max-memory-size-total":        c.CacheMaxMemorySizeTotal,
+		"cache-max-write-wait":               c.CacheMaxWriteWait,
 		"cache-snapshot-memory-size":         c.CacheSnapshotMemorySize,
 		"cache-snapshot-write-cold-duration": c.CacheSnapshotWriteColdDuration,
 		"compact-full-write-cold-duration":   c.CompactFullWriteColdDuration,
+		"compact-tombstone-threshold":        c.CompactTombstoneThreshold,
 		"max-series-per-database":            c.MaxSeriesPerDatabase,
 		"max-values-per-tag":                 c.MaxValuesPerTag,
 		"max-concurrent-compactions":         c.MaxConcurrentCompactions,
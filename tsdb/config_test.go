@@ -47,6 +47,12 @@ func TestConfig_Validate_Error(t *testing.T) {
 	}
 
 	c.WALDir = "/var/lib/influxdb/wal"
+	c.CompactTombstoneThreshold = 1.5
+	if err := c.Validate(); err == nil || err.Error() != "compact-tombstone-threshold must be between 0 and 1" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.CompactTombstoneThreshold = tsdb.DefaultCompactTombstoneThreshold
 	c.Engine = "fake1"
 	if err := c.Validate(); err == nil || err.Error() != "unrecognized engine fake1" {
 		t.Errorf("unexpected error: %s", err)
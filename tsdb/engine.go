@@ -7,6 +7,7 @@ import (
 	"os"
 	"regexp"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/influxdata/influxdb/influxql"
@@ -32,10 +33,16 @@ type Engine interface {
 	Close() error
 	SetEnabled(enabled bool)
 	SetCompactionsEnabled(enabled bool)
+	SetWALMode(mode string) error
+	SetDuplicatePolicy(policy string) error
 
 	WithLogger(zap.Logger)
 
 	LoadMetadataIndex(shardID uint64, index Index) error
+	SetIndex(index Index)
+	ForEachSeriesKey(fn func(key []byte) error) error
+	ForEachSeriesKeyWrittenSince(min int64, fn func(key []byte) error) error
+	SeriesWrittenSince(seriesKeys [][]byte, min int64) (map[string]struct{}, error)
 
 	CreateSnapshot() (string, error)
 	Backup(w io.Writer, basePath string, since time.Time) error
@@ -57,8 +64,12 @@ type Engine interface {
 	MeasurementNamesByExpr(expr influxql.Expr) ([][]byte, error)
 	MeasurementNamesByRegex(re *regexp.Regexp) ([][]byte, error)
 	MeasurementFields(measurement []byte) *MeasurementFields
+	MeasurementFieldSet() *MeasurementFieldSet
 	ForEachMeasurementName(fn func(name []byte) error) error
 	DeleteMeasurement(name []byte) error
+	RenameSeries(name []byte, fn func(seriesKey []byte) ([]byte, error)) error
+	DeleteField(name, field []byte) error
+	ConvertField(name, field []byte, typ influxql.DataType, writes sync.Locker) error
 
 	// TagKeys(name []byte) ([][]byte, error)
 	HasTagKey(name, key []byte) (bool, error)
@@ -140,13 +151,103 @@ func NewEngine(id uint64, i Index, database, path string, walPath string, option
 	return fn(id, i, database, path, walPath, options), nil
 }
 
+// WAL durability modes that can be set per database.
+const (
+	// WALModeFsync fsyncs the WAL after every write batch, ignoring wal-fsync-delay.
+	WALModeFsync = "fsync"
+
+	// WALModeGroup groups fsyncs of concurrent writes using wal-fsync-delay.
+	// This is the default mode.
+	WALModeGroup = "group"
+
+	// WALModeNone skips the WAL entirely. Points only live in the cache until
+	// it is snapshotted, so they are lost if the process crashes.
+	WALModeNone = "none"
+)
+
+// ValidWALMode returns true if mode is a known WAL durability mode. An empty
+// mode is valid and selects the default mode.
+func ValidWALMode(mode string) bool {
+	switch mode {
+	case "", WALModeFsync, WALModeGroup, WALModeNone:
+		return true
+	}
+	return false
+}
+
+// Duplicate point policies that can be set per database.  They determine what
+// happens when a value is written for a series, field and timestamp that
+// already has a value.
+const (
+	// DuplicatePolicyLast overwrites the existing value (last write wins).
+	// This is the default policy.
+	DuplicatePolicyLast = "last"
+
+	// DuplicatePolicyFirst keeps the existing value and silently drops the new
+	// one (first write wins).
+	DuplicatePolicyFirst = "first"
+
+	// DuplicatePolicyReject keeps the existing value and reports the points
+	// containing the new one as dropped.
+	DuplicatePolicyReject = "reject"
+)
+
+// ValidDuplicatePolicy returns true if policy is a known duplicate point policy.
+// An empty policy is valid and selects the default policy.
+func ValidDuplicatePolicy(policy string) bool {
+	switch policy {
+	case "", DuplicatePolicyLast, DuplicatePolicyFirst, DuplicatePolicyReject:
+		return true
+	}
+	return false
+}
+
+// Cardinality limit policies that decide what happens to a write that would
+// exceed a cardinality limit.
+const (
+	// CardinalityPolicyReject rejects all points of the write.
+	CardinalityPolicyReject = "reject"
+
+	// CardinalityPolicyDrop drops the points of the new series that would
+	// exceed the limit and writes the remaining points.  This is the default
+	// policy.
+	CardinalityPolicyDrop = "drop"
+
+	// CardinalityPolicyLog writes all points and logs that the limit was
+	// exceeded.
+	CardinalityPolicyLog = "log"
+)
+
+// ValidCardinalityPolicy returns true if policy is a known cardinality limit
+// policy.  An empty policy is valid and selects the default policy.
+func ValidCardinalityPolicy(policy string) bool {
+	switch policy {
+	case "", CardinalityPolicyReject, CardinalityPolicyDrop, CardinalityPolicyLog:
+		return true
+	}
+	return false
+}
+
+// CardinalityLimit limits the number of series in a measurement or, if TagKey
+// is set, the number of values of a tag key within a measurement.
+type CardinalityLimit struct {
+	Measurement string
+	TagKey      string
+	Max         int
+	Policy      string
+}
+
 // EngineOptions represents the options used to initialize the engine.
 type EngineOptions struct {
-	EngineVersion     string
-	IndexVersion      string
-	ShardID           uint64
-	InmemIndex        interface{} // shared in-memory index
-	CompactionLimiter limiter.Fixed
+	EngineVersion      string
+	IndexVersion       string
+	ShardID            uint64
+	InmemIndex         interface{} // shared in-memory index
+	CompactionLimiter  limiter.Fixed
+	CacheLimiter       *limiter.Memory // shared cache memory budget
+	WALMode            string
+	DuplicatePolicy    string
+	CardinalityLimiter *CardinalityLimiter // shared by the shards of a database
 
 	Config Config
 }
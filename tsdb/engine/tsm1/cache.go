@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/uber-go/zap"
 )
@@ -31,6 +32,12 @@ func ErrCacheMemorySizeLimitExceeded(n, limit uint64) error {
 	return fmt.Errorf("cache-max-memory-size exceeded: (%d/%d)", n, limit)
 }
 
+// ErrCacheTotalMemorySizeLimitExceeded returns an error indicating an operation
+// could not be completed due to exceeding the cache-max-memory-size-total setting.
+func ErrCacheTotalMemorySizeLimitExceeded(n, limit uint64) error {
+	return fmt.Errorf("cache-max-memory-size-total exceeded: (%d/%d)", n, limit)
+}
+
 // entry is a set of values and some metadata.
 type entry struct {
 	mu     sync.RWMutex
@@ -124,6 +131,18 @@ func (e *entry) deduplicate() {
 	e.values = e.values.Deduplicate()
 }
 
+// timestamps adds the timestamps of the entry's values between min and max
+// inclusive to ts.
+func (e *entry) timestamps(ts map[int64]struct{}, min, max int64) {
+	e.mu.RLock()
+	for _, v := range e.values {
+		if t := v.UnixNano(); t >= min && t <= max {
+			ts[t] = struct{}{}
+		}
+	}
+	e.mu.RUnlock()
+}
+
 // count returns the number of values in this entry.
 func (e *entry) count() int {
 	e.mu.RLock()
@@ -164,6 +183,11 @@ const (
 	statCacheWriteOK      = "writeOk"
 	statCacheWriteErr     = "writeErr"
 	statCacheWriteDropped = "writeDropped"
+
+	statCacheWriteDuplicates = "writeDuplicates" // counter: Number of values dropped because they already existed
+
+	statCacheWriteThrottled        = "writeThrottled"        // counter: Number of writes that waited for the cache to be flushed
+	statCacheWriteThrottleDuration = "writeThrottleDuration" // counter: Total number of nanoseconds writes spent waiting
 )
 
 // storer is the interface that descibes a cache's store.
@@ -190,6 +214,15 @@ type Cache struct {
 	store   storer
 	maxSize uint64
 
+	// budget is the memory budget shared with the caches of other shards.
+	budget *limiter.Memory
+
+	// freed is closed, and replaced, whenever memory is released from the cache.
+	freed chan struct{}
+
+	// distinctMu serializes writes that must not overwrite existing values.
+	distinctMu sync.Mutex
+
 	// snapshots are the cache objects that are currently being written to tsm files
 	// they're kept in memory while flushing so they can be queried along with the cache.
 	// they are read only and should never be modified
@@ -210,6 +243,7 @@ func NewCache(maxSize uint64, path string) *Cache {
 	c := &Cache{
 		maxSize:      maxSize,
 		store:        store, // Max size for now..
+		freed:        make(chan struct{}),
 		stats:        &CacheStatistics{},
 		lastSnapshot: time.Now(),
 	}
@@ -232,6 +266,10 @@ type CacheStatistics struct {
 	WriteOK             int64
 	WriteErr            int64
 	WriteDropped        int64
+	WriteDuplicates     int64
+
+	WriteThrottled        int64
+	WriteThrottleDuration int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -249,6 +287,11 @@ func (c *Cache) Statistics(tags map[string]string) []models.Statistic {
 			statCacheWriteOK:        atomic.LoadInt64(&c.stats.WriteOK),
 			statCacheWriteErr:       atomic.LoadInt64(&c.stats.WriteErr),
 			statCacheWriteDropped:   atomic.LoadInt64(&c.stats.WriteDropped),
+
+			statCacheWriteDuplicates: atomic.LoadInt64(&c.stats.WriteDuplicates),
+
+			statCacheWriteThrottled:        atomic.LoadInt64(&c.stats.WriteThrottled),
+			statCacheWriteThrottleDuration: atomic.LoadInt64(&c.stats.WriteThrottleDuration),
 		},
 	}}
 }
@@ -258,16 +301,20 @@ func (c *Cache) Statistics(tags map[string]string) []models.Statistic {
 func (c *Cache) Write(key []byte, values []Value) error {
 	addedSize := uint64(Values(values).Size())
 
-	// Enough room in the cache?
-	limit := c.maxSize
-	n := c.Size() + addedSize
+	c.mu.RLock()
+	budget := c.budget
+	c.mu.RUnlock()
 
-	if limit > 0 && n > limit {
+	// Enough room in the cache?
+	if err := c.reserve(budget, addedSize); err != nil {
 		atomic.AddInt64(&c.stats.WriteErr, 1)
-		return ErrCacheMemorySizeLimitExceeded(n, limit)
+		return err
 	}
 
 	if err := c.store.write(key, values); err != nil {
+		if budget != nil {
+			budget.Release(addedSize)
+		}
 		atomic.AddInt64(&c.stats.WriteErr, 1)
 		return err
 	}
@@ -291,19 +338,18 @@ func (c *Cache) WriteMulti(values map[string][]Value) error {
 		addedSize += uint64(Values(v).Size())
 	}
 
-	// Enough room in the cache?
-	limit := c.maxSize // maxSize is safe for reading without a lock.
-	n := c.Size() + addedSize
-	if limit > 0 && n > limit {
-		atomic.AddInt64(&c.stats.WriteErr, 1)
-		return ErrCacheMemorySizeLimitExceeded(n, limit)
-	}
-
 	var werr error
 	c.mu.RLock()
 	store := c.store
+	budget := c.budget
 	c.mu.RUnlock()
 
+	// Enough room in the cache?
+	if err := c.reserve(budget, addedSize); err != nil {
+		atomic.AddInt64(&c.stats.WriteErr, 1)
+		return err
+	}
+
 	// We'll optimistially set size here, and then decrement it for write errors.
 	c.increaseSize(addedSize)
 	for k, v := range values {
@@ -312,6 +358,9 @@ func (c *Cache) WriteMulti(values map[string][]Value) error {
 			werr = err
 			addedSize -= uint64(Values(v).Size())
 			c.decreaseSize(uint64(Values(v).Size()))
+			if budget != nil {
+				budget.Release(uint64(Values(v).Size()))
+			}
 		}
 	}
 
@@ -329,6 +378,263 @@ func (c *Cache) WriteMulti(values map[string][]Value) error {
 	return werr
 }
 
+// WriteMultiDistinct writes the map of keys and associated values to the cache
+// like WriteMulti, except that values are only written if their key does not
+// already have a value with the same timestamp.  The cache, the snapshot being
+// written, values written earlier in the same batch and, using exists, the TSM
+// files are checked.  If byPoint is set, the values of a series at a timestamp
+// form a point and none of them are written if any of them exists.  The values
+// that were not written are removed from values and returned by key.
+func (c *Cache) WriteMultiDistinct(values map[string][]Value, exists func(key []byte, ts []int64) (map[int64]struct{}, error), byPoint bool) (map[string][]Value, error) {
+	// Only one distinct write may check and add values at a time, otherwise two
+	// writers could both add the same value.
+	c.distinctMu.Lock()
+	defer c.distinctMu.Unlock()
+
+	duplicates, err := c.removeExisting(values, exists)
+	if err != nil {
+		return nil, err
+	} else if byPoint && len(duplicates) > 0 {
+		removePoints(values, duplicates)
+	}
+
+	if n := len(duplicates); n > 0 {
+		var count int64
+		for _, v := range duplicates {
+			count += int64(len(v))
+		}
+		atomic.AddInt64(&c.stats.WriteDuplicates, count)
+	}
+
+	if len(values) == 0 {
+		return duplicates, nil
+	}
+	return duplicates, c.WriteMulti(values)
+}
+
+// removeExisting removes the values that already exist from values and returns them.
+func (c *Cache) removeExisting(values map[string][]Value, exists func(key []byte, ts []int64) (map[int64]struct{}, error)) (map[string][]Value, error) {
+	// Hold the read lock so a snapshot can't move values between the cache and
+	// the TSM files while they are checked.
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var duplicates map[string][]Value
+	for k, vals := range values {
+		if len(vals) == 0 {
+			continue
+		}
+
+		key := []byte(k)
+		min, max := vals[0].UnixNano(), vals[0].UnixNano()
+		for _, v := range vals {
+			if t := v.UnixNano(); t < min {
+				min = t
+			} else if t > max {
+				max = t
+			}
+		}
+
+		seen := make(map[int64]struct{})
+		if e, ok := c.store.entry(key); ok {
+			e.timestamps(seen, min, max)
+		}
+		if c.snapshot != nil {
+			if e, ok := c.snapshot.store.entry(key); ok {
+				e.timestamps(seen, min, max)
+			}
+		}
+
+		if exists != nil {
+			ts := make([]int64, 0, len(vals))
+			for _, v := range vals {
+				if _, ok := seen[v.UnixNano()]; !ok {
+					ts = append(ts, v.UnixNano())
+				}
+			}
+
+			stored, err := exists(key, ts)
+			if err != nil {
+				return nil, err
+			}
+			for t := range stored {
+				seen[t] = struct{}{}
+			}
+		}
+
+		// Keep the first value for each new timestamp.
+		var n int
+		for _, v := range vals {
+			if _, ok := seen[v.UnixNano()]; ok {
+				if duplicates == nil {
+					duplicates = make(map[string][]Value)
+				}
+				duplicates[k] = append(duplicates[k], v)
+				continue
+			}
+			seen[v.UnixNano()] = struct{}{}
+			vals[n] = v
+			n++
+		}
+
+		if n == 0 {
+			delete(values, k)
+		} else {
+			values[k] = vals[:n]
+		}
+	}
+	return duplicates, nil
+}
+
+// removePoints moves the values of the series and timestamps in duplicates
+// from values to duplicates.
+func removePoints(values, duplicates map[string][]Value) {
+	points := make(map[string]map[int64]struct{})
+	for k, vals := range duplicates {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey([]byte(k))
+		ts := points[string(seriesKey)]
+		if ts == nil {
+			ts = make(map[int64]struct{})
+			points[string(seriesKey)] = ts
+		}
+		for _, v := range vals {
+			ts[v.UnixNano()] = struct{}{}
+		}
+	}
+
+	for k, vals := range values {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey([]byte(k))
+		ts := points[string(seriesKey)]
+		if ts == nil {
+			continue
+		}
+
+		var n int
+		for _, v := range vals {
+			if _, ok := ts[v.UnixNano()]; ok {
+				duplicates[k] = append(duplicates[k], v)
+				continue
+			}
+			vals[n] = v
+			n++
+		}
+
+		if n == 0 {
+			delete(values, k)
+		} else {
+			values[k] = vals[:n]
+		}
+	}
+}
+
+// reserve returns an error if adding n bytes would exceed the max size of the
+// cache or the shared budget.  On success, n bytes are acquired from budget.
+func (c *Cache) reserve(budget *limiter.Memory, n uint64) error {
+	limit := c.maxSize // maxSize is safe for reading without a lock.
+	if sz := c.Size() + n; limit > 0 && sz > limit {
+		return ErrCacheMemorySizeLimitExceeded(sz, limit)
+	}
+
+	if budget != nil && !budget.TryAcquire(n) {
+		return ErrCacheTotalMemorySizeLimitExceeded(budget.Used()+n, budget.Limit())
+	}
+	return nil
+}
+
+// HasRoom returns true if n bytes can be written without exceeding the max
+// size of the cache or the shared budget.
+func (c *Cache) HasRoom(n uint64) bool {
+	c.mu.RLock()
+	budget := c.budget
+	c.mu.RUnlock()
+
+	if limit := c.maxSize; limit > 0 && c.Size()+n > limit {
+		return false
+	}
+	return budget == nil || budget.Fits(n)
+}
+
+// WaitForRoom blocks writers until the cache has room for n bytes or timeout
+// elapses.  flush is called while the cache is full so the caller can request a
+// snapshot.  It returns true if there is room for the write.
+func (c *Cache) WaitForRoom(n uint64, timeout time.Duration, flush func()) bool {
+	if timeout <= 0 || c.HasRoom(n) {
+		return true
+	}
+
+	start := time.Now()
+	defer func() {
+		atomic.AddInt64(&c.stats.WriteThrottled, 1)
+		atomic.AddInt64(&c.stats.WriteThrottleDuration, time.Since(start).Nanoseconds())
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		c.mu.RLock()
+		freed, budget := c.freed, c.budget
+		c.mu.RUnlock()
+
+		var budgetFreed <-chan struct{}
+		if budget != nil {
+			budgetFreed = budget.Freed()
+		}
+
+		if c.HasRoom(n) {
+			return true
+		}
+		flush()
+
+		select {
+		case <-freed:
+		case <-budgetFreed:
+		case <-timer.C:
+			return c.HasRoom(n)
+		}
+	}
+}
+
+// SetBudget sets the memory budget shared with the caches of other shards.  The
+// memory currently used by the cache is moved from the previous budget, if any.
+func (c *Cache) SetBudget(budget *limiter.Memory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.budget == budget {
+		return
+	}
+
+	size := c.Size()
+	if c.budget != nil {
+		c.budget.Release(size)
+	}
+	if budget != nil {
+		budget.Acquire(size)
+	}
+	c.budget = budget
+}
+
+// OverBudget returns true if the shared budget of the cache is exhausted.
+func (c *Cache) OverBudget() bool {
+	c.mu.RLock()
+	budget := c.budget
+	c.mu.RUnlock()
+	return budget != nil && budget.Exceeded()
+}
+
+// release returns n bytes to the shared budget and wakes up writers waiting
+// for room in the cache.  The cache lock must be held.
+func (c *Cache) release(n uint64) {
+	if c.budget != nil {
+		c.budget.Release(n)
+	}
+	if c.freed != nil {
+		close(c.freed)
+		c.freed = make(chan struct{})
+	}
+}
+
 // Snapshot takes a snapshot of the current cache, adds it to the slice of caches that
 // are being flushed, and resets the current cache with new values.
 func (c *Cache) Snapshot() (*Cache, error) {
@@ -402,6 +708,7 @@ func (c *Cache) ClearSnapshot(success bool) {
 	if success {
 		c.snapshotAttempts = 0
 		c.updateMemSize(-int64(atomic.LoadUint64(&c.snapshotSize))) // decrement the number of bytes in cache
+		c.release(atomic.LoadUint64(&c.snapshotSize))
 
 		// Reset the snapshot's store, and reset the snapshot to a fresh Cache.
 		c.snapshot.store.reset()
@@ -522,6 +829,7 @@ func (c *Cache) DeleteRange(keys [][]byte, min, max int64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	var freed uint64
 	for _, k := range keys {
 		// Make sure key exist in the cache, skip if it does not
 		e, ok := c.store.entry(k)
@@ -533,6 +841,7 @@ func (c *Cache) DeleteRange(keys [][]byte, min, max int64) {
 		if min == math.MinInt64 && max == math.MaxInt64 {
 			c.decreaseSize(origSize)
 			c.store.remove(k)
+			freed += origSize
 			continue
 		}
 
@@ -540,10 +849,15 @@ func (c *Cache) DeleteRange(keys [][]byte, min, max int64) {
 		if e.count() == 0 {
 			c.store.remove(k)
 			c.decreaseSize(origSize)
+			freed += origSize
 			continue
 		}
 
 		c.decreaseSize(origSize - uint64(e.size()))
+		freed += origSize - uint64(e.size())
+	}
+	if freed > 0 {
+		c.release(freed)
 	}
 	atomic.StoreInt64(&c.stats.MemSizeBytes, int64(c.Size()))
 }
@@ -14,8 +14,10 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb/pkg/limiter"
 )
 
 func TestCache_NewCache(t *testing.T) {
@@ -101,6 +103,95 @@ func TestCache_CacheWriteMulti(t *testing.T) {
 	}
 }
 
+// Tests that values for existing timestamps are not written.
+func TestCache_WriteMultiDistinct(t *testing.T) {
+	c := NewCache(0, "")
+
+	if err := c.WriteMulti(map[string][]Value{"foo": {NewValue(1, 1.0)}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WriteMulti(map[string][]Value{"foo": {NewValue(2, 1.0)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Timestamp 3 exists in the TSM files.
+	exists := func(key []byte, ts []int64) (map[int64]struct{}, error) {
+		if string(key) != "foo" {
+			return nil, nil
+		}
+		for _, t := range ts {
+			if t == 3 {
+				return map[int64]struct{}{3: {}}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	values := map[string][]Value{
+		"foo": {NewValue(1, 2.0), NewValue(2, 2.0), NewValue(3, 2.0), NewValue(4, 2.0), NewValue(4, 3.0)},
+		"bar": {NewValue(1, 2.0)},
+	}
+	duplicates, err := c.WriteMultiDistinct(values, exists, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := map[string][]Value{
+		"foo": {NewValue(1, 2.0), NewValue(2, 2.0), NewValue(3, 2.0), NewValue(4, 3.0)},
+	}; !reflect.DeepEqual(duplicates, exp) {
+		t.Fatalf("unexpected duplicates: got %v, exp %v", duplicates, exp)
+	}
+
+	if exp := (Values{NewValue(1, 1.0), NewValue(2, 1.0), NewValue(4, 2.0)}); !reflect.DeepEqual(c.Values([]byte("foo")), exp) {
+		t.Fatalf("unexpected values for foo: got %v, exp %v", c.Values([]byte("foo")), exp)
+	}
+	if exp := (Values{NewValue(1, 2.0)}); !reflect.DeepEqual(c.Values([]byte("bar")), exp) {
+		t.Fatalf("unexpected values for bar: got %v, exp %v", c.Values([]byte("bar")), exp)
+	}
+	if got, exp := c.stats.WriteDuplicates, int64(4); got != exp {
+		t.Fatalf("unexpected duplicates stat: got %d, exp %d", got, exp)
+	}
+}
+
+// Tests that no values of a point are written if any of them exists.
+func TestCache_WriteMultiDistinct_ByPoint(t *testing.T) {
+	c := NewCache(0, "")
+
+	if err := c.WriteMulti(map[string][]Value{"cpu#!~#value": {NewValue(1, 1.0)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string][]Value{
+		"cpu#!~#value": {NewValue(1, 2.0), NewValue(2, 2.0)},
+		"cpu#!~#idle":  {NewValue(1, 2.0), NewValue(2, 2.0)},
+		"mem#!~#value": {NewValue(1, 2.0)},
+	}
+	duplicates, err := c.WriteMultiDistinct(values, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := map[string][]Value{
+		"cpu#!~#value": {NewValue(1, 2.0)},
+		"cpu#!~#idle":  {NewValue(1, 2.0)},
+	}; !reflect.DeepEqual(duplicates, exp) {
+		t.Fatalf("unexpected duplicates: got %v, exp %v", duplicates, exp)
+	}
+
+	if exp := (Values{NewValue(1, 1.0), NewValue(2, 2.0)}); !reflect.DeepEqual(c.Values([]byte("cpu#!~#value")), exp) {
+		t.Fatalf("unexpected values for cpu value: got %v, exp %v", c.Values([]byte("cpu#!~#value")), exp)
+	}
+	if exp := (Values{NewValue(2, 2.0)}); !reflect.DeepEqual(c.Values([]byte("cpu#!~#idle")), exp) {
+		t.Fatalf("unexpected values for cpu idle: got %v, exp %v", c.Values([]byte("cpu#!~#idle")), exp)
+	}
+	if exp := (Values{NewValue(1, 2.0)}); !reflect.DeepEqual(c.Values([]byte("mem#!~#value")), exp) {
+		t.Fatalf("unexpected values for mem: got %v, exp %v", c.Values([]byte("mem#!~#value")), exp)
+	}
+}
+
 // Tests that the cache stats and size are correctly maintained during writes.
 func TestCache_WriteMulti_Stats(t *testing.T) {
 	limit := uint64(1)
@@ -519,6 +610,89 @@ func TestCache_CacheWriteMemoryExceeded(t *testing.T) {
 	}
 }
 
+func TestCache_CacheWriteTotalMemoryExceeded(t *testing.T) {
+	v0 := NewValue(1, 1.0)
+	v1 := NewValue(2, 2.0)
+
+	budget := limiter.NewMemory(uint64(v0.Size()))
+	c0, c1 := NewCache(0, ""), NewCache(0, "")
+	c0.SetBudget(budget)
+	c1.SetBudget(budget)
+
+	if err := c0.Write([]byte("foo"), Values{v0}); err != nil {
+		t.Fatalf("failed to write key foo to cache: %s", err.Error())
+	}
+
+	// The second cache shares the budget with the first one.
+	if err := c1.Write([]byte("bar"), Values{v1}); err == nil || !strings.Contains(err.Error(), "cache-max-memory-size-total") {
+		t.Fatalf("wrong error writing key bar to cache: %v", err)
+	}
+
+	// Flushing the first cache returns its memory to the budget.
+	if _, err := c0.Snapshot(); err != nil {
+		t.Fatalf("failed to snapshot cache: %v", err)
+	}
+	c0.ClearSnapshot(true)
+
+	if err := c1.Write([]byte("bar"), Values{v1}); err != nil {
+		t.Fatalf("failed to write key bar to cache: %s", err.Error())
+	}
+	if got, exp := budget.Used(), uint64(v1.Size()); got != exp {
+		t.Fatalf("budget used mismatch: got %v, exp %v", got, exp)
+	}
+
+	c1.SetBudget(nil)
+	if got, exp := budget.Used(), uint64(0); got != exp {
+		t.Fatalf("budget used mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+func TestCache_WaitForRoom(t *testing.T) {
+	v0 := NewValue(1, 1.0)
+	v1 := NewValue(2, 2.0)
+
+	c := NewCache(uint64(v1.Size()), "")
+	if err := c.Write([]byte("foo"), Values{v0}); err != nil {
+		t.Fatalf("failed to write key foo to cache: %s", err.Error())
+	}
+
+	// Waiting on a full cache times out if it is never flushed.
+	if c.WaitForRoom(uint64(v1.Size()), 10*time.Millisecond, func() {}) {
+		t.Fatal("expected no room in the cache")
+	}
+
+	// Flushing the cache while waiting lets the write proceed.
+	flushed := make(chan struct{})
+	flush := func() {
+		select {
+		case <-flushed:
+		default:
+			close(flushed)
+			go func() {
+				if _, err := c.Snapshot(); err != nil {
+					t.Errorf("failed to snapshot cache: %v", err)
+				}
+				c.ClearSnapshot(true)
+			}()
+		}
+	}
+	if !c.WaitForRoom(uint64(v1.Size()), 10*time.Second, flush) {
+		t.Fatal("expected room in the cache")
+	}
+
+	if err := c.Write([]byte("bar"), Values{v1}); err != nil {
+		t.Fatalf("failed to write key bar to cache: %s", err.Error())
+	}
+
+	stats := c.Statistics(nil)[0].Values
+	if got, exp := stats["writeThrottled"], int64(2); got != exp {
+		t.Fatalf("write throttled mismatch: got %v, exp %v", got, exp)
+	}
+	if got := stats["writeThrottleDuration"].(int64); got <= 0 {
+		t.Fatalf("expected write throttle duration, got %v", got)
+	}
+}
+
 func TestCache_Deduplicate_Concurrent(t *testing.T) {
 	if testing.Short() || os.Getenv("GORACE") != "" || os.Getenv("APPVEYOR") != "" {
 		t.Skip("Skipping test in short, race, appveyor mode.")
@@ -91,7 +91,13 @@ func (k *tsmKeyIterator) combineFloat(dedup bool) blocks {
 					v = FloatValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedFloatValues = k.mergedFloatValues.Merge(v)
+				// Values already merged were read from earlier blocks and win over v
+				// when keeping the first value written.
+				if k.keepFirst {
+					k.mergedFloatValues = FloatValues(v).Merge(k.mergedFloatValues)
+				} else {
+					k.mergedFloatValues = k.mergedFloatValues.Merge(v)
+				}
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -299,7 +305,13 @@ func (k *tsmKeyIterator) combineInteger(dedup bool) blocks {
 					v = IntegerValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedIntegerValues = k.mergedIntegerValues.Merge(v)
+				// Values already merged were read from earlier blocks and win over v
+				// when keeping the first value written.
+				if k.keepFirst {
+					k.mergedIntegerValues = IntegerValues(v).Merge(k.mergedIntegerValues)
+				} else {
+					k.mergedIntegerValues = k.mergedIntegerValues.Merge(v)
+				}
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -507,7 +519,13 @@ func (k *tsmKeyIterator) combineUnsigned(dedup bool) blocks {
 					v = UnsignedValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedUnsignedValues = k.mergedUnsignedValues.Merge(v)
+				// Values already merged were read from earlier blocks and win over v
+				// when keeping the first value written.
+				if k.keepFirst {
+					k.mergedUnsignedValues = UnsignedValues(v).Merge(k.mergedUnsignedValues)
+				} else {
+					k.mergedUnsignedValues = k.mergedUnsignedValues.Merge(v)
+				}
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -715,7 +733,13 @@ func (k *tsmKeyIterator) combineString(dedup bool) blocks {
 					v = StringValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedStringValues = k.mergedStringValues.Merge(v)
+				// Values already merged were read from earlier blocks and win over v
+				// when keeping the first value written.
+				if k.keepFirst {
+					k.mergedStringValues = StringValues(v).Merge(k.mergedStringValues)
+				} else {
+					k.mergedStringValues = k.mergedStringValues.Merge(v)
+				}
 
 				// Allow other goroutines to run
 				runtime.Gosched()
@@ -923,7 +947,13 @@ func (k *tsmKeyIterator) combineBoolean(dedup bool) blocks {
 					v = BooleanValues(v).Exclude(ts.Min, ts.Max)
 				}
 
-				k.mergedBooleanValues = k.mergedBooleanValues.Merge(v)
+				// Values already merged were read from earlier blocks and win over v
+				// when keeping the first value written.
+				if k.keepFirst {
+					k.mergedBooleanValues = BooleanValues(v).Merge(k.mergedBooleanValues)
+				} else {
+					k.mergedBooleanValues = k.mergedBooleanValues.Merge(v)
+				}
 
 				// Allow other goroutines to run
 				runtime.Gosched()
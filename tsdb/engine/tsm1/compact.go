@@ -65,6 +65,7 @@ type CompactionPlanner interface {
 	Plan(lastWrite time.Time) []CompactionGroup
 	PlanLevel(level int) []CompactionGroup
 	PlanOptimize() []CompactionGroup
+	PlanTombstones() []CompactionGroup
 	Release(group []CompactionGroup)
 	FullyCompacted() bool
 }
@@ -82,6 +83,11 @@ type DefaultPlanner struct {
 	// should always be greater than the CacheFlushWriteColdDuraion
 	compactFullWriteColdDuration time.Duration
 
+	// TombstoneThreshold is the fraction of a TSM file's data that must be covered
+	// by tombstones before the file is rewritten by itself to remove the deleted
+	// data.  A value of 0 disables tombstone compactions.
+	TombstoneThreshold float64
+
 	// lastPlanCheck is the last time Plan was called
 	lastPlanCheck time.Time
 
@@ -315,6 +321,50 @@ func (c *DefaultPlanner) PlanOptimize() []CompactionGroup {
 	return cGroups
 }
 
+// PlanTombstones returns a set of TSM files to rewrite because tombstones cover
+// more than TombstoneThreshold of their data.  Each group contains files of a
+// single generation so they are rewritten without merging them with any other
+// generation.  Only level 4 generations are considered since lower levels are
+// already rewritten by the level planners when they have tombstones.
+func (c *DefaultPlanner) PlanTombstones() []CompactionGroup {
+	if c.TombstoneThreshold <= 0 {
+		return nil
+	}
+
+	generations := c.findGenerations()
+	if !generations.hasTombstones() {
+		return nil
+	}
+
+	var cGroups []CompactionGroup
+	for _, gen := range generations {
+		if gen.level() < 4 || !gen.hasTombstones() {
+			continue
+		}
+
+		// The rewritten files get higher sequences than the generation's
+		// other files and are read after them, so only the files over the
+		// threshold need to be rewritten.
+		var cGroup CompactionGroup
+		for _, f := range gen.files {
+			if f.HasTombstone && f.TombstoneRatio() >= c.TombstoneThreshold {
+				cGroup = append(cGroup, f.Path)
+			}
+		}
+		if len(cGroup) == 0 {
+			continue
+		}
+
+		// Skip files that are already being compacted by another planner.
+		if !c.acquire([]CompactionGroup{cGroup}) {
+			continue
+		}
+		cGroups = append(cGroups, cGroup)
+	}
+
+	return cGroups
+}
+
 // Plan returns a set of TSM files to rewrite for level 4 or higher.  The planning returns
 // multiple groups if possible to allow compactions to run concurrently.
 func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
@@ -598,6 +648,18 @@ type Compactor struct {
 	compactionsInterrupt chan struct{}
 
 	files map[string]struct{}
+
+	// keepFirst keeps the first value written for duplicate timestamps instead
+	// of the last when merging overlapping blocks.
+	keepFirst bool
+}
+
+// SetKeepFirst sets whether compactions keep the first value written for a
+// duplicate timestamp instead of the last.
+func (c *Compactor) SetKeepFirst(v bool) {
+	c.mu.Lock()
+	c.keepFirst = v
+	c.mu.Unlock()
 }
 
 // Open initializes the Compactor.
@@ -701,8 +763,9 @@ func (c *Compactor) WriteSnapshot(cache *Cache) ([]string, error) {
 	return files, err
 }
 
-// compact writes multiple smaller TSM files into 1 or more larger files.
-func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
+// compact writes multiple smaller TSM files into 1 or more larger files.  If
+// partial is set, tsmFiles may be only some of the files of their generation.
+func (c *Compactor) compact(fast, partial bool, tsmFiles []string) ([]string, error) {
 	size := c.Size
 	if size <= 0 {
 		size = tsdb.DefaultMaxPointsPerBlock
@@ -727,6 +790,17 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 		}
 	}
 
+	// The new files of a partial compaction must also follow the files of
+	// the generation that are not compacted.
+	if partial {
+		seq, err := c.maxSequence(maxGeneration)
+		if err != nil {
+			return nil, err
+		} else if seq > maxSequence {
+			maxSequence = seq
+		}
+	}
+
 	// For each TSM file, create a TSM reader
 	var trs []*TSMReader
 	for _, file := range tsmFiles {
@@ -749,9 +823,10 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 
 	c.mu.RLock()
 	intC := c.compactionsInterrupt
+	keepFirst := c.keepFirst
 	c.mu.RUnlock()
 
-	tsm, err := NewTSMKeyIterator(size, fast, intC, trs...)
+	tsm, err := newTSMKeyIterator(size, fast, keepFirst, intC, trs...)
 	if err != nil {
 		return nil, err
 	}
@@ -774,7 +849,7 @@ func (c *Compactor) CompactFull(tsmFiles []string) ([]string, error) {
 	}
 	defer c.remove(tsmFiles)
 
-	files, err := c.compact(false, tsmFiles)
+	files, err := c.compact(false, false, tsmFiles)
 
 	// See if we were disabled while writing a snapshot
 	c.mu.RLock()
@@ -806,7 +881,7 @@ func (c *Compactor) CompactFast(tsmFiles []string) ([]string, error) {
 	}
 	defer c.remove(tsmFiles)
 
-	files, err := c.compact(true, tsmFiles)
+	files, err := c.compact(true, false, tsmFiles)
 
 	// See if we were disabled while writing a snapshot
 	c.mu.RLock()
@@ -824,6 +899,40 @@ func (c *Compactor) CompactFast(tsmFiles []string) ([]string, error) {
 
 }
 
+// CompactTombstones rewrites some TSM files of a generation to remove the data
+// covered by their tombstones.  The new files follow all the files of the
+// generation, including the ones not rewritten.
+func (c *Compactor) CompactTombstones(tsmFiles []string) ([]string, error) {
+	c.mu.RLock()
+	enabled := c.compactionsEnabled
+	c.mu.RUnlock()
+
+	if !enabled {
+		return nil, errCompactionsDisabled
+	}
+
+	if !c.add(tsmFiles) {
+		return nil, errCompactionInProgress{}
+	}
+	defer c.remove(tsmFiles)
+
+	files, err := c.compact(false, true, tsmFiles)
+
+	// See if we were disabled while writing a snapshot
+	c.mu.RLock()
+	enabled = c.compactionsEnabled
+	c.mu.RUnlock()
+
+	if !enabled {
+		if err := c.removeTmpFiles(files); err != nil {
+			return nil, err
+		}
+		return nil, errCompactionsDisabled
+	}
+
+	return files, err
+}
+
 // removeTmpFiles is responsible for cleaning up a compaction that
 // was started, but then abandoned before the temporary files were dealt with.
 func (c *Compactor) removeTmpFiles(files []string) error {
@@ -835,6 +944,23 @@ func (c *Compactor) removeTmpFiles(files []string) error {
 	return nil
 }
 
+// maxSequence returns the highest sequence of the TSM files, including
+// temporary ones, of generation in the compactor's directory.
+func (c *Compactor) maxSequence(generation int) (int, error) {
+	files, err := filepath.Glob(filepath.Join(c.Dir, fmt.Sprintf("%09d-*.%s*", generation, TSMFileExtension)))
+	if err != nil {
+		return 0, err
+	}
+
+	var maxSequence int
+	for _, f := range files {
+		if _, seq, err := ParseTSMFileName(f); err == nil && seq > maxSequence {
+			maxSequence = seq
+		}
+	}
+	return maxSequence, nil
+}
+
 // writeNewFiles writes from the iterator into new TSM files, rotating
 // to a new file once it has reached the max TSM file size.
 func (c *Compactor) writeNewFiles(generation, sequence int, iter KeyIterator) ([]string, error) {
@@ -1030,6 +1156,9 @@ type tsmKeyIterator struct {
 	// without decode
 	merged    blocks
 	interrupt chan struct{}
+
+	// keepFirst keeps the values from earlier blocks when merging duplicates.
+	keepFirst bool
 }
 
 type block struct {
@@ -1083,6 +1212,10 @@ func (a blocks) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 // NewTSMKeyIterator returns a new TSM key iterator from readers.
 // size indicates the maximum number of values to encode in a single block.
 func NewTSMKeyIterator(size int, fast bool, interrupt chan struct{}, readers ...*TSMReader) (KeyIterator, error) {
+	return newTSMKeyIterator(size, fast, false, interrupt, readers...)
+}
+
+func newTSMKeyIterator(size int, fast, keepFirst bool, interrupt chan struct{}, readers ...*TSMReader) (KeyIterator, error) {
 	var iter []*BlockIterator
 	for _, r := range readers {
 		iter = append(iter, r.BlockIterator())
@@ -1097,6 +1230,7 @@ func NewTSMKeyIterator(size int, fast bool, interrupt chan struct{}, readers ...
 		fast:      fast,
 		buf:       make([]blocks, len(iter)),
 		interrupt: interrupt,
+		keepFirst: keepFirst,
 	}, nil
 }
 
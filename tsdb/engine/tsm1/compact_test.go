@@ -261,6 +261,62 @@ func TestCompactor_Compact_OverlappingBlocks(t *testing.T) {
 	}
 }
 
+// Ensures that a compaction keeps the values of older TSM files for duplicate
+// timestamps when keeping the first value written.
+func TestCompactor_Compact_OverlappingBlocksKeepFirst(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	a1 := tsm1.NewValue(4, 1.1)
+	a2 := tsm1.NewValue(5, 1.1)
+
+	writes := map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{a1, a2},
+	}
+	f1 := MustWriteTSM(dir, 1, writes)
+
+	c1 := tsm1.NewValue(3, 1.2)
+	c2 := tsm1.NewValue(5, 1.2)
+	c3 := tsm1.NewValue(6, 1.2)
+
+	writes = map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{c1, c2, c3},
+	}
+	f3 := MustWriteTSM(dir, 3, writes)
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+	}
+	compactor.SetKeepFirst(true)
+	compactor.Open()
+
+	files, err := compactor.CompactFull([]string{f1, f3})
+	if err != nil {
+		t.Fatalf("unexpected error compacting: %v", err)
+	}
+
+	if got, exp := len(files), 1; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	r := MustOpenTSMReader(files[0])
+
+	values, err := r.ReadAll([]byte("cpu,host=A#!~#value"))
+	if err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+
+	points := []tsm1.Value{c1, a1, a2, c3}
+	if got, exp := len(values), len(points); got != exp {
+		t.Fatalf("values length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for i, point := range points {
+		assertValueEqual(t, values[i], point)
+	}
+}
+
 // Ensures that a compaction will properly merge multiple TSM files
 func TestCompactor_Compact_OverlappingBlocksMultiple(t *testing.T) {
 	dir := MustTempDir()
@@ -532,6 +588,42 @@ func TestCompactor_CompactFull_TombstonedSkipBlock(t *testing.T) {
 	}
 }
 
+// Ensure compacting some files of a generation writes new files after the
+// generation's other files.
+func TestCompactor_CompactTombstones_PartialGeneration(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(1, 1.1)},
+	})
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+		"cpu,host=B#!~#value": []tsm1.Value{tsm1.NewValue(1, 1.2)},
+	})
+	if err := os.Rename(f2, filepath.Join(dir, "000000001-000000002.tsm")); err != nil {
+		t.Fatal(err)
+	}
+
+	compactor := &tsm1.Compactor{
+		Dir:       dir,
+		FileStore: &fakeFileStore{},
+	}
+	compactor.Open()
+
+	files, err := compactor.CompactTombstones([]string{f1})
+	if err != nil {
+		t.Fatalf("unexpected error compacting: %v", err)
+	} else if len(files) != 1 {
+		t.Fatalf("files length mismatch: got %v, exp 1", len(files))
+	}
+
+	if gen, seq, err := tsm1.ParseTSMFileName(files[0]); err != nil {
+		t.Fatal(err)
+	} else if gen != 1 || seq != 3 {
+		t.Fatalf("unexpected new file: %s", files[0])
+	}
+}
+
 // Ensures that a full compaction will decode and combine blocks with
 // partial tombstoned values
 func TestCompactor_CompactFull_TombstonedPartialBlock(t *testing.T) {
@@ -1707,6 +1799,78 @@ func TestDefaultPlanner_PlanLevel_Multiple(t *testing.T) {
 	}
 }
 
+func TestDefaultPlanner_PlanTombstones(t *testing.T) {
+	data := []tsm1.FileStat{
+		tsm1.FileStat{
+			Path: "01-04.tsm1",
+			Size: 100 * 1024 * 1024,
+		},
+		tsm1.FileStat{
+			Path:          "02-04.tsm1",
+			Size:          100 * 1024 * 1024,
+			HasTombstone:  true,
+			TombstoneSize: 10 * 1024 * 1024,
+		},
+		tsm1.FileStat{
+			Path: "03-04.tsm1",
+			Size: 100 * 1024 * 1024,
+		},
+		tsm1.FileStat{
+			Path:          "03-05.tsm1",
+			Size:          100 * 1024 * 1024,
+			HasTombstone:  true,
+			TombstoneSize: 60 * 1024 * 1024,
+		},
+		tsm1.FileStat{
+			Path:          "04-02.tsm1",
+			Size:          100 * 1024 * 1024,
+			HasTombstone:  true,
+			TombstoneSize: 90 * 1024 * 1024,
+		},
+	}
+
+	cp := tsm1.NewDefaultPlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsdb.DefaultCompactFullWriteColdDuration,
+	)
+
+	if tsm := cp.PlanTombstones(); len(tsm) != 0 {
+		t.Fatalf("expected no plan when disabled, got %v", tsm)
+	}
+
+	cp.TombstoneThreshold = 0.5
+
+	tsm := cp.PlanTombstones()
+	if exp, got := 1, len(tsm); got != exp {
+		t.Fatalf("compaction group length mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Only the file over the threshold is rewritten, not its whole generation.
+	expFiles := []tsm1.FileStat{data[3]}
+	if exp, got := len(expFiles), len(tsm[0]); got != exp {
+		t.Fatalf("tsm file length mismatch: got %v, exp %v", got, exp)
+	}
+
+	for i, p := range expFiles {
+		if got, exp := tsm[0][i], p.Path; got != exp {
+			t.Fatalf("tsm file mismatch: got %v, exp %v", got, exp)
+		}
+	}
+
+	// Files in use should not be planned again until released.
+	if got := cp.PlanTombstones(); len(got) != 0 {
+		t.Fatalf("expected no plan while files are in use, got %v", got)
+	}
+
+	cp.Release(tsm)
+	if got := cp.PlanTombstones(); len(got) != 1 {
+		t.Fatalf("expected plan after release, got %v", got)
+	}
+}
+
 func TestDefaultPlanner_PlanOptimize_NoLevel4(t *testing.T) {
 	data := []tsm1.FileStat{
 		tsm1.FileStat{
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -83,11 +84,21 @@ const (
 	statTSMFullCompactionsActive  = "tsmFullCompactionsActive"
 	statTSMFullCompactionError    = "tsmFullCompactionErr"
 	statTSMFullCompactionDuration = "tsmFullCompactionDuration"
+
+	statTSMTombstoneCompactions        = "tsmTombstoneCompactions"
+	statTSMTombstoneCompactionsActive  = "tsmTombstoneCompactionsActive"
+	statTSMTombstoneCompactionError    = "tsmTombstoneCompactionErr"
+	statTSMTombstoneCompactionDuration = "tsmTombstoneCompactionDuration"
+
+	statTombstones     = "tombstones"
+	statTombstoneFiles = "tombstoneFiles"
+	statTombstoneBytes = "tombstoneBytes"
 )
 
 // Engine represents a storage engine with compressed blocks.
 type Engine struct {
-	mu sync.RWMutex
+	mu       sync.RWMutex
+	renameMu sync.Mutex // serializes renames of series
 
 	// The following group of fields is used to track the state of level compactions within the
 	// Engine. The WaitGroup is used to monitor the compaction goroutines, the 'done' channel is
@@ -120,6 +131,27 @@ type Engine struct {
 	CompactionPlan CompactionPlanner
 	FileStore      *FileStore
 
+	// walMode is the durability mode of the WAL (one of the tsdb.WALMode* values).
+	// walSyncDelay is the configured wal-fsync-delay used in group-commit mode.
+	walMode      string
+	walSyncDelay time.Duration
+
+	// duplicatePolicy decides which value is kept when a point is written with
+	// the timestamp of an existing one (one of the tsdb.DuplicatePolicy* values).
+	duplicatePolicy string
+
+	// lastWrite is the time, in nanoseconds, of the last write that bypassed the WAL.
+	lastWrite int64
+
+	// cacheLimiter is the cache memory budget shared with other shards.
+	cacheLimiter *limiter.Memory
+
+	// cacheMaxWriteWait is how long writes wait for a full cache to be snapshotted.
+	cacheMaxWriteWait time.Duration
+
+	// snapshotRequest signals compactCache to snapshot the cache immediately.
+	snapshotRequest chan struct{}
+
 	MaxPointsPerBlock int
 
 	// CacheFlushMemorySizeThreshold specifies the minimum size threshodl for
@@ -143,7 +175,7 @@ type Engine struct {
 // NewEngine returns a new instance of Engine.
 func NewEngine(id uint64, idx tsdb.Index, database, path string, walPath string, opt tsdb.EngineOptions) tsdb.Engine {
 	w := NewWAL(walPath)
-	w.syncDelay = time.Duration(opt.Config.WALFsyncDelay)
+	w.syncDelay = walSyncDelay(opt.WALMode, time.Duration(opt.Config.WALFsyncDelay))
 
 	fs := NewFileStore(path)
 	cache := NewCache(uint64(opt.Config.CacheMaxMemorySize), path)
@@ -151,8 +183,12 @@ func NewEngine(id uint64, idx tsdb.Index, database, path string, walPath string,
 	c := &Compactor{
 		Dir:       path,
 		FileStore: fs,
+		keepFirst: keepFirst(opt.DuplicatePolicy),
 	}
 
+	planner := NewDefaultPlanner(fs, time.Duration(opt.Config.CompactFullWriteColdDuration))
+	planner.TombstoneThreshold = opt.Config.CompactTombstoneThreshold
+
 	logger := zap.New(zap.NullEncoder())
 	e := &Engine{
 		id:           id,
@@ -168,9 +204,18 @@ func NewEngine(id uint64, idx tsdb.Index, database, path string, walPath string,
 		WAL:   w,
 		Cache: cache,
 
+		walMode:      opt.WALMode,
+		walSyncDelay: time.Duration(opt.Config.WALFsyncDelay),
+
+		duplicatePolicy: opt.DuplicatePolicy,
+
+		cacheLimiter:      opt.CacheLimiter,
+		cacheMaxWriteWait: time.Duration(opt.Config.CacheMaxWriteWait),
+		snapshotRequest:   make(chan struct{}, 1),
+
 		FileStore:      fs,
 		Compactor:      c,
-		CompactionPlan: NewDefaultPlanner(fs, time.Duration(opt.Config.CompactFullWriteColdDuration)),
+		CompactionPlan: planner,
 
 		CacheFlushMemorySizeThreshold: opt.Config.CacheSnapshotMemorySize,
 		CacheFlushWriteColdDuration:   time.Duration(opt.Config.CacheSnapshotWriteColdDuration),
@@ -240,13 +285,14 @@ func (e *Engine) enableLevelCompactions(wait bool) {
 	quit := make(chan struct{})
 	e.done = quit
 
-	e.wg.Add(4)
+	e.wg.Add(5)
 	e.mu.Unlock()
 
 	go func() { defer e.wg.Done(); e.compactTSMFull(quit) }()
 	go func() { defer e.wg.Done(); e.compactTSMLevel(true, 1, quit) }()
 	go func() { defer e.wg.Done(); e.compactTSMLevel(true, 2, quit) }()
 	go func() { defer e.wg.Done(); e.compactTSMLevel(false, 3, quit) }()
+	go func() { defer e.wg.Done(); e.compactTSMTombstones(quit) }()
 }
 
 // disableLevelCompactions will stop level compactions before returning.
@@ -312,6 +358,66 @@ func (e *Engine) disableSnapshotCompactions() {
 	e.snapWG.Wait()
 }
 
+// SetWALMode sets the durability mode of the WAL. In tsdb.WALModeNone, writes
+// are only stored in the cache until the next snapshot.
+func (e *Engine) SetWALMode(mode string) error {
+	if !tsdb.ValidWALMode(mode) {
+		return fmt.Errorf("invalid wal mode: %q", mode)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.walMode = mode
+	e.WAL.SetSyncDelay(walSyncDelay(mode, e.walSyncDelay))
+	return nil
+}
+
+// SetDuplicatePolicy sets the policy used when writing values for timestamps
+// that already have a value.
+func (e *Engine) SetDuplicatePolicy(policy string) error {
+	if !tsdb.ValidDuplicatePolicy(policy) {
+		return fmt.Errorf("invalid duplicate policy: %q", policy)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.duplicatePolicy = policy
+	e.Compactor.SetKeepFirst(keepFirst(policy))
+	return nil
+}
+
+// keepFirst returns true if policy keeps existing values over new ones.
+func keepFirst(policy string) bool {
+	return policy == tsdb.DuplicatePolicyFirst || policy == tsdb.DuplicatePolicyReject
+}
+
+// walDisabled returns true if writes bypass the WAL.
+func (e *Engine) walDisabled() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.walMode == tsdb.WALModeNone
+}
+
+// walSyncDelay returns the fsync delay to use for the WAL in the given mode.
+func walSyncDelay(mode string, delay time.Duration) time.Duration {
+	if mode == tsdb.WALModeFsync {
+		return 0
+	}
+	return delay
+}
+
+// lastWriteTime returns the last time anything was written to the engine,
+// including writes that bypassed the WAL.
+func (e *Engine) lastWriteTime() time.Time {
+	walTime := e.WAL.LastWriteTime()
+	if n := atomic.LoadInt64(&e.lastWrite); n > 0 {
+		if t := time.Unix(0, n); t.After(walTime) {
+			return t
+		}
+	}
+	return walTime
+}
+
 // Path returns the path the engine was opened with.
 func (e *Engine) Path() string { return e.path }
 
@@ -336,6 +442,11 @@ func (e *Engine) MeasurementFields(measurement []byte) *tsdb.MeasurementFields {
 	return e.fieldset.CreateFieldsIfNotExists(measurement)
 }
 
+// MeasurementFieldSet returns the fields of all measurements in the engine.
+func (e *Engine) MeasurementFieldSet() *tsdb.MeasurementFieldSet {
+	return e.fieldset
+}
+
 func (e *Engine) ForEachMeasurementSeriesByExpr(name []byte, condition influxql.Expr, fn func(tags models.Tags) error) error {
 	return e.index.ForEachMeasurementSeriesByExpr(name, condition, fn)
 }
@@ -384,7 +495,7 @@ func (e *Engine) MeasurementsSketches() (estimator.Sketch, estimator.Sketch, err
 
 // LastModified returns the time when this shard was last modified.
 func (e *Engine) LastModified() time.Time {
-	walTime := e.WAL.LastWriteTime()
+	walTime := e.lastWriteTime()
 	fsTime := e.FileStore.LastModified()
 
 	if walTime.After(fsTime) {
@@ -415,10 +526,24 @@ type EngineStatistics struct {
 	TSMFullCompactionsActive  int64 // Gauge of full compactions currently running.
 	TSMFullCompactionErrors   int64 // Counter of full compactions that have failed due to error.
 	TSMFullCompactionDuration int64 // Counter of number of wall nanoseconds spent in full compactions.
+
+	TSMTombstoneCompactions        int64 // Counter of tombstone compactions that have ever run.
+	TSMTombstoneCompactionsActive  int64 // Gauge of tombstone compactions currently running.
+	TSMTombstoneCompactionErrors   int64 // Counter of tombstone compactions that have failed due to error.
+	TSMTombstoneCompactionDuration int64 // Counter of number of wall nanoseconds spent in tombstone compactions.
 }
 
 // Statistics returns statistics for periodic monitoring.
 func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
+	var tombstones, tombstoneFiles, tombstoneBytes int64
+	for _, f := range e.FileStore.Stats() {
+		if f.HasTombstone {
+			tombstoneFiles++
+		}
+		tombstones += int64(f.TombstoneCount)
+		tombstoneBytes += int64(f.TombstoneSize)
+	}
+
 	statistics := make([]models.Statistic, 0, 4)
 	statistics = append(statistics, models.Statistic{
 		Name: "tsm1_engine",
@@ -453,6 +578,15 @@ func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
 			statTSMFullCompactionsActive:  atomic.LoadInt64(&e.stats.TSMFullCompactionsActive),
 			statTSMFullCompactionError:    atomic.LoadInt64(&e.stats.TSMFullCompactionErrors),
 			statTSMFullCompactionDuration: atomic.LoadInt64(&e.stats.TSMFullCompactionDuration),
+
+			statTSMTombstoneCompactions:        atomic.LoadInt64(&e.stats.TSMTombstoneCompactions),
+			statTSMTombstoneCompactionsActive:  atomic.LoadInt64(&e.stats.TSMTombstoneCompactionsActive),
+			statTSMTombstoneCompactionError:    atomic.LoadInt64(&e.stats.TSMTombstoneCompactionErrors),
+			statTSMTombstoneCompactionDuration: atomic.LoadInt64(&e.stats.TSMTombstoneCompactionDuration),
+
+			statTombstones:     tombstones,
+			statTombstoneFiles: tombstoneFiles,
+			statTombstoneBytes: tombstoneBytes,
 		},
 	})
 
@@ -489,6 +623,9 @@ func (e *Engine) Open() error {
 		return err
 	}
 
+	// The reloaded cache is accounted for in the shared budget, even if it exceeds it.
+	e.Cache.SetBudget(e.cacheLimiter)
+
 	e.Compactor.Open()
 
 	if e.enableCompactionsOnOpen {
@@ -499,14 +636,28 @@ func (e *Engine) Open() error {
 }
 
 // Close closes the engine. Subsequent calls to Close are a nop.
+//
+// If the WAL is disabled, the cache is written to a TSM file before closing
+// since it holds the only copy of any recent writes.
 func (e *Engine) Close() error {
 	e.SetCompactionsEnabled(false)
 
+	if e.walDisabled() && e.Cache.Size() > 0 {
+		e.Compactor.EnableSnapshots()
+		if err := e.WriteSnapshot(); err != nil {
+			e.logger.Info(fmt.Sprintf("error writing snapshot on close: %v", err))
+		}
+		e.Compactor.DisableSnapshots()
+	}
+
 	// Lock now and close everything else down.
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	e.done = nil // Ensures that the channel will not be closed again.
 
+	// Return the memory held by the cache to the shared budget.
+	e.Cache.SetBudget(nil)
+
 	if err := e.FileStore.Close(); err != nil {
 		return err
 	}
@@ -565,6 +716,116 @@ func (e *Engine) LoadMetadataIndex(shardID uint64, index tsdb.Index) error {
 	return nil
 }
 
+// SetIndex replaces the index used by the engine.  The caller must ensure index
+// contains every series in the engine.
+func (e *Engine) SetIndex(index tsdb.Index) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	index.SetFieldSet(e.fieldset)
+	e.index = index
+}
+
+// ForEachSeriesKey calls fn for the key of every series with data in the cache
+// or TSM files.  A key may be passed more than once.
+func (e *Engine) ForEachSeriesKey(fn func(key []byte) error) error {
+	return e.forEachSeriesKey(func(walk func(key []byte) error) error {
+		return e.FileStore.WalkKeys(func(key []byte, _ byte) error {
+			return walk(key)
+		})
+	}, fn)
+}
+
+// ForEachSeriesKeyWrittenSince calls fn for the key of every series with data
+// written at or after min.  Data in the cache was written recently and data in
+// a TSM file was written when the file was, so compactions can keep a series
+// active for longer.  A key may be passed more than once.
+func (e *Engine) ForEachSeriesKeyWrittenSince(min int64, fn func(key []byte) error) error {
+	return e.forEachSeriesKey(func(walk func(key []byte) error) error {
+		return e.FileStore.WalkKeysWrittenSince(min, walk)
+	}, fn)
+}
+
+// SeriesWrittenSince returns the keys of seriesKeys with data written at or
+// after min.  Unlike ForEachSeriesKeyWrittenSince, only the given series are
+// looked up, so it is cheap for a few series.
+func (e *Engine) SeriesWrittenSince(seriesKeys [][]byte, min int64) (map[string]struct{}, error) {
+	// The cache is read before the TSM files so a series moved from the cache
+	// into a new TSM file by a snapshot is not missed.
+	e.Cache.mu.RLock()
+	stores := []storer{e.Cache.store}
+	if e.Cache.snapshot != nil {
+		stores = append(stores, e.Cache.snapshot.store)
+	}
+	e.Cache.mu.RUnlock()
+
+	written := make(map[string]struct{})
+	var remaining [][]byte
+	for _, seriesKey := range seriesKeys {
+		if e.seriesInCache(stores, seriesKey) {
+			written[string(seriesKey)] = struct{}{}
+		} else {
+			remaining = append(remaining, seriesKey)
+		}
+	}
+
+	for k := range e.FileStore.SeriesWrittenSince(remaining, min) {
+		written[k] = struct{}{}
+	}
+	return written, nil
+}
+
+// seriesInCache returns true if any field of seriesKey has values in stores.
+func (e *Engine) seriesInCache(stores []storer, seriesKey []byte) bool {
+	mf := e.fieldset.Fields(string(tsdb.MeasurementFromSeriesKey(seriesKey)))
+	if mf == nil {
+		return false
+	}
+
+	for field := range mf.FieldSet() {
+		key := SeriesFieldKeyBytes(string(seriesKey), field)
+		for _, store := range stores {
+			if entry, ok := store.entry(key); ok && entry.count() > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// forEachSeriesKey calls fn for the series of every key in the cache and of
+// every key walkFiles passes to walk.
+func (e *Engine) forEachSeriesKey(walkFiles func(walk func(key []byte) error) error, fn func(key []byte) error) error {
+	// The cache is read before the TSM files so a series moved from the cache
+	// into a new TSM file by a snapshot is not missed.
+	e.Cache.mu.RLock()
+	stores := []storer{e.Cache.store}
+	if e.Cache.snapshot != nil {
+		stores = append(stores, e.Cache.snapshot.store)
+	}
+	e.Cache.mu.RUnlock()
+
+	var prev []byte
+	walk := func(key []byte) error {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+		if bytes.Equal(seriesKey, prev) {
+			return nil
+		}
+		prev = append(prev[:0], seriesKey...)
+		return fn(seriesKey)
+	}
+
+	for _, store := range stores {
+		for _, key := range store.keys(true) {
+			if err := walk(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return walkFiles(walk)
+}
+
 // IsIdle returns true if the cache is empty, there are no running compactions and the
 // shard is fully compacted.
 func (e *Engine) IsIdle() bool {
@@ -833,10 +1094,35 @@ func (e *Engine) addToIndexFromKey(key []byte, fieldType influxql.DataType) erro
 // WritePoints writes metadata and point data into the engine.
 // It returns an error if new points are added to an existing key.
 func (e *Engine) WritePoints(points []models.Point) error {
+	e.mu.RLock()
+	policy := e.duplicatePolicy
+	e.mu.RUnlock()
+
+	// In the reject policy, a point with the series key and timestamp of an
+	// earlier point of the write is a duplicate and none of its values are
+	// written.
+	var repeated map[int]struct{}
+	var written map[pointKey]struct{}
+	if policy == tsdb.DuplicatePolicyReject {
+		written = make(map[pointKey]struct{}, len(points))
+	}
+
 	values := make(map[string][]Value, len(points))
 	var keyBuf []byte
 	var baseLen int
-	for _, p := range points {
+	for i, p := range points {
+		if written != nil {
+			k := pointKey{series: string(p.Key()), time: p.Time().UnixNano()}
+			if _, ok := written[k]; ok {
+				if repeated == nil {
+					repeated = make(map[int]struct{})
+				}
+				repeated[i] = struct{}{}
+				continue
+			}
+			written[k] = struct{}{}
+		}
+
 		keyBuf = append(keyBuf[:0], p.Key()...)
 		keyBuf = append(keyBuf, keyFieldSeparator...)
 		baseLen = len(keyBuf)
@@ -884,19 +1170,85 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		}
 	}
 
+	// If the cache is full, wait for it to be snapshotted before writing.  This
+	// must happen before taking the lock since snapshots need the write lock.
+	if e.cacheMaxWriteWait > 0 {
+		var size uint64
+		for _, v := range values {
+			size += uint64(Values(v).Size())
+		}
+		e.Cache.WaitForRoom(size, e.cacheMaxWriteWait, e.requestSnapshot)
+	}
+
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	// first try to write to the cache
-	err := e.Cache.WriteMulti(values)
-	if err != nil {
+	// Write the values that don't overwrite existing ones if the existing ones must be kept.
+	var rejected []tsdb.DroppedPoint
+	if keepFirst(policy) {
+		reject := policy == tsdb.DuplicatePolicyReject
+		duplicates, err := e.Cache.WriteMultiDistinct(values, e.FileStore.ExistingTimestamps, reject)
+		if err != nil {
+			return err
+		}
+		if reject {
+			rejected = duplicatePoints(points, duplicates, repeated)
+		}
+	} else if err := e.Cache.WriteMulti(values); err != nil {
 		return err
 	}
 
-	_, err = e.WAL.WriteMulti(values)
+	var err error
+	if len(rejected) > 0 {
+		err = tsdb.PartialWriteError{Reason: "duplicate points rejected", Dropped: len(rejected), DroppedPoints: rejected}
+	}
+
+	// Without a WAL, only track the write time so cold snapshots still happen.
+	if e.walMode == tsdb.WALModeNone {
+		atomic.StoreInt64(&e.lastWrite, time.Now().UnixNano())
+		return err
+	}
+
+	if len(values) == 0 {
+		return err
+	}
+
+	if _, werr := e.WAL.WriteMulti(values); werr != nil {
+		return werr
+	}
 	return err
 }
 
+// pointKey identifies a point by its series key and timestamp.
+type pointKey struct {
+	series string
+	time   int64
+}
+
+// duplicatePoints returns the points that the duplicate values were written
+// for and the points at the indexes in repeated, in the order they were
+// written.
+func duplicatePoints(points []models.Point, duplicates map[string][]Value, repeated map[int]struct{}) []tsdb.DroppedPoint {
+	keys := make(map[pointKey]struct{})
+	for k, vals := range duplicates {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey([]byte(k))
+		for _, v := range vals {
+			keys[pointKey{series: string(seriesKey), time: v.UnixNano()}] = struct{}{}
+		}
+	}
+
+	var dropped []tsdb.DroppedPoint
+	reason := tsdb.DropReason{Code: tsdb.DropReasonDuplicatePoint, Message: "duplicate point rejected"}
+	for i, p := range points {
+		if _, ok := repeated[i]; ok {
+			dropped = append(dropped, tsdb.DroppedPoint{Point: p, Reason: reason})
+		} else if _, ok := keys[pointKey{series: string(p.Key()), time: p.Time().UnixNano()}]; ok {
+			dropped = append(dropped, tsdb.DroppedPoint{Point: p, Reason: reason})
+		}
+	}
+	return dropped
+}
+
 // containsSeries returns a map of keys indicating whether the key exists and
 // has values or not.
 func (e *Engine) containsSeries(keys [][]byte) (map[string]bool, error) {
@@ -1054,6 +1406,730 @@ func (e *Engine) deleteMeasurement(name []byte) error {
 	return nil
 }
 
+// rewriteBatchSize is the size of the values of rewritten keys that are
+// buffered before they are written to a new TSM file.
+const rewriteBatchSize = 25 * 1024 * 1024
+
+// RenameSeries renames the series of measurement name to the series keys
+// returned by fn.  Series for which fn returns a nil key are left unchanged.
+//
+// The values of renamed series are rewritten to new TSM files under their new
+// keys and the old keys are tombstoned, so the old values are removed by the
+// next compactions.  The values in TSM files are rewritten while writes and
+// snapshots continue; writes are only blocked while the values written
+// meanwhile are rewritten and the new files are installed.
+//
+// A rename that failed can be run again.  A new key that already holds all
+// values of its old key was written by the failed rename and is not rewritten.
+func (e *Engine) RenameSeries(name []byte, fn func(seriesKey []byte) ([]byte, error)) error {
+	e.renameMu.Lock()
+	defer e.renameMu.Unlock()
+
+	// Level compactions are disabled, so the TSM files don't change while
+	// they are read without the engine lock.  Snapshots may add files, which
+	// are read once writes are blocked.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+	files := e.FileStore.Files()
+
+	// Find the keys to rename and the measurements they are renamed to.
+	renames := make(map[string][]byte)
+	newNames := make(map[string]struct{})
+	addRename := func(key []byte) error {
+		seriesKey, field := SeriesAndFieldFromCompositeKey(key)
+		if !bytes.Equal(tsdb.MeasurementFromSeriesKey(seriesKey), name) {
+			return nil
+		} else if _, ok := renames[string(key)]; ok {
+			return nil
+		}
+
+		newSeriesKey, err := fn(seriesKey)
+		if err != nil {
+			return err
+		} else if newSeriesKey == nil {
+			return nil
+		}
+		renames[string(key)] = SeriesFieldKeyBytes(string(newSeriesKey), string(field))
+		newNames[string(tsdb.MeasurementFromSeriesKey(newSeriesKey))] = struct{}{}
+		return nil
+	}
+	if err := e.walkKeys(addRename); err != nil {
+		return err
+	}
+	done := make(map[string]struct{})
+	if err := e.checkRenames(name, renames, newNames, done, e.walkKeys); err != nil {
+		return err
+	}
+
+	// Rewrite the values of the renamed keys to new TSM files in batches.
+	// The files are installed once the values written meanwhile are added.
+	w := e.newKeyWriter()
+	w.pending = true
+	defer w.Close()
+
+	type seriesRange struct{ min, max int64 }
+	series := make(map[string]seriesRange)
+	fieldTypes := make(map[string]influxql.DataType)
+	write := func(key []byte, values Values) error {
+		if len(values) == 0 {
+			return nil
+		}
+
+		newKey := renames[string(key)]
+		if _, ok := done[string(key)]; !ok {
+			if err := w.Write(newKey, values); err != nil {
+				return err
+			}
+		}
+
+		typ, err := values.InfluxQLType()
+		if err != nil {
+			return err
+		}
+		fieldTypes[string(newKey)] = typ
+
+		newSeriesKey, _ := SeriesAndFieldFromCompositeKey(newKey)
+		r, ok := series[string(newSeriesKey)]
+		if !ok {
+			r = seriesRange{min: math.MaxInt64, max: math.MinInt64}
+		}
+		if min := values.MinTime(); min < r.min {
+			r.min = min
+		}
+		if max := values.MaxTime(); max > r.max {
+			r.max = max
+		}
+		series[string(newSeriesKey)] = r
+		return nil
+	}
+
+	for _, key := range sortedRenames(renames) {
+		values, err := e.readFiles(files, key)
+		if err != nil {
+			return err
+		} else if err := write(key, values); err != nil {
+			return err
+		}
+	}
+
+	// Snapshots must not write values under the old keys once they are
+	// removed.  They are disabled before the engine lock is taken, since a
+	// running snapshot needs the lock to finish.
+	defer e.disableSnapshots()()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Values written since the keys were read are in the cache or in the
+	// files written by snapshots meanwhile, possibly under keys that were not
+	// renamed yet.
+	added := e.filesAddedSince(files)
+	walkAdded := func(fn func(key []byte) error) error {
+		return e.walkKeysOf(added, fn)
+	}
+	if err := walkAdded(addRename); err != nil {
+		return err
+	}
+	if err := e.checkRenames(name, renames, newNames, done, walkAdded); err != nil {
+		return err
+	}
+
+	oldKeys := sortedRenames(renames)
+	for _, key := range oldKeys {
+		values, err := e.readFiles(added, key)
+		if err != nil {
+			return err
+		} else if err := write(key, values); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	} else if err := w.Install(); err != nil {
+		return err
+	}
+
+	// Add the renamed series and fields to the index before the old keys are
+	// removed, so a failed rename loses no series.
+	for newKey, typ := range fieldTypes {
+		newSeriesKey, field := SeriesAndFieldFromCompositeKey([]byte(newKey))
+		mf := e.fieldset.CreateFieldsIfNotExists(tsdb.MeasurementFromSeriesKey(newSeriesKey))
+		if err := mf.CreateFieldIfNotExists(field, typ, false); err != nil {
+			return err
+		}
+	}
+
+	keys := make([][]byte, 0, len(series))
+	names := make([][]byte, 0, len(series))
+	tagsSlice := make([]models.Tags, 0, len(series))
+	mins := make([]int64, 0, len(series))
+	maxs := make([]int64, 0, len(series))
+	for key, r := range series {
+		_, tags := models.ParseKey([]byte(key))
+		keys = append(keys, []byte(key))
+		names = append(names, tsdb.MeasurementFromSeriesKey([]byte(key)))
+		tagsSlice = append(tagsSlice, tags)
+		mins = append(mins, r.min)
+		maxs = append(maxs, r.max)
+	}
+	if idx, ok := e.index.(tsdb.SeriesTimeRangeIndex); ok {
+		if err := idx.CreateSeriesListWithTimeRanges(keys, names, tagsSlice, mins, maxs); err != nil {
+			return err
+		}
+	} else if err := e.index.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+		return err
+	}
+
+	// Remove the values under the old keys.
+	if len(oldKeys) > 0 {
+		if err := e.FileStore.Delete(oldKeys); err != nil {
+			return err
+		}
+		e.Cache.Delete(oldKeys)
+		if _, err := e.WAL.DeleteRange(oldKeys, math.MinInt64, math.MaxInt64); err != nil {
+			return err
+		}
+	}
+
+	// Remove the old series from the index.  Series that are only in the
+	// index, without values, are removed as well.
+	seriesKeys, err := e.index.MeasurementSeriesKeysByExpr(name, nil)
+	if err != nil {
+		return err
+	}
+	for _, seriesKey := range seriesKeys {
+		if newSeriesKey, err := fn(seriesKey); err != nil {
+			return err
+		} else if newSeriesKey == nil {
+			continue
+		}
+		if err := e.index.UnassignShard(string(seriesKey), e.id); err != nil {
+			return err
+		}
+	}
+
+	// Drop the fields of the measurement if all its series were renamed.
+	if exists, err := e.index.MeasurementExists(name); err != nil {
+		return err
+	} else if !exists {
+		e.fieldset.Delete(string(name))
+	}
+	return nil
+}
+
+// checkRenames returns an error if a key walked by walk is the new key of a
+// renamed key, or if a renamed field changes the type of an existing field.
+// New keys that already hold all values of their old key were written by an
+// earlier rename and their old keys are added to done instead.
+func (e *Engine) checkRenames(name []byte, renames map[string][]byte, newNames, done map[string]struct{}, walk func(fn func(key []byte) error) error) error {
+	oldKeys := make(map[string][]byte, len(renames))
+	for key, newKey := range renames {
+		oldKeys[string(newKey)] = []byte(key)
+	}
+
+	if err := walk(func(key []byte) error {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+		if _, ok := newNames[string(tsdb.MeasurementFromSeriesKey(seriesKey))]; !ok {
+			return nil
+		}
+		oldKey, ok := oldKeys[string(key)]
+		if !ok {
+			return nil
+		} else if _, ok := done[string(oldKey)]; ok {
+			return nil
+		}
+
+		values, err := e.readAll(oldKey)
+		if err != nil {
+			return err
+		}
+		newValues, err := e.readAll(key)
+		if err != nil {
+			return err
+		} else if !containsValues(newValues, values) {
+			return fmt.Errorf("series %s already exists", seriesKey)
+		}
+		done[string(oldKey)] = struct{}{}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for key, newKey := range renames {
+		_, field := SeriesAndFieldFromCompositeKey([]byte(key))
+		newSeriesKey, _ := SeriesAndFieldFromCompositeKey(newKey)
+		newName := tsdb.MeasurementFromSeriesKey(newSeriesKey)
+		if bytes.Equal(newName, name) {
+			continue
+		}
+
+		var f, newF *tsdb.Field
+		if mf := e.fieldset.Fields(string(name)); mf != nil {
+			f = mf.FieldBytes(field)
+		}
+		if mf := e.fieldset.Fields(string(newName)); mf != nil {
+			newF = mf.FieldBytes(field)
+		}
+		if f != nil && newF != nil && f.Type != newF.Type {
+			return fmt.Errorf("%s: field %q of measurement %q is type %s, already exists as type %s",
+				tsdb.ErrFieldTypeConflict, field, newName, f.Type, newF.Type)
+		}
+	}
+	return nil
+}
+
+// sortedRenames returns the sorted keys of renames.
+func sortedRenames(renames map[string][]byte) [][]byte {
+	keys := make([][]byte, 0, len(renames))
+	for key := range renames {
+		keys = append(keys, []byte(key))
+	}
+	bytesutil.Sort(keys)
+	return keys
+}
+
+// containsValues returns true if a holds every value of b.  Both must be
+// sorted and deduplicated.
+func containsValues(a, b Values) bool {
+	var i int
+	for _, v := range b {
+		for i < len(a) && a[i].UnixNano() < v.UnixNano() {
+			i++
+		}
+		if i == len(a) || a[i].UnixNano() != v.UnixNano() || a[i].Value() != v.Value() {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteField removes field from the series of measurement name.  Series
+// without any other fields are removed from the index.
+func (e *Engine) DeleteField(name, field []byte) error {
+	defer e.disableRewriteCompactions()()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	keys, seriesKeys, err := e.fieldKeys(name, field)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		if err := e.FileStore.Delete(keys); err != nil {
+			return err
+		}
+		e.Cache.Delete(keys)
+		if _, err := e.WAL.DeleteRange(keys, math.MinInt64, math.MaxInt64); err != nil {
+			return err
+		}
+	}
+
+	existing, err := e.containsSeries(seriesKeys)
+	if err != nil {
+		return err
+	}
+	for k, exists := range existing {
+		if !exists {
+			if err := e.index.UnassignShard(k, e.id); err != nil {
+				return err
+			}
+		}
+	}
+
+	if mf := e.fieldset.Fields(string(name)); mf != nil {
+		mf.DeleteField(string(field))
+	}
+
+	// Drop the fields of the measurement if all its series were removed.
+	if exists, err := e.index.MeasurementExists(name); err != nil {
+		return err
+	} else if !exists {
+		e.fieldset.Delete(string(name))
+	}
+	return nil
+}
+
+// ConvertField converts the values of field of measurement name to typ.
+// Integers and unsigned integers can be converted to floats and any type can
+// be converted to strings.
+//
+// The converted values are written to new TSM files and the field is
+// tombstoned in the existing files, so the old values are removed by the
+// next compactions.  The values in TSM files are converted while writes and
+// snapshots continue.  writes, if set, must block the writes to the field;
+// it is locked while the values written meanwhile are converted and the new
+// files are installed.
+func (e *Engine) ConvertField(name, field []byte, typ influxql.DataType, writes sync.Locker) error {
+	mf := e.fieldset.Fields(string(name))
+	if mf == nil {
+		return nil
+	}
+	f := mf.FieldBytes(field)
+	if f == nil || f.Type == typ {
+		return nil
+	} else if !tsdb.CanConvertField(f.Type, typ) {
+		return fmt.Errorf("cannot convert field %q from %s to %s", field, f.Type, typ)
+	}
+
+	// Level compactions are disabled, so the TSM files don't change while
+	// they are read without the engine lock.  Snapshots may add files, which
+	// are read once writes are blocked.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+	files := e.FileStore.Files()
+
+	keys, _, err := e.fieldKeys(name, field)
+	if err != nil {
+		return err
+	}
+
+	w := e.newKeyWriter()
+	w.pending = true
+	defer w.Close()
+	convert := func(files []TSMFile, keys [][]byte) error {
+		for _, key := range keys {
+			values, err := e.readFiles(files, key)
+			if err != nil {
+				return err
+			}
+			for i, v := range values {
+				values[i] = convertValue(v, typ)
+			}
+			if err := w.Write(key, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := convert(files, keys); err != nil {
+		return err
+	}
+
+	// Snapshots must not write values of the old type once they are
+	// removed.  They are disabled before the engine lock is taken, since a
+	// running snapshot needs the lock to finish.
+	defer e.disableSnapshots()()
+
+	if writes != nil {
+		writes.Lock()
+		defer writes.Unlock()
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Values written since the keys were read are in the cache or in the
+	// files written by snapshots meanwhile, possibly under keys of new
+	// series.
+	added := e.filesAddedSince(files)
+	addedKeys, err := e.fieldKeysOf(func(fn func(key []byte) error) error {
+		return e.walkKeysOf(added, fn)
+	}, name, field)
+	if err != nil {
+		return err
+	} else if err := convert(added, addedKeys); err != nil {
+		return err
+	}
+	keys = mergeSortedKeys(keys, addedKeys)
+
+	// The converted values are written under the same keys, so only the
+	// files that exist before the converted files are installed are
+	// tombstoned.  The new files are installed before the old values are
+	// deleted so a failure never loses values.  If the conversion stops in
+	// between, both types are stored until it is run again.
+	files = e.FileStore.Files()
+	if err := w.Flush(); err != nil {
+		return err
+	} else if err := w.Install(); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := f.Delete(keys); err != nil {
+			return err
+		}
+	}
+	e.Cache.Delete(keys)
+	if _, err := e.WAL.DeleteRange(keys, math.MinInt64, math.MaxInt64); err != nil {
+		return err
+	}
+
+	mf.DeleteField(string(field))
+	return mf.CreateFieldIfNotExists(field, typ, false)
+}
+
+// mergeSortedKeys merges the sorted keys of a and b and removes duplicates.
+func mergeSortedKeys(a, b [][]byte) [][]byte {
+	merged := make([][]byte, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || (len(a) > 0 && bytes.Compare(a[0], b[0]) < 0):
+			merged, a = append(merged, a[0]), a[1:]
+		case len(a) == 0 || bytes.Compare(a[0], b[0]) > 0:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return merged
+}
+
+// convertValue converts v to typ.  The conversion must be allowed by
+// tsdb.CanConvertField.
+func convertValue(v Value, typ influxql.DataType) Value {
+	if typ == influxql.Float {
+		switch v := v.(type) {
+		case IntegerValue:
+			return NewFloatValue(v.UnixNano(), float64(v.value))
+		case UnsignedValue:
+			return NewFloatValue(v.UnixNano(), float64(v.value))
+		}
+		return v
+	}
+
+	switch v := v.(type) {
+	case FloatValue:
+		return NewStringValue(v.UnixNano(), strconv.FormatFloat(v.value, 'f', -1, 64))
+	case IntegerValue:
+		return NewStringValue(v.UnixNano(), strconv.FormatInt(v.value, 10))
+	case UnsignedValue:
+		return NewStringValue(v.UnixNano(), strconv.FormatUint(v.value, 10))
+	case BooleanValue:
+		return NewStringValue(v.UnixNano(), strconv.FormatBool(v.value))
+	}
+	return v
+}
+
+// fieldKeys returns the sorted keys of field in the series of measurement
+// name and the series keys they belong to.
+func (e *Engine) fieldKeys(name, field []byte) ([][]byte, [][]byte, error) {
+	keys, err := e.fieldKeysOf(e.walkKeys, name, field)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seriesKeys := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+		seriesKeys = append(seriesKeys, seriesKey)
+	}
+	bytesutil.Sort(seriesKeys)
+	return keys, seriesKeys, nil
+}
+
+// fieldKeysOf returns the sorted keys of field in the series of measurement
+// name among the keys walked by walk.
+func (e *Engine) fieldKeysOf(walk func(fn func(key []byte) error) error, name, field []byte) ([][]byte, error) {
+	set := make(map[string]struct{})
+	if err := walk(func(key []byte) error {
+		seriesKey, f := SeriesAndFieldFromCompositeKey(key)
+		if bytes.Equal(f, field) && bytes.Equal(tsdb.MeasurementFromSeriesKey(seriesKey), name) {
+			set[string(key)] = struct{}{}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, 0, len(set))
+	for key := range set {
+		keys = append(keys, []byte(key))
+	}
+	bytesutil.Sort(keys)
+	return keys, nil
+}
+
+// disableRewriteCompactions disables compactions while keys are rewritten and
+// returns a function that enables them again.  Tombstones must not be removed
+// by compactions and running snapshots must not write values under the old
+// keys after they are rewritten.
+func (e *Engine) disableRewriteCompactions() func() {
+	e.disableLevelCompactions(true)
+	enableSnapshots := e.disableSnapshots()
+
+	return func() {
+		enableSnapshots()
+		e.enableLevelCompactions(true)
+	}
+}
+
+// disableSnapshots disables snapshots if they are enabled and returns a
+// function that enables them again.
+func (e *Engine) disableSnapshots() func() {
+	e.mu.RLock()
+	snapshots := e.snapDone != nil
+	e.mu.RUnlock()
+	if !snapshots {
+		return func() {}
+	}
+
+	e.disableSnapshotCompactions()
+	return e.enableSnapshotCompactions
+}
+
+// readAll returns the values of key from the file store and the cache.
+func (e *Engine) readAll(key []byte) (Values, error) {
+	return e.readFiles(e.FileStore.Files(), key)
+}
+
+// readFiles returns the values of key from files and the cache.  files must
+// be ordered from oldest to newest.
+func (e *Engine) readFiles(files []TSMFile, key []byte) (Values, error) {
+	// Files are ordered from oldest to newest and the cache holds the newest
+	// values, so later values overwrite earlier ones when duplicates are
+	// removed.
+	var values Values
+	for _, f := range files {
+		a, err := f.ReadAll(key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, a...)
+	}
+	values = append(values, e.Cache.Values(key)...)
+	return values.Deduplicate(), nil
+}
+
+// keyWriter writes rewritten keys to new TSM files in batches.
+type keyWriter struct {
+	c     *Compactor
+	fs    *FileStore
+	batch *Cache
+
+	// If pending is set, written files are only added to the file store by
+	// Install.
+	pending bool
+	files   []string
+}
+
+// newKeyWriter returns a keyWriter that adds its files to the file store.
+func (e *Engine) newKeyWriter() *keyWriter {
+	c := &Compactor{Dir: e.path, FileStore: e.FileStore}
+	c.Open()
+	return &keyWriter{c: c, fs: e.FileStore, batch: NewCache(0, "")}
+}
+
+// Write buffers the values of key and writes a new file once the batch is full.
+func (w *keyWriter) Write(key []byte, values Values) error {
+	if len(values) == 0 {
+		return nil
+	} else if err := w.batch.Write(key, values); err != nil {
+		return err
+	} else if w.batch.Size() < rewriteBatchSize {
+		return nil
+	}
+	return w.Flush()
+}
+
+// Flush writes the buffered values to a new file.
+func (w *keyWriter) Flush() error {
+	if w.batch.Size() == 0 {
+		return nil
+	}
+	w.batch.Deduplicate()
+	files, err := w.c.WriteSnapshot(w.batch)
+	if err != nil {
+		return err
+	}
+	if w.pending {
+		w.files = append(w.files, files...)
+	} else if err := w.fs.Replace(nil, files); err != nil {
+		return err
+	}
+	w.batch = NewCache(0, "")
+	return nil
+}
+
+// installFiles adds new files to a file store.  Tests replace it to make
+// installs fail.
+var installFiles = func(fs *FileStore, files []string) error {
+	return fs.Replace(nil, files)
+}
+
+// Install adds the pending files to the file store.
+func (w *keyWriter) Install() error {
+	if err := installFiles(w.fs, w.files); err != nil {
+		return err
+	}
+	w.files = nil
+	return nil
+}
+
+// Close closes the compactor of the writer and removes pending files that
+// weren't installed.
+func (w *keyWriter) Close() {
+	w.c.Close()
+	for _, f := range w.files {
+		// A failed install may have already renamed the file.
+		os.RemoveAll(f)
+		os.RemoveAll(strings.TrimSuffix(f, ".tmp"))
+	}
+}
+
+// filesAddedSince returns the files of the file store that are not in files.
+func (e *Engine) filesAddedSince(files []TSMFile) []TSMFile {
+	known := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		known[f.Path()] = struct{}{}
+	}
+
+	var added []TSMFile
+	for _, f := range e.FileStore.Files() {
+		if _, ok := known[f.Path()]; !ok {
+			added = append(added, f)
+		}
+	}
+	return added
+}
+
+// walkKeysOf calls fn with every key in files and the cache, including the
+// keys of a snapshot that is being written.  A key may be passed more than
+// once.
+func (e *Engine) walkKeysOf(files []TSMFile, fn func(key []byte) error) error {
+	for _, f := range files {
+		for i, n := 0, f.KeyCount(); i < n; i++ {
+			key, _ := f.KeyAt(i)
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	e.Cache.mu.RLock()
+	stores := []storer{e.Cache.store}
+	if e.Cache.snapshot != nil {
+		stores = append(stores, e.Cache.snapshot.store)
+	}
+	e.Cache.mu.RUnlock()
+
+	for _, store := range stores {
+		for _, key := range store.keys(false) {
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkKeys calls fn with every key in the file store and the cache.  A key
+// may be passed more than once.
+func (e *Engine) walkKeys(fn func(key []byte) error) error {
+	if err := e.FileStore.WalkKeys(func(key []byte, _ byte) error {
+		return fn(key)
+	}); err != nil {
+		return err
+	}
+
+	for _, key := range e.Cache.Keys() {
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ForEachMeasurementName iterates over each measurement name in the engine.
 func (e *Engine) ForEachMeasurementName(fn func(name []byte) error) error {
 	return e.index.ForEachMeasurementName(fn)
@@ -1182,24 +2258,45 @@ func (e *Engine) compactCache(quit <-chan struct{}) {
 		case <-quit:
 			return
 
+		case <-e.snapshotRequest:
+			e.compactCacheNow()
+
 		case <-t.C:
 			e.Cache.UpdateAge()
-			if e.ShouldCompactCache(e.WAL.LastWriteTime()) {
-				start := time.Now()
-				e.traceLogger.Info(fmt.Sprintf("Compacting cache for %s", e.path))
-				err := e.WriteSnapshot()
-				if err != nil && err != errCompactionsDisabled {
-					e.logger.Info(fmt.Sprintf("error writing snapshot: %v", err))
-					atomic.AddInt64(&e.stats.CacheCompactionErrors, 1)
-				} else {
-					atomic.AddInt64(&e.stats.CacheCompactions, 1)
-				}
-				atomic.AddInt64(&e.stats.CacheCompactionDuration, time.Since(start).Nanoseconds())
+			if e.ShouldCompactCache(e.lastWriteTime()) {
+				e.compactCacheNow()
 			}
 		}
 	}
 }
 
+// compactCacheNow writes a snapshot of the cache to a TSM file.
+func (e *Engine) compactCacheNow() {
+	if e.Cache.Size() == 0 {
+		return
+	}
+
+	start := time.Now()
+	e.traceLogger.Info(fmt.Sprintf("Compacting cache for %s", e.path))
+	err := e.WriteSnapshot()
+	if err != nil && err != errCompactionsDisabled {
+		e.logger.Info(fmt.Sprintf("error writing snapshot: %v", err))
+		atomic.AddInt64(&e.stats.CacheCompactionErrors, 1)
+	} else {
+		atomic.AddInt64(&e.stats.CacheCompactions, 1)
+	}
+	atomic.AddInt64(&e.stats.CacheCompactionDuration, time.Since(start).Nanoseconds())
+}
+
+// requestSnapshot asks compactCache to snapshot the cache without waiting for
+// the next check.  It does not block.
+func (e *Engine) requestSnapshot() {
+	select {
+	case e.snapshotRequest <- struct{}{}:
+	default:
+	}
+}
+
 // ShouldCompactCache returns true if the Cache is over its flush threshold
 // or if the passed in lastWriteTime is older than the write cold threshold.
 func (e *Engine) ShouldCompactCache(lastWriteTime time.Time) bool {
@@ -1210,7 +2307,8 @@ func (e *Engine) ShouldCompactCache(lastWriteTime time.Time) bool {
 	}
 
 	return sz > e.CacheFlushMemorySizeThreshold ||
-		time.Since(lastWriteTime) > e.CacheFlushWriteColdDuration
+		time.Since(lastWriteTime) > e.CacheFlushWriteColdDuration ||
+		e.Cache.OverBudget()
 }
 
 func (e *Engine) compactTSMLevel(fast bool, level int, quit <-chan struct{}) {
@@ -1254,6 +2352,28 @@ func (e *Engine) compactTSMFull(quit <-chan struct{}) {
 	}
 }
 
+// compactTSMTombstones rewrites TSM files where a large portion of the data
+// has been deleted.
+func (e *Engine) compactTSMTombstones(quit <-chan struct{}) {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-quit:
+			return
+
+		case <-t.C:
+			s := e.tombstoneCompactionStrategy()
+			if s != nil {
+				s.Apply()
+				// Release the files in the compaction plan
+				e.CompactionPlan.Release(s.compactionGroups)
+			}
+		}
+	}
+}
+
 // onFileStoreReplace is callback handler invoked when the FileStore
 // has replaced one set of TSM files with a new set.
 func (e *Engine) onFileStoreReplace(newFiles []TSMFile) {
@@ -1314,6 +2434,7 @@ type compactionStrategy struct {
 	// enabled.
 	concurrency int
 	fast        bool
+	tombstones  bool
 	description string
 
 	durationStat *int64
@@ -1376,7 +2497,9 @@ func (s *compactionStrategy) compactGroup(groupNum int) {
 		atomic.AddInt64(s.activeStat, 1)
 		defer atomic.AddInt64(s.activeStat, -1)
 
-		if s.fast {
+		if s.tombstones {
+			return s.compactor.CompactTombstones(group)
+		} else if s.fast {
 			return s.compactor.CompactFast(group)
 		} else {
 			return s.compactor.CompactFull(group)
@@ -1444,7 +2567,7 @@ func (e *Engine) levelCompactionStrategy(fast bool, level int) *compactionStrate
 // It returns nil if there are no TSM files to compact.
 func (e *Engine) fullCompactionStrategy() *compactionStrategy {
 	optimize := false
-	compactionGroups := e.CompactionPlan.Plan(e.WAL.LastWriteTime())
+	compactionGroups := e.CompactionPlan.Plan(e.lastWriteTime())
 
 	if len(compactionGroups) == 0 {
 		optimize = true
@@ -1482,6 +2605,32 @@ func (e *Engine) fullCompactionStrategy() *compactionStrategy {
 	return s
 }
 
+// tombstoneCompactionStrategy returns a compactionStrategy for TSM files that are
+// mostly covered by tombstones.  It returns nil if there are no TSM files to compact.
+func (e *Engine) tombstoneCompactionStrategy() *compactionStrategy {
+	compactionGroups := e.CompactionPlan.PlanTombstones()
+
+	if len(compactionGroups) == 0 {
+		return nil
+	}
+
+	return &compactionStrategy{
+		concurrency:      1,
+		compactionGroups: compactionGroups,
+		logger:           e.logger,
+		fileStore:        e.FileStore,
+		compactor:        e.Compactor,
+		tombstones:       true,
+		limiter:          e.compactionLimiter,
+
+		description:  "tombstone",
+		activeStat:   &e.stats.TSMTombstoneCompactionsActive,
+		successStat:  &e.stats.TSMTombstoneCompactions,
+		errorStat:    &e.stats.TSMTombstoneCompactionErrors,
+		durationStat: &e.stats.TSMTombstoneCompactionDuration,
+	}
+}
+
 // reloadCache reads the WAL segment files and loads them into the cache.
 func (e *Engine) reloadCache() error {
 	now := time.Now()
@@ -0,0 +1,90 @@
+package tsm1
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/influxql"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/index/inmem"
+)
+
+// Ensure a conversion that fails to install its files keeps the old values.
+func TestEngine_ConvertField_InstallError(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	db := path.Base(dir)
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex(db)
+	idx := tsdb.MustOpenIndex(1, db, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+	e := NewEngine(1, idx, db, dir, walPath, opt).(*Engine)
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	mf := e.MeasurementFields([]byte("cpu"))
+	mf.CreateFieldIfNotExists([]byte("value"), influxql.Integer, false)
+
+	if err := e.WritePoints(mustParsePoints("cpu,host=A value=1i 1000000000")); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	} else if err := e.WritePoints(mustParsePoints("cpu,host=A value=2i 2000000000")); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	install := installFiles
+	defer func() { installFiles = install }()
+	installFiles = func(*FileStore, []string) error { return errors.New("install failed") }
+
+	if err := e.ConvertField([]byte("cpu"), []byte("value"), influxql.Float, nil); err == nil || err.Error() != "install failed" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if f := mf.Field("value"); f == nil || f.Type != influxql.Integer {
+		t.Fatalf("unexpected field: %v", f)
+	}
+
+	values, err := e.readAll([]byte("cpu,host=A#!~#value"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(values) != 2 || values[0].Value() != int64(1) || values[1].Value() != int64(2) {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*."+TSMFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(matches) != 1 {
+		t.Fatalf("unexpected files: %v", matches)
+	}
+}
+
+type mockPlanner struct{}
+
+func (m *mockPlanner) Plan(lastWrite time.Time) []CompactionGroup { return nil }
+func (m *mockPlanner) PlanLevel(level int) []CompactionGroup      { return nil }
+func (m *mockPlanner) PlanOptimize() []CompactionGroup            { return nil }
+func (m *mockPlanner) PlanTombstones() []CompactionGroup          { return nil }
+func (m *mockPlanner) Release(groups []CompactionGroup)           {}
+func (m *mockPlanner) FullyCompacted() bool                       { return false }
+
+func mustParsePoints(buf string) []models.Point {
+	a, err := models.ParsePointsString(buf)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/deep"
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
 	"github.com/influxdata/influxdb/tsdb/index/inmem"
@@ -145,6 +146,164 @@ func TestEngine_DeleteWALLoadMetadata(t *testing.T) {
 	}
 }
 
+// Ensure that writes bypass the WAL in WALModeNone and are flushed on close.
+func TestEngine_WALModeNone(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	if err := e.SetWALMode(tsdb.WALModeNone); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.WritePointsString(
+		`cpu,host=A value=1.1 1000000000`,
+		`cpu,host=B value=1.2 2000000000`,
+	); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if got := e.WAL.DiskSizeBytes(); got != 0 {
+		t.Fatalf("unexpected WAL size: got %d, exp 0", got)
+	} else if e.LastModified().IsZero() {
+		t.Fatal("expected non-zero last modified time")
+	}
+
+	// Closing the engine should write the cache to a TSM file.
+	if err := e.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := e.FileStore.Count(), 1; got != exp {
+		t.Fatalf("unexpected number of TSM files: got %d, exp %d", got, exp)
+	}
+
+	values, err := e.FileStore.Read(tsm1.SeriesFieldKeyBytes("cpu,host=A", "value"), 1000000000)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(values) != 1 || values[0].Value() != 1.1 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+}
+
+// Ensure that an invalid WAL mode is rejected.
+func TestEngine_SetWALMode_Invalid(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	if err := e.SetWALMode("sometimes"); err == nil || err.Error() != `invalid wal mode: "sometimes"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure that the first value written is kept in the first-write-wins policy.
+func TestEngine_DuplicatePolicyFirst(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	if err := e.SetDuplicatePolicy(tsdb.DuplicatePolicyFirst); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write a point and move it to a TSM file.
+	if err := e.WritePointsString(`cpu,host=A value=1.1 1000000000`); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.WriteSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Overwrite the TSM value and the cached value, and write a point twice.
+	if err := e.WritePointsString(
+		`cpu,host=A value=2.1 1000000000`,
+		`cpu,host=A value=1.2 2000000000`,
+		`cpu,host=A value=2.2 2000000000`,
+		`cpu,host=A value=1.3 3000000000`,
+	); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WritePointsString(`cpu,host=A value=3.3 3000000000`); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	key := tsm1.SeriesFieldKeyBytes("cpu,host=A", "value")
+	if values, err := e.FileStore.Read(key, 1000000000); err != nil {
+		t.Fatal(err)
+	} else if len(values) != 1 || values[0].Value() != 1.1 {
+		t.Fatalf("unexpected TSM values: %v", values)
+	}
+
+	values := e.Cache.Values(key)
+	if len(values) != 2 || values[0].Value() != 1.2 || values[1].Value() != 1.3 {
+		t.Fatalf("unexpected cached values: %v", values)
+	}
+}
+
+// Ensure that points overwriting existing values are reported in the reject policy.
+func TestEngine_DuplicatePolicyReject(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	if err := e.SetDuplicatePolicy(tsdb.DuplicatePolicyReject); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.WritePointsString(`cpu,host=A value=1.1,count=1i 1000000000`); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	err := e.WritePointsString(
+		`cpu,host=A value=2.1,count=2i 1000000000`,
+		`cpu,host=B value=1.1 1000000000`,
+	)
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	} else if perr.Dropped != 1 {
+		t.Fatalf("unexpected dropped points: got %d, exp 1", perr.Dropped)
+	} else if len(perr.DroppedPoints) != 1 || string(perr.DroppedPoints[0].Point.Key()) != "cpu,host=A" {
+		t.Fatalf("unexpected dropped points: %v", perr.DroppedPoints)
+	} else if code := perr.DroppedPoints[0].Reason.Code; code != tsdb.DropReasonDuplicatePoint {
+		t.Fatalf("unexpected drop reason: %s", code)
+	}
+
+	if values := e.Cache.Values(tsm1.SeriesFieldKeyBytes("cpu,host=A", "value")); len(values) != 1 || values[0].Value() != 1.1 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	if values := e.Cache.Values(tsm1.SeriesFieldKeyBytes("cpu,host=B", "value")); len(values) != 1 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	// None of the values of a duplicate point are written, including new
+	// fields and the values of a point repeated in the same write.
+	err = e.WritePointsString(
+		`cpu,host=A value=3.1,idle=1 1000000000`,
+		`cpu,host=C value=1.1 1000000000`,
+		`cpu,host=C value=2.1,idle=2 1000000000`,
+	)
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(perr.DroppedPoints) != 2 || perr.DroppedPoints[0].Point.String() != "cpu,host=A value=3.1,idle=1 1000000000" || perr.DroppedPoints[1].Point.String() != "cpu,host=C value=2.1,idle=2 1000000000" {
+		t.Fatalf("unexpected dropped points: %v", perr.DroppedPoints)
+	}
+
+	for _, key := range []string{"cpu,host=A", "cpu,host=C"} {
+		if values := e.Cache.Values(tsm1.SeriesFieldKeyBytes(key, "idle")); len(values) != 0 {
+			t.Fatalf("unexpected idle values for %s: %v", key, values)
+		}
+	}
+	if values := e.Cache.Values(tsm1.SeriesFieldKeyBytes("cpu,host=C", "value")); len(values) != 1 || values[0].Value() != 1.1 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+}
+
+// Ensure that an invalid duplicate policy is rejected.
+func TestEngine_SetDuplicatePolicy_Invalid(t *testing.T) {
+	e := MustOpenEngine()
+	defer e.Close()
+
+	if err := e.SetDuplicatePolicy("newest"); err == nil || err.Error() != `invalid duplicate policy: "newest"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // Ensure that the engine will backup any TSM files created since the passed in time
 func TestEngine_Backup(t *testing.T) {
 	// Generate temporary file.
@@ -635,6 +794,335 @@ func TestEngine_DeleteSeries(t *testing.T) {
 
 }
 
+func TestEngine_RenameSeries(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	db := path.Base(dir)
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex(db)
+	idx := tsdb.MustOpenIndex(1, db, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+	e := tsm1.NewEngine(1, idx, db, dir, walPath, opt).(*tsm1.Engine)
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	// Overwrite a value in the cache that is also in a TSM file.
+	if err := e.WritePoints([]models.Point{
+		MustParsePointString("cpu,host=A value=1.1 1000000000"),
+		MustParsePointString("cpu,host=A value=1.2 2000000000"),
+		MustParsePointString("cpu,host=B value=1.3 1000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	} else if err := e.WritePoints([]models.Point{
+		MustParsePointString("cpu,host=A value=2.2 2000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	// Only rename host A.
+	if err := e.RenameSeries([]byte("cpu"), func(key []byte) ([]byte, error) {
+		if string(key) != "cpu,host=A" {
+			return nil, nil
+		}
+		return []byte("gpu,host=A"), nil
+	}); err != nil {
+		t.Fatalf("failed to rename series: %s", err)
+	}
+
+	keys := e.FileStore.Keys()
+	if _, ok := keys["cpu,host=A#!~#value"]; ok {
+		t.Fatalf("old key not removed: %v", keys)
+	} else if _, ok := keys["cpu,host=B#!~#value"]; !ok {
+		t.Fatalf("unrenamed key removed: %v", keys)
+	} else if got := e.Cache.Values([]byte("cpu,host=A#!~#value")); len(got) != 0 {
+		t.Fatalf("old key not removed from cache: %v", got)
+	}
+
+	values, err := e.FileStore.Read([]byte("gpu,host=A#!~#value"), 2000000000)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(values) != 2 || values[0].Value() != 1.1 || values[1].Value() != 2.2 {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	if ok, err := e.MeasurementExists([]byte("gpu")); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected renamed measurement")
+	} else if mf := e.MeasurementFields([]byte("gpu")); mf.Field("value") == nil {
+		t.Fatal("expected renamed field")
+	}
+
+	// Renaming onto an existing key fails.
+	if err := e.RenameSeries([]byte("cpu"), func(key []byte) ([]byte, error) {
+		return []byte("gpu,host=A"), nil
+	}); err == nil || err.Error() != "series gpu,host=A already exists" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Renaming onto a key that holds the old values completes a failed rename.
+	if err := e.WritePoints([]models.Point{
+		MustParsePointString("gpu,host=B value=1.3 1000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.RenameSeries([]byte("cpu"), func(key []byte) ([]byte, error) {
+		return []byte("gpu,host=B"), nil
+	}); err != nil {
+		t.Fatalf("failed to rename series: %s", err)
+	}
+
+	if keys := e.FileStore.Keys(); len(keys) != 1 {
+		t.Fatalf("old key not removed: %v", keys)
+	} else if ok, err := e.MeasurementExists([]byte("cpu")); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("unexpected measurement")
+	} else if got := e.Cache.Values([]byte("gpu,host=B#!~#value")); len(got) != 1 || got[0].Value() != 1.3 {
+		t.Fatalf("unexpected values: %v", got)
+	}
+}
+
+func TestEngine_DeleteField(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	db := path.Base(dir)
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex(db)
+	idx := tsdb.MustOpenIndex(1, db, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+	e := tsm1.NewEngine(1, idx, db, dir, walPath, opt).(*tsm1.Engine)
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	e.CreateSeriesIfNotExists([]byte("cpu,host=A"), []byte("cpu"), models.NewTags(map[string]string{"host": "A"}))
+	e.CreateSeriesIfNotExists([]byte("cpu,host=B"), []byte("cpu"), models.NewTags(map[string]string{"host": "B"}))
+	mf := e.MeasurementFields([]byte("cpu"))
+	mf.CreateFieldIfNotExists([]byte("value"), influxql.Float, false)
+	mf.CreateFieldIfNotExists([]byte("idle"), influxql.Float, false)
+
+	// Host B only has the dropped field.
+	if err := e.WritePoints([]models.Point{
+		MustParsePointString("cpu,host=A value=1.1,idle=2.1 1000000000"),
+		MustParsePointString("cpu,host=B value=1.2 1000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	} else if err := e.WritePoints([]models.Point{
+		MustParsePointString("cpu,host=A value=2.2 2000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if err := e.DeleteField([]byte("cpu"), []byte("value")); err != nil {
+		t.Fatalf("failed to delete field: %s", err)
+	}
+
+	keys := e.FileStore.Keys()
+	if _, ok := keys["cpu,host=A#!~#value"]; ok {
+		t.Fatalf("field not removed: %v", keys)
+	} else if _, ok := keys["cpu,host=A#!~#idle"]; !ok {
+		t.Fatalf("other field removed: %v", keys)
+	} else if got := e.Cache.Values([]byte("cpu,host=A#!~#value")); len(got) != 0 {
+		t.Fatalf("field not removed from cache: %v", got)
+	}
+
+	if mf.Field("value") != nil {
+		t.Fatal("expected field to be removed")
+	} else if mf.Field("idle") == nil {
+		t.Fatal("expected other field to remain")
+	}
+
+	if keys, err := e.MeasurementSeriesKeysByExpr([]byte("cpu"), nil); err != nil {
+		t.Fatal(err)
+	} else if len(keys) != 1 || string(keys[0]) != "cpu,host=A" {
+		t.Fatalf("unexpected series: %q", keys)
+	}
+}
+
+func TestEngine_ConvertField(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	db := path.Base(dir)
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex(db)
+	idx := tsdb.MustOpenIndex(1, db, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+	e := tsm1.NewEngine(1, idx, db, dir, walPath, opt).(*tsm1.Engine)
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	mf := e.MeasurementFields([]byte("cpu"))
+	mf.CreateFieldIfNotExists([]byte("value"), influxql.Integer, false)
+
+	if err := e.WritePoints([]models.Point{
+		MustParsePointString("cpu,host=A value=1i 1000000000"),
+		MustParsePointString("cpu,host=A value=2i 2000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	} else if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	} else if err := e.WritePoints([]models.Point{
+		MustParsePointString("cpu,host=A value=3i 2000000000"),
+	}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	// Booleans can't be converted to.
+	if err := e.ConvertField([]byte("cpu"), []byte("value"), influxql.Boolean, nil); err == nil || err.Error() != `cannot convert field "value" from integer to boolean` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := e.ConvertField([]byte("cpu"), []byte("value"), influxql.Float, nil); err != nil {
+		t.Fatalf("failed to convert field: %s", err)
+	} else if f := mf.Field("value"); f == nil || f.Type != influxql.Float {
+		t.Fatalf("unexpected field: %v", f)
+	}
+
+	values, err := e.FileStore.Read([]byte("cpu,host=A#!~#value"), 2000000000)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(values) != 2 || values[0].Value() != 1.0 || values[1].Value() != 3.0 {
+		t.Fatalf("unexpected values: %v", values)
+	} else if got := e.Cache.Values([]byte("cpu,host=A#!~#value")); len(got) != 0 {
+		t.Fatalf("old values not removed from cache: %v", got)
+	}
+
+	if err := e.ConvertField([]byte("cpu"), []byte("value"), influxql.String, nil); err != nil {
+		t.Fatalf("failed to convert field: %s", err)
+	}
+
+	values, err = e.FileStore.Read([]byte("cpu,host=A#!~#value"), 1000000000)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(values) != 2 || values[0].Value() != "1" || values[1].Value() != "3" {
+		t.Fatalf("unexpected values: %v", values)
+	}
+}
+
+func TestEngine_Statistics_Tombstones(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	p1 := MustParsePointString("cpu,host=A value=1.1 1000000000")
+	p2 := MustParsePointString("cpu,host=B value=1.2 2000000000")
+	p3 := MustParsePointString("cpu,host=A sum=1.3 3000000000")
+
+	db := path.Base(dir)
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex(db)
+	idx := tsdb.MustOpenIndex(1, db, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+	e := tsm1.NewEngine(1, idx, db, dir, walPath, opt).(*tsm1.Engine)
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	if err := e.WritePoints([]models.Point{p1, p2, p3}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	if err := e.DeleteSeriesRange([][]byte{[]byte("cpu,host=A")}, math.MinInt64, math.MaxInt64); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	stats := e.Statistics(nil)[0].Values
+	if got, exp := stats["tombstones"], int64(2); got != exp {
+		t.Fatalf("tombstones mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := stats["tombstoneFiles"], int64(1); got != exp {
+		t.Fatalf("tombstone files mismatch: got %v, exp %v", got, exp)
+	}
+	if got := stats["tombstoneBytes"].(int64); got <= 0 {
+		t.Fatalf("expected tombstone bytes, got %v", got)
+	}
+}
+
+func TestEngine_WritePoints_CacheMaxWriteWait(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tsm")
+	walPath := filepath.Join(dir, "wal")
+	os.MkdirAll(walPath, 0777)
+	defer os.RemoveAll(dir)
+
+	p1 := MustParsePointString("cpu,host=A value=1.1 1000000000")
+	p2 := MustParsePointString("cpu,host=B value=1.2 2000000000")
+
+	db := path.Base(dir)
+	opt := tsdb.NewEngineOptions()
+	opt.InmemIndex = inmem.NewIndex(db)
+	// Only leave room for a single point in the cache.
+	opt.Config.CacheMaxMemorySize = uint64(tsm1.NewValue(0, 1.0).Size())
+	opt.Config.CacheMaxWriteWait = toml.Duration(10 * time.Second)
+	idx := tsdb.MustOpenIndex(1, db, filepath.Join(dir, "index"), opt)
+	defer idx.Close()
+	e := tsm1.NewEngine(1, idx, db, dir, walPath, opt).(*tsm1.Engine)
+
+	// mock the planner so compactions don't run during the test
+	e.CompactionPlan = &mockPlanner{}
+
+	if err := e.Open(); err != nil {
+		t.Fatalf("failed to open tsm1 engine: %s", err.Error())
+	}
+	defer e.Close()
+
+	if err := e.WritePoints([]models.Point{p1}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	// The cache is full so the write waits for it to be snapshotted.
+	if err := e.WritePoints([]models.Point{p2}); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if got, exp := e.FileStore.Count(), 1; got != exp {
+		t.Fatalf("file count mismatch: got %v, exp %v", got, exp)
+	}
+
+	stats := e.Cache.Statistics(nil)[0].Values
+	if got, exp := stats["writeThrottled"], int64(1); got != exp {
+		t.Fatalf("write throttled mismatch: got %v, exp %v", got, exp)
+	}
+}
+
 func TestEngine_LastModified(t *testing.T) {
 	// Generate temporary file.
 	dir, _ := ioutil.TempDir("", "tsm")
@@ -1085,6 +1573,7 @@ type mockPlanner struct{}
 func (m *mockPlanner) Plan(lastWrite time.Time) []tsm1.CompactionGroup { return nil }
 func (m *mockPlanner) PlanLevel(level int) []tsm1.CompactionGroup      { return nil }
 func (m *mockPlanner) PlanOptimize() []tsm1.CompactionGroup            { return nil }
+func (m *mockPlanner) PlanTombstones() []tsm1.CompactionGroup          { return nil }
 func (m *mockPlanner) Release(groups []tsm1.CompactionGroup)           {}
 func (m *mockPlanner) FullyCompacted() bool                            { return false }
 
@@ -27,6 +27,9 @@ type TSMFile interface {
 	// Read returns all the values in the block where time t resides.
 	Read(key []byte, t int64) ([]Value, error)
 
+	// ReadAll returns all the values of key that have not been deleted.
+	ReadAll(key []byte) ([]Value, error)
+
 	// ReadAt returns all the values in the block identified by entry.
 	ReadAt(entry *IndexEntry, values []Value) ([]Value, error)
 	ReadFloatBlockAt(entry *IndexEntry, values *[]FloatValue) ([]FloatValue, error)
@@ -50,6 +53,10 @@ type TSMFile interface {
 	// TimeRange returns the min and max time across all keys in the file.
 	TimeRange() (int64, int64)
 
+	// WriteTime returns the time the data of the file was written, ignoring
+	// tombstones and compactions.
+	WriteTime() int64
+
 	// TombstoneRange returns ranges of time that are deleted for the given key.
 	TombstoneRange(key []byte) []TimeRange
 
@@ -150,6 +157,19 @@ type FileStat struct {
 	LastModified     int64
 	MinTime, MaxTime int64
 	MinKey, MaxKey   []byte
+
+	// TombstoneCount is the number of tombstones applied to the file and
+	// TombstoneSize is an estimate of the bytes of blocks they cover.
+	TombstoneCount int
+	TombstoneSize  uint32
+}
+
+// TombstoneRatio returns the estimated fraction of the file covered by tombstones.
+func (f FileStat) TombstoneRatio() float64 {
+	if f.Size == 0 {
+		return 0
+	}
+	return math.Min(float64(f.TombstoneSize)/float64(f.Size), 1)
 }
 
 // OverlapsTimeRange returns true if the time range of the file intersect min and max.
@@ -285,6 +305,81 @@ func (f *FileStore) WalkKeys(fn func(key []byte, typ byte) error) error {
 	return nil
 }
 
+// WalkKeysWrittenSince calls fn for every key in the TSM files known to the
+// FileStore with data written at or after min.  Tombstones written to a file
+// don't count.  If the key exists in multiple files, it will be invoked for
+// each file.
+func (f *FileStore) WalkKeysWrittenSince(min int64, fn func(key []byte) error) error {
+	var files []TSMFile
+	f.mu.RLock()
+	for _, r := range f.files {
+		if r.WriteTime() >= min {
+			r.Ref()
+			files = append(files, r)
+		}
+	}
+	f.mu.RUnlock()
+
+	defer func() {
+		for _, r := range files {
+			r.Unref()
+		}
+	}()
+
+	for _, r := range files {
+		for i, n := 0, r.KeyCount(); i < n; i++ {
+			key, _ := r.KeyAt(i)
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SeriesWrittenSince returns the keys of seriesKeys with data in a TSM file
+// known to the FileStore written at or after min.
+func (f *FileStore) SeriesWrittenSince(seriesKeys [][]byte, min int64) map[string]struct{} {
+	var files []TSMFile
+	f.mu.RLock()
+	for _, r := range f.files {
+		if r.WriteTime() >= min {
+			r.Ref()
+			files = append(files, r)
+		}
+	}
+	f.mu.RUnlock()
+
+	defer func() {
+		for _, r := range files {
+			r.Unref()
+		}
+	}()
+
+	written := make(map[string]struct{})
+	var prefix []byte
+	for _, seriesKey := range seriesKeys {
+		prefix = append(append(prefix[:0], seriesKey...), keyFieldSeparator...)
+		for _, r := range files {
+			// Keys are sorted, so the first key at or after the prefix is
+			// a key of the series if the file contains the series.
+			n := r.KeyCount()
+			i := sort.Search(n, func(i int) bool {
+				key, _ := r.KeyAt(i)
+				return bytes.Compare(key, prefix) >= 0
+			})
+			if i == n {
+				continue
+			}
+			if key, _ := r.KeyAt(i); bytes.HasPrefix(key, prefix) {
+				written[string(seriesKey)] = struct{}{}
+				break
+			}
+		}
+	}
+	return written
+}
+
 // Keys returns all keys and types for all files in the file store.
 func (f *FileStore) Keys() map[string]byte {
 	f.mu.RLock()
@@ -475,6 +570,81 @@ func (f *FileStore) Read(key []byte, t int64) ([]Value, error) {
 	return nil, nil
 }
 
+// ExistingTimestamps returns the subset of ts that have a value stored for key
+// in any TSM file.  Deleted values are not returned.  Only the blocks whose
+// time range contains one of ts are decoded, so a write of new values usually
+// decodes nothing.
+func (f *FileStore) ExistingTimestamps(key []byte, ts []int64) (map[int64]struct{}, error) {
+	if len(ts) == 0 {
+		return nil, nil
+	}
+
+	sorted := make([]int64, len(ts))
+	copy(sorted, ts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	min, max := sorted[0], sorted[len(sorted)-1]
+
+	// contains returns true if one of ts is between min and max, inclusive.
+	contains := func(min, max int64) bool {
+		i := sort.Search(len(sorted), func(i int) bool { return sorted[i] >= min })
+		return i < len(sorted) && sorted[i] <= max
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var found map[int64]struct{}
+	var entries []IndexEntry
+	var values []Value
+	for _, tf := range f.files {
+		if fmin, fmax := tf.TimeRange(); fmax < min || fmin > max || !tf.Contains(key) {
+			continue
+		}
+
+		// Blocks are only decoded if their time range contains one of ts.
+		tf.ReadEntries(key, &entries)
+		var tombstones []TimeRange
+		var tombstonesRead bool
+		for i := range entries {
+			if !contains(entries[i].MinTime, entries[i].MaxTime) {
+				continue
+			} else if !tombstonesRead {
+				tombstones, tombstonesRead = tf.TombstoneRange(key), true
+			}
+
+			var err error
+			values, err = tf.ReadAt(&entries[i], values[:0])
+			if err != nil {
+				return nil, err
+			}
+
+			for _, v := range values {
+				t := v.UnixNano()
+				if !contains(t, t) {
+					continue
+				}
+
+				var deleted bool
+				for _, tr := range tombstones {
+					if t >= tr.Min && t <= tr.Max {
+						deleted = true
+						break
+					}
+				}
+				if deleted {
+					continue
+				}
+
+				if found == nil {
+					found = make(map[int64]struct{})
+				}
+				found[t] = struct{}{}
+			}
+		}
+	}
+	return found, nil
+}
+
 // KeyCursor returns a KeyCursor for key and t across the files in the FileStore.
 func (f *FileStore) KeyCursor(key []byte, t int64, ascending bool) *KeyCursor {
 	f.mu.RLock()
@@ -514,8 +684,19 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 		return nil
 	}
 
+	// Files written by compactions keep the write time of the newest file
+	// they replace, so compactions don't make their data look recently
+	// written.
+	var writeTime int64
 	f.mu.RLock()
 	maxTime := f.lastModified
+	for _, file := range f.files {
+		for _, remove := range oldFiles {
+			if remove == file.Path() && file.WriteTime() > writeTime {
+				writeTime = file.WriteTime()
+			}
+		}
+	}
 	f.mu.RUnlock()
 
 	updated := make([]TSMFile, 0, len(newFiles))
@@ -547,6 +728,9 @@ func (f *FileStore) Replace(oldFiles, newFiles []string) error {
 		if err != nil {
 			return err
 		}
+		if writeTime > 0 {
+			tsm.SetWriteTime(writeTime)
+		}
 		updated = append(updated, tsm)
 	}
 
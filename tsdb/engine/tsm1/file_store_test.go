@@ -51,6 +51,43 @@ func TestFileStore_Read(t *testing.T) {
 	}
 }
 
+// Ensure the stored and undeleted timestamps of a key are found.
+func TestFileStore_ExistingTimestamps(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	fs := tsm1.NewFileStore(dir)
+
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(10, 2.0)}},
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(20, 3.0), tsm1.NewValue(30, 4.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(5, 1.0)}},
+	}
+
+	files, err := newFiles(dir, data...)
+	if err != nil {
+		t.Fatalf("unexpected error creating files: %v", err)
+	}
+	fs.Replace(nil, files)
+
+	if err := fs.DeleteRange([][]byte{[]byte("cpu")}, 30, 30); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	found, err := fs.ExistingTimestamps([]byte("cpu"), []int64{30, 5, 20, 0, 40})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := map[int64]struct{}{0: {}, 20: {}}; !reflect.DeepEqual(found, exp) {
+		t.Fatalf("unexpected timestamps: got %v, exp %v", found, exp)
+	}
+
+	if found, err := fs.ExistingTimestamps([]byte("cpu"), []int64{5, 15, 25}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if found != nil {
+		t.Fatalf("unexpected timestamps: %v", found)
+	}
+}
+
 func TestFileStore_SeekToAsc_FromStart(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
@@ -2463,6 +2500,57 @@ func TestFileStore_Replace(t *testing.T) {
 
 }
 
+// Ensure files written by a compaction keep the write time of the files they
+// replace.
+func TestFileStore_Replace_WriteTime(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	data := []keyValues{
+		keyValues{"cpu,host=A#!~#value", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+		keyValues{"cpu,host=B#!~#value", []tsm1.Value{tsm1.NewValue(1, 2.0)}},
+		keyValues{"cpu,host=A#!~#value", []tsm1.Value{tsm1.NewValue(2, 3.0)}},
+	}
+
+	files, err := newFileDir(dir, data...)
+	if err != nil {
+		fatal(t, "creating test files", err)
+	}
+
+	written := time.Now().Add(-2 * time.Hour)
+	for _, f := range files[:2] {
+		if err := os.Chtimes(f, written, written); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	replacement := files[2] + ".tmp"
+	os.Rename(files[2], replacement)
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	min := time.Now().Add(-time.Hour).UnixNano()
+	keys := [][]byte{[]byte("cpu,host=A"), []byte("cpu,host=B")}
+	if got := fs.SeriesWrittenSince(keys, min); len(got) != 0 {
+		t.Fatalf("unexpected series written: %v", got)
+	}
+
+	if err := fs.Replace(files[:2], []string{replacement}); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+
+	if got := fs.SeriesWrittenSince(keys, min); len(got) != 0 {
+		t.Fatalf("unexpected series written after replace: %v", got)
+	}
+	if got := fs.SeriesWrittenSince(keys, written.Add(-time.Second).UnixNano()); !reflect.DeepEqual(got, map[string]struct{}{"cpu,host=A": struct{}{}}) {
+		t.Fatalf("unexpected series written: %v", got)
+	}
+}
+
 func TestFileStore_Open_Deleted(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
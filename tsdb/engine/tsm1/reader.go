@@ -38,6 +38,10 @@ type TSMReader struct {
 
 	// lastModified is the last time this file was modified on disk
 	lastModified int64
+
+	// writeTime is the time the data of this file was written.  Files written
+	// by compactions keep the write time of the newest file they replace.
+	writeTime int64
 }
 
 // TSMIndex represent the index section of a TSM file.  The index records all
@@ -91,6 +95,10 @@ type TSMIndex interface {
 	// TombstoneRange returns ranges of time that are deleted for the given key.
 	TombstoneRange(key []byte) []TimeRange
 
+	// TombstoneStats returns the number of tombstones applied to the index and an
+	// estimate of the size in bytes of the blocks they cover.
+	TombstoneStats() (n int, size uint32)
+
 	// KeyRange returns the min and max keys in the file.
 	KeyRange() ([]byte, []byte)
 
@@ -197,6 +205,7 @@ func NewTSMReader(f *os.File) (*TSMReader, error) {
 	}
 	t.size = stat.Size()
 	t.lastModified = stat.ModTime().UnixNano()
+	t.writeTime = t.lastModified
 	t.accessor = &mmapAccessor{
 		f: f,
 	}
@@ -507,6 +516,23 @@ func (t *TSMReader) LastModified() int64 {
 	return lm
 }
 
+// WriteTime returns the time the data of the file was written.  Unlike
+// LastModified, it ignores the file's tombstones and isn't reset when the
+// file is compacted.  The time is only kept in memory, so a compacted file
+// counts as written when it was compacted once it is reopened.
+func (t *TSMReader) WriteTime() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.writeTime
+}
+
+// SetWriteTime sets the time the data of the file was written.
+func (t *TSMReader) SetWriteTime(writeTime int64) {
+	t.mu.Lock()
+	t.writeTime = writeTime
+	t.mu.Unlock()
+}
+
 // HasTombstones return true if there are any tombstone entries recorded.
 func (t *TSMReader) HasTombstones() bool {
 	t.mu.RLock()
@@ -535,15 +561,18 @@ func (t *TSMReader) TombstoneRange(key []byte) []TimeRange {
 func (t *TSMReader) Stats() FileStat {
 	minTime, maxTime := t.index.TimeRange()
 	minKey, maxKey := t.index.KeyRange()
+	tombstoneN, tombstoneSize := t.index.TombstoneStats()
 	return FileStat{
-		Path:         t.Path(),
-		Size:         t.Size(),
-		LastModified: t.LastModified(),
-		MinTime:      minTime,
-		MaxTime:      maxTime,
-		MinKey:       minKey,
-		MaxKey:       maxKey,
-		HasTombstone: t.tombstoner.HasTombstones(),
+		Path:           t.Path(),
+		Size:           t.Size(),
+		LastModified:   t.LastModified(),
+		MinTime:        minTime,
+		MaxTime:        maxTime,
+		MinKey:         minKey,
+		MaxKey:         maxKey,
+		HasTombstone:   t.tombstoner.HasTombstones(),
+		TombstoneCount: tombstoneN,
+		TombstoneSize:  tombstoneSize,
 	}
 }
 
@@ -612,6 +641,11 @@ type indirectIndex struct {
 	// entry would exist here if a subset of the points for a key were deleted and the file
 	// had not be re-compacted to remove the points on disk.
 	tombstones map[string][]TimeRange
+
+	// tombstoneN is the number of keys and key ranges that have been tombstoned and
+	// tombstoneSize is the size of the blocks they cover that still exist on disk.
+	tombstoneN    int
+	tombstoneSize uint32
 }
 
 // TimeRange holds a min and max timestamp.
@@ -775,7 +809,7 @@ func (d *indirectIndex) Delete(keys [][]byte) {
 	// any keys that exist in both.
 	offsets := make([]int32, 0, len(d.offsets))
 	for _, offset := range d.offsets {
-		_, indexKey, _ := readKey(d.b[offset:])
+		n, indexKey, _ := readKey(d.b[offset:])
 
 		for len(keys) > 0 && bytes.Compare(keys[0], indexKey) < 0 {
 			keys = keys[1:]
@@ -783,6 +817,18 @@ func (d *indirectIndex) Delete(keys [][]byte) {
 
 		if len(keys) > 0 && bytes.Equal(keys[0], indexKey) {
 			keys = keys[1:]
+
+			// Blocks of a removed key are still on disk until the file is
+			// compacted.  Parts already covered by tombstones of the key are
+			// counted already.
+			var entries indexEntries
+			if _, err := readEntries(d.b[int(offset)+n:], &entries); err == nil {
+				existing := d.tombstones[string(indexKey)]
+				for i := range entries.entries {
+					d.tombstoneSize += tombstonedSize(&entries.entries[i], math.MinInt64, math.MaxInt64, existing)
+				}
+			}
+			d.tombstoneN++
 			continue
 		}
 
@@ -812,6 +858,7 @@ func (d *indirectIndex) DeleteRange(keys [][]byte, minTime, maxTime int64) {
 	}
 
 	tombstones := map[string][]TimeRange{}
+	var size uint32
 	for _, k := range keys {
 		// Is the range passed in outside the time range for this key?
 		entries := d.Entries(k)
@@ -832,6 +879,11 @@ func (d *indirectIndex) DeleteRange(keys [][]byte, minTime, maxTime int64) {
 			continue
 		}
 
+		existing := d.TombstoneRange(k)
+		for i := range entries {
+			size += tombstonedSize(&entries[i], minTime, maxTime, existing)
+		}
+
 		tombstones[string(k)] = append(tombstones[string(k)], TimeRange{minTime, maxTime})
 	}
 
@@ -842,10 +894,53 @@ func (d *indirectIndex) DeleteRange(keys [][]byte, minTime, maxTime int64) {
 	d.mu.Lock()
 	for k, v := range tombstones {
 		d.tombstones[k] = append(d.tombstones[k], v...)
+		d.tombstoneN += len(v)
 	}
+	d.tombstoneSize += size
 	d.mu.Unlock()
 }
 
+// tombstonedSize estimates the bytes of block e removed by a tombstone from
+// min to max as the block's size in proportion to the part of its time range
+// the tombstone covers.  Parts covered by the existing tombstones of the key
+// are not counted again.
+func tombstonedSize(e *IndexEntry, min, max int64, existing []TimeRange) uint32 {
+	if min < e.MinTime {
+		min = e.MinTime
+	}
+	if max > e.MaxTime {
+		max = e.MaxTime
+	}
+	if min > max {
+		return 0
+	}
+
+	// Remove the existing tombstones from the covered range.
+	covered := []TimeRange{{min, max}}
+	for _, t := range existing {
+		var remaining []TimeRange
+		for _, r := range covered {
+			if t.Max < r.Min || t.Min > r.Max {
+				remaining = append(remaining, r)
+				continue
+			}
+			if t.Min > r.Min {
+				remaining = append(remaining, TimeRange{r.Min, t.Min - 1})
+			}
+			if t.Max < r.Max {
+				remaining = append(remaining, TimeRange{t.Max + 1, r.Max})
+			}
+		}
+		covered = remaining
+	}
+
+	var n float64
+	for _, r := range covered {
+		n += float64(r.Max) - float64(r.Min) + 1
+	}
+	return uint32(float64(e.Size)*n/(float64(e.MaxTime)-float64(e.MinTime)+1) + 0.5)
+}
+
 // TombstoneRange returns ranges of time that are deleted for the given key.
 func (d *indirectIndex) TombstoneRange(key []byte) []TimeRange {
 	d.mu.RLock()
@@ -854,6 +949,14 @@ func (d *indirectIndex) TombstoneRange(key []byte) []TimeRange {
 	return r
 }
 
+// TombstoneStats returns the number of tombstones applied to the index and an
+// estimate of the size in bytes of the blocks they cover.
+func (d *indirectIndex) TombstoneStats() (int, uint32) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.tombstoneN, d.tombstoneSize
+}
+
 // Contains return true if the given key exists in the index.
 func (d *indirectIndex) Contains(key []byte) bool {
 	return len(d.Entries(key)) > 0
@@ -413,6 +413,109 @@ func TestTSMReader_MMAP_TombstoneRange(t *testing.T) {
 	}
 }
 
+func TestTSMReader_MMAP_TombstoneStats(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+	defer f.Close()
+
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		t.Fatalf("unexpected error creating writer: %v", err)
+	}
+
+	for _, key := range []string{"cpu", "mem"} {
+		values := []tsm1.Value{
+			tsm1.NewValue(1, 1.0),
+			tsm1.NewValue(2, 2.0),
+			tsm1.NewValue(3, 3.0),
+		}
+		if err := w.Write([]byte(key), values); err != nil {
+			t.Fatalf("unexpected error writing: %v", err)
+		}
+	}
+
+	if err := w.WriteIndex(); err != nil {
+		t.Fatalf("unexpected error writing index: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	f, err = os.Open(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+
+	if stat := r.Stats(); stat.TombstoneCount != 0 || stat.TombstoneSize != 0 {
+		t.Fatalf("unexpected tombstone stats: %d, %d", stat.TombstoneCount, stat.TombstoneSize)
+	}
+
+	blockSize := r.Entries([]byte("cpu"))[0].Size
+
+	if err := r.DeleteRange([][]byte{[]byte("cpu")}, 2, math.MaxInt64); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	// Deleting the same range again should not count the block twice.
+	if err := r.DeleteRange([][]byte{[]byte("cpu")}, 2, 3); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	if err := r.Delete([][]byte{[]byte("mem")}); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+
+	stat := r.Stats()
+	if got, exp := stat.TombstoneCount, 3; got != exp {
+		t.Fatalf("tombstone count mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Two thirds of the cpu block and the whole mem block are deleted.
+	size := blockSize + uint32(float64(2*blockSize)/3+0.5)
+	if got, exp := stat.TombstoneSize, size; got != exp {
+		t.Fatalf("tombstone size mismatch: got %v, exp %v", got, exp)
+	}
+
+	if stat.TombstoneRatio() <= 0 || stat.TombstoneRatio() > 1 {
+		t.Fatalf("unexpected tombstone ratio: %v", stat.TombstoneRatio())
+	}
+
+	// Reopening the file should restore the stats from the tombstone file.
+	path := r.Path()
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+
+	f, err = os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error open file: %v", err)
+	}
+
+	r, err = tsm1.NewTSMReader(f)
+	if err != nil {
+		t.Fatalf("unexpected error created reader: %v", err)
+	}
+	defer r.Close()
+
+	if got, exp := r.Stats().TombstoneSize, size; got != exp {
+		t.Fatalf("tombstone size mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Deleting the rest of the cpu block only counts the remaining third.
+	if err := r.DeleteRange([][]byte{[]byte("cpu")}, 0, 2); err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	} else if got, exp := r.Stats().TombstoneSize, 2*blockSize; got < exp-1 || got > exp+1 {
+		t.Fatalf("tombstone size mismatch: got %v, exp %v", got, exp)
+	}
+}
+
 func TestTSMReader_MMAP_TombstoneOutsideTimeRange(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
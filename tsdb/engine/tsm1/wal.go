@@ -99,8 +99,8 @@ type WAL struct {
 	closing chan struct{}
 
 	// syncDelay sets the duration to wait before fsyncing writes.  A value of 0 (default)
-	// will cause every write to be fsync'd.  This must be set before the WAL
-	// is opened if a non-default value is required.
+	// will cause every write to be fsync'd.  Use SetSyncDelay to change it once
+	// the WAL is open.
 	syncDelay time.Duration
 
 	// WALOutput is the writer used by the logger.
@@ -237,29 +237,40 @@ func (l *WAL) Open() error {
 	return nil
 }
 
+// SetSyncDelay sets the duration to wait before fsyncing writes. The new delay
+// applies to the next scheduled fsync.
+func (l *WAL) SetSyncDelay(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.syncDelay = d
+}
+
 // scheduleSync will schedule an fsync to the current wal segment and notify any
 // waiting gorutines.  If an fsync is already scheduled, subsequent calls will
 // not schedule a new fsync and will be handle by the existing scheduled fsync.
+// Callers must ensure a write lock on the WAL is obtained before calling scheduleSync.
 func (l *WAL) scheduleSync() {
 	// If we're not the first to sync, then another goroutine is fsyncing the wal for us.
 	if !atomic.CompareAndSwapUint64(&l.syncCount, 0, 1) {
 		return
 	}
 
+	delay := l.syncDelay
+
 	// Fsync the wal and notify all pending waiters
 	go func() {
 		var timerCh <-chan time.Time
 
 		// time.NewTicker requires a > 0 delay, since 0 indicates no delay, use a closed
 		// channel which will always be ready to read from.
-		if l.syncDelay == 0 {
+		if delay == 0 {
 			// Create a RW chan and close it
 			timerChrw := make(chan time.Time)
 			close(timerChrw)
 			// Convert it to a read-only
 			timerCh = timerChrw
 		} else {
-			t := time.NewTicker(l.syncDelay)
+			t := time.NewTicker(delay)
 			defer t.Stop()
 			timerCh = t.C
 		}
@@ -60,6 +60,15 @@ type Index interface {
 	Type() string
 }
 
+// SeriesTimeRangeIndex is implemented by indexes that record the time range of
+// the points in each series so queries can skip series without points in
+// their time range.
+type SeriesTimeRangeIndex interface {
+	// CreateSeriesListWithTimeRanges creates the series that don't exist and
+	// extends the time range of each series to include mins & maxs.
+	CreateSeriesListWithTimeRanges(keys, names [][]byte, tags []models.Tags, mins, maxs []int64) error
+}
+
 // IndexFormat represents the format for an index.
 type IndexFormat int
 
@@ -127,3 +136,53 @@ func MustOpenIndex(id uint64, database, path string, options EngineOptions) Inde
 	}
 	return idx
 }
+
+// SeriesBatchSize is the number of series created in an index at a time when
+// an index is built from the series of a shard.
+const SeriesBatchSize = 10000
+
+// SeriesBatch batches the series created in an index when an index is built
+// from the series of a shard. Series already in the batch are skipped.
+type SeriesBatch struct {
+	idx       Index
+	seen      map[string]struct{}
+	keys      [][]byte
+	names     [][]byte
+	tagsSlice []models.Tags
+}
+
+// NewSeriesBatch returns a batch of series created in idx.
+func NewSeriesBatch(idx Index) *SeriesBatch {
+	return &SeriesBatch{idx: idx, seen: make(map[string]struct{})}
+}
+
+// Add adds the series with key to the batch, flushing it if it's full.
+func (b *SeriesBatch) Add(key []byte) error {
+	if _, ok := b.seen[string(key)]; ok {
+		return nil
+	}
+	b.seen[string(key)] = struct{}{}
+
+	// Copy the key since it may point into a TSM file that's unmapped or
+	// closed before the batch is flushed.
+	key = append([]byte(nil), key...)
+	name, tags := models.ParseKey(key)
+	b.keys = append(b.keys, key)
+	b.names = append(b.names, []byte(name))
+	b.tagsSlice = append(b.tagsSlice, tags)
+	if len(b.keys) < SeriesBatchSize {
+		return nil
+	}
+	return b.Flush()
+}
+
+// Flush creates the batched series in the index.
+func (b *SeriesBatch) Flush() error {
+	if len(b.keys) == 0 {
+		return nil
+	}
+	err := b.idx.CreateSeriesListIfNotExists(b.keys, b.names, b.tagsSlice)
+	b.seen = make(map[string]struct{})
+	b.keys, b.names, b.tagsSlice = b.keys[:0], b.names[:0], b.tagsSlice[:0]
+	return err
+}
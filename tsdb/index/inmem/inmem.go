@@ -817,7 +817,7 @@ func (idx *ShardIndex) CreateSeriesListIfNotExists(keys, names [][]byte, tagsSli
 
 	var reason string
 	var dropped int
-	var droppedKeys map[string]struct{}
+	var droppedKeys map[string]tsdb.DropReason
 
 	// Ensure that no tags go over the maximum cardinality.
 	if maxValuesPerTag := idx.opt.Config.MaxValuesPerTag; maxValuesPerTag > 0 {
@@ -843,9 +843,9 @@ func (idx *ShardIndex) CreateSeriesListIfNotExists(keys, names [][]byte, tagsSli
 					n, maxValuesPerTag, name, string(tag.Key), string(tag.Value))
 
 				if droppedKeys == nil {
-					droppedKeys = make(map[string]struct{})
+					droppedKeys = make(map[string]tsdb.DropReason)
 				}
-				droppedKeys[string(keys[i])] = struct{}{}
+				droppedKeys[string(keys[i])] = tsdb.DropReason{Code: tsdb.DropReasonMaxValuesPerTag, Message: reason}
 				continue outer
 			}
 
@@ -864,9 +864,9 @@ func (idx *ShardIndex) CreateSeriesListIfNotExists(keys, names [][]byte, tagsSli
 			dropped++
 			reason = fmt.Sprintf("max-series-per-database limit exceeded: (%d)", idx.opt.Config.MaxSeriesPerDatabase)
 			if droppedKeys == nil {
-				droppedKeys = make(map[string]struct{})
+				droppedKeys = make(map[string]tsdb.DropReason)
 			}
-			droppedKeys[string(keys[i])] = struct{}{}
+			droppedKeys[string(keys[i])] = tsdb.DropReason{Code: tsdb.DropReasonMaxSeriesPerDatabase, Message: reason}
 			continue
 		} else if err != nil {
 			return err
@@ -895,6 +895,16 @@ func (i *ShardIndex) CreateSeriesIfNotExists(key, name []byte, tags models.Tags)
 	return i.Index.CreateSeriesIfNotExists(i.id, key, name, tags, &i.opt, false)
 }
 
+// ShardMeasurementSeriesKeys returns the keys of the series of measurement
+// name that belong to the shard.
+func (i *ShardIndex) ShardMeasurementSeriesKeys(name []byte) ([][]byte, error) {
+	m, err := i.Index.Measurement(name)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return m.ShardSeriesKeys(i.id), nil
+}
+
 // TagSets returns a list of tag sets based on series filtering.
 func (i *ShardIndex) TagSets(name []byte, opt influxql.IteratorOptions) ([]*influxql.TagSet, error) {
 	return i.Index.TagSets(i.id, name, opt)
@@ -115,6 +115,20 @@ func (m *Measurement) SeriesKeys() [][]byte {
 	return keys
 }
 
+// ShardSeriesKeys returns the keys of the series in this measurement that are
+// assigned to shard shardID.
+func (m *Measurement) ShardSeriesKeys(shardID uint64) [][]byte {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var keys [][]byte
+	for _, s := range m.seriesByID {
+		if s.Assigned(shardID) {
+			keys = append(keys, []byte(s.Key))
+		}
+	}
+	return keys
+}
+
 func (m *Measurement) SeriesIDs() SeriesIDs {
 	m.mu.RLock()
 	if len(m.sortedSeriesIDs) == len(m.seriesByID) {
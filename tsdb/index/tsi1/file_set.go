@@ -394,6 +394,19 @@ func (fs *FileSet) TagValueIterator(name, key []byte) TagValueIterator {
 	return MergeTagValueIterators(a...)
 }
 
+// TagValueIteratorByRegex returns an iterator over the values of a tag key
+// which match re.
+func (fs *FileSet) TagValueIteratorByRegex(name, key []byte, re *regexp.Regexp) TagValueIterator {
+	a := make([]TagValueIterator, 0, len(fs.files))
+	for _, f := range fs.files {
+		itr := f.TagValueIteratorByRegex(name, key, re)
+		if itr != nil {
+			a = append(a, itr)
+		}
+	}
+	return MergeTagValueIterators(a...)
+}
+
 // TagValueSeriesIterator returns a series iterator for a single tag value.
 func (fs *FileSet) TagValueSeriesIterator(name, key, value []byte) SeriesIterator {
 	a := make([]SeriesIterator, 0, len(fs.files))
@@ -444,16 +457,14 @@ func (fs *FileSet) matchTagValueEqualEmptySeriesIterator(name, key []byte, value
 }
 
 func (fs *FileSet) matchTagValueEqualNotEmptySeriesIterator(name, key []byte, value *regexp.Regexp) SeriesIterator {
-	vitr := fs.TagValueIterator(name, key)
+	vitr := fs.TagValueIteratorByRegex(name, key, value)
 	if vitr == nil {
 		return nil
 	}
 
 	var itrs []SeriesIterator
 	for e := vitr.Next(); e != nil; e = vitr.Next() {
-		if value.Match(e.Value()) {
-			itrs = append(itrs, fs.TagValueSeriesIterator(name, key, e.Value()))
-		}
+		itrs = append(itrs, fs.TagValueSeriesIterator(name, key, e.Value()))
 	}
 	return MergeSeriesIterators(itrs...)
 }
@@ -474,16 +485,14 @@ func (fs *FileSet) matchTagValueNotEqualEmptySeriesIterator(name, key []byte, va
 }
 
 func (fs *FileSet) matchTagValueNotEqualNotEmptySeriesIterator(name, key []byte, value *regexp.Regexp) SeriesIterator {
-	vitr := fs.TagValueIterator(name, key)
+	vitr := fs.TagValueIteratorByRegex(name, key, value)
 	if vitr == nil {
 		return fs.MeasurementSeriesIterator(name)
 	}
 
 	var itrs []SeriesIterator
 	for e := vitr.Next(); e != nil; e = vitr.Next() {
-		if value.Match(e.Value()) {
-			itrs = append(itrs, fs.TagValueSeriesIterator(name, key, e.Value()))
-		}
+		itrs = append(itrs, fs.TagValueSeriesIterator(name, key, e.Value()))
 	}
 
 	return DifferenceSeriesIterators(
@@ -610,16 +619,11 @@ func (fs *FileSet) measurementNamesByTagFilter(op influxql.Token, key, val strin
 				tagMatch = true
 			}
 		} else {
-			// Else, the operator is a regex and we have to check all tag
-			// values against the regular expression.
-			vitr := fs.TagValueIterator(me.Name(), []byte(key))
-			if vitr != nil {
-				for ve := vitr.Next(); ve != nil; ve = vitr.Next() {
-					if regex.Match(ve.Value()) {
-						tagMatch = true
-						break
-					}
-				}
+			// Else, the operator is a regex and we have to check the tag
+			// values which may match the regular expression.
+			vitr := fs.TagValueIteratorByRegex(me.Name(), []byte(key), regex)
+			if vitr != nil && vitr.Next() != nil {
+				tagMatch = true
 			}
 		}
 
@@ -652,6 +656,34 @@ func (fs *FileSet) HasSeries(name []byte, tags models.Tags, buf []byte) bool {
 	return false
 }
 
+// Series returns the latest element for a series, which may be tombstoned.
+// Returns nil if the series doesn't exist.
+func (fs *FileSet) Series(name []byte, tags models.Tags, buf []byte) SeriesElem {
+	currentLevel, skipLevel := -1, false
+	for _, f := range fs.files {
+		// Check for existence on the level when it changes.
+		if level := f.Level(); currentLevel != level {
+			currentLevel, skipLevel = level, false
+
+			if level < len(fs.filters) && fs.filters[level] != nil {
+				if !fs.filters[level].Contains(AppendSeriesKey(buf[:0], name, tags)) {
+					skipLevel = true
+				}
+			}
+		}
+
+		// Skip file if in level where it doesn't exist.
+		if skipLevel {
+			continue
+		}
+
+		if e := f.Series(name, tags); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
 // FilterNamesTags filters out any series which already exist. It modifies the
 // provided slices of names and tags.
 func (fs *FileSet) FilterNamesTags(names [][]byte, tagsSlice []models.Tags) ([][]byte, []models.Tags) {
@@ -743,7 +775,13 @@ func (fs *FileSet) MeasurementSeriesByExprIterator(name []byte, expr influxql.Ex
 }
 
 // MeasurementSeriesKeysByExpr returns a list of series keys matching expr.
+// Series without points in the time range of expr are skipped.
 func (fs *FileSet) MeasurementSeriesKeysByExpr(name []byte, expr influxql.Expr, fieldset *tsdb.MeasurementFieldSet) ([][]byte, error) {
+	min, max, err := influxql.TimeRangeAsEpochNano(expr)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create iterator for all matching series.
 	itr, err := fs.MeasurementSeriesByExprIterator(name, expr, fieldset)
 	if err != nil {
@@ -761,6 +799,10 @@ func (fs *FileSet) MeasurementSeriesKeysByExpr(name []byte, expr influxql.Expr,
 			return nil, errors.New("fields not supported in WHERE clause during deletion")
 		}
 
+		if !SeriesElemOverlaps(e, min, max) {
+			continue
+		}
+
 		keys = append(keys, models.MakeKey(e.Name(), e.Tags()))
 	}
 	return keys, nil
@@ -968,6 +1010,7 @@ type File interface {
 
 	TagValue(name, key, value []byte) TagValueElem
 	TagValueIterator(name, key []byte) TagValueIterator
+	TagValueIteratorByRegex(name, key []byte, re *regexp.Regexp) TagValueIterator
 
 	// Series iteration.
 	SeriesIterator() SeriesIterator
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -30,6 +31,10 @@ const (
 	DefaultMaxLogFileSize = 5 * 1024 * 1024
 )
 
+// DefaultSeriesTimeResolution is the default granularity of the time ranges
+// recorded for series.
+const DefaultSeriesTimeResolution = time.Hour
+
 func init() {
 	tsdb.RegisterIndex(IndexName, func(id uint64, database, path string, opt tsdb.EngineOptions) tsdb.Index {
 		idx := NewIndex()
@@ -89,6 +94,11 @@ type Index struct {
 	// Log file compaction thresholds.
 	MaxLogFileSize int64
 
+	// Granularity of series time ranges. Ranges are widened to multiples of
+	// the resolution so series written to regularly are only updated once
+	// per interval.
+	SeriesTimeResolution time.Duration
+
 	// Frequency of compaction checks.
 	CompactionEnabled         bool
 	CompactionMonitorInterval time.Duration
@@ -102,8 +112,9 @@ func NewIndex() *Index {
 		closing: make(chan struct{}),
 
 		// Default compaction thresholds.
-		MaxLogFileSize:    DefaultMaxLogFileSize,
-		CompactionEnabled: true,
+		MaxLogFileSize:       DefaultMaxLogFileSize,
+		SeriesTimeResolution: DefaultSeriesTimeResolution,
+		CompactionEnabled:    true,
 
 		logger: zap.New(zap.NullEncoder()),
 	}
@@ -495,6 +506,89 @@ func (i *Index) CreateSeriesListIfNotExists(_, names [][]byte, tagsSlice []model
 	return i.CheckLogFile()
 }
 
+// CreateSeriesListWithTimeRanges creates a list of series if they don't exist
+// and extends the time range of each series to include mins & maxs.
+func (i *Index) CreateSeriesListWithTimeRanges(_, names [][]byte, tagsSlice []models.Tags, mins, maxs []int64) error {
+	// All slices must be of equal length.
+	if len(names) != len(tagsSlice) || len(names) != len(mins) || len(names) != len(maxs) {
+		return errors.New("names/tags/times length mismatch")
+	}
+
+	if err := func() error {
+		// Hold the lock so the file set and active log file can't change
+		// between reading and extending a time range.
+		i.mu.RLock()
+		defer i.mu.RUnlock()
+
+		fs := i.retainFileSet()
+		defer fs.Release()
+
+		// Filter out series whose time range already covers the points.
+		var buf []byte
+		newNames, newTagsSlice := names[:0], tagsSlice[:0]
+		newMins, newMaxs := mins[:0], maxs[:0]
+		for j := range names {
+			min, max := roundTimeRange(mins[j], maxs[j], i.SeriesTimeResolution)
+
+			if e := fs.Series(names[j], tagsSlice[j], buf); e != nil && !e.Deleted() {
+				emin, emax := e.TimeRange()
+				if emin <= min && emax >= max {
+					continue
+				}
+				if emin < min {
+					min = emin
+				}
+				if emax > max {
+					max = emax
+				}
+			}
+
+			newNames, newTagsSlice = append(newNames, names[j]), append(newTagsSlice, tagsSlice[j])
+			newMins, newMaxs = append(newMins, min), append(newMaxs, max)
+		}
+		if len(newNames) == 0 {
+			return nil
+		}
+
+		return i.activeLogFile.AddSeriesTimeRanges(newNames, newTagsSlice, newMins, newMaxs)
+	}(); err != nil {
+		return err
+	}
+
+	// Swap log file, if necesssary.
+	return i.CheckLogFile()
+}
+
+// roundTimeRange widens min & max to multiples of resolution.
+func roundTimeRange(min, max int64, resolution time.Duration) (int64, int64) {
+	r := int64(resolution)
+	if r <= 0 {
+		return min, max
+	}
+
+	// Integer division truncates towards zero so adjust in the other direction.
+	if q := min / r * r; q > min {
+		if q < math.MinInt64+r {
+			min = math.MinInt64
+		} else {
+			min = q - r
+		}
+	} else {
+		min = q
+	}
+
+	if q := max / r * r; q < max {
+		if q > math.MaxInt64-r {
+			max = math.MaxInt64
+		} else {
+			max = q + r
+		}
+	} else {
+		max = q
+	}
+	return min, max
+}
+
 // InitializeSeries is a no-op. This only applies to the in-memory index.
 func (i *Index) InitializeSeries(key, name []byte, tags models.Tags) error {
 	return nil
@@ -738,6 +832,15 @@ func (i *Index) TagSets(name []byte, opt influxql.IteratorOptions) ([]*influxql.
 
 	if itr != nil {
 		for e := itr.Next(); e != nil; e = itr.Next() {
+			// Skip series without points in the query's time range.
+			if !SeriesElemOverlaps(e, opt.StartTime, opt.EndTime) {
+				continue
+			}
+
+			if opt.Authorizer != nil && !opt.Authorizer.AuthorizeSeriesRead(i.Database, e.Name(), e.Tags()) {
+				continue
+			}
+
 			tags := make(map[string]string, len(opt.Dimensions))
 
 			// Build the TagSet for this series.
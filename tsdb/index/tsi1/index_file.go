@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"sync"
 
 	"github.com/influxdata/influxdb/models"
@@ -15,7 +16,11 @@ import (
 )
 
 // IndexFileVersion is the current TSI1 index file version.
-const IndexFileVersion = 1
+// Version 2 adds the time range of each series to the series block.
+const IndexFileVersion = 2
+
+// MinIndexFileVersion is the oldest TSI1 index file version that can be read.
+const MinIndexFileVersion = 1
 
 // FileSignature represents a magic number at the header of the index file.
 const FileSignature = "TSI1"
@@ -152,6 +157,13 @@ func (f *IndexFile) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
+	// Ensure the blocks are within the file.
+	for _, blk := range []struct{ Offset, Size int64 }{t.SeriesBlock, t.MeasurementBlock} {
+		if blk.Offset < 0 || blk.Size < 0 || blk.Offset+blk.Size > int64(len(data)) {
+			return io.ErrShortBuffer
+		}
+	}
+
 	// Slice measurement block data.
 	buf := data[t.MeasurementBlock.Offset:]
 	buf = buf[:t.MeasurementBlock.Size]
@@ -231,6 +243,22 @@ func (f *IndexFile) TagValueIterator(name, key []byte) TagValueIterator {
 	return ke.TagValueIterator()
 }
 
+// TagValueIteratorByRegex returns a value iterator for a tag key over the
+// values matching re. The trigram index is used to skip values, if available.
+func (f *IndexFile) TagValueIteratorByRegex(name, key []byte, re *regexp.Regexp) TagValueIterator {
+	tblk := f.tblks[string(name)]
+	if tblk == nil {
+		return nil
+	}
+
+	// Find key element.
+	ke, _ := tblk.TagKeyElem(key).(*TagBlockKeyElem)
+	if ke == nil {
+		return nil
+	}
+	return ke.TagValueIteratorByRegex(re)
+}
+
 // TagKeySeriesIterator returns a series iterator for a tag key and a flag
 // indicating if a tombstone exists on the measurement or key.
 func (f *IndexFile) TagKeySeriesIterator(name, key []byte) SeriesIterator {
@@ -371,10 +399,13 @@ func (f *IndexFile) MergeSeriesSketches(s, t estimator.Sketch) error {
 // ReadIndexFileTrailer returns the index file trailer from data.
 func ReadIndexFileTrailer(data []byte) (IndexFileTrailer, error) {
 	var t IndexFileTrailer
+	if len(data) < IndexFileTrailerSize {
+		return t, io.ErrShortBuffer
+	}
 
 	// Read version.
 	t.Version = int(binary.BigEndian.Uint16(data[len(data)-IndexFileVersionSize:]))
-	if t.Version != IndexFileVersion {
+	if t.Version < MinIndexFileVersion || t.Version > IndexFileVersion {
 		return t, ErrUnsupportedIndexFileVersion
 	}
 
@@ -201,7 +201,8 @@ func (p IndexFiles) writeSeriesBlockTo(w io.Writer, m, k uint64, info *indexComp
 
 	// Write all series.
 	for e := itr.Next(); e != nil; e = itr.Next() {
-		if err := enc.Encode(e.Name(), e.Tags(), e.Deleted()); err != nil {
+		min, max := e.TimeRange()
+		if err := enc.Encode(e.Name(), e.Tags(), e.Deleted(), min, max); err != nil {
 			return err
 		}
 	}
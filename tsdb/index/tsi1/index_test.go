@@ -5,7 +5,9 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
@@ -243,6 +245,68 @@ func TestIndex_DropMeasurement(t *testing.T) {
 	})
 }
 
+// Ensure index records series time ranges and skips series outside a query's time range.
+func TestIndex_SeriesTimeRanges(t *testing.T) {
+	idx := MustOpenIndex()
+	defer idx.Close()
+
+	hour := int64(time.Hour)
+	if err := idx.CreateSeriesListWithTimeRanges(nil,
+		[][]byte{[]byte("cpu"), []byte("cpu")},
+		[]models.Tags{
+			models.NewTags(map[string]string{"region": "east"}),
+			models.NewTags(map[string]string{"region": "west"}),
+		},
+		[]int64{0, 10 * hour},
+		[]int64{hour / 2, 10*hour + 1},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Extend the range of the first series.
+	if err := idx.CreateSeriesListWithTimeRanges(nil,
+		[][]byte{[]byte("cpu")},
+		[]models.Tags{models.NewTags(map[string]string{"region": "east"})},
+		[]int64{5 * hour},
+		[]int64{5 * hour},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	idx.Run(t, func(t *testing.T) {
+		for _, tt := range []struct {
+			min, max int64
+			exp      []string
+		}{
+			{min: influxql.MinTime, max: influxql.MaxTime, exp: []string{"cpu,region=east", "cpu,region=west"}},
+			{min: 2 * hour, max: 3 * hour, exp: []string{"cpu,region=east"}},
+			{min: 6 * hour, max: 10 * hour, exp: []string{"cpu,region=west"}},
+			{min: 12 * hour, max: 13 * hour, exp: nil},
+		} {
+			tagSets, err := idx.TagSets([]byte("cpu"), influxql.IteratorOptions{StartTime: tt.min, EndTime: tt.max})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var keys []string
+			for _, ts := range tagSets {
+				keys = append(keys, ts.SeriesKeys...)
+			}
+			sort.Strings(keys)
+			if !reflect.DeepEqual(keys, tt.exp) {
+				t.Fatalf("unexpected keys for [%d,%d]: %v", tt.min, tt.max, keys)
+			}
+		}
+
+		expr := influxql.MustParseExpr(fmt.Sprintf("time >= %d AND time < %d", 6*hour, 7*hour))
+		if keys, err := idx.MeasurementSeriesKeysByExpr([]byte("cpu"), expr); err != nil {
+			t.Fatal(err)
+		} else if len(keys) != 0 {
+			t.Fatalf("unexpected keys: %q", keys)
+		}
+	})
+}
+
 // Index is a test wrapper for tsi1.Index.
 type Index struct {
 	*tsi1.Index
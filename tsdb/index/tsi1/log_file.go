@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"os"
+	"regexp"
 	"sort"
 	"sync"
 	"time"
@@ -33,6 +35,9 @@ const (
 	LogEntryMeasurementTombstoneFlag = 0x02
 	LogEntryTagKeyTombstoneFlag      = 0x04
 	LogEntryTagValueTombstoneFlag    = 0x08
+
+	// Marks a series entry as followed by the min & max time of its points.
+	LogEntrySeriesTimeRangeFlag = 0x10
 )
 
 // LogFile represents an on-disk write-ahead log file.
@@ -47,6 +52,7 @@ type LogFile struct {
 
 	size    int64     // tracks current file size
 	modTime time.Time // tracks last time write occurred
+	partial bool      // partial write skipped by OpenReadOnly
 
 	mSketch, mTSketch estimator.Sketch // Measurement sketches
 	sSketch, sTSketch estimator.Sketch // Series sketche
@@ -72,29 +78,49 @@ func NewLogFile(path string) *LogFile {
 
 // Open reads the log from a file and validates all the checksums.
 func (f *LogFile) Open() error {
-	if err := f.open(); err != nil {
+	if err := f.open(false); err != nil {
+		f.Close()
+		return err
+	}
+	return nil
+}
+
+// OpenReadOnly reads the log from an existing file without modifying it.  A
+// partial write at the end of the file is skipped instead of truncated and is
+// reported by PartialWrite.  The file can't be written to.
+func (f *LogFile) OpenReadOnly() error {
+	if err := f.open(true); err != nil {
 		f.Close()
 		return err
 	}
 	return nil
 }
 
-func (f *LogFile) open() error {
+func (f *LogFile) open(readOnly bool) error {
 	f.id, _ = ParseFilename(f.path)
 
-	// Open file for appending.
-	file, err := os.OpenFile(f.Path(), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
-	if err != nil {
-		return err
+	var fi os.FileInfo
+	if readOnly {
+		var err error
+		if fi, err = os.Stat(f.Path()); err != nil {
+			return err
+		}
+	} else {
+		// Open file for appending.
+		file, err := os.OpenFile(f.Path(), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+		if err != nil {
+			return err
+		}
+		f.file = file
+		f.w = bufio.NewWriter(f.file)
+
+		if fi, err = file.Stat(); err != nil {
+			return err
+		}
 	}
-	f.file = file
-	f.w = bufio.NewWriter(f.file)
 
 	// Finish opening if file is empty.
-	fi, err := file.Stat()
-	if err != nil {
-		return err
-	} else if fi.Size() == 0 {
+	if fi.Size() == 0 {
 		return nil
 	}
 	f.size = fi.Size()
@@ -113,9 +139,13 @@ func (f *LogFile) open() error {
 		// Read next entry. Truncate partial writes.
 		var e LogEntry
 		if err := e.UnmarshalBinary(buf); err == io.ErrShortBuffer {
-			if err := file.Truncate(n); err != nil {
+			if readOnly {
+				f.partial = true
+				break
+			}
+			if err := f.file.Truncate(n); err != nil {
 				return err
-			} else if _, err := file.Seek(0, io.SeekEnd); err != nil {
+			} else if _, err := f.file.Seek(0, io.SeekEnd); err != nil {
 				return err
 			}
 			break
@@ -158,6 +188,10 @@ func (f *LogFile) Close() error {
 	return nil
 }
 
+// PartialWrite returns true if a partial write was skipped at the end of a
+// file opened with OpenReadOnly.
+func (f *LogFile) PartialWrite() bool { return f.partial }
+
 // Flush flushes buffered data to disk.
 func (f *LogFile) Flush() error {
 	if f.w != nil {
@@ -347,6 +381,12 @@ func (f *LogFile) TagValueIterator(name, key []byte) TagValueIterator {
 	return tk.TagValueIterator()
 }
 
+// TagValueIteratorByRegex returns a value iterator for a tag key over the
+// values matching re.
+func (f *LogFile) TagValueIteratorByRegex(name, key []byte, re *regexp.Regexp) TagValueIterator {
+	return newRegexTagValueIterator(f.TagValueIterator(name, key), re)
+}
+
 // DeleteTagKey adds a tombstone for a tag key to the log file.
 func (f *LogFile) DeleteTagKey(name, key []byte) error {
 	f.mu.Lock()
@@ -460,6 +500,49 @@ func (f *LogFile) AddSeries(name []byte, tags models.Tags) error {
 	return nil
 }
 
+// AddSeriesTimeRanges adds series to the log file or extends the time range of
+// existing series to include min & max. Only series which are new or whose
+// time range changes are written to the log.
+func (f *LogFile) AddSeriesTimeRanges(names [][]byte, tagsSlice []models.Tags, mins, maxs []int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var buf []byte
+	for i := range names {
+		min, max := mins[i], maxs[i]
+
+		// Merge with the series' range in this file since it's the latest.
+		if mm := f.mms[string(names[i])]; mm != nil {
+			buf = AppendSeriesKey(buf[:0], names[i], tagsSlice[i])
+			if s := mm.series[string(buf)]; s != nil && !s.deleted {
+				if s.minTime <= min && s.maxTime >= max {
+					continue
+				}
+				if s.minTime < min {
+					min = s.minTime
+				}
+				if s.maxTime > max {
+					max = s.maxTime
+				}
+			}
+		}
+
+		// The name and tags are clone to prevent a memory leak
+		e := LogEntry{
+			Flag:    LogEntrySeriesTimeRangeFlag,
+			Name:    []byte(string(names[i])),
+			Tags:    tagsSlice[i].Clone(),
+			MinTime: min,
+			MaxTime: max,
+		}
+		if err := f.appendEntry(&e); err != nil {
+			return err
+		}
+		f.execEntry(&e)
+	}
+	return nil
+}
+
 // DeleteSeries adds a tombstone for a series to the log file.
 func (f *LogFile) DeleteSeries(name []byte, tags models.Tags) error {
 	f.mu.Lock()
@@ -543,7 +626,10 @@ func (f *LogFile) SeriesWithBuffer(name []byte, tags models.Tags, buf []byte) Se
 	if s == nil {
 		return nil
 	}
-	return s
+
+	// Return a copy since the series is updated under the lock.
+	other := *s
+	return &other
 }
 
 // appendEntry adds a log entry to the end of the file.
@@ -636,9 +722,15 @@ func (f *LogFile) execSeriesEntry(e *LogEntry) {
 		mm.deleted = false
 	}
 
+	// Series without a time range cover all time.
+	min, max := int64(math.MinInt64), int64(math.MaxInt64)
+	if (e.Flag & LogEntrySeriesTimeRangeFlag) != 0 {
+		min, max = e.MinTime, e.MaxTime
+	}
+
 	// Generate key & series, if not exists.
 	key := AppendSeriesKey(nil, e.Name, e.Tags)
-	serie := mm.createSeriesIfNotExists(key, e.Name, e.Tags, deleted)
+	serie := mm.createSeriesIfNotExists(key, e.Name, e.Tags, deleted, min, max)
 
 	// Save tags.
 	for _, t := range e.Tags {
@@ -843,7 +935,7 @@ func (f *LogFile) writeSeriesBlockTo(w io.Writer, names []string, m, k uint64, i
 
 		for _, key := range keys {
 			serie := mm.series[string(key)]
-			if err := enc.Encode(serie.name, serie.tags, serie.deleted); err != nil {
+			if err := enc.Encode(serie.name, serie.tags, serie.deleted, serie.minTime, serie.maxTime); err != nil {
 				return err
 			}
 		}
@@ -1063,7 +1155,9 @@ type LogEntry struct {
 	Flag     byte        // flag
 	Name     []byte      // measurement name
 	Tags     models.Tags // tagset
-	Checksum uint32      // checksum of flag/name/tags.
+	MinTime  int64       // min time of series points, if LogEntrySeriesTimeRangeFlag is set
+	MaxTime  int64       // max time of series points, if LogEntrySeriesTimeRangeFlag is set
+	Checksum uint32      // checksum of flag/name/tags/time range.
 	Size     int         // total size of record, in bytes.
 }
 
@@ -1128,6 +1222,19 @@ func (e *LogEntry) UnmarshalBinary(data []byte) error {
 	}
 	e.Tags = tags
 
+	// Parse time range.
+	if (e.Flag & LogEntrySeriesTimeRangeFlag) != 0 {
+		if e.MinTime, n = binary.Varint(data); n <= 0 {
+			return io.ErrShortBuffer
+		}
+		data = data[n:]
+
+		if e.MaxTime, n = binary.Varint(data); n <= 0 {
+			return io.ErrShortBuffer
+		}
+		data = data[n:]
+	}
+
 	// Compute checksum.
 	chk := crc32.ChecksumIEEE(orig[:start-len(data)])
 
@@ -1181,6 +1288,11 @@ func appendLogEntry(dst []byte, e *LogEntry) []byte {
 		dst = append(dst, t.Value...)
 	}
 
+	// Append time range.
+	if (e.Flag & LogEntrySeriesTimeRangeFlag) != 0 {
+		dst = appendSeriesTimeRange(dst, e.MinTime, e.MaxTime)
+	}
+
 	// Calculate checksum.
 	e.Checksum = crc32.ChecksumIEEE(dst[start:])
 
@@ -1195,16 +1307,19 @@ type logSerie struct {
 	name    []byte
 	tags    models.Tags
 	deleted bool
+	minTime int64
+	maxTime int64
 }
 
 func (s *logSerie) String() string {
 	return fmt.Sprintf("key: %s tags: %v", s.name, s.tags)
 }
 
-func (s *logSerie) Name() []byte        { return s.name }
-func (s *logSerie) Tags() models.Tags   { return s.tags }
-func (s *logSerie) Deleted() bool       { return s.deleted }
-func (s *logSerie) Expr() influxql.Expr { return nil }
+func (s *logSerie) Name() []byte                { return s.name }
+func (s *logSerie) Tags() models.Tags           { return s.tags }
+func (s *logSerie) Deleted() bool               { return s.deleted }
+func (s *logSerie) TimeRange() (min, max int64) { return s.minTime, s.maxTime }
+func (s *logSerie) Expr() influxql.Expr         { return nil }
 func (s *logSerie) Compare(name []byte, tags models.Tags) int {
 	if cmp := bytes.Compare(s.name, name); cmp != 0 {
 		return cmp
@@ -1252,13 +1367,14 @@ func (m *logMeasurement) createTagSetIfNotExists(key []byte) logTagKey {
 }
 
 // createSeriesIfNotExists creates or returns an existing series on the measurement.
-func (m *logMeasurement) createSeriesIfNotExists(key []byte, name []byte, tags models.Tags, deleted bool) *logSerie {
+// The series' time range is replaced by min & max.
+func (m *logMeasurement) createSeriesIfNotExists(key []byte, name []byte, tags models.Tags, deleted bool, min, max int64) *logSerie {
 	s := m.series[string(key)]
 	if s == nil {
-		s = &logSerie{name: name, tags: tags, deleted: deleted}
+		s = &logSerie{name: name, tags: tags, deleted: deleted, minTime: min, maxTime: max}
 		m.series[string(key)] = s
 	} else {
-		s.deleted = deleted
+		s.deleted, s.minTime, s.maxTime = deleted, min, max
 	}
 	return s
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"sort"
 
@@ -46,6 +47,9 @@ const (
 	// Marks the following bytes as a hash index.
 	// These bytes should be skipped by an iterator.
 	SeriesHashIndexFlag = 0x02
+
+	// Marks the series as followed by the min & max time of its points.
+	SeriesTimeRangeFlag = 0x04
 )
 
 // MaxSeriesBlockHashSize is the maximum number of series in a single hash.
@@ -309,10 +313,12 @@ func (itr *seriesDecodeIterator) Next() SeriesElem {
 
 // SeriesBlockElem represents a series element in the series list.
 type SeriesBlockElem struct {
-	flag byte
-	name []byte
-	tags models.Tags
-	size int
+	flag    byte
+	name    []byte
+	tags    models.Tags
+	minTime int64
+	maxTime int64
+	size    int
 }
 
 // Deleted returns true if the tombstone flag is set.
@@ -324,6 +330,15 @@ func (e *SeriesBlockElem) Name() []byte { return e.name }
 // Tags returns the tag set.
 func (e *SeriesBlockElem) Tags() models.Tags { return e.tags }
 
+// TimeRange returns the min & max time of the series' points.
+// Series without a recorded time range cover all time.
+func (e *SeriesBlockElem) TimeRange() (min, max int64) {
+	if (e.flag & SeriesTimeRangeFlag) == 0 {
+		return math.MinInt64, math.MaxInt64
+	}
+	return e.minTime, e.maxTime
+}
+
 // Expr always returns a nil expression.
 // This is only used by higher level query planning.
 func (e *SeriesBlockElem) Expr() influxql.Expr { return nil }
@@ -360,6 +375,14 @@ func (e *SeriesBlockElem) UnmarshalBinary(data []byte) error {
 		e.tags = append(e.tags, tag)
 	}
 
+	// Parse time range.
+	if (e.flag & SeriesTimeRangeFlag) != 0 {
+		e.minTime, szN = binary.Varint(data)
+		data = data[szN:]
+		e.maxTime, szN = binary.Varint(data)
+		data = data[szN:]
+	}
+
 	// Save length of elem.
 	e.size = start - len(data)
 
@@ -372,6 +395,16 @@ func AppendSeriesElem(dst []byte, flag byte, name []byte, tags models.Tags) []by
 	return AppendSeriesKey(dst, name, tags)
 }
 
+// appendSeriesTimeRange serializes the min & max time of a series to dst and
+// returns the new buffer.
+func appendSeriesTimeRange(dst []byte, min, max int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], min)
+	dst = append(dst, buf[:n]...)
+	n = binary.PutVarint(buf[:], max)
+	return append(dst, buf[:n]...)
+}
+
 // AppendSeriesKey serializes name and tags to a byte slice.
 // The total length is prepended as a uvarint.
 func AppendSeriesKey(dst []byte, name []byte, tags models.Tags) []byte {
@@ -558,21 +591,32 @@ func NewSeriesBlockEncoder(w io.Writer, n uint32, m, k uint64) *SeriesBlockEncod
 // N returns the number of bytes written.
 func (enc *SeriesBlockEncoder) N() int64 { return enc.n }
 
-// Encode writes a series to the underlying writer.
+// Encode writes a series and the time range of its points to the underlying
+// writer. The time range is omitted if it covers all time.
 // The series must be lexicographical sorted after the previous encoded series.
-func (enc *SeriesBlockEncoder) Encode(name []byte, tags models.Tags, deleted bool) error {
+func (enc *SeriesBlockEncoder) Encode(name []byte, tags models.Tags, deleted bool, min, max int64) error {
 	// An initial empty byte must be written.
 	if err := enc.ensureHeaderWritten(); err != nil {
 		return err
 	}
 
 	// Generate the series element.
-	buf := AppendSeriesElem(enc.buf[0][:0], encodeSerieFlag(deleted), name, tags)
+	flag := encodeSerieFlag(deleted)
+	hasTimeRange := min != math.MinInt64 || max != math.MaxInt64
+	if hasTimeRange {
+		flag |= SeriesTimeRangeFlag
+	}
+	buf := AppendSeriesElem(enc.buf[0][:0], flag, name, tags)
+	elem := buf // flag & key, excluding the time range
+	if hasTimeRange {
+		buf = appendSeriesTimeRange(buf, min, max)
+	}
+	key := buf[1:len(elem)]
 
 	// Verify series is after previous series.
 	if enc.buf[1] != nil {
 		// Skip the first byte since it is the flag. Remaining bytes are key.
-		key0, key1 := buf[1:], enc.buf[1][1:]
+		key0, key1 := key, enc.buf[1][1:]
 
 		if cmp := CompareSeriesKeys(key0, key1); cmp == -1 {
 			return fmt.Errorf("series out of order: prev=%q, new=%q", enc.buf[1], buf)
@@ -582,7 +626,7 @@ func (enc *SeriesBlockEncoder) Encode(name []byte, tags models.Tags, deleted boo
 	}
 
 	// Flush a hash index, if necessary.
-	if err := enc.checkFlushIndex(buf[1:]); err != nil {
+	if err := enc.checkFlushIndex(key); err != nil {
 		return err
 	}
 
@@ -597,18 +641,18 @@ func (enc *SeriesBlockEncoder) Encode(name []byte, tags models.Tags, deleted boo
 
 	// Save offset to generate index later.
 	// Key is copied by the RHH map.
-	enc.offsets.Put(buf[1:], uint32(offset))
+	enc.offsets.Put(key, uint32(offset))
 
 	// Update bloom filter.
-	enc.filter.Insert(buf[1:])
+	enc.filter.Insert(key)
 
 	// Update sketches & trailer.
 	if deleted {
 		enc.trailer.TombstoneN++
-		enc.tSketch.Add(buf)
+		enc.tSketch.Add(elem)
 	} else {
 		enc.trailer.SeriesN++
-		enc.sketch.Add(buf)
+		enc.sketch.Add(elem)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package tsi1_test
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/influxdata/influxdb/models"
@@ -51,6 +52,47 @@ func TestSeriesBlock_Series(t *testing.T) {
 	}
 }
 
+// Ensure series block stores the time range of series.
+func TestSeriesBlock_TimeRange(t *testing.T) {
+	var buf bytes.Buffer
+	enc := tsi1.NewSeriesBlockEncoder(&buf, 2, M, K)
+	if err := enc.Encode([]byte("cpu"), models.NewTags(map[string]string{"region": "east"}), false, -10, 20); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Encode([]byte("cpu"), models.NewTags(map[string]string{"region": "west"}), false, math.MinInt64, math.MaxInt64); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var blk tsi1.SeriesBlock
+	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	if e := blk.Series([]byte("cpu"), models.NewTags(map[string]string{"region": "east"})); e == nil {
+		t.Fatal("expected series")
+	} else if min, max := e.TimeRange(); min != -10 || max != 20 {
+		t.Fatalf("unexpected time range: %d-%d", min, max)
+	}
+
+	if e := blk.Series([]byte("cpu"), models.NewTags(map[string]string{"region": "west"})); e == nil {
+		t.Fatal("expected series")
+	} else if min, max := e.TimeRange(); min != math.MinInt64 || max != math.MaxInt64 {
+		t.Fatalf("unexpected time range: %d-%d", min, max)
+	}
+
+	// Iteration skips over the time ranges.
+	itr := blk.SeriesIterator()
+	for _, region := range []string{"east", "west"} {
+		if e := itr.Next(); e == nil || e.Tags().GetString("region") != region {
+			t.Fatalf("unexpected series: %v", e)
+		}
+	}
+	if e := itr.Next(); e != nil {
+		t.Fatalf("unexpected series: %v", e)
+	}
+}
+
 // CreateSeriesBlock returns an in-memory SeriesBlock with a list of series.
 func CreateSeriesBlock(a []Series) (*tsi1.SeriesBlock, error) {
 	var buf bytes.Buffer
@@ -58,7 +100,7 @@ func CreateSeriesBlock(a []Series) (*tsi1.SeriesBlock, error) {
 	// Create writer and sketches. Add series.
 	enc := tsi1.NewSeriesBlockEncoder(&buf, uint32(len(a)), M, K)
 	for i, s := range a {
-		if err := enc.Encode(s.Name, s.Tags, s.Deleted); err != nil {
+		if err := enc.Encode(s.Name, s.Tags, s.Deleted, math.MinInt64, math.MaxInt64); err != nil {
 			return nil, fmt.Errorf("SeriesBlockWriter.Add(): i=%d, err=%s", i, err)
 		}
 	}
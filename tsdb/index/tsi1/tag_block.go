@@ -6,16 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
 
 	"github.com/influxdata/influxdb/pkg/rhh"
 )
 
 // TagBlockVersion is the version of the tag block.
-const TagBlockVersion = 1
+// Version 2 adds the optional trigram index for tag keys.
+const TagBlockVersion = 2
+
+// MinTagBlockVersion is the oldest version of the tag block that can be read.
+const MinTagBlockVersion = 1
+
+// DefaultTrigramMinValues is the default number of values a tag key must have
+// for a trigram index to be written for it.
+const DefaultTrigramMinValues = 64
 
 // Tag key flag constants.
 const (
 	TagKeyTombstoneFlag = 0x01
+	TagKeyTrigramFlag   = 0x02
 )
 
 // Tag value flag constants.
@@ -32,6 +43,10 @@ const (
 	// TagBlock value block fields.
 	TagValueNSize      = 8
 	TagValueOffsetSize = 8
+
+	// TagBlock trigram index fields.
+	TrigramNSize         = 8
+	TrigramIndexElemSize = TrigramSize + 8
 )
 
 // TagBlock errors.
@@ -83,6 +98,7 @@ func (blk *TagBlock) UnmarshalBinary(data []byte) error {
 
 	// Save entire block.
 	blk.data = data
+	blk.version = t.Version
 
 	return nil
 }
@@ -227,6 +243,25 @@ func (itr *tagBlockValueIterator) Next() TagValueElem {
 	return &itr.e
 }
 
+// tagBlockOffsetValueIterator represents an iterator over the tag values at a
+// sorted list of offsets in a key's value data.
+type tagBlockOffsetValueIterator struct {
+	data    []byte
+	offsets []uint64
+	e       TagBlockValueElem
+}
+
+// Next returns the next element in the iterator.
+func (itr *tagBlockOffsetValueIterator) Next() TagValueElem {
+	if len(itr.offsets) == 0 {
+		return nil
+	}
+
+	itr.e.unmarshal(itr.data[itr.offsets[0]:])
+	itr.offsets = itr.offsets[1:]
+	return &itr.e
+}
+
 // TagBlockKeyElem represents a tag key element in a TagBlock.
 type TagBlockKeyElem struct {
 	flag byte
@@ -246,6 +281,13 @@ type TagBlockKeyElem struct {
 		buf    []byte
 	}
 
+	// Value trigram index data
+	trigramIndex struct {
+		offset uint64
+		size   uint64
+		buf    []byte
+	}
+
 	size int
 
 	// Reusable iterator.
@@ -263,6 +305,83 @@ func (e *TagBlockKeyElem) TagValueIterator() TagValueIterator {
 	return &tagBlockValueIterator{data: e.data.buf}
 }
 
+// HasTrigramIndex returns true if a trigram index exists for the key's values.
+func (e *TagBlockKeyElem) HasTrigramIndex() bool { return (e.flag & TagKeyTrigramFlag) != 0 }
+
+// TagValueIteratorByRegex returns an iterator over the key's values matching re.
+// Values which can't match re are skipped using the trigram index, if one exists.
+func (e *TagBlockKeyElem) TagValueIteratorByRegex(re *regexp.Regexp) TagValueIterator {
+	var itr TagValueIterator = &tagBlockValueIterator{data: e.data.buf}
+	if e.HasTrigramIndex() {
+		if q := newTrigramQuery(re); q != nil {
+			itr = &tagBlockOffsetValueIterator{
+				data:    e.data.buf,
+				offsets: q.eval(e.TrigramOffsets),
+			}
+		}
+	}
+	return newRegexTagValueIterator(itr, re)
+}
+
+// ForEachTrigram calls fn for each trigram in the key's trigram index with the
+// offsets of the values containing it.
+func (e *TagBlockKeyElem) ForEachTrigram(fn func(t []byte, offsets []uint64) error) error {
+	if !e.HasTrigramIndex() {
+		return nil
+	}
+
+	n := int(binary.BigEndian.Uint64(e.trigramIndex.buf[:TrigramNSize]))
+	for i := 0; i < n; i++ {
+		elem := e.trigramIndex.buf[TrigramNSize+(i*TrigramIndexElemSize):]
+		if err := fn(elem[:TrigramSize], e.trigramOffsetsAt(binary.BigEndian.Uint64(elem[TrigramSize:]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TrigramOffsets returns the sorted offsets of the values containing the
+// trigram t. Offsets are relative to the start of the key's value data.
+func (e *TagBlockKeyElem) TrigramOffsets(t []byte) []uint64 {
+	if !e.HasTrigramIndex() {
+		return nil
+	}
+
+	// Binary search the fixed size trigram elements.
+	n := int(binary.BigEndian.Uint64(e.trigramIndex.buf[:TrigramNSize]))
+	elems := e.trigramIndex.buf[TrigramNSize:]
+	i := sort.Search(n, func(i int) bool {
+		return bytes.Compare(elems[i*TrigramIndexElemSize:i*TrigramIndexElemSize+TrigramSize], t) != -1
+	})
+	if i == n {
+		return nil
+	}
+
+	elem := elems[i*TrigramIndexElemSize:]
+	if !bytes.Equal(elem[:TrigramSize], t) {
+		return nil
+	}
+	return e.trigramOffsetsAt(binary.BigEndian.Uint64(elem[TrigramSize:]))
+}
+
+// trigramOffsetsAt decodes the list of value offsets at pos in the trigram index.
+func (e *TagBlockKeyElem) trigramOffsetsAt(pos uint64) []uint64 {
+	buf := e.trigramIndex.buf[pos:]
+	n, sz := binary.Uvarint(buf)
+	buf = buf[sz:]
+
+	a := make([]uint64, n)
+	var prev uint64
+	for i := range a {
+		delta, sz := binary.Uvarint(buf)
+		buf = buf[sz:]
+
+		prev += delta
+		a[i] = prev
+	}
+	return a
+}
+
 // unmarshal unmarshals buf into e.
 // The data argument represents the entire block data.
 func (e *TagBlockKeyElem) unmarshal(buf, data []byte) {
@@ -287,6 +406,16 @@ func (e *TagBlockKeyElem) unmarshal(buf, data []byte) {
 	e.hashIndex.buf = data[e.hashIndex.offset:]
 	e.hashIndex.buf = e.hashIndex.buf[:e.hashIndex.size]
 
+	// Parse trigram index offset/size & slice data, if available.
+	e.trigramIndex.offset, e.trigramIndex.size, e.trigramIndex.buf = 0, 0, nil
+	if e.HasTrigramIndex() {
+		e.trigramIndex.offset, buf = binary.BigEndian.Uint64(buf), buf[8:]
+		e.trigramIndex.size, buf = binary.BigEndian.Uint64(buf), buf[8:]
+
+		e.trigramIndex.buf = data[e.trigramIndex.offset:]
+		e.trigramIndex.buf = e.trigramIndex.buf[:e.trigramIndex.size]
+	}
+
 	// Parse key.
 	n, sz := binary.Uvarint(buf)
 	e.key, buf = buf[sz:sz+int(n)], buf[int(n)+sz:]
@@ -428,7 +557,7 @@ func (t *TagBlockTrailer) WriteTo(w io.Writer) (n int64, err error) {
 	// Write total size & encoding version.
 	if err := writeUint64To(w, uint64(t.Size), &n); err != nil {
 		return n, err
-	} else if err := writeUint16To(w, IndexFileVersion, &n); err != nil {
+	} else if err := writeUint16To(w, TagBlockVersion, &n); err != nil {
 		return n, err
 	}
 
@@ -441,7 +570,7 @@ func ReadTagBlockTrailer(data []byte) (TagBlockTrailer, error) {
 
 	// Read version.
 	t.Version = int(binary.BigEndian.Uint16(data[len(data)-2:]))
-	if t.Version != TagBlockVersion {
+	if t.Version < MinTagBlockVersion || t.Version > TagBlockVersion {
 		return t, ErrUnsupportedTagBlockVersion
 	}
 
@@ -480,16 +609,27 @@ type TagBlockEncoder struct {
 
 	// Track tag keys.
 	keys []tagKeyEncodeEntry
+
+	// Track value offsets by trigram & the number of values for the current key.
+	trigrams map[string][]uint64
+	valueN   int
+
+	// Minimum number of values a tag key must have for a trigram index to be
+	// written for it. A trigram index is never written if zero.
+	TrigramMinValues int
 }
 
 // NewTagBlockEncoder returns a new TagBlockEncoder.
 func NewTagBlockEncoder(w io.Writer) *TagBlockEncoder {
 	return &TagBlockEncoder{
-		w:       w,
-		offsets: rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor}),
+		w:        w,
+		offsets:  rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor}),
+		trigrams: make(map[string][]uint64),
 		trailer: TagBlockTrailer{
 			Version: TagBlockVersion,
 		},
+
+		TrigramMinValues: DefaultTrigramMinValues,
 	}
 }
 
@@ -542,6 +682,17 @@ func (enc *TagBlockEncoder) EncodeValue(value []byte, deleted bool, seriesIDs []
 	// Save offset to hash map.
 	enc.offsets.Put(value, enc.n)
 
+	// Save offset relative to the key's value data to the trigram lists.
+	// Deleted values are included so tombstones are merged across files.
+	if enc.TrigramMinValues > 0 {
+		offset := uint64(enc.n - enc.keys[len(enc.keys)-1].data.offset)
+		var buf [16][]byte
+		for _, t := range appendTrigrams(buf[:0], value) {
+			enc.trigrams[string(t)] = append(enc.trigrams[string(t)], offset)
+		}
+	}
+	enc.valueN++
+
 	// Write flag.
 	if err := writeUint8To(enc.w, encodeTagValueFlag(deleted), &enc.n); err != nil {
 		return err
@@ -660,6 +811,64 @@ func (enc *TagBlockEncoder) flushValueHashIndex() error {
 	// Clear offsets.
 	enc.offsets = rhh.NewHashMap(rhh.Options{LoadFactor: LoadFactor})
 
+	return enc.flushTrigramIndex()
+}
+
+// flushTrigramIndex writes the trigram index at the end of a value set if the
+// key has enough values.
+func (enc *TagBlockEncoder) flushTrigramIndex() error {
+	key := &enc.keys[len(enc.keys)-1]
+	trigrams, valueN := enc.trigrams, enc.valueN
+	enc.trigrams, enc.valueN = make(map[string][]uint64), 0
+
+	if enc.TrigramMinValues <= 0 || valueN < enc.TrigramMinValues || len(trigrams) == 0 {
+		return nil
+	}
+
+	// Sort trigrams so they can be binary searched.
+	a := make([]string, 0, len(trigrams))
+	for t := range trigrams {
+		a = append(a, t)
+	}
+	sort.Strings(a)
+
+	// Encode offset lists into buffer, after the fixed size elements.
+	enc.buf.Reset()
+	listOffsets := make([]uint64, len(a))
+	base := uint64(TrigramNSize + len(a)*TrigramIndexElemSize)
+	for i, t := range a {
+		listOffsets[i] = base + uint64(enc.buf.Len())
+
+		var buf [binary.MaxVarintLen64]byte
+		enc.buf.Write(buf[:binary.PutUvarint(buf[:], uint64(len(trigrams[t])))])
+
+		var prev uint64
+		for _, offset := range trigrams[t] {
+			enc.buf.Write(buf[:binary.PutUvarint(buf[:], offset-prev)])
+			prev = offset
+		}
+	}
+
+	// Encode trigram count & elements.
+	key.trigramIndex.offset = enc.n
+	if err := writeUint64To(enc.w, uint64(len(a)), &enc.n); err != nil {
+		return err
+	}
+	for i, t := range a {
+		if err := writeTo(enc.w, []byte(t), &enc.n); err != nil {
+			return err
+		} else if err := writeUint64To(enc.w, listOffsets[i], &enc.n); err != nil {
+			return err
+		}
+	}
+
+	// Write offset lists.
+	nn, err := enc.buf.WriteTo(enc.w)
+	if enc.n += nn; err != nil {
+		return err
+	}
+	key.trigramIndex.size = enc.n - key.trigramIndex.offset
+
 	return nil
 }
 
@@ -675,7 +884,7 @@ func (enc *TagBlockEncoder) encodeTagKeyBlock() error {
 		// Save current offset so we can use it in the hash index.
 		offsets.Put(entry.key, enc.n)
 
-		if err := writeUint8To(enc.w, encodeTagKeyFlag(entry.deleted), &enc.n); err != nil {
+		if err := writeUint8To(enc.w, encodeTagKeyFlag(entry.deleted, entry.trigramIndex.size > 0), &enc.n); err != nil {
 			return err
 		}
 
@@ -693,6 +902,15 @@ func (enc *TagBlockEncoder) encodeTagKeyBlock() error {
 			return err
 		}
 
+		// Write value trigram index offset & size, if available.
+		if entry.trigramIndex.size > 0 {
+			if err := writeUint64To(enc.w, uint64(entry.trigramIndex.offset), &enc.n); err != nil {
+				return err
+			} else if err := writeUint64To(enc.w, uint64(entry.trigramIndex.size), &enc.n); err != nil {
+				return err
+			}
+		}
+
 		// Write key length and data.
 		if err := writeUvarintTo(enc.w, uint64(len(entry.key)), &enc.n); err != nil {
 			return err
@@ -733,13 +951,20 @@ type tagKeyEncodeEntry struct {
 		offset int64
 		size   int64
 	}
+	trigramIndex struct {
+		offset int64
+		size   int64
+	}
 }
 
-func encodeTagKeyFlag(deleted bool) byte {
+func encodeTagKeyFlag(deleted, trigrams bool) byte {
 	var flag byte
 	if deleted {
 		flag |= TagKeyTombstoneFlag
 	}
+	if trigrams {
+		flag |= TagKeyTrigramFlag
+	}
 	return flag
 }
 
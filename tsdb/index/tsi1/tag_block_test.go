@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"testing"
 
 	"github.com/influxdata/influxdb/tsdb/index/tsi1"
@@ -75,6 +77,116 @@ func TestTagBlockWriter(t *testing.T) {
 	}
 }
 
+// Ensure tag blocks can filter values by regex using the trigram index.
+func TestTagBlock_TagValueIteratorByRegex(t *testing.T) {
+	var values []string
+	for _, env := range []string{"dev", "prod", "test"} {
+		for i := 0; i < 10; i++ {
+			values = append(values, fmt.Sprintf("web-%02d-%s", i, env), fmt.Sprintf("db-%02d-%s", i, env))
+		}
+	}
+	values = append(values, "a", "WEB-01-PROD")
+	sort.Strings(values)
+
+	for _, minValues := range []int{0, 1} {
+		var buf bytes.Buffer
+		enc := tsi1.NewTagBlockEncoder(&buf)
+		enc.TrigramMinValues = minValues
+		if err := enc.EncodeKey([]byte("host"), false); err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range values {
+			if err := enc.EncodeValue([]byte(v), false, []uint32{uint32(i + 1)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		var blk tsi1.TagBlock
+		if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		ke := blk.TagKeyElem([]byte("host")).(*tsi1.TagBlockKeyElem)
+		if got, exp := ke.HasTrigramIndex(), minValues > 0; got != exp {
+			t.Fatalf("unexpected trigram index flag: %v", got)
+		}
+
+		for _, expr := range []string{
+			`web-.*-prod`,
+			`^db-0[0-4]-`,
+			`prod|test`,
+			`(?i)web-01-prod`,
+			`(web|db)-05`,
+			`x?`,
+			`-(dev)+$`,
+			`^a$`,
+		} {
+			re := regexp.MustCompile(expr)
+
+			var exp []string
+			for _, v := range values {
+				if re.MatchString(v) {
+					exp = append(exp, v)
+				}
+			}
+
+			var got []string
+			itr := ke.TagValueIteratorByRegex(re)
+			for e := itr.Next(); e != nil; e = itr.Next() {
+				got = append(got, string(e.Value()))
+			}
+
+			if !reflect.DeepEqual(got, exp) {
+				t.Fatalf("unexpected values for %s (min=%d): %v, expected %v", expr, minValues, got, exp)
+			}
+		}
+	}
+}
+
+// Ensure tag blocks return the values containing a trigram.
+func TestTagBlock_TrigramOffsets(t *testing.T) {
+	var buf bytes.Buffer
+	enc := tsi1.NewTagBlockEncoder(&buf)
+	enc.TrigramMinValues = 1
+	if err := enc.EncodeKey([]byte("host"), false); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("abcd"), false, []uint32{1}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("bcde"), false, []uint32{2}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.EncodeValue([]byte("xy"), true, []uint32{3}); err != nil {
+		t.Fatal(err)
+	} else if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var blk tsi1.TagBlock
+	if err := blk.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	ke := blk.TagKeyElem([]byte("host")).(*tsi1.TagBlockKeyElem)
+
+	if a := ke.TrigramOffsets([]byte("bcd")); len(a) != 2 {
+		t.Fatalf("unexpected offsets: %v", a)
+	} else if a := ke.TrigramOffsets([]byte("abc")); len(a) != 1 {
+		t.Fatalf("unexpected offsets: %v", a)
+	} else if a := ke.TrigramOffsets([]byte("zzz")); len(a) != 0 {
+		t.Fatalf("unexpected offsets: %v", a)
+	}
+
+	var trigrams []string
+	if err := ke.ForEachTrigram(func(t []byte, offsets []uint64) error {
+		trigrams = append(trigrams, string(t))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{"abc", "bcd", "cde"}; !reflect.DeepEqual(trigrams, exp) {
+		t.Fatalf("unexpected trigrams: %v", trigrams)
+	}
+}
+
 var benchmarkTagBlock10x1000 *tsi1.TagBlock
 var benchmarkTagBlock100x1000 *tsi1.TagBlock
 var benchmarkTagBlock1000x1000 *tsi1.TagBlock
@@ -0,0 +1,207 @@
+package tsi1
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+)
+
+// TrigramSize is the number of bytes in a trigram.
+const TrigramSize = 3
+
+// appendTrigrams appends the distinct trigrams of v to dst.
+func appendTrigrams(dst [][]byte, v []byte) [][]byte {
+	n := len(dst)
+	for i := 0; i+TrigramSize <= len(v); i++ {
+		t := v[i : i+TrigramSize]
+
+		var found bool
+		for _, other := range dst[n:] {
+			if string(other) == string(t) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, t)
+		}
+	}
+	return dst
+}
+
+// Trigram query operators.
+const (
+	trigramAnd = iota
+	trigramOr
+)
+
+// trigramQuery represents the trigrams that a value must contain to match a
+// regular expression. A nil query matches all values.
+type trigramQuery struct {
+	op       int
+	trigrams [][]byte       // required trigrams, and-queries only
+	subs     []trigramQuery // sub-queries, combined using op
+}
+
+// newTrigramQuery returns a query for the trigrams a value matching re must
+// contain. Returns nil if re does not require any trigrams.
+func newTrigramQuery(re *regexp.Regexp) *trigramQuery {
+	expr, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	return buildTrigramQuery(expr)
+}
+
+// buildTrigramQuery returns the trigram query for a parsed regular expression.
+func buildTrigramQuery(re *syntax.Regexp) *trigramQuery {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return nil
+		}
+		return literalTrigramQuery([]byte(string(re.Rune)))
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return buildTrigramQuery(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return buildTrigramQuery(re.Sub[0])
+
+	case syntax.OpConcat:
+		// Adjacent literals are joined so trigrams spanning them are used.
+		var a []*trigramQuery
+		var lit []byte
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral && sub.Flags&syntax.FoldCase == 0 {
+				lit = append(lit, string(sub.Rune)...)
+				continue
+			}
+			a = append(a, literalTrigramQuery(lit), buildTrigramQuery(sub))
+			lit = nil
+		}
+		a = append(a, literalTrigramQuery(lit))
+		return andTrigramQueries(a)
+
+	case syntax.OpAlternate:
+		// Every alternative must require trigrams to narrow the values.
+		q := &trigramQuery{op: trigramOr}
+		for _, sub := range re.Sub {
+			other := buildTrigramQuery(sub)
+			if other == nil {
+				return nil
+			}
+			q.subs = append(q.subs, *other)
+		}
+		return q
+
+	default:
+		return nil
+	}
+}
+
+// literalTrigramQuery returns a query requiring all trigrams of lit.
+func literalTrigramQuery(lit []byte) *trigramQuery {
+	trigrams := appendTrigrams(nil, lit)
+	if len(trigrams) == 0 {
+		return nil
+	}
+	return &trigramQuery{op: trigramAnd, trigrams: trigrams}
+}
+
+// andTrigramQueries returns a query requiring all non-nil queries in a.
+func andTrigramQueries(a []*trigramQuery) *trigramQuery {
+	var q *trigramQuery
+	for _, other := range a {
+		if other == nil {
+			continue
+		} else if q == nil {
+			q = &trigramQuery{op: trigramAnd}
+		}
+
+		if other.op == trigramAnd {
+			q.trigrams = append(q.trigrams, other.trigrams...)
+			q.subs = append(q.subs, other.subs...)
+		} else {
+			q.subs = append(q.subs, *other)
+		}
+	}
+	return q
+}
+
+// eval returns the sorted offsets of the values that may match the query.
+// The postings function returns the sorted offsets of the values containing
+// a trigram.
+func (q *trigramQuery) eval(postings func(t []byte) []uint64) []uint64 {
+	switch q.op {
+	case trigramAnd:
+		var a []uint64
+		for i, t := range q.trigrams {
+			if i == 0 {
+				a = postings(t)
+			} else {
+				a = intersectOffsets(a, postings(t))
+			}
+			if len(a) == 0 {
+				return nil
+			}
+		}
+
+		for i := range q.subs {
+			other := q.subs[i].eval(postings)
+			if i == 0 && len(q.trigrams) == 0 {
+				a = other
+			} else {
+				a = intersectOffsets(a, other)
+			}
+			if len(a) == 0 {
+				return nil
+			}
+		}
+		return a
+
+	default:
+		var a []uint64
+		for i := range q.subs {
+			a = unionOffsets(a, q.subs[i].eval(postings))
+		}
+		return a
+	}
+}
+
+// intersectOffsets returns the offsets in both a and b.
+func intersectOffsets(a, b []uint64) []uint64 {
+	var other []uint64
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i] < b[j] {
+			i++
+		} else if a[i] > b[j] {
+			j++
+		} else {
+			other = append(other, a[i])
+			i, j = i+1, j+1
+		}
+	}
+	return other
+}
+
+// unionOffsets returns the offsets in either a or b.
+func unionOffsets(a, b []uint64) []uint64 {
+	other := make([]uint64, 0, len(a)+len(b))
+	other = append(other, a...)
+	other = append(other, b...)
+	sort.Slice(other, func(i, j int) bool { return other[i] < other[j] })
+
+	// Remove duplicates.
+	n := 0
+	for i := range other {
+		if i == 0 || other[i] != other[n-1] {
+			other[n] = other[i]
+			n++
+		}
+	}
+	return other[:n]
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"regexp"
 
 	"github.com/influxdata/influxdb/influxql"
 	"github.com/influxdata/influxdb/models"
@@ -340,12 +342,43 @@ func (p tagValueMergeElem) Deleted() bool {
 	return p[0].Deleted()
 }
 
+// newRegexTagValueIterator returns an iterator over the elements of itr with
+// values matching re. Returns nil if itr is nil.
+func newRegexTagValueIterator(itr TagValueIterator, re *regexp.Regexp) TagValueIterator {
+	if itr == nil {
+		return nil
+	}
+	return &regexTagValueIterator{itr: itr, re: re}
+}
+
+// regexTagValueIterator represents an iterator over tag values matching a regex.
+type regexTagValueIterator struct {
+	itr TagValueIterator
+	re  *regexp.Regexp
+}
+
+// Next returns the next element matching the regex.
+func (itr *regexTagValueIterator) Next() TagValueElem {
+	for {
+		e := itr.itr.Next()
+		if e == nil {
+			return nil
+		} else if itr.re.Match(e.Value()) {
+			return e
+		}
+	}
+}
+
 // SeriesElem represents a generic series element.
 type SeriesElem interface {
 	Name() []byte
 	Tags() models.Tags
 	Deleted() bool
 
+	// Min & max time of the series' points. Series written before time
+	// ranges were recorded return math.MinInt64 & math.MaxInt64.
+	TimeRange() (min, max int64)
+
 	// InfluxQL expression associated with series during filtering.
 	Expr() influxql.Expr
 }
@@ -383,10 +416,17 @@ type seriesElem struct {
 	deleted bool
 }
 
-func (e *seriesElem) Name() []byte        { return e.name }
-func (e *seriesElem) Tags() models.Tags   { return e.tags }
-func (e *seriesElem) Deleted() bool       { return e.deleted }
-func (e *seriesElem) Expr() influxql.Expr { return nil }
+func (e *seriesElem) Name() []byte                { return e.name }
+func (e *seriesElem) Tags() models.Tags           { return e.tags }
+func (e *seriesElem) Deleted() bool               { return e.deleted }
+func (e *seriesElem) TimeRange() (min, max int64) { return math.MinInt64, math.MaxInt64 }
+func (e *seriesElem) Expr() influxql.Expr         { return nil }
+
+// SeriesElemOverlaps returns true if e may have points between min and max.
+func SeriesElemOverlaps(e SeriesElem, min, max int64) bool {
+	emin, emax := e.TimeRange()
+	return emin <= max && emax >= min
+}
 
 // SeriesIterator represents a iterator over a list of series.
 type SeriesIterator interface {
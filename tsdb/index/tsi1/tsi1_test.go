@@ -3,6 +3,7 @@ package tsi1_test
 import (
 	"bytes"
 	"io/ioutil"
+	"math"
 	"reflect"
 	"testing"
 
@@ -279,10 +280,11 @@ type SeriesElem struct {
 	expr    influxql.Expr
 }
 
-func (e *SeriesElem) Name() []byte        { return e.name }
-func (e *SeriesElem) Tags() models.Tags   { return e.tags }
-func (e *SeriesElem) Deleted() bool       { return e.deleted }
-func (e *SeriesElem) Expr() influxql.Expr { return e.expr }
+func (e *SeriesElem) Name() []byte                { return e.name }
+func (e *SeriesElem) Tags() models.Tags           { return e.tags }
+func (e *SeriesElem) Deleted() bool               { return e.deleted }
+func (e *SeriesElem) TimeRange() (min, max int64) { return math.MinInt64, math.MaxInt64 }
+func (e *SeriesElem) Expr() influxql.Expr         { return e.expr }
 
 // SeriesIterator represents an iterator over a slice of tag values.
 type SeriesIterator struct {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -37,6 +38,7 @@ const (
 	statWritePointsOK      = "writePointsOk"
 	statWriteBytes         = "writeBytes"
 	statDiskBytes          = "diskBytes"
+	statSeriesExpired      = "seriesExpired"
 )
 
 var (
@@ -60,6 +62,14 @@ var (
 	// ErrShardDisabled is returned when a the shard is not available for
 	// queries or writes.
 	ErrShardDisabled = errors.New("shard is disabled")
+
+	// ErrIndexConversionInProgress is returned when converting the index of a
+	// shard that is already being converted.
+	ErrIndexConversionInProgress = errors.New("index conversion already in progress")
+
+	// ErrIndexConversionAborted is returned when series were deleted from a shard
+	// while its index was converted.
+	ErrIndexConversionAborted = errors.New("index conversion aborted: series deleted during conversion")
 )
 
 var (
@@ -87,14 +97,46 @@ func (e ShardError) Error() string {
 	return fmt.Sprintf("[shard %d] %s", e.id, e.Err)
 }
 
+// Codes of the reasons points are dropped from a write.
+const (
+	DropReasonParseError           = "parse_error"
+	DropReasonInvalidPoint         = "invalid_point"
+	DropReasonFieldTypeConflict    = "field_type_conflict"
+	DropReasonMaxValuesPerTag      = "max_values_per_tag"
+	DropReasonMaxSeriesPerDatabase = "max_series_per_database"
+	DropReasonCardinalityLimit     = "cardinality_limit"
+	DropReasonDuplicatePoint       = "duplicate_point"
+	DropReasonOutsideRetention     = "outside_retention"
+	DropReasonUnauthorizedSeries   = "unauthorized_series"
+)
+
+// DropReason describes why a point was dropped from a write.
+type DropReason struct {
+	Code    string // One of the DropReason constants.
+	Message string
+}
+
+// DroppedPoint is a point that was dropped from a write.
+type DroppedPoint struct {
+	Point  models.Point
+	Reason DropReason
+}
+
 // PartialWriteError indicates a write request could only write a portion of the
 // requested values.
 type PartialWriteError struct {
 	Reason  string
 	Dropped int
 
-	// The set of series keys that were dropped. Can be nil.
-	DroppedKeys map[string]struct{}
+	// The set of series keys that were dropped and why. Can be nil.
+	DroppedKeys map[string]DropReason
+
+	// The points that were dropped. Can be nil or incomplete if the
+	// points are not known where the error is returned. Dropped counts
+	// series rather than points for series dropped by the index, and
+	// conflicting fields rather than points for field type conflicts, so
+	// it may differ from the number of DroppedPoints.
+	DroppedPoints []DroppedPoint
 }
 
 func (e PartialWriteError) Error() string {
@@ -130,9 +172,29 @@ type Shard struct {
 	baseLogger zap.Logger
 	logger     zap.Logger
 
+	// conversion tracks deletes of series while the index is converted.
+	convMu     sync.Mutex
+	conversion *indexConversion
+
+	// expiring holds the series being expired and writes to them wait until
+	// expired is closed.
+	expiringMu sync.Mutex
+	expiring   map[string]struct{}
+	expired    chan struct{}
+
+	// writeMu blocks writes while series are checked for expiry or fields
+	// are converted.
+	writeMu sync.RWMutex
+
 	EnableOnOpen bool
 }
 
+// indexConversion records whether series were deleted from a shard while its
+// index is rebuilt in the background.
+type indexConversion struct {
+	deleted bool
+}
+
 // NewShard returns a new initialized Shard. walPath doesn't apply to the b1 type index
 func NewShard(id uint64, path string, walPath string, opt EngineOptions) *Shard {
 	db, rp := decodeStorePath(path)
@@ -214,6 +276,7 @@ type ShardStatistics struct {
 	WritePointsOK      int64
 	BytesWritten       int64
 	DiskBytes          int64
+	SeriesExpired      int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -241,6 +304,7 @@ func (s *Shard) Statistics(tags map[string]string) []models.Statistic {
 			statWritePointsOK:      atomic.LoadInt64(&s.stats.WritePointsOK),
 			statWriteBytes:         atomic.LoadInt64(&s.stats.BytesWritten),
 			statDiskBytes:          atomic.LoadInt64(&s.stats.DiskBytes),
+			statSeriesExpired:      atomic.LoadInt64(&s.stats.SeriesExpired),
 		},
 	}}
 
@@ -300,6 +364,12 @@ func (s *Shard) Open() error {
 		}
 		s.engine = e
 
+		if l := s.options.CardinalityLimiter; l != nil {
+			if err := l.setIndex(s.id, s.index); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}(); err != nil {
 		s.close(true)
@@ -347,6 +417,10 @@ func (s *Shard) close(clean bool) error {
 	}
 	s.wg.Wait()
 
+	if l := s.options.CardinalityLimiter; l != nil {
+		l.removeIndex(s.id)
+	}
+
 	if clean {
 		// Don't leak our shard ID and series keys in the index
 		s.UnloadIndex()
@@ -418,6 +492,28 @@ func (s *Shard) SetCompactionsEnabled(enabled bool) {
 	s.engine.SetCompactionsEnabled(enabled)
 }
 
+// SetWALMode sets the durability mode of the shard's WAL.
+func (s *Shard) SetWALMode(mode string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.options.WALMode = mode
+	if s.engine == nil {
+		return nil
+	}
+	return s.engine.SetWALMode(mode)
+}
+
+// SetDuplicatePolicy sets the policy used when points overwrite existing values.
+func (s *Shard) SetDuplicatePolicy(policy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.options.DuplicatePolicy = policy
+	if s.engine == nil {
+		return nil
+	}
+	return s.engine.SetDuplicatePolicy(policy)
+}
+
 // DiskSize returns the size on disk of this shard
 func (s *Shard) DiskSize() (int64, error) {
 	size := s.engine.DiskSize()
@@ -439,6 +535,14 @@ func (s *Shard) WritePoints(points []models.Point) error {
 
 	var writeError error
 
+	s.writeMu.RLock()
+	for done := s.expiringDone(points); done != nil; done = s.expiringDone(points) {
+		s.writeMu.RUnlock()
+		<-done
+		s.writeMu.RLock()
+	}
+	defer s.writeMu.RUnlock()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -462,9 +566,23 @@ func (s *Shard) WritePoints(points []models.Point) error {
 
 	// Write to the engine.
 	if err := s.engine.WritePoints(points); err != nil {
-		atomic.AddInt64(&s.stats.WritePointsErr, int64(len(points)))
-		atomic.AddInt64(&s.stats.WriteReqErr, 1)
-		return fmt.Errorf("engine: %s", err)
+		// Points rejected by the engine's duplicate policy are a partial write.
+		perr, ok := err.(PartialWriteError)
+		if !ok {
+			atomic.AddInt64(&s.stats.WritePointsErr, int64(len(points)))
+			atomic.AddInt64(&s.stats.WriteReqErr, 1)
+			return fmt.Errorf("engine: %s", err)
+		}
+
+		atomic.AddInt64(&s.stats.WritePointsDropped, int64(perr.Dropped))
+		atomic.AddInt64(&s.stats.WritePointsOK, int64(len(points)-perr.Dropped))
+		atomic.AddInt64(&s.stats.WriteReqOK, 1)
+		if werr, ok := writeError.(PartialWriteError); ok {
+			perr.Reason = werr.Reason + ", " + perr.Reason
+			perr.Dropped += werr.Dropped
+			perr.DroppedPoints = append(werr.DroppedPoints, perr.DroppedPoints...)
+		}
+		return perr
 	}
 	atomic.AddInt64(&s.stats.WritePointsOK, int64(len(points)))
 	atomic.AddInt64(&s.stats.WriteReqOK, 1)
@@ -472,6 +590,213 @@ func (s *Shard) WritePoints(points []models.Point) error {
 	return writeError
 }
 
+// ConvertIndex converts the shard's inmem index to a tsi1 index.  The tsi1
+// index is built in the background from the series in the engine while the
+// shard keeps serving writes and queries.  The series written in the meantime
+// are added from the engine's cache and the TSM files written since, and
+// writes and queries are only blocked while the series written during the
+// last catch up are added and the shard switches to the new index.
+func (s *Shard) ConvertIndex() error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	indexType, engine := s.index.Type(), s.engine
+	s.mu.RUnlock()
+
+	if indexType != "inmem" {
+		return fmt.Errorf("cannot convert %s index", indexType)
+	}
+
+	// Start recording deletes of series from now on.
+	s.convMu.Lock()
+	if s.conversion != nil {
+		s.convMu.Unlock()
+		return ErrIndexConversionInProgress
+	}
+	s.conversion = &indexConversion{}
+	s.convMu.Unlock()
+
+	defer func() {
+		s.convMu.Lock()
+		s.conversion = nil
+		s.convMu.Unlock()
+	}()
+
+	// Build the new index next to the current one.
+	opt := s.options
+	opt.IndexVersion = "tsi1"
+	tmpPath := filepath.Join(s.path, "index.tmp")
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	idx, err := NewIndex(s.id, s.database, tmpPath, opt)
+	if err != nil {
+		return err
+	}
+	idx.WithLogger(s.baseLogger)
+	if err := idx.Open(); err != nil {
+		return err
+	}
+
+	// The series written since are found by the time their TSM files were
+	// written, which is only as precise as the file system's times.
+	since := time.Now().Add(-time.Second).UnixNano()
+	if err := func() error {
+		batch := NewSeriesBatch(idx)
+		if err := engine.ForEachSeriesKey(batch.Add); err != nil {
+			return err
+		}
+
+		// Catch up with the series written while the index was built, so
+		// only the few series written meanwhile are added with writes
+		// blocked.
+		next := time.Now().Add(-time.Second).UnixNano()
+		if err := engine.ForEachSeriesKeyWrittenSince(since, batch.Add); err != nil {
+			return err
+		}
+		since = next
+		return batch.Flush()
+	}(); err != nil {
+		idx.Close()
+		os.RemoveAll(tmpPath)
+		return err
+	}
+
+	// Block writes and queries while catching up and switching indexes.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := func() error {
+		if s.engine != engine {
+			return ErrEngineClosed
+		}
+
+		s.convMu.Lock()
+		conv := s.conversion
+		s.convMu.Unlock()
+		if conv.deleted {
+			return ErrIndexConversionAborted
+		}
+
+		batch := NewSeriesBatch(idx)
+		if err := engine.ForEachSeriesKeyWrittenSince(since, batch.Add); err != nil {
+			return err
+		}
+		return batch.Flush()
+	}(); err != nil {
+		idx.Close()
+		os.RemoveAll(tmpPath)
+		return err
+	}
+
+	if err := idx.Close(); err != nil {
+		os.RemoveAll(tmpPath)
+		return err
+	}
+
+	// Move the new index into place and switch to it.
+	ipath := filepath.Join(s.path, "index")
+	if err := os.Rename(tmpPath, ipath); err != nil {
+		return err
+	}
+
+	idx, err = NewIndex(s.id, s.database, ipath, opt)
+	if err != nil {
+		return err
+	}
+	idx.WithLogger(s.baseLogger)
+	if err := idx.Open(); err != nil {
+		return err
+	}
+
+	old := s.index
+	s.engine.SetIndex(idx)
+	s.index = idx
+	s.options = opt
+	if l := s.options.CardinalityLimiter; l != nil {
+		if err := l.setIndex(s.id, idx); err != nil {
+			return err
+		}
+	}
+
+	// Remove the shard's series from the shared inmem index.
+	old.RemoveShard(s.id)
+	return old.Close()
+}
+
+// expiringDone returns a channel that is closed once the series being
+// expired are dropped if any of points belongs to them, or nil otherwise.
+func (s *Shard) expiringDone(points []models.Point) chan struct{} {
+	s.expiringMu.Lock()
+	defer s.expiringMu.Unlock()
+	if s.expiring == nil {
+		return nil
+	}
+	for _, p := range points {
+		if _, ok := s.expiring[string(p.Key())]; ok {
+			return s.expired
+		}
+	}
+	return nil
+}
+
+// seriesDeleted records that series were deleted while the index is converted.
+func (s *Shard) seriesDeleted() {
+	s.convMu.Lock()
+	defer s.convMu.Unlock()
+	if s.conversion != nil {
+		s.conversion.deleted = true
+	}
+}
+
+// recountCardinality recounts the series of measurements in names, or of all
+// measurements if names is nil, for the cardinality limits after series were
+// removed from the shard.
+func (s *Shard) recountCardinality(names [][]byte) {
+	if l := s.options.CardinalityLimiter; l != nil {
+		if err := l.recount(s.id, names); err != nil {
+			s.logger.Error("cannot recount series cardinality", zap.Error(err))
+		}
+	}
+}
+
+// createSeriesList creates the series of points in the index. Indexes which
+// record the time range of series are also passed the range of the points.
+func (s *Shard) createSeriesList(points []models.Point, keys, names [][]byte, tagsSlice []models.Tags) error {
+	idx, ok := s.index.(SeriesTimeRangeIndex)
+	if !ok {
+		return s.engine.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	}
+
+	// Collapse points to a single time range per series.
+	var (
+		seriesKeys, seriesNames [][]byte
+		seriesTags              []models.Tags
+		mins, maxs              []int64
+	)
+	offsets := make(map[string]int, len(keys))
+	for i, p := range points {
+		t := p.Time().UnixNano()
+		if j, ok := offsets[string(keys[i])]; ok {
+			if t < mins[j] {
+				mins[j] = t
+			}
+			if t > maxs[j] {
+				maxs[j] = t
+			}
+			continue
+		}
+
+		offsets[string(keys[i])] = len(seriesKeys)
+		seriesKeys, seriesNames = append(seriesKeys, keys[i]), append(seriesNames, names[i])
+		seriesTags = append(seriesTags, tagsSlice[i])
+		mins, maxs = append(mins, t), append(maxs, t)
+	}
+	return idx.CreateSeriesListWithTimeRanges(seriesKeys, seriesNames, seriesTags, mins, maxs)
+}
+
 // DeleteSeries deletes a list of series.
 func (s *Shard) DeleteSeries(seriesKeys [][]byte) error {
 	return s.DeleteSeriesRange(seriesKeys, math.MinInt64, math.MaxInt64)
@@ -483,6 +808,8 @@ func (s *Shard) DeleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 		return err
 	}
 
+	s.seriesDeleted()
+	defer s.recountCardinality(measurementNames(seriesKeys))
 	if err := s.engine.DeleteSeriesRange(seriesKeys, min, max); err != nil {
 		return err
 	}
@@ -490,14 +817,120 @@ func (s *Shard) DeleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 	return nil
 }
 
+// ForEachSeriesKey calls fn for the key of every series with data in the
+// shard.  A key may be passed more than once.
+func (s *Shard) ForEachSeriesKey(fn func(key []byte) error) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	return s.engine.ForEachSeriesKey(fn)
+}
+
+// ForEachSeriesKeyWrittenSince calls fn for the key of every series in the
+// shard with data written at or after min.  A key may be passed more than
+// once.
+func (s *Shard) ForEachSeriesKeyWrittenSince(min int64, fn func(key []byte) error) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	return s.engine.ForEachSeriesKeyWrittenSince(min, fn)
+}
+
+// SeriesWrittenSince returns the keys of seriesKeys with data written to the
+// shard at or after min.
+func (s *Shard) SeriesWrittenSince(seriesKeys [][]byte, min int64) (map[string]struct{}, error) {
+	if err := s.ready(); err != nil {
+		return nil, err
+	}
+	return s.engine.SeriesWrittenSince(seriesKeys, min)
+}
+
+// setExpiring makes writes to the series in seriesKeys wait until the series
+// are dropped by expireSeries.  The caller must hold writeMu.
+func (s *Shard) setExpiring(seriesKeys [][]byte) {
+	expiring := make(map[string]struct{}, len(seriesKeys))
+	for _, key := range seriesKeys {
+		expiring[string(key)] = struct{}{}
+	}
+
+	s.expiringMu.Lock()
+	s.expiring, s.expired = expiring, make(chan struct{})
+	s.expiringMu.Unlock()
+}
+
+// expireSeries drops the series passed to setExpiring and lets the writes
+// waiting for them continue.
+func (s *Shard) expireSeries(seriesKeys [][]byte) error {
+	defer func() {
+		s.expiringMu.Lock()
+		close(s.expired)
+		s.expiring, s.expired = nil, nil
+		s.expiringMu.Unlock()
+	}()
+
+	if err := s.DeleteSeriesRange(seriesKeys, math.MinInt64, math.MaxInt64); err != nil {
+		return err
+	}
+	atomic.AddInt64(&s.stats.SeriesExpired, int64(len(seriesKeys)))
+	return nil
+}
+
+// measurementNames returns the distinct measurement names of seriesKeys.
+func measurementNames(seriesKeys [][]byte) [][]byte {
+	var names [][]byte
+	set := make(map[string]struct{})
+	for _, key := range seriesKeys {
+		name := MeasurementFromSeriesKey(key)
+		if _, ok := set[string(name)]; !ok {
+			set[string(name)] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // DeleteMeasurement deletes a measurement and all underlying series.
 func (s *Shard) DeleteMeasurement(name []byte) error {
 	if err := s.ready(); err != nil {
 		return err
 	}
+	s.seriesDeleted()
+	defer s.recountCardinality([][]byte{name})
 	return s.engine.DeleteMeasurement(name)
 }
 
+// RenameSeries renames the series of measurement name to the series keys
+// returned by fn.  Series for which fn returns a nil key are left unchanged.
+func (s *Shard) RenameSeries(name []byte, fn func(seriesKey []byte) ([]byte, error)) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	s.seriesDeleted()
+	defer s.recountCardinality(nil)
+	return s.engine.RenameSeries(name, fn)
+}
+
+// DeleteField removes field from the series of measurement name.
+func (s *Shard) DeleteField(name, field []byte) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	s.seriesDeleted()
+	defer s.recountCardinality([][]byte{name})
+	return s.engine.DeleteField(name, field)
+}
+
+// ConvertField converts the values of field of measurement name to typ.
+// Writes to the shard are blocked while the values written during the
+// conversion are converted, so no values of the old type are written once
+// the field's type is changed.
+func (s *Shard) ConvertField(name, field []byte, typ influxql.DataType) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	return s.engine.ConvertField(name, field, typ, &s.writeMu)
+}
+
 // SeriesN returns the unique number of series in the shard.
 func (s *Shard) SeriesN() int64 {
 	return s.engine.SeriesN()
@@ -548,6 +981,7 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 		err            error
 		dropped        int
 		reason         string // only first error reason is set unless returned from CreateSeriesListIfNotExists
+		droppedPoints  []DroppedPoint
 	)
 
 	// Create all series against the index in bulk.
@@ -561,9 +995,11 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 		tags := p.Tags()
 		if v := tags.Get(timeBytes); v != nil {
 			dropped++
+			msg := fmt.Sprintf("invalid tag key: input tag \"%s\" on measurement \"%s\" is invalid", "time", string(p.Name()))
 			if reason == "" {
-				reason = fmt.Sprintf("invalid tag key: input tag \"%s\" on measurement \"%s\" is invalid", "time", string(p.Name()))
+				reason = msg
 			}
+			droppedPoints = append(droppedPoints, DroppedPoint{Point: p, Reason: DropReason{Code: DropReasonInvalidPoint, Message: msg}})
 			continue
 		}
 		keys[j] = p.Key()
@@ -574,9 +1010,56 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 	}
 	points, keys, names, tagsSlice = points[:j], keys[:j], names[:j], tagsSlice[:j]
 
+	// New series are reserved against the cardinality limits of the database
+	// before they are created so concurrent writes to the database's shards
+	// can't exceed a limit together.
+	limiter := s.options.CardinalityLimiter
+	if limiter != nil && !limiter.limitedAny(names) {
+		limiter = nil
+	}
+
+	// Drop the points of new series that exceed a cardinality limit, or the
+	// whole write if the limit's policy rejects it.
+	var drop map[int]string
+	var limitReason string
+	var reject bool
+	if limiter != nil {
+		if drop, limitReason, reject, err = limiter.reserve(s.id, keys, names, tagsSlice, s.logger, s.database); err != nil {
+			return nil, nil, err
+		}
+	}
+	if reject {
+		for _, p := range points {
+			droppedPoints = append(droppedPoints, DroppedPoint{Point: p, Reason: DropReason{Code: DropReasonCardinalityLimit, Message: limitReason}})
+		}
+		dropped += len(points)
+		if reason == "" {
+			reason = limitReason
+		}
+		atomic.AddInt64(&s.stats.WritePointsDropped, int64(len(points)))
+		return nil, nil, PartialWriteError{Reason: reason, Dropped: dropped, DroppedPoints: droppedPoints}
+	} else if len(drop) > 0 {
+		j = 0
+		for i, p := range points {
+			if msg, ok := drop[i]; ok {
+				droppedPoints = append(droppedPoints, DroppedPoint{Point: p, Reason: DropReason{Code: DropReasonCardinalityLimit, Message: msg}})
+				continue
+			}
+			points[j], keys[j], names[j], tagsSlice[j] = points[i], keys[i], names[i], tagsSlice[i]
+			j++
+		}
+		points, keys, names, tagsSlice = points[:j], keys[:j], names[:j], tagsSlice[:j]
+
+		dropped += len(drop)
+		if reason == "" {
+			reason = limitReason
+		}
+		atomic.AddInt64(&s.stats.WritePointsDropped, int64(len(drop)))
+	}
+
 	// Add new series. Check for partial writes.
-	var droppedKeys map[string]struct{}
-	if err := s.engine.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+	var droppedKeys map[string]DropReason
+	if err := s.createSeriesList(points, keys, names, tagsSlice); err != nil {
 		switch err := err.(type) {
 		case *PartialWriteError:
 			reason = err.Reason
@@ -584,9 +1067,15 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 			droppedKeys = err.DroppedKeys
 			atomic.AddInt64(&s.stats.WritePointsDropped, int64(err.Dropped))
 		default:
+			if limiter != nil {
+				limiter.recount(s.id, names)
+			}
 			return nil, nil, err
 		}
 	}
+	if limiter != nil && len(droppedKeys) > 0 {
+		limiter.release(s.id, keys, names, droppedKeys)
+	}
 
 	// get the shard mutex for locally defined fields
 	n := 0
@@ -608,9 +1097,11 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 
 		if !validField {
 			dropped++
+			msg := fmt.Sprintf("invalid field name: input field \"%s\" on measurement \"%s\" is invalid", "time", string(p.Name()))
 			if reason == "" {
-				reason = fmt.Sprintf("invalid field name: input field \"%s\" on measurement \"%s\" is invalid", "time", string(p.Name()))
+				reason = msg
 			}
+			droppedPoints = append(droppedPoints, DroppedPoint{Point: p, Reason: DropReason{Code: DropReasonInvalidPoint, Message: msg}})
 			continue
 		}
 
@@ -619,7 +1110,8 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 		// Skip points if keys have been dropped.
 		// The drop count has already been incremented during series creation.
 		if droppedKeys != nil {
-			if _, ok := droppedKeys[string(keys[i])]; ok {
+			if r, ok := droppedKeys[string(keys[i])]; ok {
+				droppedPoints = append(droppedPoints, DroppedPoint{Point: p, Reason: r})
 				continue
 			}
 		}
@@ -662,8 +1154,12 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 				if f.Type != fieldType {
 					atomic.AddInt64(&s.stats.WritePointsDropped, 1)
 					dropped++
+					msg := fmt.Sprintf("%s: input field \"%s\" on measurement \"%s\" is type %s, already exists as type %s", ErrFieldTypeConflict, iter.FieldKey(), name, fieldType, f.Type)
 					if reason == "" {
-						reason = fmt.Sprintf("%s: input field \"%s\" on measurement \"%s\" is type %s, already exists as type %s", ErrFieldTypeConflict, iter.FieldKey(), name, fieldType, f.Type)
+						reason = msg
+					}
+					if !skip {
+						droppedPoints = append(droppedPoints, DroppedPoint{Point: p, Reason: DropReason{Code: DropReasonFieldTypeConflict, Message: msg}})
 					}
 					skip = true
 				} else {
@@ -684,7 +1180,7 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 	points = points[:n]
 
 	if dropped > 0 {
-		err = PartialWriteError{Reason: reason, Dropped: dropped}
+		err = PartialWriteError{Reason: reason, Dropped: dropped, DroppedPoints: droppedPoints}
 	}
 
 	return points, fieldsToCreate, err
@@ -701,6 +1197,12 @@ func (s *Shard) MeasurementFields(name []byte) *MeasurementFields {
 	return s.engine.MeasurementFields(name)
 }
 
+// MeasurementFieldSet returns the fields of all measurements in the shard.
+// Unlike MeasurementFields, it doesn't create the fields of a measurement.
+func (s *Shard) MeasurementFieldSet() *MeasurementFieldSet {
+	return s.engine.MeasurementFieldSet()
+}
+
 func (s *Shard) MeasurementExists(name []byte) (bool, error) {
 	return s.engine.MeasurementExists(name)
 }
@@ -1211,6 +1713,13 @@ func (m *MeasurementFields) CreateFieldIfNotExists(name []byte, typ influxql.Dat
 	return nil
 }
 
+// DeleteField removes the field name.
+func (m *MeasurementFields) DeleteField(name string) {
+	m.mu.Lock()
+	delete(m.fields, name)
+	m.mu.Unlock()
+}
+
 func (m *MeasurementFields) FieldN() int {
 	m.mu.RLock()
 	n := len(m.fields)
@@ -1269,6 +1778,19 @@ func (m *MeasurementFields) Clone() *MeasurementFields {
 	}
 }
 
+// CanConvertField returns true if the values of a field of type from can be
+// converted to type to.  Integers can be converted to floats and any type can
+// be converted to strings.
+func CanConvertField(from, to influxql.DataType) bool {
+	switch to {
+	case influxql.Float:
+		return from == influxql.Integer || from == influxql.Unsigned || from == influxql.Float
+	case influxql.String:
+		return true
+	}
+	return from == to
+}
+
 // MeasurementFieldSet represents a collection of fields by measurement.
 // This safe for concurrent use.
 type MeasurementFieldSet struct {
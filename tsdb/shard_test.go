@@ -193,6 +193,10 @@ func TestShard_MaxTagValuesLimit(t *testing.T) {
 		t.Fatal("expected error")
 	} else if exp, got := `partial write: max-values-per-tag limit exceeded (1000/1000): measurement="cpu" tag="host" value="server9999" dropped=1`, err.Error(); exp != got {
 		t.Fatalf("unexpected error message:\n\texp = %s\n\tgot = %s", exp, got)
+	} else if perr := err.(tsdb.PartialWriteError); len(perr.DroppedPoints) != 1 || perr.DroppedPoints[0].Point != pt {
+		t.Fatalf("unexpected dropped points: %v", perr.DroppedPoints)
+	} else if code := perr.DroppedPoints[0].Reason.Code; code != tsdb.DropReasonMaxValuesPerTag {
+		t.Fatalf("unexpected drop reason: %s", code)
 	}
 
 	sh.Close()
@@ -324,6 +328,50 @@ func TestShardWriteAddNewField(t *testing.T) {
 	}
 }
 
+// Ensure the points dropped from a write are reported with the reason each
+// was dropped.
+func TestShard_WritePoints_DroppedPoints(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+	tmpShard := path.Join(tmpDir, "shard")
+	tmpWal := path.Join(tmpDir, "wal")
+
+	opts := tsdb.NewEngineOptions()
+	opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+	opts.InmemIndex = inmem.NewIndex(path.Base(tmpDir))
+
+	sh := tsdb.NewShard(1, tmpShard, tmpWal, opts)
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+	defer sh.Close()
+
+	if err := sh.WritePoints([]models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	points := []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"time": "now"}), map[string]interface{}{"value": 1.0}, time.Unix(2, 0)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": "x"}, time.Unix(2, 0)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a"}), map[string]interface{}{"value": 2.0}, time.Unix(3, 0)),
+	}
+	exp := []tsdb.DroppedPoint{
+		{Point: points[0], Reason: tsdb.DropReason{Code: tsdb.DropReasonInvalidPoint, Message: `invalid tag key: input tag "time" on measurement "cpu" is invalid`}},
+		{Point: points[1], Reason: tsdb.DropReason{Code: tsdb.DropReasonFieldTypeConflict, Message: `field type conflict: input field "value" on measurement "cpu" is type string, already exists as type float`}},
+	}
+
+	err := sh.WritePoints(points)
+	if perr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	} else if perr.Dropped != 2 {
+		t.Fatalf("unexpected dropped points: got %d, exp 2", perr.Dropped)
+	} else if !deep.Equal(perr.DroppedPoints, exp) {
+		t.Fatalf("unexpected dropped points:\n\nexp=%v\n\ngot=%v", exp, perr.DroppedPoints)
+	}
+}
+
 // Tests concurrently writing to the same shard with different field types which
 // can trigger a panic when the shard is snapshotted to TSM files.
 func TestShard_WritePoints_FieldConflictConcurrent(t *testing.T) {
@@ -598,6 +646,131 @@ func TestShard_Close_RemoveIndex(t *testing.T) {
 	}
 }
 
+// Ensure a shard enforces the cardinality limits of its database.
+func TestShard_WritePoints_CardinalityLimits(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			tmpDir, _ := ioutil.TempDir("", "shard_test")
+			defer os.RemoveAll(tmpDir)
+			tmpShard := path.Join(tmpDir, "db", "rp", "1")
+			tmpWal := path.Join(tmpDir, "wal")
+
+			opts := tsdb.NewEngineOptions()
+			opts.IndexVersion = index
+			opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+			opts.InmemIndex = inmem.NewIndex(path.Base(tmpDir))
+			opts.CardinalityLimiter = tsdb.NewCardinalityLimiter()
+
+			sh := tsdb.NewShard(1, tmpShard, tmpWal, opts)
+			if err := sh.Open(); err != nil {
+				t.Fatalf("error opening shard: %s", err.Error())
+			}
+			defer sh.Close()
+
+			opts.CardinalityLimiter.SetLimits([]tsdb.CardinalityLimit{
+				{Measurement: "cpu", Max: 3},
+				{Measurement: "cpu", TagKey: "region", Max: 1, Policy: tsdb.CardinalityPolicyReject},
+				{Measurement: "mem", TagKey: "host", Max: 1, Policy: tsdb.CardinalityPolicyLog},
+			})
+
+			point := func(name, host, region string) models.Point {
+				tags := map[string]string{"host": host}
+				if region != "" {
+					tags["region"] = region
+				}
+				return models.MustNewPoint(name, models.NewTags(tags), map[string]interface{}{"value": 1.0}, time.Unix(1, 2))
+			}
+
+			if err := sh.WritePoints([]models.Point{point("cpu", "a", "east"), point("cpu", "b", "east")}); err != nil {
+				t.Fatal(err)
+			}
+
+			// The series over the limit is dropped.
+			err := sh.WritePoints([]models.Point{point("cpu", "c", "east"), point("cpu", "d", "east"), point("cpu", "c", "east")})
+			if exp := `partial write: cardinality limit exceeded (3/3): measurement="cpu" series="cpu,host=d,region=east" dropped=1`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error:\n\texp = %s\n\tgot = %v", exp, err)
+			}
+
+			// A new tag value over the reject limit rejects the whole write.
+			err = sh.WritePoints([]models.Point{point("cpu", "a", "east"), point("cpu", "a", "west")})
+			if exp := `partial write: cardinality limit exceeded (1/1): measurement="cpu" tag="region" value="west" dropped=2`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error:\n\texp = %s\n\tgot = %v", exp, err)
+			}
+
+			// Existing series can still be written.
+			if err := sh.WritePoints([]models.Point{point("cpu", "a", "east")}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Limits with the log policy don't drop points.
+			if err := sh.WritePoints([]models.Point{point("mem", "x", ""), point("mem", "y", "")}); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, exp := sh.SeriesN(), int64(5); got != exp {
+				t.Fatalf("got %d series, exp %d series in index", got, exp)
+			}
+		})
+	}
+}
+
+// Ensure a shard can switch from the inmem index to tsi1 while open.
+func TestShard_ConvertIndex(t *testing.T) {
+	tmpDir, _ := ioutil.TempDir("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+	tmpShard := path.Join(tmpDir, "shard")
+	tmpWal := path.Join(tmpDir, "wal")
+
+	opts := tsdb.NewEngineOptions()
+	opts.Config.WALDir = filepath.Join(tmpDir, "wal")
+	opts.InmemIndex = inmem.NewIndex(path.Base(tmpDir))
+
+	sh := tsdb.NewShard(1, tmpShard, tmpWal, opts)
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+	defer sh.Close()
+
+	if err := sh.WritePoints([]models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "serverA"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 2)),
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "serverB"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 2)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sh.ConvertIndex(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if got, exp := sh.IndexType(), "tsi1"; got != exp {
+		t.Fatalf("got index type %s, exp %s", got, exp)
+	} else if got, exp := sh.SeriesN(), int64(2); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	// Converting again is an error.
+	if err := sh.ConvertIndex(); err == nil || err.Error() != "cannot convert tsi1 index" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// New series are added to the tsi1 index.
+	if err := sh.WritePoints([]models.Point{
+		models.MustNewPoint("mem", models.NewTags(map[string]string{"host": "serverA"}), map[string]interface{}{"value": 1.0}, time.Unix(1, 2)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The shard keeps using the tsi1 index after reopening.
+	sh.Close()
+	if err := sh.Open(); err != nil {
+		t.Fatalf("error opening shard: %s", err.Error())
+	}
+
+	if got, exp := sh.IndexType(), "tsi1"; got != exp {
+		t.Fatalf("got index type %s, exp %s", got, exp)
+	} else if got, exp := sh.SeriesN(), int64(3); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+}
+
 // Ensure a shard can create iterators for its underlying data.
 func TestShard_CreateIterator_Ascending(t *testing.T) {
 	sh := NewShard()
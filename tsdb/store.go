@@ -50,6 +50,21 @@ type Store struct {
 	// shards is a map of shard IDs to the associated Shard.
 	shards map[uint64]*Shard
 
+	// walModes holds the WAL durability mode of each database that does not
+	// use the default mode.
+	walModes map[string]string
+
+	// duplicatePolicies holds the duplicate point policy of each database that
+	// does not use the default policy.
+	duplicatePolicies map[string]string
+
+	// cardinalityLimiters holds the cardinality limiter shared by the shards
+	// of each database.
+	cardinalityLimiters map[string]*CardinalityLimiter
+
+	// expireMu serializes the expiry of inactive series.
+	expireMu sync.Mutex
+
 	EngineOptions EngineOptions
 
 	baseLogger zap.Logger
@@ -65,12 +80,15 @@ type Store struct {
 func NewStore(path string) *Store {
 	logger := zap.New(zap.NullEncoder())
 	return &Store{
-		databases:     make(map[string]struct{}),
-		path:          path,
-		indexes:       make(map[string]interface{}),
-		EngineOptions: NewEngineOptions(),
-		Logger:        logger,
-		baseLogger:    logger,
+		databases:           make(map[string]struct{}),
+		path:                path,
+		indexes:             make(map[string]interface{}),
+		walModes:            make(map[string]string),
+		duplicatePolicies:   make(map[string]string),
+		cardinalityLimiters: make(map[string]*CardinalityLimiter),
+		EngineOptions:       NewEngineOptions(),
+		Logger:              logger,
+		baseLogger:          logger,
 	}
 }
 
@@ -149,6 +167,11 @@ func (s *Store) Open() error {
 	s.wg.Add(1)
 	go s.monitorShards()
 
+	if s.EngineOptions.IndexVersion == "tsi1" && s.EngineOptions.Config.IndexConvertInmem {
+		s.wg.Add(1)
+		go s.convertInmemShards()
+	}
+
 	return nil
 }
 
@@ -168,9 +191,25 @@ func (s *Store) loadShards() error {
 	}
 	s.EngineOptions.CompactionLimiter = limiter.NewFixed(lim)
 
+	// Setup a shared memory budget for the caches of all shards
+	if total := s.EngineOptions.Config.CacheMaxMemorySizeTotal; total > 0 {
+		s.EngineOptions.CacheLimiter = limiter.NewMemory(total)
+	}
+
 	resC := make(chan *res)
 	var n int
 
+	// Copy the WAL modes and duplicate policies since shards are opened
+	// without holding the lock.
+	walModes := make(map[string]string, len(s.walModes))
+	for db, mode := range s.walModes {
+		walModes[db] = mode
+	}
+	duplicatePolicies := make(map[string]string, len(s.duplicatePolicies))
+	for db, policy := range s.duplicatePolicies {
+		duplicatePolicies[db] = policy
+	}
+
 	// Determine how many shards we need to open by checking the store path.
 	dbDirs, err := ioutil.ReadDir(s.path)
 	if err != nil {
@@ -188,6 +227,7 @@ func (s *Store) loadShards() error {
 		if err != nil {
 			return err
 		}
+		limiter := s.createCardinalityLimiterIfNotExists(db.Name())
 
 		// Load each retention policy within the database directory.
 		rpDirs, err := ioutil.ReadDir(filepath.Join(s.path, db.Name()))
@@ -226,6 +266,9 @@ func (s *Store) loadShards() error {
 					// Copy options and assign shared index.
 					opt := s.EngineOptions
 					opt.InmemIndex = idx
+					opt.WALMode = walModes[db]
+					opt.DuplicatePolicy = duplicatePolicies[db]
+					opt.CardinalityLimiter = limiter
 
 					// Existing shards should continue to use inmem index.
 					if _, err := os.Stat(filepath.Join(path, "index")); os.IsNotExist(err) {
@@ -317,6 +360,18 @@ func (s *Store) createIndexIfNotExists(name string) (interface{}, error) {
 	return idx, nil
 }
 
+// createCardinalityLimiterIfNotExists returns the cardinality limiter shared by
+// the shards of a database.
+func (s *Store) createCardinalityLimiterIfNotExists(name string) *CardinalityLimiter {
+	if l := s.cardinalityLimiters[name]; l != nil {
+		return l
+	}
+
+	l := NewCardinalityLimiter()
+	s.cardinalityLimiters[name] = l
+	return l
+}
+
 // Shard returns a shard by id.
 func (s *Store) Shard(id uint64) *Shard {
 	s.mu.RLock()
@@ -391,6 +446,9 @@ func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, en
 	// Copy index options and pass in shared index.
 	opt := s.EngineOptions
 	opt.InmemIndex = idx
+	opt.WALMode = s.walModes[database]
+	opt.DuplicatePolicy = s.duplicatePolicies[database]
+	opt.CardinalityLimiter = s.createCardinalityLimiterIfNotExists(database)
 
 	path := filepath.Join(s.path, database, retentionPolicy, strconv.FormatUint(shardID, 10))
 	shard := NewShard(shardID, path, walPath, opt)
@@ -407,6 +465,126 @@ func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, en
 	return nil
 }
 
+// SetDatabaseWALMode sets the WAL durability mode for all current and future
+// shards of a database. It may be called before the store is opened.
+func (s *Store) SetDatabaseWALMode(database, mode string) error {
+	if !ValidWALMode(mode) {
+		return fmt.Errorf("invalid wal mode: %q", mode)
+	}
+
+	s.mu.Lock()
+	if mode == "" {
+		delete(s.walModes, database)
+	} else {
+		s.walModes[database] = mode
+	}
+	shards := s.filterShards(byDatabase(database))
+	s.mu.Unlock()
+
+	return s.walkShards(shards, func(sh *Shard) error {
+		return sh.SetWALMode(mode)
+	})
+}
+
+// SetDatabaseDuplicatePolicy sets the duplicate point policy for all current
+// and future shards of a database. It may be called before the store is opened.
+func (s *Store) SetDatabaseDuplicatePolicy(database, policy string) error {
+	if !ValidDuplicatePolicy(policy) {
+		return fmt.Errorf("invalid duplicate policy: %q", policy)
+	}
+
+	s.mu.Lock()
+	if policy == "" {
+		delete(s.duplicatePolicies, database)
+	} else {
+		s.duplicatePolicies[database] = policy
+	}
+	shards := s.filterShards(byDatabase(database))
+	s.mu.Unlock()
+
+	return s.walkShards(shards, func(sh *Shard) error {
+		return sh.SetDuplicatePolicy(policy)
+	})
+}
+
+// ConvertShardIndex converts the inmem index of a shard to a tsi1 index while
+// the shard stays online.
+func (s *Store) ConvertShardIndex(id uint64) error {
+	sh := s.Shard(id)
+	if sh == nil {
+		return ErrShardNotFound
+	}
+	return sh.ConvertIndex()
+}
+
+// indexConversionRetryInterval is the time to wait before converting the
+// index of a shard again after series were deleted during its conversion.
+var indexConversionRetryInterval = time.Minute
+
+// convertInmemShards converts the shards using the inmem index to tsi1, one
+// shard at a time.  Conversions aborted by deletes are retried after the
+// other shards are converted.
+func (s *Store) convertInmemShards() {
+	defer s.wg.Done()
+
+	s.mu.RLock()
+	shards := s.filterShards(func(sh *Shard) bool {
+		return sh.IndexType() == "inmem"
+	})
+	s.mu.RUnlock()
+	sort.Sort(Shards(shards))
+
+	for len(shards) > 0 {
+		var aborted []*Shard
+		for _, sh := range shards {
+			select {
+			case <-s.closing:
+				return
+			default:
+			}
+
+			start := time.Now()
+			if err := sh.ConvertIndex(); err == ErrIndexConversionAborted {
+				s.Logger.Info(fmt.Sprintf("Failed to convert index of shard %d, retrying in %s: %s", sh.ID(), indexConversionRetryInterval, err))
+				aborted = append(aborted, sh)
+				continue
+			} else if err != nil {
+				s.Logger.Info(fmt.Sprintf("Failed to convert index of shard %d: %s", sh.ID(), err))
+				continue
+			}
+			s.Logger.Info(fmt.Sprintf("Converted index of shard %d to tsi1 in %s", sh.ID(), time.Since(start)))
+		}
+
+		if len(aborted) == 0 {
+			return
+		}
+		select {
+		case <-s.closing:
+			return
+		case <-time.After(indexConversionRetryInterval):
+		}
+		shards = aborted
+	}
+}
+
+// SetDatabaseCardinalityLimits sets the cardinality limits of a database. The
+// limits count the series of all of the database's shards together. It may be
+// called before the store is opened.
+func (s *Store) SetDatabaseCardinalityLimits(database string, limits []CardinalityLimit) error {
+	for _, l := range limits {
+		if !ValidCardinalityPolicy(l.Policy) {
+			return fmt.Errorf("invalid cardinality limit policy: %q", l.Policy)
+		}
+	}
+
+	s.mu.Lock()
+	l := s.createCardinalityLimiterIfNotExists(database)
+	s.mu.Unlock()
+
+	l.SetLimits(limits)
+	return nil
+}
+
 // CreateShardSnapShot will create a hard link to the underlying shard and return a path.
 // The caller is responsible for cleaning up (removing) the file path returned.
 func (s *Store) CreateShardSnapshot(id uint64) (string, error) {
@@ -507,6 +685,9 @@ func (s *Store) DeleteDatabase(name string) error {
 
 	// Remove shared index for database if using inmem index.
 	delete(s.indexes, name)
+	delete(s.walModes, name)
+	delete(s.duplicatePolicies, name)
+	delete(s.cardinalityLimiters, name)
 	s.mu.Unlock()
 
 	return nil
@@ -585,6 +766,115 @@ func (s *Store) DeleteMeasurement(database, name string) error {
 	})
 }
 
+// DeleteField removes field from measurement name in all shards of a database.
+func (s *Store) DeleteField(database, name, field string) error {
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	return s.walkShards(shards, func(sh *Shard) error {
+		return sh.DeleteField([]byte(name), []byte(field))
+	})
+}
+
+// ConvertField converts the values of field of measurement name to typ in all
+// shards of a database.  Integers can be converted to floats and any type can
+// be converted to strings.  Shards are converted one at a time like
+// RenameMeasurement.
+func (s *Store) ConvertField(database, name, field string, typ influxql.DataType, interrupt <-chan struct{}) error {
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	// Check every shard before converting any, so a field is not converted
+	// in only some of the shards.
+	for _, sh := range shards {
+		mf := sh.MeasurementFieldSet().Fields(name)
+		if mf == nil {
+			continue
+		} else if f := mf.Field(field); f != nil && !CanConvertField(f.Type, typ) {
+			return fmt.Errorf("cannot convert field %q from %s to %s", field, f.Type, typ)
+		}
+	}
+
+	sort.Sort(Shards(shards))
+	for _, sh := range shards {
+		select {
+		case <-interrupt:
+			return influxql.ErrQueryInterrupted
+		default:
+		}
+
+		if err := sh.ConvertField([]byte(name), []byte(field), typ); err != nil {
+			return fmt.Errorf("shard %d: %s", sh.id, err)
+		}
+	}
+	return nil
+}
+
+// RenameMeasurement renames measurement name to newName in all shards of a
+// database.  Shards are renamed one at a time; if interrupt is closed the
+// rename stops before the next shard.  Shards that were already renamed keep
+// the new name, so running the rename again finishes it.
+func (s *Store) RenameMeasurement(database, name, newName string, interrupt <-chan struct{}) error {
+	if name == newName {
+		return nil
+	}
+	return s.renameSeries(database, []byte(name), func(seriesKey []byte) ([]byte, error) {
+		_, tags := models.ParseKey(seriesKey)
+		return models.MakeKey([]byte(newName), tags), nil
+	}, interrupt)
+}
+
+// RenameTagKey renames tag key to newKey in the series of measurement name in
+// all shards of a database.  Renaming fails if a series has both tag keys.
+// Shards are renamed one at a time like RenameMeasurement.
+func (s *Store) RenameTagKey(database, name, key, newKey string, interrupt <-chan struct{}) error {
+	if key == newKey {
+		return nil
+	}
+	return s.renameSeries(database, []byte(name), func(seriesKey []byte) ([]byte, error) {
+		measurement, tags := models.ParseKey(seriesKey)
+		if tags.Get([]byte(key)) == nil {
+			return nil, nil
+		} else if tags.Get([]byte(newKey)) != nil {
+			return nil, fmt.Errorf("series %s already has tag key %q", seriesKey, newKey)
+		}
+
+		newTags := make(models.Tags, 0, len(tags))
+		for _, t := range tags {
+			if string(t.Key) == key {
+				t = models.Tag{Key: []byte(newKey), Value: t.Value}
+			}
+			newTags = append(newTags, t)
+		}
+		sort.Sort(newTags)
+		return models.MakeKey([]byte(measurement), newTags), nil
+	}, interrupt)
+}
+
+// renameSeries renames the series of measurement name in each shard of a
+// database, in shard order.
+func (s *Store) renameSeries(database string, name []byte, fn func(seriesKey []byte) ([]byte, error), interrupt <-chan struct{}) error {
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	sort.Sort(Shards(shards))
+	for _, sh := range shards {
+		select {
+		case <-interrupt:
+			return influxql.ErrQueryInterrupted
+		default:
+		}
+
+		if err := sh.RenameSeries(name, fn); err != nil {
+			return fmt.Errorf("shard %d: %s", sh.id, err)
+		}
+	}
+	return nil
+}
+
 // filterShards returns a slice of shards where fn returns true
 // for the shard. If the provided predicate is nil then all shards are returned.
 func (s *Store) filterShards(fn func(sh *Shard) bool) []*Shard {
@@ -888,6 +1178,114 @@ func (s *Store) DeleteSeries(database string, sources []influxql.Source, conditi
 	})
 }
 
+// ExpireInactiveSeries drops the series of the local shards in shardIDs
+// that were not written at or after min to any of those shards. It returns
+// the number of distinct series dropped.
+func (s *Store) ExpireInactiveSeries(shardIDs []uint64, min int64) (int, error) {
+	s.expireMu.Lock()
+	defer s.expireMu.Unlock()
+
+	var shards []*Shard
+	for _, id := range shardIDs {
+		if sh := s.Shard(id); sh != nil {
+			shards = append(shards, sh)
+		}
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].id < shards[j].id })
+
+	// A series is active if it was recently written to any of the shards.
+	active := make(map[string]struct{})
+	for _, sh := range shards {
+		if err := sh.ForEachSeriesKeyWrittenSince(min, func(key []byte) error {
+			active[string(key)] = struct{}{}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	var candidates [][]byte
+	inactive := make([][][]byte, len(shards))
+	seen := make(map[string]struct{})
+	for i, sh := range shards {
+		shardSeen := make(map[string]struct{})
+		if err := sh.ForEachSeriesKey(func(key []byte) error {
+			if _, ok := active[string(key)]; ok {
+				return nil
+			} else if _, ok := shardSeen[string(key)]; ok {
+				return nil
+			}
+			shardSeen[string(key)] = struct{}{}
+			inactive[i] = append(inactive[i], []byte(string(key)))
+			if _, ok := seen[string(key)]; !ok {
+				seen[string(key)] = struct{}{}
+				candidates = append(candidates, inactive[i][len(inactive[i])-1])
+			}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	// Writes to all the shards are blocked while the inactive series are
+	// checked again, so a series written to any of the shards since it was
+	// found inactive is kept.  Afterwards, only writes to the expired series
+	// wait until they are dropped.
+	expiring, err := func() ([][][]byte, error) {
+		for _, sh := range shards {
+			sh.writeMu.Lock()
+			defer sh.writeMu.Unlock()
+		}
+
+		for _, sh := range shards {
+			written, err := sh.SeriesWrittenSince(candidates, min)
+			if err != nil {
+				return nil, err
+			}
+			for key := range written {
+				active[key] = struct{}{}
+			}
+		}
+
+		expiring := make([][][]byte, len(shards))
+		for i, sh := range shards {
+			for _, key := range inactive[i] {
+				if _, ok := active[string(key)]; !ok {
+					expiring[i] = append(expiring[i], key)
+				}
+			}
+			if len(expiring[i]) > 0 {
+				bytesutil.Sort(expiring[i])
+				sh.setExpiring(expiring[i])
+			}
+		}
+		return expiring, nil
+	}()
+	if err != nil {
+		return 0, err
+	}
+
+	expired := make(map[string]struct{})
+	for i, sh := range shards {
+		if len(expiring[i]) == 0 {
+			continue
+		}
+		if e := sh.expireSeries(expiring[i]); e != nil && err == nil {
+			err = e
+		}
+		for _, key := range expiring[i] {
+			expired[string(key)] = struct{}{}
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(expired), nil
+}
+
 // ExpandSources expands sources against all local shards.
 func (s *Store) ExpandSources(sources influxql.Sources) (influxql.Sources, error) {
 	shards := func() Shards {
@@ -780,6 +780,274 @@ func TestStore_Cardinality_Compactions_TSI1(t *testing.T) {
 	testStoreCardinalityCompactions(t, store)
 }
 
+// Ensure cardinality limits count the series of all shards of a database.
+func TestStore_CardinalityLimits(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			store := NewStore()
+			store.EngineOptions.IndexVersion = index
+			if err := store.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer store.Close()
+
+			if err := store.SetDatabaseCardinalityLimits("db0", []tsdb.CardinalityLimit{
+				{Measurement: "cpu", Max: 2},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			store.MustCreateShardWithData("db0", "rp0", 1,
+				`cpu,host=A value=1 10`,
+				`cpu,host=B value=1 10`,
+			)
+			if err := store.CreateShard("db0", "rp0", 2, true); err != nil {
+				t.Fatal(err)
+			}
+
+			// Existing series can be written to a new shard but new series
+			// are over the limit.
+			err := store.WriteToShard(2, []models.Point{
+				models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "A"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 100)),
+				models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "C"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 100)),
+			})
+			if exp := `partial write: cardinality limit exceeded (2/2): measurement="cpu" series="cpu,host=C" dropped=1`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error:\n\texp = %s\n\tgot = %v", exp, err)
+			}
+
+			// Series dropped from the database no longer count.
+			if err := store.DeleteSeries("db0", []influxql.Source{&influxql.Measurement{Name: "cpu"}}, &influxql.BinaryExpr{
+				Op:  influxql.EQ,
+				LHS: &influxql.VarRef{Val: "host"},
+				RHS: &influxql.StringLiteral{Val: "B"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			store.MustWriteToShardString(2, `cpu,host=C value=1 100`)
+
+			// Series only in a deleted shard no longer count.
+			if err := store.DeleteShard(2); err != nil {
+				t.Fatal(err)
+			}
+			store.MustWriteToShardString(1, `cpu,host=D value=1 20`)
+			err = store.WriteToShard(1, []models.Point{
+				models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "E"}), map[string]interface{}{"value": 1.0}, time.Unix(0, 20)),
+			})
+			if exp := `partial write: cardinality limit exceeded (2/2): measurement="cpu" series="cpu,host=E" dropped=1`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error:\n\texp = %s\n\tgot = %v", exp, err)
+			}
+		})
+	}
+}
+
+// Ensure the store drops series not recently written to any shard.
+func TestStore_ExpireInactiveSeries(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			store := NewStore()
+			store.EngineOptions.IndexVersion = index
+			if err := store.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer store.Close()
+
+			store.MustCreateShardWithData("db0", "rp0", 1,
+				`cpu,host=A value=1 10`,
+				`cpu,host=B value=1 10`,
+				`mem,host=C value=1 10`,
+			)
+			store.MustCreateShardWithData("db0", "rp0", 2,
+				`cpu,host=A value=2 100`,
+			)
+
+			// Series written recently are kept.
+			min := time.Now().Add(-time.Hour).UnixNano()
+			if n, err := store.ExpireInactiveSeries([]uint64{1, 2}, min); err != nil {
+				t.Fatal(err)
+			} else if n != 0 {
+				t.Fatalf("unexpected expired series count: %d", n)
+			}
+
+			// Move the series of shard 1 to a TSM file written two hours ago.
+			if path, err := store.Shard(1).CreateSnapshot(); err != nil {
+				t.Fatal(err)
+			} else {
+				os.RemoveAll(path)
+			}
+			files, err := filepath.Glob(filepath.Join(store.Shard(1).Path(), "*.tsm"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			written := time.Now().Add(-2 * time.Hour)
+			for _, f := range files {
+				if err := os.Chtimes(f, written, written); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := store.Reopen(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Writing old points keeps a series active.
+			store.MustWriteToShardString(1, `cpu,host=B value=2 5`)
+
+			n, err := store.ExpireInactiveSeries([]uint64{1, 2}, min)
+			if err != nil {
+				t.Fatal(err)
+			} else if n != 1 {
+				t.Fatalf("unexpected expired series count: %d", n)
+			}
+
+			// The series active in any shard keep their points in every shard.
+			if keys := MustSeriesKeys(store.Shard(1)); !reflect.DeepEqual(keys, []string{"cpu,host=A", "cpu,host=B"}) {
+				t.Fatalf("unexpected series: %v", keys)
+			}
+
+			names, err := store.MeasurementNames("db0", nil)
+			if err != nil {
+				t.Fatal(err)
+			} else if got, exp := names, [][]byte{[]byte("cpu")}; !reflect.DeepEqual(got, exp) {
+				t.Fatalf("unexpected measurements: %s", got)
+			}
+
+			// Nothing is left to expire.
+			if n, err := store.ExpireInactiveSeries([]uint64{1, 2}, min); err != nil {
+				t.Fatal(err)
+			} else if n != 0 {
+				t.Fatalf("unexpected expired series count: %d", n)
+			}
+		})
+	}
+}
+
+func TestStore_RenameSeries(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			store := NewStore()
+			store.EngineOptions.IndexVersion = index
+			if err := store.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer store.Close()
+
+			store.MustCreateShardWithData("db0", "rp0", 1,
+				`cpu,host=A value=1 10`,
+				`cpu,host=B value=1 10`,
+			)
+			store.MustCreateShardWithData("db0", "rp0", 2,
+				`cpu,host=A value=3 100`,
+				`mem,host=C value=1 100`,
+			)
+
+			// Rename values in both TSM files and the cache.
+			if path, err := store.Shard(1).CreateSnapshot(); err != nil {
+				t.Fatal(err)
+			} else {
+				os.RemoveAll(path)
+			}
+			store.MustWriteToShardString(1, `cpu,host=A value=2 20`)
+
+			if err := store.RenameMeasurement("db0", "cpu", "cpu2", nil); err != nil {
+				t.Fatal(err)
+			} else if err := store.RenameTagKey("db0", "cpu2", "host", "server", nil); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 2; i++ {
+				if keys := MustSeriesKeys(store.Shard(1)); !reflect.DeepEqual(keys, []string{"cpu2,server=A", "cpu2,server=B"}) {
+					t.Fatalf("unexpected series: %v", keys)
+				}
+
+				if names, err := store.MeasurementNames("db0", nil); err != nil {
+					t.Fatal(err)
+				} else if exp := [][]byte{[]byte("cpu2"), []byte("mem")}; !reflect.DeepEqual(names, exp) {
+					t.Fatalf("unexpected measurements: %s", names)
+				}
+
+				if mf := store.Shard(2).MeasurementFields([]byte("cpu2")); mf.Field("value") == nil {
+					t.Fatal("expected renamed field")
+				}
+
+				// Renames are kept when the store is reopened.
+				if err := store.Reopen(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Tag keys can't be renamed to a key a series already has.
+			store.MustWriteToShardString(2, `cpu2,server=Z,host=Z value=1 200`)
+			if err := store.RenameTagKey("db0", "cpu2", "host", "server", nil); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestStore_DeleteAndConvertField(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			store := NewStore()
+			store.EngineOptions.IndexVersion = index
+			if err := store.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer store.Close()
+
+			store.MustCreateShardWithData("db0", "rp0", 1,
+				`cpu,host=A value=1i,idle=1 10`,
+				`cpu,host=B idle=1 10`,
+			)
+			store.MustCreateShardWithData("db0", "rp0", 2,
+				`cpu,host=A value=3.5 100`,
+			)
+
+			// Convert values in both TSM files and the cache.
+			if path, err := store.Shard(1).CreateSnapshot(); err != nil {
+				t.Fatal(err)
+			} else {
+				os.RemoveAll(path)
+			}
+			store.MustWriteToShardString(1, `cpu,host=A value=2i 20`)
+
+			// Fields are only converted if all shards can be converted.
+			if err := store.ConvertField("db0", "cpu", "value", influxql.Integer, nil); err == nil {
+				t.Fatal("expected error")
+			} else if f := store.Shard(1).MeasurementFields([]byte("cpu")).Field("value"); f.Type != influxql.Integer {
+				t.Fatalf("unexpected field type: %s", f.Type)
+			}
+
+			// Converting a field of a missing measurement doesn't create it.
+			if err := store.ConvertField("db0", "mem", "value", influxql.Float, nil); err != nil {
+				t.Fatal(err)
+			} else if mf := store.Shard(1).MeasurementFieldSet().Fields("mem"); mf != nil {
+				t.Fatalf("unexpected fields: %v", mf.FieldSet())
+			}
+
+			if err := store.ConvertField("db0", "cpu", "value", influxql.Float, nil); err != nil {
+				t.Fatal(err)
+			} else if err := store.DeleteField("db0", "cpu", "idle"); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 2; i++ {
+				if fields := store.Shard(1).MeasurementFields([]byte("cpu")).FieldSet(); !reflect.DeepEqual(fields, map[string]influxql.DataType{"value": influxql.Float}) {
+					t.Fatalf("unexpected fields: %v", fields)
+				}
+
+				// Series without other fields are removed.
+				if keys := MustSeriesKeys(store.Shard(1)); !reflect.DeepEqual(keys, []string{"cpu,host=A"}) {
+					t.Fatalf("unexpected series: %v", keys)
+				}
+
+				// Changes are kept when the store is reopened.
+				if err := store.Reopen(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestStore_TagValues(t *testing.T) {
 	t.Parallel()
 
@@ -1252,6 +1520,24 @@ func ParseTags(s string) influxql.Tags {
 	return influxql.NewTags(m)
 }
 
+// MustSeriesKeys returns the sorted keys of the series with data in sh.
+func MustSeriesKeys(sh *tsdb.Shard) []string {
+	set := make(map[string]struct{})
+	if err := sh.ForEachSeriesKey(func(key []byte) error {
+		set[string(key)] = struct{}{}
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func dirExists(path string) bool {
 	var err error
 	if _, err = os.Stat(path); err == nil {